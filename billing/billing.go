@@ -0,0 +1,357 @@
+// Package billing invoices customers for their cargos as the cargos reach
+// the milestones of their delivery, from the price agreed in the quote they
+// were booked at.
+package billing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+	"github.com/mproyyan/grpc-shipping-microservice/pricing"
+	"github.com/pborman/uuid"
+)
+
+var (
+	// ErrUnknownInvoice is used when an invoice could not be found.
+	ErrUnknownInvoice = errors.New("unknown invoice")
+
+	// ErrInvoiceFinalized is returned when changing an invoice after it was
+	// finalized.
+	ErrInvoiceFinalized = errors.New("invoice has already been finalized")
+
+	// ErrEmptyInvoice is returned when finalizing an invoice without lines.
+	ErrEmptyInvoice = errors.New("invoice has no lines")
+)
+
+// Milestone is a point in the delivery of a cargo it is billed at.
+type Milestone int
+
+// Milestones a cargo is billed at, Loaded being its first load.
+const (
+	Booked Milestone = iota
+	Loaded
+	Claimed
+)
+
+func (m Milestone) String() string {
+	switch m {
+	case Booked:
+		return "Booked"
+	case Loaded:
+		return "Loaded"
+	case Claimed:
+		return "Claimed"
+	}
+
+	return ""
+}
+
+// Plan splits the agreed price of a cargo over its milestones, in percent
+// of the price.
+type Plan struct {
+	Booked  float64
+	Loaded  float64
+	Claimed float64
+}
+
+// DefaultPlan is used unless a plan is configured.
+var DefaultPlan = Plan{Booked: 20, Loaded: 50, Claimed: 30}
+
+// Valid reports whether no share is negative and the shares add up to 100.
+func (p Plan) Valid() bool {
+	return p.Booked >= 0 && p.Loaded >= 0 && p.Claimed >= 0 &&
+		math.Abs(p.Booked+p.Loaded+p.Claimed-100) < 1e-9
+}
+
+// Share returns the percentage of the price billed at the milestone and
+// its amount. Rounding differences go to Claimed, so that the amounts of
+// every milestone add up to the price.
+func (p Plan) Share(m Milestone, price int64) (float64, int64) {
+	booked, loaded := percent(price, p.Booked), percent(price, p.Loaded)
+	switch m {
+	case Booked:
+		return p.Booked, booked
+	case Loaded:
+		return p.Loaded, loaded
+	}
+
+	return p.Claimed, price - booked - loaded
+}
+
+// Line bills the cargo its share of the price for the milestone. It
+// returns false when the plan bills nothing at the milestone.
+func (p Plan) Line(id cargo.TrackingID, m Milestone, price pricing.Money, occurred time.Time) (Line, bool) {
+	share, amount := p.Share(m, price.Amount)
+	if share == 0 {
+		return Line{}, false
+	}
+
+	return Line{
+		TrackingID:  id,
+		Milestone:   m,
+		Description: fmt.Sprintf("%s %s: %g%% of %s", id, strings.ToLower(m.String()), share, price),
+		Amount:      amount,
+		Occurred:    occurred,
+	}, true
+}
+
+func percent(amount int64, p float64) int64 {
+	return int64(math.Round(float64(amount) * p / 100))
+}
+
+// Line is the amount billed for a cargo reaching a milestone, in the minor
+// unit of the currency of its invoice.
+type Line struct {
+	TrackingID  cargo.TrackingID
+	Milestone   Milestone
+	Description string
+	Amount      int64
+	Occurred    time.Time
+}
+
+// InvoiceID uniquely identifies a particular invoice.
+type InvoiceID string
+
+// NextInvoiceID generates a new invoice ID.
+func NextInvoiceID() InvoiceID {
+	return InvoiceID(strings.Split(strings.ToUpper(uuid.New()), "-")[0])
+}
+
+// Status tells whether lines can still be added to an invoice.
+type Status int
+
+// Valid invoice statuses.
+const (
+	Draft Status = iota
+	Finalized
+)
+
+func (s Status) String() string {
+	switch s {
+	case Draft:
+		return "Draft"
+	case Finalized:
+		return "Finalized"
+	}
+
+	return ""
+}
+
+// Invoice collects the lines billed to a customer in one currency. Lines
+// are added to the draft invoice of the customer until it is finalized, it
+// does not change afterwards and later lines start a new draft.
+type Invoice struct {
+	ID         InvoiceID
+	CustomerID customer.ID
+	Currency   string
+	Status     Status
+	Lines      []Line
+	Created    time.Time
+	Finalized  time.Time
+}
+
+// NewInvoice creates a draft invoice without lines.
+func NewInvoice(id InvoiceID, customerID customer.ID, currency string, now time.Time) *Invoice {
+	return &Invoice{
+		ID:         id,
+		CustomerID: customerID,
+		Currency:   currency,
+		Status:     Draft,
+		Created:    now,
+	}
+}
+
+// Add adds the line to a draft invoice.
+func (i *Invoice) Add(l Line) error {
+	if i.Status == Finalized {
+		return ErrInvoiceFinalized
+	}
+
+	i.Lines = append(i.Lines, l)
+	return nil
+}
+
+// Finalize closes the invoice so that it can be sent to the customer.
+func (i *Invoice) Finalize(now time.Time) error {
+	if i.Status == Finalized {
+		return ErrInvoiceFinalized
+	}
+
+	if len(i.Lines) == 0 {
+		return ErrEmptyInvoice
+	}
+
+	i.Status = Finalized
+	i.Finalized = now
+	return nil
+}
+
+// Total returns the sum of the lines.
+func (i Invoice) Total() pricing.Money {
+	total := pricing.Money{Currency: i.Currency}
+	for _, l := range i.Lines {
+		total.Amount += l.Amount
+	}
+
+	return total
+}
+
+type InvoiceRepositoryContract interface {
+	Store(ctx context.Context, dbtx db.DBTX, i *Invoice) error
+	Find(ctx context.Context, dbtx db.DBTX, id InvoiceID) (*Invoice, error)
+	FindDraft(ctx context.Context, dbtx db.DBTX, customerID customer.ID, currency string) (*Invoice, error)
+	FindByCustomer(ctx context.Context, dbtx db.DBTX, customerID customer.ID) ([]*Invoice, error)
+	Billed(ctx context.Context, dbtx db.DBTX, id cargo.TrackingID, m Milestone) (bool, error)
+}
+
+type InvoiceRepository struct {
+}
+
+func NewInvoiceRepository() InvoiceRepository {
+	return InvoiceRepository{}
+}
+
+// Store creates the invoice or updates its status and adds its new lines,
+// lines are never changed once stored.
+func (ir InvoiceRepository) Store(ctx context.Context, dbtx db.DBTX, i *Invoice) error {
+	var finalized sql.NullTime
+	if !i.Finalized.IsZero() {
+		finalized = sql.NullTime{Time: i.Finalized, Valid: true}
+	}
+
+	query := `
+		INSERT INTO invoices (id, customer_id, currency, status, created_at, finalized_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (id) DO UPDATE SET status = EXCLUDED.status, finalized_at = EXCLUDED.finalized_at
+	`
+
+	if _, err := dbtx.ExecContext(ctx, query, i.ID, i.CustomerID, i.Currency, i.Status, i.Created, finalized); err != nil {
+		return err
+	}
+
+	for _, l := range i.Lines {
+		query := `
+			INSERT INTO invoice_lines (invoice_id, tracking_id, milestone, description, amount, occurred_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (tracking_id, milestone) DO NOTHING
+		`
+
+		if _, err := dbtx.ExecContext(ctx, query, i.ID, l.TrackingID, l.Milestone, l.Description, l.Amount, l.Occurred); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Find returns the invoice. Within a transaction it stays locked until the
+// transaction ends.
+func (ir InvoiceRepository) Find(ctx context.Context, dbtx db.DBTX, id InvoiceID) (*Invoice, error) {
+	invoices, err := ir.find(ctx, dbtx, "id = $1 FOR UPDATE", id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(invoices) == 0 {
+		return nil, ErrUnknownInvoice
+	}
+
+	return invoices[0], nil
+}
+
+// FindDraft returns the draft invoice of the customer in the currency,
+// locked like Find.
+func (ir InvoiceRepository) FindDraft(ctx context.Context, dbtx db.DBTX, customerID customer.ID, currency string) (*Invoice, error) {
+	invoices, err := ir.find(ctx, dbtx, "customer_id = $1 AND currency = $2 AND status = $3 FOR UPDATE", customerID, currency, Draft)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(invoices) == 0 {
+		return nil, ErrUnknownInvoice
+	}
+
+	return invoices[0], nil
+}
+
+// FindByCustomer returns the invoices of the customer, newest first.
+func (ir InvoiceRepository) FindByCustomer(ctx context.Context, dbtx db.DBTX, customerID customer.ID) ([]*Invoice, error) {
+	return ir.find(ctx, dbtx, "customer_id = $1 ORDER BY created_at DESC, id", customerID)
+}
+
+// Billed reports whether the cargo was already billed for the milestone.
+func (ir InvoiceRepository) Billed(ctx context.Context, dbtx db.DBTX, id cargo.TrackingID, m Milestone) (bool, error) {
+	var billed bool
+	err := dbtx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM invoice_lines WHERE tracking_id = $1 AND milestone = $2)", id, m).Scan(&billed)
+	return billed, err
+}
+
+func (ir InvoiceRepository) find(ctx context.Context, dbtx db.DBTX, where string, args ...interface{}) ([]*Invoice, error) {
+	query := `SELECT id, customer_id, currency, status, created_at, finalized_at FROM invoices WHERE ` + where
+
+	rows, err := dbtx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invoices []*Invoice
+	for rows.Next() {
+		var (
+			i         Invoice
+			finalized sql.NullTime
+		)
+
+		if err := rows.Scan(&i.ID, &i.CustomerID, &i.Currency, &i.Status, &i.Created, &finalized); err != nil {
+			return nil, err
+		}
+
+		i.Finalized = finalized.Time
+		invoices = append(invoices, &i)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, i := range invoices {
+		if i.Lines, err = ir.lines(ctx, dbtx, i.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return invoices, nil
+}
+
+func (ir InvoiceRepository) lines(ctx context.Context, dbtx db.DBTX, id InvoiceID) ([]Line, error) {
+	query := `
+		SELECT tracking_id, milestone, description, amount, occurred_at
+		FROM invoice_lines WHERE invoice_id = $1 ORDER BY occurred_at, tracking_id, milestone
+	`
+
+	rows, err := dbtx.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lines []Line
+	for rows.Next() {
+		var l Line
+		if err := rows.Scan(&l.TrackingID, &l.Milestone, &l.Description, &l.Amount, &l.Occurred); err != nil {
+			return nil, err
+		}
+
+		lines = append(lines, l)
+	}
+
+	return lines, rows.Err()
+}
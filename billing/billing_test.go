@@ -0,0 +1,92 @@
+package billing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/pricing"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlan(t *testing.T) {
+	require.True(t, DefaultPlan.Valid())
+	require.False(t, Plan{Booked: 50, Loaded: 40}.Valid())
+	require.False(t, Plan{Booked: -10, Loaded: 80, Claimed: 30}.Valid())
+
+	// rounding differences go to the claim so the shares add up
+	plan := Plan{Booked: 33.3, Loaded: 33.3, Claimed: 33.4}
+	var total int64
+	for _, m := range []Milestone{Booked, Loaded, Claimed} {
+		_, amount := plan.Share(m, 1001)
+		total += amount
+	}
+	require.EqualValues(t, 1001, total)
+
+	now := time.Now()
+	price := pricing.Money{Amount: 125000, Currency: "EUR"}
+	line, ok := DefaultPlan.Line("ABC123", Loaded, price, now)
+	require.True(t, ok)
+	require.Equal(t, Line{TrackingID: "ABC123", Milestone: Loaded, Description: "ABC123 loaded: 50% of 1250.00 EUR", Amount: 62500, Occurred: now}, line)
+
+	_, ok = Plan{Loaded: 70, Claimed: 30}.Line("ABC123", Booked, price, now)
+	require.False(t, ok)
+}
+
+func TestFinalizeInvoice(t *testing.T) {
+	now := time.Now()
+	i := NewInvoice(NextInvoiceID(), "ACME", "EUR", now)
+	require.Equal(t, ErrEmptyInvoice, i.Finalize(now))
+
+	require.NoError(t, i.Add(Line{TrackingID: "ABC123", Milestone: Booked, Amount: 25000}))
+	require.NoError(t, i.Add(Line{TrackingID: "ABC123", Milestone: Loaded, Amount: 62500}))
+	require.Equal(t, pricing.Money{Amount: 87500, Currency: "EUR"}, i.Total())
+
+	require.NoError(t, i.Finalize(now))
+	require.Equal(t, Finalized, i.Status)
+	require.Equal(t, ErrInvoiceFinalized, i.Finalize(now))
+	require.Equal(t, ErrInvoiceFinalized, i.Add(Line{TrackingID: "ABC123", Milestone: Claimed}))
+}
+
+func TestStoreInvoice(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	c, err := customerTest.Upsert(ctx, dbTest, customer.New(customer.NextID(), "Acme", customer.Contact{}, customer.NotificationPreferences{}))
+	require.NoError(t, err)
+
+	booked, err := cargoTest.Upsert(ctx, dbTest, cargo.New(cargo.NextTrackingID(), cargo.RouteSpecification{Origin: "SESTO", Destination: "AUMEL", ArrivalDeadline: now}))
+	require.NoError(t, err)
+
+	_, err = invoiceTest.FindDraft(ctx, dbTest, c.ID, "EUR")
+	require.Equal(t, ErrUnknownInvoice, err)
+
+	i := NewInvoice(NextInvoiceID(), c.ID, "EUR", now)
+	line, _ := DefaultPlan.Line(booked.TrackingID, Booked, pricing.Money{Amount: 125000, Currency: "EUR"}, now)
+	require.NoError(t, i.Add(line))
+	require.NoError(t, invoiceTest.Store(ctx, dbTest, i))
+
+	billed, err := invoiceTest.Billed(ctx, dbTest, booked.TrackingID, Booked)
+	require.NoError(t, err)
+	require.True(t, billed)
+
+	draft, err := invoiceTest.FindDraft(ctx, dbTest, c.ID, "EUR")
+	require.NoError(t, err)
+	require.Equal(t, i.ID, draft.ID)
+	require.Len(t, draft.Lines, 1)
+	require.EqualValues(t, 25000, draft.Lines[0].Amount)
+
+	require.NoError(t, draft.Finalize(now))
+	require.NoError(t, invoiceTest.Store(ctx, dbTest, draft))
+
+	invoices, err := invoiceTest.FindByCustomer(ctx, dbTest, c.ID)
+	require.NoError(t, err)
+	require.Len(t, invoices, 1)
+	require.Equal(t, Finalized, invoices[0].Status)
+	require.True(t, now.Equal(invoices[0].Finalized))
+
+	_, err = invoiceTest.FindDraft(ctx, dbTest, c.ID, "EUR")
+	require.Equal(t, ErrUnknownInvoice, err)
+}
@@ -0,0 +1,56 @@
+package billing
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+)
+
+type instrumentingInvoiceRepository struct {
+	latency metrics.Histogram
+	InvoiceRepositoryContract
+}
+
+// NewInstrumentingInvoiceRepository returns an invoice repository that
+// records query latency, labeled by repository, method and outcome.
+func NewInstrumentingInvoiceRepository(latency metrics.Histogram, r InvoiceRepositoryContract) InvoiceRepositoryContract {
+	return &instrumentingInvoiceRepository{latency: latency, InvoiceRepositoryContract: r}
+}
+
+func (r *instrumentingInvoiceRepository) observe(method string, begin time.Time, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+
+	r.latency.With("repository", "invoice", "method", method, "outcome", outcome).Observe(time.Since(begin).Seconds())
+}
+
+func (r *instrumentingInvoiceRepository) Store(ctx context.Context, dbtx db.DBTX, i *Invoice) (err error) {
+	defer func(begin time.Time) { r.observe("store", begin, err) }(time.Now())
+	return r.InvoiceRepositoryContract.Store(ctx, dbtx, i)
+}
+
+func (r *instrumentingInvoiceRepository) Find(ctx context.Context, dbtx db.DBTX, id InvoiceID) (i *Invoice, err error) {
+	defer func(begin time.Time) { r.observe("find", begin, err) }(time.Now())
+	return r.InvoiceRepositoryContract.Find(ctx, dbtx, id)
+}
+
+func (r *instrumentingInvoiceRepository) FindDraft(ctx context.Context, dbtx db.DBTX, customerID customer.ID, currency string) (i *Invoice, err error) {
+	defer func(begin time.Time) { r.observe("find_draft", begin, err) }(time.Now())
+	return r.InvoiceRepositoryContract.FindDraft(ctx, dbtx, customerID, currency)
+}
+
+func (r *instrumentingInvoiceRepository) FindByCustomer(ctx context.Context, dbtx db.DBTX, customerID customer.ID) (invoices []*Invoice, err error) {
+	defer func(begin time.Time) { r.observe("find_by_customer", begin, err) }(time.Now())
+	return r.InvoiceRepositoryContract.FindByCustomer(ctx, dbtx, customerID)
+}
+
+func (r *instrumentingInvoiceRepository) Billed(ctx context.Context, dbtx db.DBTX, id cargo.TrackingID, m Milestone) (billed bool, err error) {
+	defer func(begin time.Time) { r.observe("billed", begin, err) }(time.Now())
+	return r.InvoiceRepositoryContract.Billed(ctx, dbtx, id, m)
+}
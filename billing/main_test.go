@@ -0,0 +1,37 @@
+package billing
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/config"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+)
+
+var (
+	dbTest       *sql.DB
+	cargoTest    cargo.CargoRepositoryContract
+	customerTest customer.CustomerRepositoryContract
+	invoiceTest  InvoiceRepositoryContract
+)
+
+func TestMain(m *testing.M) {
+	env := config.Environment{
+		DBUsername: "postgres",
+		DBPassword: "ligmaballs",
+		DBHost:     "localhost",
+		DBPort:     "5432",
+		DBName:     "grpc_shipping",
+	}
+
+	dbTest, _ = db.NewPostgreSQL(env).Connect()
+
+	cargoTest = cargo.NewCargoRepository(cargo.NewItineraryRepository(), cargo.NewDeliveryRepository())
+	customerTest = customer.NewCustomerRepository()
+	invoiceTest = InvoiceRepository{}
+
+	os.Exit(m.Run())
+}
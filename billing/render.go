@@ -0,0 +1,216 @@
+package billing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/pricing"
+)
+
+// Supported rendering formats.
+const (
+	FormatJSON = "json"
+	FormatPDF  = "pdf"
+)
+
+// Formats lists the supported rendering formats.
+var Formats = []string{FormatJSON, FormatPDF}
+
+// Supported reports whether format is one of Formats.
+func Supported(format string) bool {
+	for _, f := range Formats {
+		if f == format {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ContentType returns the media type of format.
+func ContentType(format string) string {
+	switch format {
+	case FormatJSON:
+		return "application/json"
+	case FormatPDF:
+		return "application/pdf"
+	}
+
+	return "application/octet-stream"
+}
+
+// Render writes the invoice, addressed to the customer, in format.
+func Render(w io.Writer, format string, i Invoice, c customer.Customer) error {
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(newDocument(i, c))
+	case FormatPDF:
+		return writePDF(w, text(i, c))
+	}
+
+	return fmt.Errorf("unsupported invoice format %q, use one of %s", format, strings.Join(Formats, ", "))
+}
+
+// document is the JSON rendering of an invoice. Amounts are in the minor
+// unit of the currency.
+type document struct {
+	InvoiceID InvoiceID     `json:"invoice_id"`
+	Status    string        `json:"status"`
+	Customer  billTo        `json:"customer"`
+	Created   time.Time     `json:"created"`
+	Finalized *time.Time    `json:"finalized,omitempty"`
+	Lines     []lineView    `json:"lines"`
+	Total     pricing.Money `json:"total"`
+}
+
+type billTo struct {
+	ID    customer.ID `json:"id"`
+	Name  string      `json:"name"`
+	Email string      `json:"email,omitempty"`
+}
+
+type lineView struct {
+	TrackingID  string    `json:"tracking_id"`
+	Milestone   string    `json:"milestone"`
+	Description string    `json:"description"`
+	Amount      int64     `json:"amount"`
+	Occurred    time.Time `json:"occurred"`
+}
+
+func newDocument(i Invoice, c customer.Customer) document {
+	d := document{
+		InvoiceID: i.ID,
+		Status:    i.Status.String(),
+		Customer:  billTo{ID: c.ID, Name: c.Name, Email: c.Contact.Email},
+		Created:   i.Created,
+		Lines:     []lineView{},
+		Total:     i.Total(),
+	}
+
+	if !i.Finalized.IsZero() {
+		finalized := i.Finalized
+		d.Finalized = &finalized
+	}
+
+	for _, l := range i.Lines {
+		d.Lines = append(d.Lines, lineView{
+			TrackingID:  string(l.TrackingID),
+			Milestone:   l.Milestone.String(),
+			Description: l.Description,
+			Amount:      l.Amount,
+			Occurred:    l.Occurred,
+		})
+	}
+
+	return d
+}
+
+// text lays the invoice out as lines of a fixed width font.
+func text(i Invoice, c customer.Customer) []string {
+	lines := []string{
+		fmt.Sprintf("INVOICE %s (%s)", i.ID, i.Status),
+		"",
+		"Bill to:  " + c.Name,
+	}
+
+	if c.Contact.Email != "" {
+		lines = append(lines, "          "+c.Contact.Email)
+	}
+
+	lines = append(lines, "Customer: "+string(c.ID), "Created:  "+i.Created.Format("2006-01-02"))
+	if !i.Finalized.IsZero() {
+		lines = append(lines, "Issued:   "+i.Finalized.Format("2006-01-02"))
+	}
+
+	lines = append(lines, "", fmt.Sprintf("%-10s  %-52s  %14s", "DATE", "DESCRIPTION", "AMOUNT"))
+	for _, l := range i.Lines {
+		amount := pricing.Money{Amount: l.Amount, Currency: i.Currency}
+		lines = append(lines, fmt.Sprintf("%-10s  %-52.52s  %14s", l.Occurred.Format("2006-01-02"), l.Description, amount))
+	}
+
+	return append(lines, "", fmt.Sprintf("%-64s  %14s", "TOTAL", i.Total()))
+}
+
+// pdfLinesPerPage fits an A4 page with 12pt leading and 50pt margins.
+const pdfLinesPerPage = 62
+
+// writePDF writes the lines as a PDF document in Courier, as many pages as
+// needed.
+func writePDF(w io.Writer, lines []string) error {
+	var pages [][]string
+	for len(lines) > pdfLinesPerPage {
+		pages = append(pages, lines[:pdfLinesPerPage])
+		lines = lines[pdfLinesPerPage:]
+	}
+	pages = append(pages, lines)
+
+	var (
+		buf     bytes.Buffer
+		offsets []int
+	)
+
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	// objects 1 to 3 are the catalog, the page tree and the font, then
+	// every page is followed by its content stream
+	kids := make([]string, len(pages))
+	for n := range pages {
+		kids[n] = fmt.Sprintf("%d 0 R", 4+2*n)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+
+	for n, page := range pages {
+		var content strings.Builder
+		content.WriteString("BT\n/F1 10 Tf\n12 TL\n50 792 Td\n")
+		for _, l := range page {
+			fmt.Fprintf(&content, "(%s) '\n", pdfEscape(l))
+		}
+		content.WriteString("ET")
+
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 595 842] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>", 5+2*n))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// pdfEscape escapes a PDF string literal. Characters outside of printable
+// ASCII are replaced, the standard fonts cannot be relied on for them.
+func pdfEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < ' ' || r > '~':
+			b.WriteByte('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
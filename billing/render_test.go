@@ -0,0 +1,72 @@
+package billing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/stretchr/testify/require"
+)
+
+func renderTestInvoice() (Invoice, customer.Customer) {
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	i := NewInvoice("INV1", "ACME", "EUR", now)
+	i.Add(Line{TrackingID: "ABC123", Milestone: Booked, Description: "ABC123 booked: 20% of 1250.00 EUR (net)", Amount: 25000, Occurred: now})
+
+	return *i, customer.Customer{ID: "ACME", Name: "Acme", Contact: customer.Contact{Email: "billing@acme.example"}}
+}
+
+func TestRenderJSON(t *testing.T) {
+	i, c := renderTestInvoice()
+
+	var buf bytes.Buffer
+	require.NoError(t, Render(&buf, FormatJSON, i, c))
+
+	var d document
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &d))
+	require.Equal(t, "Draft", d.Status)
+	require.Equal(t, "Acme", d.Customer.Name)
+	require.Nil(t, d.Finalized)
+	require.Len(t, d.Lines, 1)
+	require.Equal(t, "Booked", d.Lines[0].Milestone)
+	require.EqualValues(t, 25000, d.Total.Amount)
+}
+
+func TestRenderPDF(t *testing.T) {
+	i, c := renderTestInvoice()
+	for n := 0; n < 2*pdfLinesPerPage; n++ {
+		i.Lines = append(i.Lines, i.Lines[0])
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Render(&buf, FormatPDF, i, c))
+
+	pdf := buf.String()
+	require.True(t, strings.HasPrefix(pdf, "%PDF-1.4\n"))
+	require.True(t, strings.HasSuffix(pdf, "%%EOF\n"))
+	require.Contains(t, pdf, "/Count 3")
+	require.Contains(t, pdf, `ABC123 booked: 20% of 1250.00 EUR \(net\)`)
+
+	// every cross-reference entry points at its object
+	start := regexp.MustCompile(`startxref\n(\d+)`).FindStringSubmatch(pdf)
+	require.NotNil(t, start)
+	xref, err := strconv.Atoi(start[1])
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(pdf[xref:], "xref\n"))
+
+	offsets := regexp.MustCompile(`(\d{10}) 00000 n`).FindAllStringSubmatch(pdf[xref:], -1)
+	require.Len(t, offsets, 3+2*3)
+	for n, o := range offsets {
+		offset, err := strconv.Atoi(o[1])
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(pdf[offset:], fmt.Sprintf("%d 0 obj\n", n+1)))
+	}
+
+	require.Error(t, Render(&buf, "html", i, c))
+}
@@ -0,0 +1,61 @@
+package billing
+
+import (
+	"context"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+	"github.com/mproyyan/grpc-shipping-microservice/tracing"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = tracing.Tracer("github.com/mproyyan/grpc-shipping-microservice/billing")
+
+type tracingInvoiceRepository struct {
+	InvoiceRepositoryContract
+}
+
+// NewTracingInvoiceRepository returns an invoice repository that wraps
+// every query in a client span.
+func NewTracingInvoiceRepository(r InvoiceRepositoryContract) InvoiceRepositoryContract {
+	return &tracingInvoiceRepository{InvoiceRepositoryContract: r}
+}
+
+func (r *tracingInvoiceRepository) start(ctx context.Context, name string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemPostgreSQL),
+	)
+}
+
+func (r *tracingInvoiceRepository) Store(ctx context.Context, dbtx db.DBTX, i *Invoice) (err error) {
+	ctx, span := r.start(ctx, "InvoiceRepository.Store")
+	defer func() { tracing.End(span, err) }()
+	return r.InvoiceRepositoryContract.Store(ctx, dbtx, i)
+}
+
+func (r *tracingInvoiceRepository) Find(ctx context.Context, dbtx db.DBTX, id InvoiceID) (i *Invoice, err error) {
+	ctx, span := r.start(ctx, "InvoiceRepository.Find")
+	defer func() { tracing.End(span, err) }()
+	return r.InvoiceRepositoryContract.Find(ctx, dbtx, id)
+}
+
+func (r *tracingInvoiceRepository) FindDraft(ctx context.Context, dbtx db.DBTX, customerID customer.ID, currency string) (i *Invoice, err error) {
+	ctx, span := r.start(ctx, "InvoiceRepository.FindDraft")
+	defer func() { tracing.End(span, err) }()
+	return r.InvoiceRepositoryContract.FindDraft(ctx, dbtx, customerID, currency)
+}
+
+func (r *tracingInvoiceRepository) FindByCustomer(ctx context.Context, dbtx db.DBTX, customerID customer.ID) (invoices []*Invoice, err error) {
+	ctx, span := r.start(ctx, "InvoiceRepository.FindByCustomer")
+	defer func() { tracing.End(span, err) }()
+	return r.InvoiceRepositoryContract.FindByCustomer(ctx, dbtx, customerID)
+}
+
+func (r *tracingInvoiceRepository) Billed(ctx context.Context, dbtx db.DBTX, id cargo.TrackingID, m Milestone) (billed bool, err error) {
+	ctx, span := r.start(ctx, "InvoiceRepository.Billed")
+	defer func() { tracing.End(span, err) }()
+	return r.InvoiceRepositoryContract.Billed(ctx, dbtx, id, m)
+}
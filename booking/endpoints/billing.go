@@ -0,0 +1,260 @@
+package endpoints
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/log"
+	"github.com/mproyyan/grpc-shipping-microservice/billing"
+	"github.com/mproyyan/grpc-shipping-microservice/booking/services"
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type BillingSet struct {
+	ListInvoicesEndpoint    endpoint.Endpoint
+	FinalizeInvoiceEndpoint endpoint.Endpoint
+	RenderInvoiceEndpoint   endpoint.Endpoint
+}
+
+func NewBillingEndpoints(bs services.BillingServiceContract, logger log.Logger) BillingSet {
+	middleware := serverMiddleware(logger)
+
+	return BillingSet{
+		ListInvoicesEndpoint:    middleware("Billing.ListInvoices")(MakeListInvoicesEndpoint(bs)),
+		FinalizeInvoiceEndpoint: middleware("Billing.FinalizeInvoice")(MakeFinalizeInvoiceEndpoint(bs)),
+		RenderInvoiceEndpoint:   middleware("Billing.RenderInvoice")(MakeRenderInvoiceEndpoint(bs)),
+	}
+}
+
+func (s BillingSet) Invoices(ctx context.Context, customerID customer.ID) ([]billing.Invoice, error) {
+	resp, err := s.ListInvoicesEndpoint(ctx, ListInvoicesRequest{CustomerID: customerID})
+	if err != nil {
+		return nil, err
+	}
+
+	res := resp.(ListInvoicesResponse)
+	return res.Invoices, res.Error
+}
+
+func (s BillingSet) FinalizeInvoice(ctx context.Context, id billing.InvoiceID) (billing.Invoice, error) {
+	resp, err := s.FinalizeInvoiceEndpoint(ctx, FinalizeInvoiceRequest{InvoiceID: id})
+	if err != nil {
+		return billing.Invoice{}, err
+	}
+
+	res := resp.(FinalizeInvoiceResponse)
+	return res.Invoice, res.Error
+}
+
+func (s BillingSet) RenderInvoice(ctx context.Context, id billing.InvoiceID, format string) ([]byte, error) {
+	resp, err := s.RenderInvoiceEndpoint(ctx, RenderInvoiceRequest{InvoiceID: id, Format: format})
+	if err != nil {
+		return nil, err
+	}
+
+	res := resp.(RenderInvoiceResponse)
+	return res.Document, res.Error
+}
+
+type ListInvoicesRequest struct {
+	CustomerID customer.ID `json:"customer_id"`
+}
+
+func (r ListInvoicesRequest) Build(req *pb.ListInvoicesRequest) ListInvoicesRequest {
+	return ListInvoicesRequest{
+		CustomerID: customer.ID(req.GetCustomerId()),
+	}
+}
+
+type ListInvoicesResponse struct {
+	Invoices []billing.Invoice `json:"invoices"`
+	Error    error             `json:"error,omitempty"`
+}
+
+func (r ListInvoicesResponse) error() error { return r.Error }
+
+func (r ListInvoicesResponse) Protobuf() *pb.ListInvoicesResponse {
+	res := &pb.ListInvoicesResponse{Error: err2str(r.Error)}
+	for _, i := range r.Invoices {
+		res.Invoices = append(res.Invoices, InvoiceToProto(i))
+	}
+
+	return res
+}
+
+func MakeListInvoicesEndpoint(bs services.BillingServiceContract) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req, ok := request.(ListInvoicesRequest)
+		if !ok {
+			return nil, errors.New("failed to convert request to ListInvoicesRequest")
+		}
+
+		invoices, err := bs.Invoices(ctx, req.CustomerID)
+		return ListInvoicesResponse{Invoices: invoices, Error: err}, nil
+	}
+}
+
+type FinalizeInvoiceRequest struct {
+	InvoiceID billing.InvoiceID `json:"invoice_id"`
+}
+
+func (r FinalizeInvoiceRequest) Build(req *pb.FinalizeInvoiceRequest) FinalizeInvoiceRequest {
+	return FinalizeInvoiceRequest{
+		InvoiceID: billing.InvoiceID(req.GetInvoiceId()),
+	}
+}
+
+type FinalizeInvoiceResponse struct {
+	Invoice billing.Invoice `json:"invoice"`
+	Error   error           `json:"error,omitempty"`
+}
+
+func (r FinalizeInvoiceResponse) error() error { return r.Error }
+
+func (r FinalizeInvoiceResponse) Protobuf() *pb.FinalizeInvoiceResponse {
+	res := &pb.FinalizeInvoiceResponse{Error: err2str(r.Error)}
+	if r.Error == nil {
+		res.Invoice = InvoiceToProto(r.Invoice)
+	}
+
+	return res
+}
+
+func MakeFinalizeInvoiceEndpoint(bs services.BillingServiceContract) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req, ok := request.(FinalizeInvoiceRequest)
+		if !ok {
+			return nil, errors.New("failed to convert request to FinalizeInvoiceRequest")
+		}
+
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+
+		i, err := bs.FinalizeInvoice(ctx, req.InvoiceID)
+		return FinalizeInvoiceResponse{Invoice: i, Error: err}, nil
+	}
+}
+
+type RenderInvoiceRequest struct {
+	InvoiceID billing.InvoiceID `json:"invoice_id"`
+	Format    string            `json:"format"`
+}
+
+// Build defaults the format to JSON.
+func (r RenderInvoiceRequest) Build(req *pb.RenderInvoiceRequest) RenderInvoiceRequest {
+	format := req.GetFormat()
+	if format == "" {
+		format = billing.FormatJSON
+	}
+
+	return RenderInvoiceRequest{
+		InvoiceID: billing.InvoiceID(req.GetInvoiceId()),
+		Format:    format,
+	}
+}
+
+type RenderInvoiceResponse struct {
+	Document    []byte `json:"document,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Error       error  `json:"error,omitempty"`
+}
+
+func (r RenderInvoiceResponse) error() error { return r.Error }
+
+func (r RenderInvoiceResponse) Protobuf() *pb.RenderInvoiceResponse {
+	return &pb.RenderInvoiceResponse{
+		Document:    r.Document,
+		ContentType: r.ContentType,
+		Error:       err2str(r.Error),
+	}
+}
+
+func MakeRenderInvoiceEndpoint(bs services.BillingServiceContract) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req, ok := request.(RenderInvoiceRequest)
+		if !ok {
+			return nil, errors.New("failed to convert request to RenderInvoiceRequest")
+		}
+
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+
+		document, err := bs.RenderInvoice(ctx, req.InvoiceID, req.Format)
+		if err != nil {
+			return RenderInvoiceResponse{Error: err}, nil
+		}
+
+		return RenderInvoiceResponse{Document: document, ContentType: billing.ContentType(req.Format)}, nil
+	}
+}
+
+// InvoiceToProto converts an invoice into its protobuf model.
+func InvoiceToProto(i billing.Invoice) *pb.InvoiceModel {
+	total := i.Total()
+	m := &pb.InvoiceModel{
+		InvoiceId:  string(i.ID),
+		CustomerId: string(i.CustomerID),
+		Status:     i.Status.String(),
+		Created:    timestamppb.New(i.Created),
+		Total:      MoneyToProto(&total),
+	}
+
+	if !i.Finalized.IsZero() {
+		m.Finalized = timestamppb.New(i.Finalized)
+	}
+
+	for _, l := range i.Lines {
+		m.Lines = append(m.Lines, &pb.InvoiceLine{
+			TrackingId:  string(l.TrackingID),
+			Milestone:   l.Milestone.String(),
+			Description: l.Description,
+			Amount:      l.Amount,
+			Occurred:    timestamppb.New(l.Occurred),
+		})
+	}
+
+	return m
+}
+
+// InvoiceFromProto converts a protobuf invoice model into an invoice.
+func InvoiceFromProto(m *pb.InvoiceModel) billing.Invoice {
+	i := billing.Invoice{
+		ID:         billing.InvoiceID(m.GetInvoiceId()),
+		CustomerID: customer.ID(m.GetCustomerId()),
+		Currency:   m.GetTotal().GetCurrency(),
+		Created:    m.GetCreated().AsTime(),
+	}
+
+	if m.GetStatus() == billing.Finalized.String() {
+		i.Status = billing.Finalized
+	}
+
+	if m.GetFinalized() != nil {
+		i.Finalized = m.GetFinalized().AsTime()
+	}
+
+	for _, l := range m.GetLines() {
+		line := billing.Line{
+			TrackingID:  cargo.TrackingID(l.GetTrackingId()),
+			Description: l.GetDescription(),
+			Amount:      l.GetAmount(),
+			Occurred:    l.GetOccurred().AsTime(),
+		}
+
+		for _, milestone := range []billing.Milestone{billing.Booked, billing.Loaded, billing.Claimed} {
+			if l.GetMilestone() == milestone.String() {
+				line.Milestone = milestone
+			}
+		}
+
+		i.Lines = append(i.Lines, line)
+	}
+
+	return i
+}
@@ -0,0 +1,137 @@
+package endpoints
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/mproyyan/grpc-shipping-microservice/booking/services"
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/imdg"
+	"github.com/mproyyan/grpc-shipping-microservice/location"
+	"github.com/mproyyan/grpc-shipping-microservice/pb"
+)
+
+func (s Set) BookCargos(ctx context.Context, bookings []services.Booking, dryRun bool) ([]services.BookingResult, error) {
+	req := BookCargosRequest{DryRun: dryRun}
+	for _, b := range bookings {
+		req.Rows = append(req.Rows, BookingRow{
+			Origin:      b.Origin,
+			Destination: b.Destination,
+			Deadline:    b.Deadline,
+			Attributes:  b.Attributes,
+		})
+	}
+
+	resp, err := s.BookCargosEndpoint(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	res := resp.(BookCargosResponse)
+	return res.Results, res.Error
+}
+
+// BookingRow is a single row of a bulk booking upload.
+type BookingRow struct {
+	Origin      location.UNLocode `json:"origin"`
+	Destination location.UNLocode `json:"destination"`
+	Deadline    time.Time         `json:"deadline"`
+	Attributes  cargo.Attributes  `json:"attributes"`
+}
+
+func (row BookingRow) Build(req *pb.BookCargosRequest) BookingRow {
+	return BookingRow{
+		Origin:      location.UNLocode(req.GetOrigin()),
+		Destination: location.UNLocode(req.GetDestination()),
+		Deadline:    req.GetDeadline().AsTime(),
+		Attributes: cargo.Attributes{
+			Weight:    req.GetWeight(),
+			Volume:    req.GetVolume(),
+			Packages:  int(req.GetPackages()),
+			Commodity: req.GetCommodity(),
+			HSCode:    req.GetHsCode(),
+			DangerousGoods: imdg.Goods{
+				Class:    imdg.Class(req.GetImdgClass()),
+				UNNumber: imdg.UNNumber(req.GetUnNumber()),
+			},
+		},
+	}
+}
+
+// BookCargosRequest is one batch of a bulk booking.
+type BookCargosRequest struct {
+	Rows   []BookingRow
+	DryRun bool
+}
+
+// BookCargosResponse holds one result per row of the request, in order. A
+// bulk upload booked in several batches may stop at a failed one, Error is
+// then set and Results only covers the rows before that batch.
+type BookCargosResponse struct {
+	Results []services.BookingResult
+	Error   error
+}
+
+func (res BookCargosResponse) error() error { return res.Error }
+
+// Protobuf converts the results, numbering the rows from offset+1.
+func (res BookCargosResponse) Protobuf(offset int) []*pb.BookingResult {
+	results := make([]*pb.BookingResult, 0, len(res.Results))
+	for i, r := range res.Results {
+		results = append(results, &pb.BookingResult{
+			Row:        int32(offset + i + 1),
+			TrackingId: string(r.TrackingID),
+			Error:      err2str(r.Err),
+		})
+	}
+
+	return results
+}
+
+// MakeBookCargosEndpoint validates every row with the same rules as a single
+// booking. Invalid rows are reported without reaching the service, the
+// others are booked together.
+func MakeBookCargosEndpoint(bs services.BookingServiceContract) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req, ok := request.(BookCargosRequest)
+		if !ok {
+			return nil, errors.New("failed to convert request to BookCargosRequest")
+		}
+
+		results := make([]services.BookingResult, len(req.Rows))
+		var (
+			valid    []int
+			bookings []services.Booking
+		)
+
+		for i, row := range req.Rows {
+			if err := (BookNewCargoRequest{Origin: row.Origin, Destination: row.Destination, Deadline: row.Deadline, Attributes: row.Attributes}).Validate(); err != nil {
+				results[i].Err = err
+				continue
+			}
+
+			valid = append(valid, i)
+			bookings = append(bookings, services.Booking{
+				Origin:      row.Origin,
+				Destination: row.Destination,
+				Deadline:    row.Deadline,
+				Attributes:  row.Attributes,
+			})
+		}
+
+		if len(bookings) > 0 {
+			booked, err := bs.BookCargos(ctx, bookings, req.DryRun)
+			if err != nil {
+				return BookCargosResponse{Error: err}, nil
+			}
+
+			for j, i := range valid {
+				results[i] = booked[j]
+			}
+		}
+
+		return BookCargosResponse{Results: results}, nil
+	}
+}
@@ -0,0 +1,293 @@
+package endpoints
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/log"
+	"github.com/mproyyan/grpc-shipping-microservice/booking/services"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/pb"
+)
+
+type CustomerSet struct {
+	RegisterCustomerEndpoint endpoint.Endpoint
+	LoadCustomerEndpoint     endpoint.Endpoint
+	UpdateCustomerEndpoint   endpoint.Endpoint
+	CustomersEndpoint        endpoint.Endpoint
+}
+
+func NewCustomerEndpoints(cs services.CustomerServiceContract, logger log.Logger) CustomerSet {
+	middleware := serverMiddleware(logger)
+
+	return CustomerSet{
+		RegisterCustomerEndpoint: middleware("Customer.RegisterCustomer")(MakeRegisterCustomerEndpoint(cs)),
+		LoadCustomerEndpoint:     middleware("Customer.LoadCustomer")(MakeLoadCustomerEndpoint(cs)),
+		UpdateCustomerEndpoint:   middleware("Customer.UpdateCustomer")(MakeUpdateCustomerEndpoint(cs)),
+		CustomersEndpoint:        middleware("Customer.Customers")(MakeListCustomersEndpoint(cs)),
+	}
+}
+
+func (s CustomerSet) RegisterCustomer(ctx context.Context, name string, contact customer.Contact, preferences customer.NotificationPreferences) (customer.ID, error) {
+	resp, err := s.RegisterCustomerEndpoint(ctx, RegisterCustomerRequest{
+		Name:        name,
+		Contact:     contact,
+		Preferences: preferences,
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	res := resp.(RegisterCustomerResponse)
+	return res.CustomerID, res.Error
+}
+
+func (s CustomerSet) LoadCustomer(ctx context.Context, id customer.ID) (customer.Customer, error) {
+	resp, err := s.LoadCustomerEndpoint(ctx, LoadCustomerRequest{CustomerID: id})
+	if err != nil {
+		return customer.Customer{}, err
+	}
+
+	res := resp.(LoadCustomerResponse)
+	return res.Customer, res.Error
+}
+
+func (s CustomerSet) UpdateCustomer(ctx context.Context, c customer.Customer) error {
+	resp, err := s.UpdateCustomerEndpoint(ctx, UpdateCustomerRequest{Customer: c})
+	if err != nil {
+		return err
+	}
+
+	res := resp.(UpdateCustomerResponse)
+	return res.Error
+}
+
+func (s CustomerSet) Customers(ctx context.Context) ([]customer.Customer, error) {
+	resp, err := s.CustomersEndpoint(ctx, ListCustomersRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	res := resp.(ListCustomersResponse)
+	return res.Customers, res.Error
+}
+
+type RegisterCustomerRequest struct {
+	Name        string                           `json:"name"`
+	Contact     customer.Contact                 `json:"contact"`
+	Preferences customer.NotificationPreferences `json:"preferences"`
+}
+
+func (r RegisterCustomerRequest) Build(req *pb.RegisterCustomerRequest) RegisterCustomerRequest {
+	return RegisterCustomerRequest{
+		Name:        req.GetName(),
+		Contact:     contactFromProto(req.GetContact()),
+		Preferences: preferencesFromProto(req.GetPreferences()),
+	}
+}
+
+type RegisterCustomerResponse struct {
+	CustomerID customer.ID `json:"customer_id,omitempty"`
+	Error      error       `json:"error,omitempty"`
+}
+
+func (r RegisterCustomerResponse) error() error { return r.Error }
+
+func (r RegisterCustomerResponse) Protobuf() *pb.RegisterCustomerResponse {
+	return &pb.RegisterCustomerResponse{
+		CustomerId: string(r.CustomerID),
+		Error:      err2str(r.Error),
+	}
+}
+
+func MakeRegisterCustomerEndpoint(cs services.CustomerServiceContract) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req, ok := request.(RegisterCustomerRequest)
+		if !ok {
+			return nil, errors.New("failed to convert request to RegisterCustomerRequest")
+		}
+
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+
+		id, err := cs.RegisterCustomer(ctx, req.Name, req.Contact, req.Preferences)
+		return RegisterCustomerResponse{
+			CustomerID: id,
+			Error:      err,
+		}, nil
+	}
+}
+
+type LoadCustomerRequest struct {
+	CustomerID customer.ID `json:"customer_id"`
+}
+
+func (r LoadCustomerRequest) Build(req *pb.LoadCustomerRequest) LoadCustomerRequest {
+	return LoadCustomerRequest{
+		CustomerID: customer.ID(req.GetCustomerId()),
+	}
+}
+
+type LoadCustomerResponse struct {
+	Customer customer.Customer `json:"customer,omitempty"`
+	Error    error             `json:"error,omitempty"`
+}
+
+func (r LoadCustomerResponse) error() error { return r.Error }
+
+func (r LoadCustomerResponse) Protobuf() *pb.LoadCustomerResponse {
+	return &pb.LoadCustomerResponse{
+		Customer: CustomerToProto(r.Customer),
+		Error:    err2str(r.Error),
+	}
+}
+
+func MakeLoadCustomerEndpoint(cs services.CustomerServiceContract) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req, ok := request.(LoadCustomerRequest)
+		if !ok {
+			return nil, errors.New("failed to convert request to LoadCustomerRequest")
+		}
+
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+
+		c, err := cs.LoadCustomer(ctx, req.CustomerID)
+		return LoadCustomerResponse{
+			Customer: c,
+			Error:    err,
+		}, nil
+	}
+}
+
+type UpdateCustomerRequest struct {
+	Customer customer.Customer `json:"customer"`
+}
+
+func (r UpdateCustomerRequest) Build(req *pb.UpdateCustomerRequest) UpdateCustomerRequest {
+	return UpdateCustomerRequest{
+		Customer: CustomerFromProto(req.GetCustomer()),
+	}
+}
+
+type UpdateCustomerResponse struct {
+	Status Status `json:"status"`
+	Error  error  `json:"error,omitempty"`
+}
+
+func (r UpdateCustomerResponse) error() error { return r.Error }
+
+func (r UpdateCustomerResponse) Protobuf() *pb.UpdateCustomerResponse {
+	return &pb.UpdateCustomerResponse{
+		Error: err2str(r.Error),
+	}
+}
+
+func MakeUpdateCustomerEndpoint(cs services.CustomerServiceContract) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req, ok := request.(UpdateCustomerRequest)
+		if !ok {
+			return nil, errors.New("failed to convert request to UpdateCustomerRequest")
+		}
+
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+
+		err = cs.UpdateCustomer(ctx, req.Customer)
+		return UpdateCustomerResponse{
+			Status: newStatus(err),
+			Error:  err,
+		}, nil
+	}
+}
+
+type ListCustomersRequest struct{}
+
+type ListCustomersResponse struct {
+	Customers []customer.Customer `json:"customers"`
+	Error     error               `json:"error,omitempty"`
+}
+
+func (r ListCustomersResponse) error() error { return r.Error }
+
+func (r ListCustomersResponse) Protobuf() *pb.CustomersResponse {
+	var customers []*pb.CustomerModel
+	for _, c := range r.Customers {
+		customers = append(customers, CustomerToProto(c))
+	}
+
+	return &pb.CustomersResponse{
+		Customers: customers,
+		Error:     err2str(r.Error),
+	}
+}
+
+func MakeListCustomersEndpoint(cs services.CustomerServiceContract) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		_, ok := request.(ListCustomersRequest)
+		if !ok {
+			return nil, errors.New("failed to convert request to ListCustomersRequest")
+		}
+
+		customers, err := cs.Customers(ctx)
+		return ListCustomersResponse{
+			Customers: customers,
+			Error:     err,
+		}, nil
+	}
+}
+
+// CustomerToProto converts a customer into its protobuf model.
+func CustomerToProto(c customer.Customer) *pb.CustomerModel {
+	return &pb.CustomerModel{
+		Id:   string(c.ID),
+		Name: c.Name,
+		Contact: &pb.CustomerContact{
+			Email:   c.Contact.Email,
+			Phone:   c.Contact.Phone,
+			Address: c.Contact.Address,
+		},
+		Preferences: &pb.NotificationPreferences{
+			Email:       c.Preferences.Email,
+			Sms:         c.Preferences.SMS,
+			WebhookUrl:  c.Preferences.WebhookURL,
+			Misdirected: c.Preferences.Misdirected,
+			Unloaded:    c.Preferences.Unloaded,
+			Late:        c.Preferences.Late,
+		},
+	}
+}
+
+// CustomerFromProto converts a protobuf customer model into a customer.
+func CustomerFromProto(m *pb.CustomerModel) customer.Customer {
+	return customer.Customer{
+		ID:          customer.ID(m.GetId()),
+		Name:        m.GetName(),
+		Contact:     contactFromProto(m.GetContact()),
+		Preferences: preferencesFromProto(m.GetPreferences()),
+	}
+}
+
+func contactFromProto(m *pb.CustomerContact) customer.Contact {
+	return customer.Contact{
+		Email:   m.GetEmail(),
+		Phone:   m.GetPhone(),
+		Address: m.GetAddress(),
+	}
+}
+
+func preferencesFromProto(m *pb.NotificationPreferences) customer.NotificationPreferences {
+	return customer.NotificationPreferences{
+		Email:       m.GetEmail(),
+		SMS:         m.GetSms(),
+		WebhookURL:  m.GetWebhookUrl(),
+		Misdirected: m.GetMisdirected(),
+		Unloaded:    m.GetUnloaded(),
+		Late:        m.GetLate(),
+	}
+}
@@ -0,0 +1,160 @@
+package endpoints
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/mproyyan/grpc-shipping-microservice/booking/services"
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/export"
+	"github.com/mproyyan/grpc-shipping-microservice/location"
+	"github.com/mproyyan/grpc-shipping-microservice/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s Set) ExportCargos(ctx context.Context, filter cargo.Filter, history bool, yield func(export.Record) error) error {
+	resp, err := s.ExportCargosEndpoint(ctx, ExportCargosRequest{
+		Filter:  filter,
+		History: history,
+		Yield:   yield,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return resp.(ExportCargosResponse).Error
+}
+
+// ExportCargosRequest selects the exported cargos. Yield is called once per
+// cargo, on the server it sends the cargo down the stream and on the client
+// it hands the received cargo to the caller.
+type ExportCargosRequest struct {
+	Filter  cargo.Filter
+	History bool
+	Yield   func(export.Record) error
+}
+
+func (ecreq ExportCargosRequest) Build(req *pb.ExportCargosRequest) ExportCargosRequest {
+	filter := buildFilter(req.GetOrigin(), req.GetDestination(), req.GetCustomerId(), req.GetDeadlineFrom(), req.GetDeadlineTo(), req.GetExcludeClosed())
+	filter.Misdirected = req.GetMisdirected()
+	filter.Late = req.GetLate()
+
+	return ExportCargosRequest{
+		Filter:  filter,
+		History: req.GetIncludeHistory(),
+	}
+}
+
+type ExportCargosResponse struct {
+	Error error
+}
+
+func (res ExportCargosResponse) error() error { return res.Error }
+
+func MakeExportCargosEndpoint(bs services.BookingServiceContract) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req, ok := request.(ExportCargosRequest)
+		if !ok {
+			return nil, errors.New("failed to convert request to ExportCargosRequest")
+		}
+
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+
+		err = bs.ExportCargos(ctx, req.Filter, req.History, req.Yield)
+		return ExportCargosResponse{Error: err}, nil
+	}
+}
+
+// ExportedCargo converts a record to its protobuf message.
+func ExportedCargo(r export.Record) *pb.ExportedCargo {
+	out := &pb.ExportedCargo{
+		TrackingId:            r.TrackingID,
+		CustomerId:            r.CustomerID,
+		Origin:                r.Origin,
+		Destination:           r.Destination,
+		ArrivalDeadline:       timestamppb.New(r.ArrivalDeadline),
+		RoutingStatus:         r.RoutingStatus,
+		TransportStatus:       r.TransportStatus,
+		LastKnownLocation:     r.LastKnownLocation,
+		CurrentVoyage:         r.CurrentVoyage,
+		Misdirected:           r.Misdirected,
+		UnloadedAtDestination: r.UnloadedAtDestination,
+		NextExpectedActivity:  r.NextExpectedActivity,
+	}
+
+	if r.ETA != nil {
+		out.Eta = timestamppb.New(*r.ETA)
+	}
+
+	for _, e := range r.History {
+		out.HandlingHistory = append(out.HandlingHistory, &pb.ExportedEvent{
+			Type:         e.Type,
+			Location:     e.Location,
+			VoyageNumber: e.VoyageNumber,
+			Completed:    timestamppb.New(e.Completed),
+		})
+	}
+
+	return out
+}
+
+// ExportRecord converts a received cargo back to a record.
+func ExportRecord(c *pb.ExportedCargo) export.Record {
+	r := export.Record{
+		TrackingID:            c.GetTrackingId(),
+		CustomerID:            c.GetCustomerId(),
+		Origin:                c.GetOrigin(),
+		Destination:           c.GetDestination(),
+		ArrivalDeadline:       optionalTime(c.GetArrivalDeadline()),
+		RoutingStatus:         c.GetRoutingStatus(),
+		TransportStatus:       c.GetTransportStatus(),
+		LastKnownLocation:     c.GetLastKnownLocation(),
+		CurrentVoyage:         c.GetCurrentVoyage(),
+		Misdirected:           c.GetMisdirected(),
+		UnloadedAtDestination: c.GetUnloadedAtDestination(),
+		NextExpectedActivity:  c.GetNextExpectedActivity(),
+	}
+
+	if c.GetEta() != nil {
+		eta := c.GetEta().AsTime()
+		r.ETA = &eta
+	}
+
+	for _, e := range c.GetHandlingHistory() {
+		r.History = append(r.History, export.Event{
+			Type:         e.GetType(),
+			Location:     e.GetLocation(),
+			VoyageNumber: e.GetVoyageNumber(),
+			Completed:    optionalTime(e.GetCompleted()),
+		})
+	}
+
+	return r
+}
+
+func buildFilter(origin, destination, customerID string, from, to *timestamppb.Timestamp, excludeClosed bool) cargo.Filter {
+	return cargo.Filter{
+		Origin:        location.UNLocode(origin),
+		Destination:   location.UNLocode(destination),
+		CustomerID:    customer.ID(customerID),
+		DeadlineFrom:  optionalTime(from),
+		DeadlineTo:    optionalTime(to),
+		ExcludeClosed: excludeClosed,
+	}
+}
+
+// optionalTime maps an unset timestamp to the zero time rather than the
+// Unix epoch.
+func optionalTime(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+
+	return ts.AsTime()
+}
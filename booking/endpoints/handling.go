@@ -0,0 +1,231 @@
+package endpoints
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/log"
+	"github.com/mproyyan/grpc-shipping-microservice/booking/services"
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/location"
+	"github.com/mproyyan/grpc-shipping-microservice/pb"
+	"github.com/mproyyan/grpc-shipping-microservice/shipment"
+	"github.com/mproyyan/grpc-shipping-microservice/voyage"
+)
+
+type HandlingSet struct {
+	RegisterHandlingEventEndpoint          endpoint.Endpoint
+	RegisterContainerHandlingEventEndpoint endpoint.Endpoint
+	IngestEdifactEndpoint                  endpoint.Endpoint
+}
+
+func NewHandlingEndpoints(hs services.HandlingServiceContract, logger log.Logger) HandlingSet {
+	middleware := serverMiddleware(logger)
+
+	return HandlingSet{
+		RegisterHandlingEventEndpoint:          middleware("Handling.RegisterHandlingEvent")(MakeRegisterHandlingEventEndpoint(hs)),
+		RegisterContainerHandlingEventEndpoint: middleware("Handling.RegisterContainerHandlingEvent")(MakeRegisterContainerHandlingEventEndpoint(hs)),
+		IngestEdifactEndpoint:                  middleware("Handling.IngestEdifact")(MakeIngestEdifactEndpoint(hs)),
+	}
+}
+
+func (s HandlingSet) RegisterHandlingEvent(ctx context.Context, completed time.Time, id cargo.TrackingID, voyageNumber voyage.Number, loc location.UNLocode, eventType cargo.HandlingEventType) error {
+	resp, err := s.RegisterHandlingEventEndpoint(ctx, RegisterHandlingEventRequest{
+		TrackingID:   id,
+		Type:         eventType.String(),
+		Location:     loc,
+		VoyageNumber: voyageNumber,
+		Completed:    completed,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	res := resp.(RegisterHandlingEventResponse)
+	return res.Error
+}
+
+func (s HandlingSet) RegisterContainerHandlingEvent(ctx context.Context, completed time.Time, container shipment.ContainerID, voyageNumber voyage.Number, loc location.UNLocode, eventType cargo.HandlingEventType) ([]cargo.TrackingID, error) {
+	resp, err := s.RegisterContainerHandlingEventEndpoint(ctx, RegisterContainerHandlingEventRequest{
+		ContainerID:  container,
+		Type:         eventType.String(),
+		Location:     loc,
+		VoyageNumber: voyageNumber,
+		Completed:    completed,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := resp.(RegisterContainerHandlingEventResponse)
+	return res.TrackingIDs, res.Error
+}
+
+func (s HandlingSet) IngestEDIFACT(ctx context.Context, interchange []byte) (services.IngestionReport, error) {
+	resp, err := s.IngestEdifactEndpoint(ctx, IngestEdifactRequest{Interchange: interchange})
+	if err != nil {
+		return services.IngestionReport{}, err
+	}
+
+	res := resp.(IngestEdifactResponse)
+	return res.Report, res.Error
+}
+
+// RegisterHandlingEventRequest carries the event type by name, it is parsed
+// while validating.
+type RegisterHandlingEventRequest struct {
+	TrackingID   cargo.TrackingID  `json:"tracking_id"`
+	Type         string            `json:"type"`
+	Location     location.UNLocode `json:"location"`
+	VoyageNumber voyage.Number     `json:"voyage_number"`
+	Completed    time.Time         `json:"completed"`
+}
+
+func (rhereq RegisterHandlingEventRequest) Build(req *pb.RegisterHandlingEventRequest) RegisterHandlingEventRequest {
+	return RegisterHandlingEventRequest{
+		TrackingID:   cargo.TrackingID(req.GetTrackingId()),
+		Type:         req.GetType(),
+		Location:     location.UNLocode(req.GetLocation()),
+		VoyageNumber: voyage.Number(req.GetVoyageNumber()),
+		Completed:    optionalTime(req.GetCompleted()),
+	}
+}
+
+type RegisterHandlingEventResponse struct {
+	Error error `json:"error,omitempty"`
+}
+
+func (res RegisterHandlingEventResponse) error() error { return res.Error }
+
+func (res RegisterHandlingEventResponse) Protobuf() *pb.RegisterHandlingEventResponse {
+	return &pb.RegisterHandlingEventResponse{Error: err2str(res.Error)}
+}
+
+func MakeRegisterHandlingEventEndpoint(hs services.HandlingServiceContract) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req, ok := request.(RegisterHandlingEventRequest)
+		if !ok {
+			return nil, errors.New("failed to convert request to RegisterHandlingEventRequest")
+		}
+
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+
+		eventType, _ := cargo.ParseHandlingEventType(req.Type)
+		err = hs.RegisterHandlingEvent(ctx, req.Completed, req.TrackingID, req.VoyageNumber, req.Location, eventType)
+		return RegisterHandlingEventResponse{Error: err}, nil
+	}
+}
+
+// RegisterContainerHandlingEventRequest registers one event for every cargo
+// in the container.
+type RegisterContainerHandlingEventRequest struct {
+	ContainerID  shipment.ContainerID `json:"container_id"`
+	Type         string               `json:"type"`
+	Location     location.UNLocode    `json:"location"`
+	VoyageNumber voyage.Number        `json:"voyage_number"`
+	Completed    time.Time            `json:"completed"`
+}
+
+func (r RegisterContainerHandlingEventRequest) Build(req *pb.RegisterContainerHandlingEventRequest) RegisterContainerHandlingEventRequest {
+	return RegisterContainerHandlingEventRequest{
+		ContainerID:  shipment.ContainerID(req.GetContainerId()),
+		Type:         req.GetType(),
+		Location:     location.UNLocode(req.GetLocation()),
+		VoyageNumber: voyage.Number(req.GetVoyageNumber()),
+		Completed:    optionalTime(req.GetCompleted()),
+	}
+}
+
+type RegisterContainerHandlingEventResponse struct {
+	TrackingIDs []cargo.TrackingID `json:"tracking_ids"`
+	Error       error              `json:"error,omitempty"`
+}
+
+func (res RegisterContainerHandlingEventResponse) error() error { return res.Error }
+
+func (res RegisterContainerHandlingEventResponse) Protobuf() *pb.RegisterContainerHandlingEventResponse {
+	var ids []string
+	for _, id := range res.TrackingIDs {
+		ids = append(ids, string(id))
+	}
+
+	return &pb.RegisterContainerHandlingEventResponse{
+		TrackingIds: ids,
+		Error:       err2str(res.Error),
+	}
+}
+
+func MakeRegisterContainerHandlingEventEndpoint(hs services.HandlingServiceContract) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req, ok := request.(RegisterContainerHandlingEventRequest)
+		if !ok {
+			return nil, errors.New("failed to convert request to RegisterContainerHandlingEventRequest")
+		}
+
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+
+		eventType, _ := cargo.ParseHandlingEventType(req.Type)
+		ids, err := hs.RegisterContainerHandlingEvent(ctx, req.Completed, req.ContainerID, req.VoyageNumber, req.Location, eventType)
+		return RegisterContainerHandlingEventResponse{TrackingIDs: ids, Error: err}, nil
+	}
+}
+
+type IngestEdifactRequest struct {
+	Interchange []byte `json:"interchange"`
+}
+
+func (r IngestEdifactRequest) Build(req *pb.IngestEdifactRequest) IngestEdifactRequest {
+	return IngestEdifactRequest{Interchange: req.GetInterchange()}
+}
+
+type IngestEdifactResponse struct {
+	Report services.IngestionReport `json:"report"`
+	Error  error                    `json:"error,omitempty"`
+}
+
+func (res IngestEdifactResponse) error() error { return res.Error }
+
+func (res IngestEdifactResponse) Protobuf() *pb.IngestEdifactResponse {
+	var results []*pb.EdifactMessageResult
+	for _, r := range res.Report.Results {
+		results = append(results, &pb.EdifactMessageResult{
+			Reference: r.Reference,
+			Type:      r.Type,
+			Events:    int32(r.Events),
+			Error:     err2str(r.Err),
+		})
+	}
+
+	return &pb.IngestEdifactResponse{
+		Interchange: res.Report.Interchange,
+		Sender:      res.Report.Sender,
+		Results:     results,
+		Error:       err2str(res.Error),
+	}
+}
+
+// MakeIngestEdifactEndpoint hands the interchange to the service as is, the
+// messages are checked one by one while they are mapped.
+func MakeIngestEdifactEndpoint(hs services.HandlingServiceContract) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req, ok := request.(IngestEdifactRequest)
+		if !ok {
+			return nil, errors.New("failed to convert request to IngestEdifactRequest")
+		}
+
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+
+		report, err := hs.IngestEDIFACT(ctx, req.Interchange)
+		return IngestEdifactResponse{Report: report, Error: err}, nil
+	}
+}
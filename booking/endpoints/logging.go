@@ -0,0 +1,46 @@
+package endpoints
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/mproyyan/grpc-shipping-microservice/logging"
+)
+
+type errorer interface {
+	error() error
+}
+
+// LoggingMiddleware logs one line per endpoint call with the request ID,
+// method, duration and error. Business errors carried inside the response are
+// logged as well. Requests and responses themselves are never logged since
+// they may contain customer data.
+func LoggingMiddleware(logger log.Logger, method string) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+			defer func(begin time.Time) {
+				failure := err
+				if e, ok := response.(errorer); ok && failure == nil {
+					failure = e.error()
+				}
+
+				l := level.Info(logger)
+				if failure != nil {
+					l = level.Warn(logger)
+				}
+
+				l.Log(
+					"request_id", logging.RequestID(ctx),
+					"method", method,
+					"took", time.Since(begin),
+					"err", failure,
+				)
+			}(time.Now())
+
+			return next(ctx, request)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package endpoints
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/log"
+	"github.com/mproyyan/grpc-shipping-microservice/booking/services"
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/imdg"
+	"github.com/mproyyan/grpc-shipping-microservice/location"
+	"github.com/mproyyan/grpc-shipping-microservice/pb"
+	"github.com/mproyyan/grpc-shipping-microservice/pricing"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type PricingSet struct {
+	QuoteEndpoint endpoint.Endpoint
+}
+
+func NewPricingEndpoints(ps services.PricingServiceContract, logger log.Logger) PricingSet {
+	middleware := serverMiddleware(logger)
+
+	return PricingSet{
+		QuoteEndpoint: middleware("Pricing.Quote")(MakeQuoteEndpoint(ps)),
+	}
+}
+
+func (s PricingSet) Quote(ctx context.Context, rs cargo.RouteSpecification, attributes cargo.Attributes) (pricing.Quote, error) {
+	resp, err := s.QuoteEndpoint(ctx, QuoteRequest{
+		Origin:          rs.Origin,
+		Destination:     rs.Destination,
+		ArrivalDeadline: rs.ArrivalDeadline,
+		Attributes:      attributes,
+	})
+
+	if err != nil {
+		return pricing.Quote{}, err
+	}
+
+	res := resp.(QuoteResponse)
+	return res.Quote, res.Error
+}
+
+type QuoteRequest struct {
+	Origin          location.UNLocode `json:"origin"`
+	Destination     location.UNLocode `json:"destination"`
+	ArrivalDeadline time.Time         `json:"arrival_deadline"`
+	Attributes      cargo.Attributes  `json:"attributes"`
+}
+
+func (r QuoteRequest) Build(req *pb.QuoteRequest) QuoteRequest {
+	return QuoteRequest{
+		Origin:          location.UNLocode(req.GetOrigin()),
+		Destination:     location.UNLocode(req.GetDestination()),
+		ArrivalDeadline: req.GetArrivalDeadline().AsTime(),
+		Attributes: cargo.Attributes{
+			Weight: req.GetWeight(),
+			Volume: req.GetVolume(),
+			DangerousGoods: imdg.Goods{
+				Class:    imdg.Class(req.GetImdgClass()),
+				UNNumber: imdg.UNNumber(req.GetUnNumber()),
+			},
+		},
+	}
+}
+
+type QuoteResponse struct {
+	Quote pricing.Quote `json:"quote"`
+	Error error         `json:"error,omitempty"`
+}
+
+func (res QuoteResponse) error() error { return res.Error }
+
+func (res QuoteResponse) Protobuf() *pb.QuoteResponse {
+	return &pb.QuoteResponse{
+		Quote: QuoteToProto(res.Quote),
+		Error: err2str(res.Error),
+	}
+}
+
+func MakeQuoteEndpoint(ps services.PricingServiceContract) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req, ok := request.(QuoteRequest)
+		if !ok {
+			return nil, errors.New("failed to convert request to QuoteRequest")
+		}
+
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+
+		rs := cargo.RouteSpecification{
+			Origin:          req.Origin,
+			Destination:     req.Destination,
+			ArrivalDeadline: req.ArrivalDeadline,
+		}
+
+		q, err := ps.Quote(ctx, rs, req.Attributes)
+		return QuoteResponse{Quote: q, Error: err}, nil
+	}
+}
+
+// QuoteToProto converts a quote into its protobuf model. The customer and
+// the attributes quoted are left out, the caller knows them.
+func QuoteToProto(q pricing.Quote) *pb.QuoteModel {
+	m := &pb.QuoteModel{
+		QuoteId:         string(q.ID),
+		Origin:          string(q.RouteSpecification.Origin),
+		Destination:     string(q.RouteSpecification.Destination),
+		ArrivalDeadline: timestamppb.New(q.RouteSpecification.ArrivalDeadline),
+		Expires:         timestamppb.New(q.Expires),
+	}
+
+	for _, o := range q.Options {
+		option := &pb.PricedOption{
+			Legs:  LegsToProto(o.Itinerary.Legs),
+			Price: MoneyToProto(&o.Price),
+		}
+
+		for _, c := range o.Charges {
+			option.Charges = append(option.Charges, &pb.Charge{Description: c.Description, Amount: c.Amount})
+		}
+
+		m.Options = append(m.Options, option)
+	}
+
+	return m
+}
+
+// QuoteFromProto converts a protobuf quote model into a quote.
+func QuoteFromProto(m *pb.QuoteModel) pricing.Quote {
+	q := pricing.Quote{
+		ID: pricing.QuoteID(m.GetQuoteId()),
+		RouteSpecification: cargo.RouteSpecification{
+			Origin:          location.UNLocode(m.GetOrigin()),
+			Destination:     location.UNLocode(m.GetDestination()),
+			ArrivalDeadline: m.GetArrivalDeadline().AsTime(),
+		},
+		Expires: m.GetExpires().AsTime(),
+	}
+
+	for _, o := range m.GetOptions() {
+		option := pricing.Option{Itinerary: cargo.Itinerary{Legs: LegsFromProto(o.GetLegs())}}
+		if price := MoneyFromProto(o.GetPrice()); price != nil {
+			option.Price = *price
+		}
+
+		for _, c := range o.GetCharges() {
+			option.Charges = append(option.Charges, pricing.Charge{Description: c.GetDescription(), Amount: c.GetAmount()})
+		}
+
+		q.Options = append(q.Options, option)
+	}
+
+	return q
+}
+
+// MoneyToProto converts an optional amount into its protobuf model.
+func MoneyToProto(m *pricing.Money) *pb.Money {
+	if m == nil {
+		return nil
+	}
+
+	return &pb.Money{Amount: m.Amount, Currency: m.Currency}
+}
+
+// MoneyFromProto converts an optional protobuf amount.
+func MoneyFromProto(m *pb.Money) *pricing.Money {
+	if m == nil {
+		return nil
+	}
+
+	return &pricing.Money{Amount: m.GetAmount(), Currency: m.GetCurrency()}
+}
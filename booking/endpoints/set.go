@@ -3,47 +3,76 @@ package endpoints
 import (
 	"context"
 	"errors"
-	"fmt"
 	"time"
 
 	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/log"
 	"github.com/mproyyan/grpc-shipping-microservice/booking/services"
 	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/imdg"
 	"github.com/mproyyan/grpc-shipping-microservice/location"
 	"github.com/mproyyan/grpc-shipping-microservice/pb"
+	"github.com/mproyyan/grpc-shipping-microservice/pricing"
+	"github.com/mproyyan/grpc-shipping-microservice/tracing"
 	"github.com/mproyyan/grpc-shipping-microservice/voyage"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type Set struct {
-	BookNewCargoEndpoint       endpoint.Endpoint
-	LoadCargoEndpoint          endpoint.Endpoint
-	AssignCargoToRouteEndpoint endpoint.Endpoint
-	ChangeDestinationEndpoint  endpoint.Endpoint
-	CargosEndpoint             endpoint.Endpoint
-}
-
-func NewBookingEndpoints(bs services.BookingServiceContract) Set {
-	var bookNewCargoEndpoint = MakeBookNewCargoEndpoint(bs)
-	var loadCargoEndpoint = MakeLoadCargoEndpoint(bs)
-	var assignCargoToRouteEndpoint = MakeAssignCargoToRouteEndpoint(bs)
-	var changeDestinationEndpoint = MakeChangeDestinationEndpoint(bs)
-	var listCargosEndpoint = MakeListCargosEndpoint(bs)
+	BookNewCargoEndpoint             endpoint.Endpoint
+	LoadCargoEndpoint                endpoint.Endpoint
+	AssignCargoToRouteEndpoint       endpoint.Endpoint
+	ChangeDestinationEndpoint        endpoint.Endpoint
+	UpdateRouteSpecificationEndpoint endpoint.Endpoint
+	CancelBookingEndpoint            endpoint.Endpoint
+	HoldCargoEndpoint                endpoint.Endpoint
+	ReleaseCargoEndpoint             endpoint.Endpoint
+	HandlingHistoryEndpoint          endpoint.Endpoint
+	CargosEndpoint                   endpoint.Endpoint
+	BookCargosEndpoint               endpoint.Endpoint
+	ExportCargosEndpoint             endpoint.Endpoint
+}
+
+func NewBookingEndpoints(bs services.BookingServiceContract, logger log.Logger) Set {
+	middleware := serverMiddleware(logger)
+
+	var bookNewCargoEndpoint = middleware("Booking.BookNewCargo")(MakeBookNewCargoEndpoint(bs))
+	var loadCargoEndpoint = middleware("Booking.LoadCargo")(MakeLoadCargoEndpoint(bs))
+	var assignCargoToRouteEndpoint = middleware("Booking.AssignCargoToRoute")(MakeAssignCargoToRouteEndpoint(bs))
+	var changeDestinationEndpoint = middleware("Booking.ChangeDestination")(MakeChangeDestinationEndpoint(bs))
+	var updateRouteSpecificationEndpoint = middleware("Booking.UpdateRouteSpecification")(MakeUpdateRouteSpecificationEndpoint(bs))
+	var cancelBookingEndpoint = middleware("Booking.CancelBooking")(MakeCancelBookingEndpoint(bs))
+	var holdCargoEndpoint = middleware("Booking.HoldCargo")(MakeHoldCargoEndpoint(bs))
+	var releaseCargoEndpoint = middleware("Booking.ReleaseCargo")(MakeReleaseCargoEndpoint(bs))
+	var handlingHistoryEndpoint = middleware("Booking.HandlingHistory")(MakeHandlingHistoryEndpoint(bs))
+	var listCargosEndpoint = middleware("Booking.Cargos")(MakeListCargosEndpoint(bs))
+	var bookCargosEndpoint = middleware("Booking.BookCargos")(MakeBookCargosEndpoint(bs))
+	var exportCargosEndpoint = middleware("Booking.ExportCargos")(MakeExportCargosEndpoint(bs))
 
 	return Set{
-		BookNewCargoEndpoint:       bookNewCargoEndpoint,
-		LoadCargoEndpoint:          loadCargoEndpoint,
-		AssignCargoToRouteEndpoint: assignCargoToRouteEndpoint,
-		ChangeDestinationEndpoint:  changeDestinationEndpoint,
-		CargosEndpoint:             listCargosEndpoint,
+		BookNewCargoEndpoint:             bookNewCargoEndpoint,
+		LoadCargoEndpoint:                loadCargoEndpoint,
+		AssignCargoToRouteEndpoint:       assignCargoToRouteEndpoint,
+		ChangeDestinationEndpoint:        changeDestinationEndpoint,
+		UpdateRouteSpecificationEndpoint: updateRouteSpecificationEndpoint,
+		CancelBookingEndpoint:            cancelBookingEndpoint,
+		HoldCargoEndpoint:                holdCargoEndpoint,
+		ReleaseCargoEndpoint:             releaseCargoEndpoint,
+		HandlingHistoryEndpoint:          handlingHistoryEndpoint,
+		CargosEndpoint:                   listCargosEndpoint,
+		BookCargosEndpoint:               bookCargosEndpoint,
+		ExportCargosEndpoint:             exportCargosEndpoint,
 	}
 }
 
-func (s Set) BookNewCargo(ctx context.Context, origin location.UNLocode, destination location.UNLocode, deadline time.Time) (cargo.TrackingID, error) {
+func (s Set) BookNewCargo(ctx context.Context, origin location.UNLocode, destination location.UNLocode, deadline time.Time, attributes cargo.Attributes, quote pricing.Acceptance) (cargo.TrackingID, error) {
 	resp, err := s.BookNewCargoEndpoint(ctx, BookNewCargoRequest{
 		Origin:      origin,
 		Destination: destination,
 		Deadline:    deadline,
+		Attributes:  attributes,
+		Quote:       quote,
 	})
 
 	if err != nil {
@@ -51,7 +80,7 @@ func (s Set) BookNewCargo(ctx context.Context, origin location.UNLocode, destina
 	}
 
 	res := resp.(BookNewCargoResponse)
-	return res.TrackingID, nil
+	return res.TrackingID, res.Error
 }
 
 func (s Set) LoadCargo(ctx context.Context, id cargo.TrackingID) (services.Cargo, error) {
@@ -64,12 +93,11 @@ func (s Set) LoadCargo(ctx context.Context, id cargo.TrackingID) (services.Cargo
 	}
 
 	res := resp.(LoadCargoResponse)
-	return res.Cargo, nil
+	return res.Cargo, res.Error
 }
 
 func (s Set) AssignCargoToRoute(ctx context.Context, id cargo.TrackingID, itinerary cargo.Itinerary) error {
-	fmt.Println(itinerary)
-	_, err := s.AssignCargoToRouteEndpoint(ctx, AssignCargoToRouteRequest{
+	resp, err := s.AssignCargoToRouteEndpoint(ctx, AssignCargoToRouteRequest{
 		TrackingID: id,
 		Itinerary:  itinerary,
 	})
@@ -78,11 +106,12 @@ func (s Set) AssignCargoToRoute(ctx context.Context, id cargo.TrackingID, itiner
 		return err
 	}
 
-	return nil
+	res := resp.(AssignCargoToRouteResponse)
+	return res.Error
 }
 
 func (s Set) ChangeDestination(ctx context.Context, id cargo.TrackingID, destination location.UNLocode) error {
-	_, err := s.ChangeDestinationEndpoint(ctx, ChangeDestinationRequest{
+	resp, err := s.ChangeDestinationEndpoint(ctx, ChangeDestinationRequest{
 		TrackingID:  id,
 		Destination: destination,
 	})
@@ -91,23 +120,82 @@ func (s Set) ChangeDestination(ctx context.Context, id cargo.TrackingID, destina
 		return err
 	}
 
-	return nil
+	res := resp.(ChangeDestinationResponse)
+	return res.Error
+}
+
+func (s Set) UpdateRouteSpecification(ctx context.Context, id cargo.TrackingID, rs cargo.RouteSpecification) (bool, error) {
+	resp, err := s.UpdateRouteSpecificationEndpoint(ctx, UpdateRouteSpecificationRequest{
+		TrackingID:      id,
+		Origin:          rs.Origin,
+		Destination:     rs.Destination,
+		ArrivalDeadline: rs.ArrivalDeadline,
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	res := resp.(UpdateRouteSpecificationResponse)
+	return res.ItinerarySatisfied, res.Error
 }
 
-func (s Set) Cargos(ctx context.Context) ([]services.Cargo, error) {
-	resp, err := s.CargosEndpoint(ctx, ListCargosRequest{})
+func (s Set) CancelBooking(ctx context.Context, id cargo.TrackingID) error {
+	resp, err := s.CancelBookingEndpoint(ctx, CancelBookingRequest{TrackingID: id})
+	if err != nil {
+		return err
+	}
+
+	res := resp.(CancelBookingResponse)
+	return res.Error
+}
+
+func (s Set) HoldCargo(ctx context.Context, id cargo.TrackingID, reason string) error {
+	resp, err := s.HoldCargoEndpoint(ctx, HoldCargoRequest{TrackingID: id, Reason: reason})
+	if err != nil {
+		return err
+	}
+
+	res := resp.(HoldCargoResponse)
+	return res.Error
+}
+
+func (s Set) ReleaseCargo(ctx context.Context, id cargo.TrackingID) error {
+	resp, err := s.ReleaseCargoEndpoint(ctx, ReleaseCargoRequest{TrackingID: id})
+	if err != nil {
+		return err
+	}
+
+	res := resp.(ReleaseCargoResponse)
+	return res.Error
+}
+
+func (s Set) HandlingHistory(ctx context.Context, id cargo.TrackingID) ([]services.HandlingEvent, error) {
+	resp, err := s.HandlingHistoryEndpoint(ctx, HandlingHistoryRequest{TrackingID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	res := resp.(HandlingHistoryResponse)
+	return res.Events, res.Error
+}
+
+func (s Set) Cargos(ctx context.Context, filter cargo.Filter) ([]services.Cargo, error) {
+	resp, err := s.CargosEndpoint(ctx, ListCargosRequest{Filter: filter})
 	if err != nil {
 		return nil, err
 	}
 
 	res := resp.(ListCargosResponse)
-	return res.Cargos, nil
+	return res.Cargos, res.Error
 }
 
 type BookNewCargoRequest struct {
-	Origin      location.UNLocode `json:"origin"`
-	Destination location.UNLocode `json:"destination"`
-	Deadline    time.Time         `json:"deadline"`
+	Origin      location.UNLocode  `json:"origin"`
+	Destination location.UNLocode  `json:"destination"`
+	Deadline    time.Time          `json:"deadline"`
+	Attributes  cargo.Attributes   `json:"attributes"`
+	Quote       pricing.Acceptance `json:"quote"`
 }
 
 func (bncreq BookNewCargoRequest) Build(req *pb.BookNewCargoRequest) BookNewCargoRequest {
@@ -115,6 +203,21 @@ func (bncreq BookNewCargoRequest) Build(req *pb.BookNewCargoRequest) BookNewCarg
 		Origin:      location.UNLocode(req.GetOrigin()),
 		Destination: location.UNLocode(req.GetDestination()),
 		Deadline:    req.Deadline.AsTime(),
+		Attributes: cargo.Attributes{
+			Weight:    req.GetWeight(),
+			Volume:    req.GetVolume(),
+			Packages:  int(req.GetPackages()),
+			Commodity: req.GetCommodity(),
+			HSCode:    req.GetHsCode(),
+			DangerousGoods: imdg.Goods{
+				Class:    imdg.Class(req.GetImdgClass()),
+				UNNumber: imdg.UNNumber(req.GetUnNumber()),
+			},
+		},
+		Quote: pricing.Acceptance{
+			QuoteID: pricing.QuoteID(req.GetQuoteId()),
+			Option:  int(req.GetQuoteOption()),
+		},
 	}
 }
 
@@ -139,7 +242,11 @@ func MakeBookNewCargoEndpoint(bs services.BookingServiceContract) endpoint.Endpo
 			return nil, errors.New("failed to convert request to BookNewCargoRequest")
 		}
 
-		id, err := bs.BookNewCargo(ctx, req.Origin, req.Destination, req.Deadline)
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+
+		id, err := bs.BookNewCargo(ctx, req.Origin, req.Destination, req.Deadline, req.Attributes, req.Quote)
 		return BookNewCargoResponse{
 			TrackingID: id,
 			Error:      err,
@@ -181,12 +288,25 @@ func (lcres LoadCargoResponse) Protobuf() *pb.LoadCargoResponse {
 	return &pb.LoadCargoResponse{
 		Cargo: &pb.BookingCargoModel{
 			TrackingId:      string(lcres.Cargo.TrackingID),
+			CustomerId:      lcres.Cargo.CustomerID,
 			ArrivalDeadline: timestamppb.New(lcres.Cargo.ArrivalDeadline),
 			Destination:     lcres.Cargo.Destination,
 			Legs:            legs,
 			Misrouted:       lcres.Cargo.Misrouted,
 			Origin:          string(lcres.Cargo.Origin),
 			Routed:          lcres.Cargo.Routed,
+			Status:          lcres.Cargo.Status,
+			Weight:          lcres.Cargo.Weight,
+			Volume:          lcres.Cargo.Volume,
+			Packages:        int32(lcres.Cargo.Packages),
+			Commodity:       lcres.Cargo.Commodity,
+			HsCode:          lcres.Cargo.HSCode,
+			Clearance:       lcres.Cargo.Clearance,
+			HoldReason:      lcres.Cargo.HoldReason,
+			ImdgClass:       lcres.Cargo.IMDGClass,
+			UnNumber:        lcres.Cargo.UNNumber,
+			QuoteId:         lcres.Cargo.QuoteID,
+			Price:           MoneyToProto(lcres.Cargo.Price),
 		},
 		Error: err2str(lcres.Error),
 	}
@@ -199,6 +319,10 @@ func MakeLoadCargoEndpoint(bs services.BookingServiceContract) endpoint.Endpoint
 			return nil, errors.New("failed to convert request to LoadCargoRequest")
 		}
 
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+
 		cargo, err := bs.LoadCargo(ctx, req.TrackingID)
 		return LoadCargoResponse{
 			Cargo: cargo,
@@ -264,7 +388,10 @@ func MakeAssignCargoToRouteEndpoint(bs services.BookingServiceContract) endpoint
 		if !ok {
 			return nil, errors.New("failed to convert request to AssignCargoToRouteRequest")
 		}
-		fmt.Println("req.itineraty :", req.Itinerary)
+
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
 		err = bs.AssignCargoToRoute(ctx, req.TrackingID, req.Itinerary)
 		return AssignCargoToRouteResponse{
 			Status: newStatus(err),
@@ -305,6 +432,10 @@ func MakeChangeDestinationEndpoint(bs services.BookingServiceContract) endpoint.
 			return nil, errors.New("failed to convert request to ChangeDestinationRequest")
 		}
 
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+
 		err = bs.ChangeDestination(ctx, req.TrackingID, req.Destination)
 		return ChangeDestinationResponse{
 			Status: newStatus(err),
@@ -313,7 +444,255 @@ func MakeChangeDestinationEndpoint(bs services.BookingServiceContract) endpoint.
 	}
 }
 
-type ListCargosRequest struct{}
+// UpdateRouteSpecificationRequest changes the fields that are set, zero
+// fields keep their current value.
+type UpdateRouteSpecificationRequest struct {
+	TrackingID      cargo.TrackingID  `json:"tracking_id"`
+	Origin          location.UNLocode `json:"origin,omitempty"`
+	Destination     location.UNLocode `json:"destination,omitempty"`
+	ArrivalDeadline time.Time         `json:"arrival_deadline,omitempty"`
+}
+
+func (r UpdateRouteSpecificationRequest) Build(req *pb.UpdateRouteSpecificationRequest) UpdateRouteSpecificationRequest {
+	return UpdateRouteSpecificationRequest{
+		TrackingID:      cargo.TrackingID(req.GetTrackingId()),
+		Origin:          location.UNLocode(req.GetOrigin()),
+		Destination:     location.UNLocode(req.GetDestination()),
+		ArrivalDeadline: optionalTime(req.GetArrivalDeadline()),
+	}
+}
+
+type UpdateRouteSpecificationResponse struct {
+	ItinerarySatisfied bool  `json:"itinerary_satisfied"`
+	Error              error `json:"error,omitempty"`
+}
+
+func (res UpdateRouteSpecificationResponse) error() error { return res.Error }
+
+func (r UpdateRouteSpecificationResponse) Protobuf() *pb.UpdateRouteSpecificationResponse {
+	return &pb.UpdateRouteSpecificationResponse{
+		ItinerarySatisfied: r.ItinerarySatisfied,
+		Error:              err2str(r.Error),
+	}
+}
+
+func MakeUpdateRouteSpecificationEndpoint(bs services.BookingServiceContract) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req, ok := request.(UpdateRouteSpecificationRequest)
+		if !ok {
+			return nil, errors.New("failed to convert request to UpdateRouteSpecificationRequest")
+		}
+
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+
+		satisfied, err := bs.UpdateRouteSpecification(ctx, req.TrackingID, cargo.RouteSpecification{
+			Origin:          req.Origin,
+			Destination:     req.Destination,
+			ArrivalDeadline: req.ArrivalDeadline,
+		})
+		return UpdateRouteSpecificationResponse{
+			ItinerarySatisfied: satisfied,
+			Error:              err,
+		}, nil
+	}
+}
+
+type CancelBookingRequest struct {
+	TrackingID cargo.TrackingID `json:"tracking_id"`
+}
+
+func (r CancelBookingRequest) Build(req *pb.CancelBookingRequest) CancelBookingRequest {
+	return CancelBookingRequest{
+		TrackingID: cargo.TrackingID(req.GetTrackingId()),
+	}
+}
+
+type CancelBookingResponse struct {
+	Status Status `json:"status"`
+	Error  error  `json:"error,omitempty"`
+}
+
+func (res CancelBookingResponse) error() error { return res.Error }
+
+func (r CancelBookingResponse) Protobuf() *pb.CancelBookingResponse {
+	return &pb.CancelBookingResponse{
+		Error: err2str(r.Error),
+	}
+}
+
+func MakeCancelBookingEndpoint(bs services.BookingServiceContract) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req, ok := request.(CancelBookingRequest)
+		if !ok {
+			return nil, errors.New("failed to convert request to CancelBookingRequest")
+		}
+
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+
+		err = bs.CancelBooking(ctx, req.TrackingID)
+		return CancelBookingResponse{
+			Status: newStatus(err),
+			Error:  err,
+		}, nil
+	}
+}
+
+type HoldCargoRequest struct {
+	TrackingID cargo.TrackingID `json:"tracking_id"`
+	Reason     string           `json:"reason"`
+}
+
+func (r HoldCargoRequest) Build(req *pb.HoldCargoRequest) HoldCargoRequest {
+	return HoldCargoRequest{
+		TrackingID: cargo.TrackingID(req.GetTrackingId()),
+		Reason:     req.GetReason(),
+	}
+}
+
+type HoldCargoResponse struct {
+	Status Status `json:"status"`
+	Error  error  `json:"error,omitempty"`
+}
+
+func (res HoldCargoResponse) error() error { return res.Error }
+
+func (r HoldCargoResponse) Protobuf() *pb.HoldCargoResponse {
+	return &pb.HoldCargoResponse{
+		Error: err2str(r.Error),
+	}
+}
+
+func MakeHoldCargoEndpoint(bs services.BookingServiceContract) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req, ok := request.(HoldCargoRequest)
+		if !ok {
+			return nil, errors.New("failed to convert request to HoldCargoRequest")
+		}
+
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+
+		err = bs.HoldCargo(ctx, req.TrackingID, req.Reason)
+		return HoldCargoResponse{
+			Status: newStatus(err),
+			Error:  err,
+		}, nil
+	}
+}
+
+type ReleaseCargoRequest struct {
+	TrackingID cargo.TrackingID `json:"tracking_id"`
+}
+
+func (r ReleaseCargoRequest) Build(req *pb.ReleaseCargoRequest) ReleaseCargoRequest {
+	return ReleaseCargoRequest{
+		TrackingID: cargo.TrackingID(req.GetTrackingId()),
+	}
+}
+
+type ReleaseCargoResponse struct {
+	Status Status `json:"status"`
+	Error  error  `json:"error,omitempty"`
+}
+
+func (res ReleaseCargoResponse) error() error { return res.Error }
+
+func (r ReleaseCargoResponse) Protobuf() *pb.ReleaseCargoResponse {
+	return &pb.ReleaseCargoResponse{
+		Error: err2str(r.Error),
+	}
+}
+
+func MakeReleaseCargoEndpoint(bs services.BookingServiceContract) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req, ok := request.(ReleaseCargoRequest)
+		if !ok {
+			return nil, errors.New("failed to convert request to ReleaseCargoRequest")
+		}
+
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+
+		err = bs.ReleaseCargo(ctx, req.TrackingID)
+		return ReleaseCargoResponse{
+			Status: newStatus(err),
+			Error:  err,
+		}, nil
+	}
+}
+
+type HandlingHistoryRequest struct {
+	TrackingID cargo.TrackingID `json:"tracking_id"`
+}
+
+func (r HandlingHistoryRequest) Build(req *pb.HandlingHistoryRequest) HandlingHistoryRequest {
+	return HandlingHistoryRequest{
+		TrackingID: cargo.TrackingID(req.GetTrackingId()),
+	}
+}
+
+type HandlingHistoryResponse struct {
+	Events []services.HandlingEvent `json:"events"`
+	Error  error                    `json:"error,omitempty"`
+}
+
+func (res HandlingHistoryResponse) error() error { return res.Error }
+
+func (r HandlingHistoryResponse) Protobuf() *pb.HandlingHistoryResponse {
+	events := make([]*pb.HandlingHistoryEvent, 0, len(r.Events))
+	for _, e := range r.Events {
+		events = append(events, &pb.HandlingHistoryEvent{
+			Type:         e.Type,
+			Location:     e.Location,
+			LocationName: e.LocationName,
+			VoyageNumber: e.VoyageNumber,
+			Completed:    timestamppb.New(e.Completed),
+			Expected:     e.Expected,
+		})
+	}
+
+	return &pb.HandlingHistoryResponse{
+		Events: events,
+		Error:  err2str(r.Error),
+	}
+}
+
+func MakeHandlingHistoryEndpoint(bs services.BookingServiceContract) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req, ok := request.(HandlingHistoryRequest)
+		if !ok {
+			return nil, errors.New("failed to convert request to HandlingHistoryRequest")
+		}
+
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+
+		events, err := bs.HandlingHistory(ctx, req.TrackingID)
+		return HandlingHistoryResponse{
+			Events: events,
+			Error:  err,
+		}, nil
+	}
+}
+
+type ListCargosRequest struct {
+	Filter cargo.Filter
+}
+
+func (lcreq ListCargosRequest) Build(req *pb.CargosRequest) ListCargosRequest {
+	filter := buildFilter(req.GetOrigin(), req.GetDestination(), req.GetCustomerId(), req.GetDeadlineFrom(), req.GetDeadlineTo(), req.GetExcludeClosed())
+	filter.Misdirected = req.GetMisdirected()
+	filter.Late = req.GetLate()
+
+	return ListCargosRequest{Filter: filter}
+}
 
 type ListCargosResponse struct {
 	Cargos []services.Cargo `json:"cargos"`
@@ -340,28 +719,43 @@ func (r ListCargosResponse) Protobuf() *pb.CargosResponse {
 
 		cargo := &pb.BookingCargoModel{
 			TrackingId:      string(c.TrackingID),
+			CustomerId:      c.CustomerID,
 			ArrivalDeadline: timestamppb.New(c.ArrivalDeadline),
 			Destination:     string(c.Destination),
 			Legs:            legs,
 			Misrouted:       c.Misrouted,
 			Origin:          c.Origin,
 			Routed:          c.Routed,
+			Status:          c.Status,
+			Weight:          c.Weight,
+			Volume:          c.Volume,
+			Packages:        int32(c.Packages),
+			Commodity:       c.Commodity,
+			HsCode:          c.HSCode,
+			Clearance:       c.Clearance,
+			HoldReason:      c.HoldReason,
+			ImdgClass:       c.IMDGClass,
+			UnNumber:        c.UNNumber,
 		}
 
 		cargos = append(cargos, cargo)
 	}
 
-	return &pb.CargosResponse{Cargos: cargos}
+	return &pb.CargosResponse{Cargos: cargos, Error: err2str(r.Error)}
 }
 
 func MakeListCargosEndpoint(bs services.BookingServiceContract) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
-		_, ok := request.(ListCargosRequest)
+		req, ok := request.(ListCargosRequest)
 		if !ok {
 			return nil, errors.New("failed to convert request to ListCargoRequest")
 		}
 
-		cargos, err := bs.Cargos(ctx)
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+
+		cargos, err := bs.Cargos(ctx, req.Filter)
 		return ListCargosResponse{
 			Cargos: cargos,
 			Error:  err,
@@ -369,6 +763,17 @@ func MakeListCargosEndpoint(bs services.BookingServiceContract) endpoint.Endpoin
 	}
 }
 
+// serverMiddleware returns the middlewares applied to every server side
+// endpoint: a span around the call and a log line once it completes.
+func serverMiddleware(logger log.Logger) func(method string) endpoint.Middleware {
+	return func(method string) endpoint.Middleware {
+		return endpoint.Chain(
+			tracing.EndpointMiddleware(method, trace.SpanKindServer),
+			LoggingMiddleware(logger, method),
+		)
+	}
+}
+
 func err2str(err error) string {
 	if err == nil {
 		return ""
@@ -0,0 +1,408 @@
+package endpoints
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/log"
+	"github.com/mproyyan/grpc-shipping-microservice/booking/services"
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/location"
+	"github.com/mproyyan/grpc-shipping-microservice/pb"
+	"github.com/mproyyan/grpc-shipping-microservice/shipment"
+	"github.com/mproyyan/grpc-shipping-microservice/voyage"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type ShipmentSet struct {
+	CreateShipmentEndpoint        endpoint.Endpoint
+	LoadShipmentEndpoint          endpoint.Endpoint
+	StuffCargoEndpoint            endpoint.Endpoint
+	UnstuffCargoEndpoint          endpoint.Endpoint
+	AssignShipmentToRouteEndpoint endpoint.Endpoint
+}
+
+func NewShipmentEndpoints(ss services.ShipmentServiceContract, logger log.Logger) ShipmentSet {
+	middleware := serverMiddleware(logger)
+
+	return ShipmentSet{
+		CreateShipmentEndpoint:        middleware("Shipment.CreateShipment")(MakeCreateShipmentEndpoint(ss)),
+		LoadShipmentEndpoint:          middleware("Shipment.LoadShipment")(MakeLoadShipmentEndpoint(ss)),
+		StuffCargoEndpoint:            middleware("Shipment.StuffCargo")(MakeStuffCargoEndpoint(ss)),
+		UnstuffCargoEndpoint:          middleware("Shipment.UnstuffCargo")(MakeUnstuffCargoEndpoint(ss)),
+		AssignShipmentToRouteEndpoint: middleware("Shipment.AssignShipmentToRoute")(MakeAssignShipmentToRouteEndpoint(ss)),
+	}
+}
+
+func (s ShipmentSet) CreateShipment(ctx context.Context, origin, destination location.UNLocode, containers []shipment.Container) (shipment.ID, error) {
+	resp, err := s.CreateShipmentEndpoint(ctx, CreateShipmentRequest{
+		Origin:      origin,
+		Destination: destination,
+		Containers:  containers,
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	res := resp.(CreateShipmentResponse)
+	return res.ShipmentID, res.Error
+}
+
+func (s ShipmentSet) LoadShipment(ctx context.Context, id shipment.ID) (shipment.Shipment, error) {
+	resp, err := s.LoadShipmentEndpoint(ctx, LoadShipmentRequest{ShipmentID: id})
+	if err != nil {
+		return shipment.Shipment{}, err
+	}
+
+	res := resp.(LoadShipmentResponse)
+	return res.Shipment, res.Error
+}
+
+func (s ShipmentSet) StuffCargo(ctx context.Context, container shipment.ContainerID, id cargo.TrackingID, weight, volume float64) error {
+	resp, err := s.StuffCargoEndpoint(ctx, StuffCargoRequest{
+		ContainerID: container,
+		TrackingID:  id,
+		Weight:      weight,
+		Volume:      volume,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	res := resp.(StuffCargoResponse)
+	return res.Error
+}
+
+func (s ShipmentSet) UnstuffCargo(ctx context.Context, container shipment.ContainerID, id cargo.TrackingID) error {
+	resp, err := s.UnstuffCargoEndpoint(ctx, UnstuffCargoRequest{
+		ContainerID: container,
+		TrackingID:  id,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	res := resp.(UnstuffCargoResponse)
+	return res.Error
+}
+
+func (s ShipmentSet) AssignShipmentToRoute(ctx context.Context, id shipment.ID, itinerary cargo.Itinerary) error {
+	resp, err := s.AssignShipmentToRouteEndpoint(ctx, AssignShipmentToRouteRequest{
+		ShipmentID: id,
+		Legs:       itinerary.Legs,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	res := resp.(AssignShipmentToRouteResponse)
+	return res.Error
+}
+
+type CreateShipmentRequest struct {
+	Origin      location.UNLocode    `json:"origin"`
+	Destination location.UNLocode    `json:"destination"`
+	Containers  []shipment.Container `json:"containers"`
+}
+
+func (r CreateShipmentRequest) Build(req *pb.CreateShipmentRequest) CreateShipmentRequest {
+	return CreateShipmentRequest{
+		Origin:      location.UNLocode(req.GetOrigin()),
+		Destination: location.UNLocode(req.GetDestination()),
+		Containers:  ContainersFromProto(req.GetContainers()),
+	}
+}
+
+type CreateShipmentResponse struct {
+	ShipmentID shipment.ID `json:"shipment_id"`
+	Error      error       `json:"error,omitempty"`
+}
+
+func (res CreateShipmentResponse) error() error { return res.Error }
+
+func (res CreateShipmentResponse) Protobuf() *pb.CreateShipmentResponse {
+	return &pb.CreateShipmentResponse{
+		ShipmentId: string(res.ShipmentID),
+		Error:      err2str(res.Error),
+	}
+}
+
+func MakeCreateShipmentEndpoint(ss services.ShipmentServiceContract) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req, ok := request.(CreateShipmentRequest)
+		if !ok {
+			return nil, errors.New("failed to convert request to CreateShipmentRequest")
+		}
+
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+
+		id, err := ss.CreateShipment(ctx, req.Origin, req.Destination, req.Containers)
+		return CreateShipmentResponse{ShipmentID: id, Error: err}, nil
+	}
+}
+
+type LoadShipmentRequest struct {
+	ShipmentID shipment.ID `json:"shipment_id"`
+}
+
+func (r LoadShipmentRequest) Build(req *pb.LoadShipmentRequest) LoadShipmentRequest {
+	return LoadShipmentRequest{ShipmentID: shipment.ID(req.GetShipmentId())}
+}
+
+type LoadShipmentResponse struct {
+	Shipment shipment.Shipment `json:"shipment"`
+	Error    error             `json:"error,omitempty"`
+}
+
+func (res LoadShipmentResponse) error() error { return res.Error }
+
+func (res LoadShipmentResponse) Protobuf() *pb.LoadShipmentResponse {
+	return &pb.LoadShipmentResponse{
+		Shipment: ShipmentToProto(res.Shipment),
+		Error:    err2str(res.Error),
+	}
+}
+
+func MakeLoadShipmentEndpoint(ss services.ShipmentServiceContract) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req, ok := request.(LoadShipmentRequest)
+		if !ok {
+			return nil, errors.New("failed to convert request to LoadShipmentRequest")
+		}
+
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+
+		s, err := ss.LoadShipment(ctx, req.ShipmentID)
+		return LoadShipmentResponse{Shipment: s, Error: err}, nil
+	}
+}
+
+type StuffCargoRequest struct {
+	ContainerID shipment.ContainerID `json:"container_id"`
+	TrackingID  cargo.TrackingID     `json:"tracking_id"`
+	Weight      float64              `json:"weight"`
+	Volume      float64              `json:"volume"`
+}
+
+func (r StuffCargoRequest) Build(req *pb.StuffCargoRequest) StuffCargoRequest {
+	return StuffCargoRequest{
+		ContainerID: shipment.ContainerID(req.GetContainerId()),
+		TrackingID:  cargo.TrackingID(req.GetTrackingId()),
+		Weight:      req.GetWeight(),
+		Volume:      req.GetVolume(),
+	}
+}
+
+type StuffCargoResponse struct {
+	Error error `json:"error,omitempty"`
+}
+
+func (res StuffCargoResponse) error() error { return res.Error }
+
+func (res StuffCargoResponse) Protobuf() *pb.StuffCargoResponse {
+	return &pb.StuffCargoResponse{Error: err2str(res.Error)}
+}
+
+func MakeStuffCargoEndpoint(ss services.ShipmentServiceContract) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req, ok := request.(StuffCargoRequest)
+		if !ok {
+			return nil, errors.New("failed to convert request to StuffCargoRequest")
+		}
+
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+
+		err = ss.StuffCargo(ctx, req.ContainerID, req.TrackingID, req.Weight, req.Volume)
+		return StuffCargoResponse{Error: err}, nil
+	}
+}
+
+type UnstuffCargoRequest struct {
+	ContainerID shipment.ContainerID `json:"container_id"`
+	TrackingID  cargo.TrackingID     `json:"tracking_id"`
+}
+
+func (r UnstuffCargoRequest) Build(req *pb.UnstuffCargoRequest) UnstuffCargoRequest {
+	return UnstuffCargoRequest{
+		ContainerID: shipment.ContainerID(req.GetContainerId()),
+		TrackingID:  cargo.TrackingID(req.GetTrackingId()),
+	}
+}
+
+type UnstuffCargoResponse struct {
+	Error error `json:"error,omitempty"`
+}
+
+func (res UnstuffCargoResponse) error() error { return res.Error }
+
+func (res UnstuffCargoResponse) Protobuf() *pb.UnstuffCargoResponse {
+	return &pb.UnstuffCargoResponse{Error: err2str(res.Error)}
+}
+
+func MakeUnstuffCargoEndpoint(ss services.ShipmentServiceContract) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req, ok := request.(UnstuffCargoRequest)
+		if !ok {
+			return nil, errors.New("failed to convert request to UnstuffCargoRequest")
+		}
+
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+
+		err = ss.UnstuffCargo(ctx, req.ContainerID, req.TrackingID)
+		return UnstuffCargoResponse{Error: err}, nil
+	}
+}
+
+type AssignShipmentToRouteRequest struct {
+	ShipmentID shipment.ID `json:"shipment_id"`
+	Legs       []cargo.Leg `json:"legs"`
+}
+
+func (r AssignShipmentToRouteRequest) Build(req *pb.AssignShipmentToRouteRequest) AssignShipmentToRouteRequest {
+	return AssignShipmentToRouteRequest{
+		ShipmentID: shipment.ID(req.GetShipmentId()),
+		Legs:       LegsFromProto(req.GetLegs()),
+	}
+}
+
+type AssignShipmentToRouteResponse struct {
+	Error error `json:"error,omitempty"`
+}
+
+func (res AssignShipmentToRouteResponse) error() error { return res.Error }
+
+func (res AssignShipmentToRouteResponse) Protobuf() *pb.AssignShipmentToRouteResponse {
+	return &pb.AssignShipmentToRouteResponse{Error: err2str(res.Error)}
+}
+
+func MakeAssignShipmentToRouteEndpoint(ss services.ShipmentServiceContract) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req, ok := request.(AssignShipmentToRouteRequest)
+		if !ok {
+			return nil, errors.New("failed to convert request to AssignShipmentToRouteRequest")
+		}
+
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+
+		err = ss.AssignShipmentToRoute(ctx, req.ShipmentID, cargo.Itinerary{Legs: req.Legs})
+		return AssignShipmentToRouteResponse{Error: err}, nil
+	}
+}
+
+// ShipmentToProto converts a shipment into its protobuf model.
+func ShipmentToProto(s shipment.Shipment) *pb.ShipmentModel {
+	return &pb.ShipmentModel{
+		ShipmentId:  string(s.ID),
+		Origin:      string(s.Origin),
+		Destination: string(s.Destination),
+		Legs:        LegsToProto(s.Itinerary.Legs),
+		Containers:  ContainersToProto(s.Containers),
+	}
+}
+
+// ShipmentFromProto converts a protobuf shipment model into a shipment.
+func ShipmentFromProto(m *pb.ShipmentModel) shipment.Shipment {
+	return shipment.Shipment{
+		ID:          shipment.ID(m.GetShipmentId()),
+		Origin:      location.UNLocode(m.GetOrigin()),
+		Destination: location.UNLocode(m.GetDestination()),
+		Itinerary:   cargo.Itinerary{Legs: LegsFromProto(m.GetLegs())},
+		Containers:  ContainersFromProto(m.GetContainers()),
+	}
+}
+
+// ContainersToProto converts containers and their cargos into protobuf
+// models.
+func ContainersToProto(containers []shipment.Container) []*pb.ContainerModel {
+	var models []*pb.ContainerModel
+	for _, c := range containers {
+		m := &pb.ContainerModel{
+			ContainerId: string(c.ID),
+			MaxWeight:   c.MaxWeight,
+			MaxVolume:   c.MaxVolume,
+		}
+
+		for _, s := range c.Cargos {
+			m.Cargos = append(m.Cargos, &pb.StuffedCargo{
+				TrackingId: string(s.TrackingID),
+				Weight:     s.Weight,
+				Volume:     s.Volume,
+			})
+		}
+
+		models = append(models, m)
+	}
+
+	return models
+}
+
+// ContainersFromProto converts protobuf container models into containers.
+func ContainersFromProto(models []*pb.ContainerModel) []shipment.Container {
+	var containers []shipment.Container
+	for _, m := range models {
+		c := shipment.Container{
+			ID:        shipment.ContainerID(m.GetContainerId()),
+			MaxWeight: m.GetMaxWeight(),
+			MaxVolume: m.GetMaxVolume(),
+		}
+
+		for _, s := range m.GetCargos() {
+			c.Cargos = append(c.Cargos, shipment.Stuffing{
+				TrackingID: cargo.TrackingID(s.GetTrackingId()),
+				Weight:     s.GetWeight(),
+				Volume:     s.GetVolume(),
+			})
+		}
+
+		containers = append(containers, c)
+	}
+
+	return containers
+}
+
+// LegsToProto converts itinerary legs into protobuf legs.
+func LegsToProto(legs []cargo.Leg) []*pb.Leg {
+	var pbLegs []*pb.Leg
+	for _, l := range legs {
+		pbLegs = append(pbLegs, &pb.Leg{
+			VoyageNumber:   string(l.VoyageNumber),
+			LoadLocation:   string(l.LoadLocation),
+			UnloadLocation: string(l.UnloadLocation),
+			LoadTime:       timestamppb.New(l.LoadTime),
+			UnloadTime:     timestamppb.New(l.UnloadTime),
+		})
+	}
+
+	return pbLegs
+}
+
+// LegsFromProto converts protobuf legs into itinerary legs.
+func LegsFromProto(pbLegs []*pb.Leg) []cargo.Leg {
+	var legs []cargo.Leg
+	for _, l := range pbLegs {
+		legs = append(legs, cargo.Leg{
+			VoyageNumber:   voyage.Number(l.GetVoyageNumber()),
+			LoadLocation:   location.UNLocode(l.GetLoadLocation()),
+			UnloadLocation: location.UNLocode(l.GetUnloadLocation()),
+			LoadTime:       l.GetLoadTime().AsTime(),
+			UnloadTime:     l.GetUnloadTime().AsTime(),
+		})
+	}
+
+	return legs
+}
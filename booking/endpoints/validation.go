@@ -0,0 +1,390 @@
+package endpoints
+
+import (
+	"bytes"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/billing"
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/location"
+	"github.com/mproyyan/grpc-shipping-microservice/shipment"
+	"github.com/mproyyan/grpc-shipping-microservice/validation"
+	"github.com/mproyyan/grpc-shipping-microservice/voyage"
+	"github.com/mproyyan/grpc-shipping-microservice/webhook"
+)
+
+// Request validation. Field paths follow the protobuf field names so the
+// same violations make sense to gRPC and HTTP clients.
+
+func (r BookNewCargoRequest) Validate() error {
+	var v validation.Validator
+	validOrigin := validateLocode(&v, "origin", r.Origin)
+	validDestination := validateLocode(&v, "destination", r.Destination)
+	if validOrigin && validDestination {
+		v.Check(r.Origin != r.Destination, "destination", "must differ from origin")
+	}
+
+	if v.Check(!r.Deadline.IsZero(), "deadline", "is required") {
+		v.Check(r.Deadline.After(time.Now()), "deadline", "must be in the future")
+	}
+
+	validateAttributes(&v, r.Attributes)
+	if r.Quote.QuoteID != "" {
+		v.Check(r.Quote.Option >= 0, "quote_option", "must not be negative")
+	} else {
+		v.Check(r.Quote.Option == 0, "quote_id", "is required with quote_option")
+	}
+
+	return v.Err()
+}
+
+func (r LoadCargoRequest) Validate() error {
+	var v validation.Validator
+	v.Check(r.TrackingID != "", "tracking_id", "is required")
+	return v.Err()
+}
+
+func (r AssignCargoToRouteRequest) Validate() error {
+	var v validation.Validator
+	v.Check(r.TrackingID != "", "tracking_id", "is required")
+	if !v.Check(len(r.Itinerary.Legs) > 0, "itinerary.legs", "must contain at least one leg") {
+		return v.Err()
+	}
+
+	validateLegs(&v, "itinerary.legs", r.Itinerary.Legs)
+	return v.Err()
+}
+
+func (r ChangeDestinationRequest) Validate() error {
+	var v validation.Validator
+	v.Check(r.TrackingID != "", "tracking_id", "is required")
+	validateLocode(&v, "destination", r.Destination)
+	return v.Err()
+}
+
+func (r UpdateRouteSpecificationRequest) Validate() error {
+	var v validation.Validator
+	v.Check(r.TrackingID != "", "tracking_id", "is required")
+	if !v.Check(r.Origin != "" || r.Destination != "" || !r.ArrivalDeadline.IsZero(), "route_specification", "must change origin, destination or arrival_deadline") {
+		return v.Err()
+	}
+
+	validOrigin := r.Origin == "" || validateLocode(&v, "origin", r.Origin)
+	validDestination := r.Destination == "" || validateLocode(&v, "destination", r.Destination)
+	if r.Origin != "" && r.Destination != "" && validOrigin && validDestination {
+		v.Check(r.Origin != r.Destination, "destination", "must differ from origin")
+	}
+
+	if !r.ArrivalDeadline.IsZero() {
+		v.Check(r.ArrivalDeadline.After(time.Now()), "arrival_deadline", "must be in the future")
+	}
+
+	return v.Err()
+}
+
+func (r CancelBookingRequest) Validate() error {
+	var v validation.Validator
+	v.Check(r.TrackingID != "", "tracking_id", "is required")
+	return v.Err()
+}
+
+func (r HoldCargoRequest) Validate() error {
+	var v validation.Validator
+	v.Check(r.TrackingID != "", "tracking_id", "is required")
+	v.Check(strings.TrimSpace(r.Reason) != "", "reason", "is required")
+	return v.Err()
+}
+
+func (r ReleaseCargoRequest) Validate() error {
+	var v validation.Validator
+	v.Check(r.TrackingID != "", "tracking_id", "is required")
+	return v.Err()
+}
+
+func (r HandlingHistoryRequest) Validate() error {
+	var v validation.Validator
+	v.Check(r.TrackingID != "", "tracking_id", "is required")
+	return v.Err()
+}
+
+func (r ListCargosRequest) Validate() error {
+	var v validation.Validator
+	validateFilter(&v, r.Filter)
+	return v.Err()
+}
+
+func (r ExportCargosRequest) Validate() error {
+	var v validation.Validator
+	validateFilter(&v, r.Filter)
+	return v.Err()
+}
+
+func (r RegisterHandlingEventRequest) Validate() error {
+	var v validation.Validator
+	v.Check(r.TrackingID != "", "tracking_id", "is required")
+	validateEvent(&v, r.Type, r.Location, r.VoyageNumber, r.Completed)
+	return v.Err()
+}
+
+func (r RegisterContainerHandlingEventRequest) Validate() error {
+	var v validation.Validator
+	validateContainerID(&v, "container_id", r.ContainerID)
+	validateEvent(&v, r.Type, r.Location, r.VoyageNumber, r.Completed)
+	return v.Err()
+}
+
+func (r IngestEdifactRequest) Validate() error {
+	var v validation.Validator
+	v.Check(len(bytes.TrimSpace(r.Interchange)) > 0, "interchange", "is required")
+	return v.Err()
+}
+
+func (r CreateShipmentRequest) Validate() error {
+	var v validation.Validator
+	validOrigin := validateLocode(&v, "origin", r.Origin)
+	validDestination := validateLocode(&v, "destination", r.Destination)
+	if validOrigin && validDestination {
+		v.Check(r.Origin != r.Destination, "destination", "must differ from origin")
+	}
+
+	if !v.Check(len(r.Containers) > 0, "containers", "must contain at least one container") {
+		return v.Err()
+	}
+
+	seen := make(map[shipment.ContainerID]bool)
+	for i, c := range r.Containers {
+		field := fmt.Sprintf("containers[%d]", i)
+		if validateContainerID(&v, field+".container_id", c.ID) {
+			v.Check(!seen[c.ID], field+".container_id", "must be unique")
+			seen[c.ID] = true
+		}
+
+		v.Check(c.MaxWeight > 0, field+".max_weight", "must be positive")
+		v.Check(c.MaxVolume > 0, field+".max_volume", "must be positive")
+		v.Check(len(c.Cargos) == 0, field+".cargos", "must be empty, cargos are stuffed afterwards")
+	}
+
+	return v.Err()
+}
+
+func (r LoadShipmentRequest) Validate() error {
+	var v validation.Validator
+	v.Check(r.ShipmentID != "", "shipment_id", "is required")
+	return v.Err()
+}
+
+func (r StuffCargoRequest) Validate() error {
+	var v validation.Validator
+	validateContainerID(&v, "container_id", r.ContainerID)
+	v.Check(r.TrackingID != "", "tracking_id", "is required")
+	v.Check(r.Weight >= 0, "weight", "must not be negative")
+	v.Check(r.Volume >= 0, "volume", "must not be negative")
+	return v.Err()
+}
+
+func (r UnstuffCargoRequest) Validate() error {
+	var v validation.Validator
+	validateContainerID(&v, "container_id", r.ContainerID)
+	v.Check(r.TrackingID != "", "tracking_id", "is required")
+	return v.Err()
+}
+
+func (r AssignShipmentToRouteRequest) Validate() error {
+	var v validation.Validator
+	v.Check(r.ShipmentID != "", "shipment_id", "is required")
+	if v.Check(len(r.Legs) > 0, "legs", "must contain at least one leg") {
+		validateLegs(&v, "legs", r.Legs)
+	}
+
+	return v.Err()
+}
+
+func (r RegisterCustomerRequest) Validate() error {
+	var v validation.Validator
+	v.Check(r.Name != "", "name", "is required")
+	validateEmail(&v, "contact.email", r.Contact.Email)
+	validateURL(&v, "preferences.webhook_url", r.Preferences.WebhookURL)
+	return v.Err()
+}
+
+func (r LoadCustomerRequest) Validate() error {
+	var v validation.Validator
+	v.Check(r.CustomerID != "", "customer_id", "is required")
+	return v.Err()
+}
+
+func (r UpdateCustomerRequest) Validate() error {
+	var v validation.Validator
+	v.Check(r.Customer.ID != "", "customer.id", "is required")
+	v.Check(r.Customer.Name != "", "customer.name", "is required")
+	validateEmail(&v, "customer.contact.email", r.Customer.Contact.Email)
+	validateURL(&v, "customer.preferences.webhook_url", r.Customer.Preferences.WebhookURL)
+	return v.Err()
+}
+
+func (r QuoteRequest) Validate() error {
+	var v validation.Validator
+	validOrigin := validateLocode(&v, "origin", r.Origin)
+	validDestination := validateLocode(&v, "destination", r.Destination)
+	if validOrigin && validDestination {
+		v.Check(r.Origin != r.Destination, "destination", "must differ from origin")
+	}
+
+	if v.Check(!r.ArrivalDeadline.IsZero(), "arrival_deadline", "is required") {
+		v.Check(r.ArrivalDeadline.After(time.Now()), "arrival_deadline", "must be in the future")
+	}
+
+	validateAttributes(&v, r.Attributes)
+	return v.Err()
+}
+
+func (r FinalizeInvoiceRequest) Validate() error {
+	var v validation.Validator
+	v.Check(r.InvoiceID != "", "invoice_id", "is required")
+	return v.Err()
+}
+
+func (r RenderInvoiceRequest) Validate() error {
+	var v validation.Validator
+	v.Check(r.InvoiceID != "", "invoice_id", "is required")
+	v.Check(billing.Supported(r.Format), "format", "must be one of "+strings.Join(billing.Formats, ", "))
+	return v.Err()
+}
+
+func (r CreateSubscriptionRequest) Validate() error {
+	var v validation.Validator
+	if v.Check(r.URL != "", "url", "is required") {
+		validateURL(&v, "url", r.URL)
+	}
+
+	for i, name := range r.Events {
+		_, err := webhook.ParseEvent(name)
+		v.Check(err == nil, fmt.Sprintf("events[%d]", i), "must be one of "+strings.Join(webhook.Filter(webhook.Events).Names(), ", "))
+	}
+
+	return v.Err()
+}
+
+func (r DeleteSubscriptionRequest) Validate() error {
+	var v validation.Validator
+	v.Check(r.SubscriptionID != "", "subscription_id", "is required")
+	return v.Err()
+}
+
+func (r ReplayDeliveryRequest) Validate() error {
+	var v validation.Validator
+	v.Check(r.DeliveryID != "", "delivery_id", "is required")
+	return v.Err()
+}
+
+func validateLocode(v *validation.Validator, field string, code location.UNLocode) bool {
+	if !v.Check(code != "", field, "is required") {
+		return false
+	}
+
+	return v.Check(code.IsValid(), field, "must be a UN/LOCODE such as SESTO")
+}
+
+// validateAttributes checks the optional physical attributes and commodity
+// of a cargo.
+func validateAttributes(v *validation.Validator, a cargo.Attributes) {
+	v.Check(a.Weight >= 0, "weight", "must not be negative")
+	v.Check(a.Volume >= 0, "volume", "must not be negative")
+	v.Check(a.Packages >= 0, "packages", "must not be negative")
+	if a.HSCode != "" {
+		v.Check(cargo.IsValidHSCode(a.HSCode), "hs_code", "must be a Harmonized System code of 6, 8 or 10 digits")
+	}
+
+	g := a.DangerousGoods
+	if g.Class != "" || g.UNNumber != "" {
+		v.Check(g.Class.IsValid(), "imdg_class", "must be an IMDG class or division such as 3 or 2.1")
+		v.Check(g.UNNumber.IsValid(), "un_number", "must be a UN number of 4 digits")
+	}
+}
+
+func validateContainerID(v *validation.Validator, field string, id shipment.ContainerID) bool {
+	if !v.Check(id != "", field, "is required") {
+		return false
+	}
+
+	return v.Check(id.IsValid(), field, "must be an ISO 6346 container number such as MSCU1234565")
+}
+
+// validateLegs checks that the legs connect, in place and in time.
+func validateLegs(v *validation.Validator, field string, legs []cargo.Leg) {
+	for i, leg := range legs {
+		field := fmt.Sprintf("%s[%d]", field, i)
+		v.Check(leg.VoyageNumber != "", field+".voyage_number", "is required")
+		validateLocode(v, field+".load_location", leg.LoadLocation)
+		validateLocode(v, field+".unload_location", leg.UnloadLocation)
+		if leg.LoadLocation != "" {
+			v.Check(leg.LoadLocation != leg.UnloadLocation, field+".unload_location", "must differ from load_location")
+		}
+
+		if v.Check(!leg.LoadTime.IsZero(), field+".load_time", "is required") &&
+			v.Check(!leg.UnloadTime.IsZero(), field+".unload_time", "is required") {
+			v.Check(leg.UnloadTime.After(leg.LoadTime), field+".unload_time", "must be after load_time")
+		}
+
+		if i > 0 {
+			prev := legs[i-1]
+			v.Check(prev.UnloadLocation == leg.LoadLocation, field+".load_location", "must match the unload_location of the previous leg")
+			v.Check(!leg.LoadTime.Before(prev.UnloadTime), field+".load_time", "must not be before the unload_time of the previous leg")
+		}
+	}
+}
+
+// validateEvent checks the fields shared by cargo and container handling
+// events.
+func validateEvent(v *validation.Validator, typ string, loc location.UNLocode, voyageNumber voyage.Number, completed time.Time) {
+	validateLocode(v, "location", loc)
+
+	eventType, err := cargo.ParseHandlingEventType(typ)
+	if v.Check(err == nil, "type", "must be one of load, unload, receive, claim or customs") &&
+		(eventType == cargo.Load || eventType == cargo.Unload) {
+		v.Check(voyageNumber != "", "voyage_number", "is required for load and unload events")
+	}
+
+	if !completed.IsZero() {
+		v.Check(!completed.After(time.Now().Add(time.Minute)), "completed", "must not be in the future")
+	}
+}
+
+func validateEmail(v *validation.Validator, field, email string) {
+	if !v.Check(email != "", field, "is required") {
+		return
+	}
+
+	_, err := mail.ParseAddress(email)
+	v.Check(err == nil, field, "must be a valid email address")
+}
+
+// validateURL checks optional webhook URLs, empty values are accepted. They
+// must not point at internal hosts.
+func validateURL(v *validation.Validator, field, raw string) {
+	if raw == "" {
+		return
+	}
+
+	u, err := url.Parse(raw)
+	if v.Check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "", field, "must be an absolute http(s) URL") {
+		v.Check(webhook.IsPublicHost(u.Hostname()), field, "must not point at a loopback, link-local or private host")
+	}
+}
+
+// validateFilter checks the optional filters shared by listing and export.
+func validateFilter(v *validation.Validator, f cargo.Filter) {
+	if f.Origin != "" {
+		validateLocode(v, "origin", f.Origin)
+	}
+	if f.Destination != "" {
+		validateLocode(v, "destination", f.Destination)
+	}
+	if !f.DeadlineFrom.IsZero() && !f.DeadlineTo.IsZero() {
+		v.Check(f.DeadlineTo.After(f.DeadlineFrom), "deadline_to", "must be after deadline_from")
+	}
+}
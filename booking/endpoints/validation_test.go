@@ -0,0 +1,193 @@
+package endpoints
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/imdg"
+	"github.com/mproyyan/grpc-shipping-microservice/pricing"
+	"github.com/mproyyan/grpc-shipping-microservice/shipment"
+	"github.com/mproyyan/grpc-shipping-microservice/validation"
+	"github.com/stretchr/testify/require"
+)
+
+func fields(t *testing.T, err error) []string {
+	t.Helper()
+	violations, ok := validation.FromError(err)
+	require.True(t, ok, "expected validation errors, got %v", err)
+
+	var names []string
+	for _, v := range violations {
+		names = append(names, v.Field)
+	}
+
+	return names
+}
+
+func TestBookNewCargoRequestValidate(t *testing.T) {
+	valid := BookNewCargoRequest{Origin: "SESTO", Destination: "AUMEL", Deadline: time.Now().Add(24 * time.Hour)}
+	require.NoError(t, valid.Validate())
+
+	err := BookNewCargoRequest{Origin: "SESTO", Destination: "SESTO", Deadline: time.Now().Add(-time.Hour)}.Validate()
+	require.Equal(t, []string{"destination", "deadline"}, fields(t, err))
+
+	err = BookNewCargoRequest{Origin: "stockholm"}.Validate()
+	require.Equal(t, []string{"origin", "destination", "deadline"}, fields(t, err))
+
+	valid.Attributes = cargo.Attributes{Weight: 1200, Volume: 4.5, Packages: 12, HSCode: "090111"}
+	require.NoError(t, valid.Validate())
+
+	valid.Attributes = cargo.Attributes{Weight: -1, HSCode: "0901.11"}
+	require.Equal(t, []string{"weight", "hs_code"}, fields(t, valid.Validate()))
+
+	valid.Attributes = cargo.Attributes{DangerousGoods: imdg.Goods{Class: "3", UNNumber: "1203"}}
+	require.NoError(t, valid.Validate())
+
+	valid.Attributes = cargo.Attributes{DangerousGoods: imdg.Goods{Class: "3.1"}}
+	require.Equal(t, []string{"imdg_class", "un_number"}, fields(t, valid.Validate()))
+
+	valid.Attributes = cargo.Attributes{}
+	valid.Quote = pricing.Acceptance{QuoteID: "Q1W2E3R4", Option: 1}
+	require.NoError(t, valid.Validate())
+
+	valid.Quote = pricing.Acceptance{QuoteID: "Q1W2E3R4", Option: -1}
+	require.Equal(t, []string{"quote_option"}, fields(t, valid.Validate()))
+
+	valid.Quote = pricing.Acceptance{Option: 1}
+	require.Equal(t, []string{"quote_id"}, fields(t, valid.Validate()))
+}
+
+func TestAssignCargoToRouteRequestValidate(t *testing.T) {
+	now := time.Now()
+	req := AssignCargoToRouteRequest{
+		TrackingID: "ABC123",
+		Itinerary: cargo.Itinerary{Legs: []cargo.Leg{
+			{VoyageNumber: "V100", LoadLocation: "SESTO", UnloadLocation: "CNHKG", LoadTime: now, UnloadTime: now.Add(time.Hour)},
+			{VoyageNumber: "V200", LoadLocation: "CNHKG", UnloadLocation: "AUMEL", LoadTime: now.Add(2 * time.Hour), UnloadTime: now.Add(3 * time.Hour)},
+		}},
+	}
+	require.NoError(t, req.Validate())
+
+	req.Itinerary.Legs[1].LoadLocation = "USNYC"
+	req.Itinerary.Legs[1].UnloadTime = now
+	require.Equal(t, []string{
+		"itinerary.legs[1].unload_time",
+		"itinerary.legs[1].load_location",
+	}, fields(t, req.Validate()))
+
+	require.Equal(t, []string{"tracking_id", "itinerary.legs"}, fields(t, AssignCargoToRouteRequest{}.Validate()))
+}
+
+func TestListCargosRequestValidate(t *testing.T) {
+	require.NoError(t, ListCargosRequest{}.Validate())
+
+	now := time.Now()
+	err := ListCargosRequest{Filter: cargo.Filter{Origin: "sesto", DeadlineFrom: now, DeadlineTo: now.Add(-time.Hour)}}.Validate()
+	require.Equal(t, []string{"origin", "deadline_to"}, fields(t, err))
+}
+
+func TestUpdateRouteSpecificationRequestValidate(t *testing.T) {
+	require.NoError(t, UpdateRouteSpecificationRequest{TrackingID: "ABC123", ArrivalDeadline: time.Now().Add(time.Hour)}.Validate())
+	require.NoError(t, UpdateRouteSpecificationRequest{TrackingID: "ABC123", Origin: "SESTO"}.Validate())
+
+	require.Equal(t, []string{"tracking_id", "route_specification"}, fields(t, UpdateRouteSpecificationRequest{}.Validate()))
+
+	req := UpdateRouteSpecificationRequest{TrackingID: "ABC123", Origin: "SESTO", Destination: "SESTO", ArrivalDeadline: time.Now().Add(-time.Hour)}
+	require.Equal(t, []string{"destination", "arrival_deadline"}, fields(t, req.Validate()))
+
+	req = UpdateRouteSpecificationRequest{TrackingID: "ABC123", Origin: "stockholm"}
+	require.Equal(t, []string{"origin"}, fields(t, req.Validate()))
+}
+
+func TestHoldCargoRequestValidate(t *testing.T) {
+	require.NoError(t, HoldCargoRequest{TrackingID: "ABC123", Reason: "inspection"}.Validate())
+	require.Equal(t, []string{"tracking_id", "reason"}, fields(t, HoldCargoRequest{Reason: "  "}.Validate()))
+}
+
+func TestHandlingHistoryRequestValidate(t *testing.T) {
+	require.NoError(t, HandlingHistoryRequest{TrackingID: "ABC123"}.Validate())
+	require.Equal(t, []string{"tracking_id"}, fields(t, HandlingHistoryRequest{}.Validate()))
+}
+
+func TestRegisterHandlingEventRequestValidate(t *testing.T) {
+	req := RegisterHandlingEventRequest{TrackingID: "ABC123", Type: "load", Location: "SESTO", VoyageNumber: "V100"}
+	require.NoError(t, req.Validate())
+
+	req.VoyageNumber = ""
+	req.Completed = time.Now().Add(time.Hour)
+	require.Equal(t, []string{"voyage_number", "completed"}, fields(t, req.Validate()))
+
+	require.Equal(t, []string{"tracking_id", "location", "type"}, fields(t, RegisterHandlingEventRequest{Type: "Not Handled"}.Validate()))
+}
+
+func TestIngestEdifactRequestValidate(t *testing.T) {
+	require.NoError(t, IngestEdifactRequest{Interchange: []byte("UNB+UNOC:3+A+B+191018:1200+1'UNZ+0+1'")}.Validate())
+	require.Equal(t, []string{"interchange"}, fields(t, IngestEdifactRequest{Interchange: []byte("\r\n")}.Validate()))
+}
+
+func TestCreateShipmentRequestValidate(t *testing.T) {
+	req := CreateShipmentRequest{
+		Origin:      "SESTO",
+		Destination: "AUMEL",
+		Containers:  []shipment.Container{{ID: "MSCU1234565", MaxWeight: 26000, MaxVolume: 33}},
+	}
+	require.NoError(t, req.Validate())
+
+	req.Containers = append(req.Containers, shipment.Container{ID: "MSCU1234565", MaxVolume: 33})
+	require.Equal(t, []string{"containers[1].container_id", "containers[1].max_weight"}, fields(t, req.Validate()))
+
+	require.Equal(t, []string{"origin", "destination", "containers"}, fields(t, CreateShipmentRequest{}.Validate()))
+}
+
+func TestStuffCargoRequestValidate(t *testing.T) {
+	require.NoError(t, StuffCargoRequest{ContainerID: "MSCU1234565", TrackingID: "ABC123", Weight: 1200, Volume: 4.5}.Validate())
+
+	require.NoError(t, StuffCargoRequest{ContainerID: "MSCU1234565", TrackingID: "ABC123"}.Validate())
+
+	err := StuffCargoRequest{ContainerID: "mscu1234565", TrackingID: "ABC123", Weight: -1}.Validate()
+	require.Equal(t, []string{"container_id", "weight"}, fields(t, err))
+}
+
+func TestRegisterCustomerRequestValidate(t *testing.T) {
+	req := RegisterCustomerRequest{Name: "Acme"}
+	req.Contact.Email = "ops@acme.example"
+	require.NoError(t, req.Validate())
+
+	req.Contact.Email = "not an email"
+	req.Preferences.WebhookURL = "ftp://acme.example"
+	require.Equal(t, []string{"contact.email", "preferences.webhook_url"}, fields(t, req.Validate()))
+}
+
+func TestQuoteRequestValidate(t *testing.T) {
+	valid := QuoteRequest{Origin: "SESTO", Destination: "AUMEL", ArrivalDeadline: time.Now().Add(24 * time.Hour)}
+	require.NoError(t, valid.Validate())
+
+	err := QuoteRequest{Origin: "SESTO", Destination: "SESTO", ArrivalDeadline: time.Now().Add(-time.Hour)}.Validate()
+	require.Equal(t, []string{"destination", "arrival_deadline"}, fields(t, err))
+
+	valid.Attributes = cargo.Attributes{Weight: -1, Volume: -1}
+	require.Equal(t, []string{"weight", "volume"}, fields(t, valid.Validate()))
+}
+
+func TestRenderInvoiceRequestValidate(t *testing.T) {
+	require.NoError(t, RenderInvoiceRequest{InvoiceID: "INV1", Format: "pdf"}.Validate())
+
+	err := RenderInvoiceRequest{Format: "html"}.Validate()
+	require.Equal(t, []string{"invoice_id", "format"}, fields(t, err))
+}
+
+func TestCreateSubscriptionRequestValidate(t *testing.T) {
+	require.NoError(t, CreateSubscriptionRequest{URL: "https://partner.example/hooks", Events: []string{"handled", "Late"}}.Validate())
+
+	err := CreateSubscriptionRequest{URL: "ftp://partner.example", Events: []string{"Booked", "Shipped"}}.Validate()
+	require.Equal(t, []string{"url", "events[1]"}, fields(t, err))
+
+	err = CreateSubscriptionRequest{}.Validate()
+	require.Equal(t, []string{"url"}, fields(t, err))
+
+	for _, url := range []string{"http://169.254.169.254/latest/meta-data", "http://localhost:8080/hooks", "https://10.0.0.7/hooks", "http://[::1]/hooks"} {
+		err = CreateSubscriptionRequest{URL: url}.Validate()
+		require.Equal(t, []string{"url"}, fields(t, err), url)
+	}
+}
@@ -0,0 +1,366 @@
+package endpoints
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/log"
+	"github.com/mproyyan/grpc-shipping-microservice/booking/services"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/pb"
+	"github.com/mproyyan/grpc-shipping-microservice/webhook"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type WebhookSet struct {
+	CreateSubscriptionEndpoint   endpoint.Endpoint
+	ListSubscriptionsEndpoint    endpoint.Endpoint
+	DeleteSubscriptionEndpoint   endpoint.Endpoint
+	ListFailedDeliveriesEndpoint endpoint.Endpoint
+	ReplayDeliveryEndpoint       endpoint.Endpoint
+}
+
+func NewWebhookEndpoints(ws services.WebhookServiceContract, logger log.Logger) WebhookSet {
+	middleware := serverMiddleware(logger)
+
+	return WebhookSet{
+		CreateSubscriptionEndpoint:   middleware("Webhooks.CreateSubscription")(MakeCreateSubscriptionEndpoint(ws)),
+		ListSubscriptionsEndpoint:    middleware("Webhooks.ListSubscriptions")(MakeListSubscriptionsEndpoint(ws)),
+		DeleteSubscriptionEndpoint:   middleware("Webhooks.DeleteSubscription")(MakeDeleteSubscriptionEndpoint(ws)),
+		ListFailedDeliveriesEndpoint: middleware("Webhooks.ListFailedDeliveries")(MakeListFailedDeliveriesEndpoint(ws)),
+		ReplayDeliveryEndpoint:       middleware("Webhooks.ReplayDelivery")(MakeReplayDeliveryEndpoint(ws)),
+	}
+}
+
+func (s WebhookSet) Subscribe(ctx context.Context, customerID customer.ID, url string, filter webhook.Filter, secret string) (webhook.Subscription, error) {
+	resp, err := s.CreateSubscriptionEndpoint(ctx, CreateSubscriptionRequest{
+		CustomerID: customerID,
+		URL:        url,
+		Events:     filter.Names(),
+		Secret:     secret,
+	})
+	if err != nil {
+		return webhook.Subscription{}, err
+	}
+
+	res := resp.(CreateSubscriptionResponse)
+	return res.Subscription, res.Error
+}
+
+func (s WebhookSet) Subscriptions(ctx context.Context, customerID customer.ID) ([]webhook.Subscription, error) {
+	resp, err := s.ListSubscriptionsEndpoint(ctx, ListSubscriptionsRequest{CustomerID: customerID})
+	if err != nil {
+		return nil, err
+	}
+
+	res := resp.(ListSubscriptionsResponse)
+	return res.Subscriptions, res.Error
+}
+
+func (s WebhookSet) Unsubscribe(ctx context.Context, id webhook.SubscriptionID) error {
+	resp, err := s.DeleteSubscriptionEndpoint(ctx, DeleteSubscriptionRequest{SubscriptionID: id})
+	if err != nil {
+		return err
+	}
+
+	res := resp.(DeleteSubscriptionResponse)
+	return res.Error
+}
+
+func (s WebhookSet) FailedDeliveries(ctx context.Context, customerID customer.ID) ([]webhook.Delivery, error) {
+	resp, err := s.ListFailedDeliveriesEndpoint(ctx, ListFailedDeliveriesRequest{CustomerID: customerID})
+	if err != nil {
+		return nil, err
+	}
+
+	res := resp.(ListFailedDeliveriesResponse)
+	return res.Deliveries, res.Error
+}
+
+func (s WebhookSet) ReplayDelivery(ctx context.Context, id webhook.DeliveryID) (webhook.Delivery, error) {
+	resp, err := s.ReplayDeliveryEndpoint(ctx, ReplayDeliveryRequest{DeliveryID: id})
+	if err != nil {
+		return webhook.Delivery{}, err
+	}
+
+	res := resp.(ReplayDeliveryResponse)
+	return res.Delivery, res.Error
+}
+
+// CreateSubscriptionRequest carries the events of the filter by name, they
+// are parsed while validating.
+type CreateSubscriptionRequest struct {
+	CustomerID customer.ID `json:"customer_id"`
+	URL        string      `json:"url"`
+	Events     []string    `json:"events"`
+	Secret     string      `json:"secret,omitempty"`
+}
+
+func (r CreateSubscriptionRequest) Build(req *pb.CreateSubscriptionRequest) CreateSubscriptionRequest {
+	return CreateSubscriptionRequest{
+		CustomerID: customer.ID(req.GetCustomerId()),
+		URL:        req.GetUrl(),
+		Events:     req.GetEvents(),
+		Secret:     req.GetSecret(),
+	}
+}
+
+type CreateSubscriptionResponse struct {
+	Subscription webhook.Subscription `json:"subscription"`
+	Error        error                `json:"error,omitempty"`
+}
+
+func (r CreateSubscriptionResponse) error() error { return r.Error }
+
+func (r CreateSubscriptionResponse) Protobuf() *pb.CreateSubscriptionResponse {
+	res := &pb.CreateSubscriptionResponse{Error: err2str(r.Error)}
+	if r.Error == nil {
+		res.Subscription = SubscriptionToProto(r.Subscription)
+	}
+
+	return res
+}
+
+func MakeCreateSubscriptionEndpoint(ws services.WebhookServiceContract) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req, ok := request.(CreateSubscriptionRequest)
+		if !ok {
+			return nil, errors.New("failed to convert request to CreateSubscriptionRequest")
+		}
+
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+
+		filter, _ := webhook.ParseFilter(req.Events)
+		s, err := ws.Subscribe(ctx, req.CustomerID, req.URL, filter, req.Secret)
+		return CreateSubscriptionResponse{Subscription: s, Error: err}, nil
+	}
+}
+
+type ListSubscriptionsRequest struct {
+	CustomerID customer.ID `json:"customer_id"`
+}
+
+func (r ListSubscriptionsRequest) Build(req *pb.ListSubscriptionsRequest) ListSubscriptionsRequest {
+	return ListSubscriptionsRequest{
+		CustomerID: customer.ID(req.GetCustomerId()),
+	}
+}
+
+type ListSubscriptionsResponse struct {
+	Subscriptions []webhook.Subscription `json:"subscriptions"`
+	Error         error                  `json:"error,omitempty"`
+}
+
+func (r ListSubscriptionsResponse) error() error { return r.Error }
+
+func (r ListSubscriptionsResponse) Protobuf() *pb.ListSubscriptionsResponse {
+	res := &pb.ListSubscriptionsResponse{Error: err2str(r.Error)}
+	for _, s := range r.Subscriptions {
+		res.Subscriptions = append(res.Subscriptions, SubscriptionToProto(s))
+	}
+
+	return res
+}
+
+func MakeListSubscriptionsEndpoint(ws services.WebhookServiceContract) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req, ok := request.(ListSubscriptionsRequest)
+		if !ok {
+			return nil, errors.New("failed to convert request to ListSubscriptionsRequest")
+		}
+
+		subscriptions, err := ws.Subscriptions(ctx, req.CustomerID)
+		return ListSubscriptionsResponse{Subscriptions: subscriptions, Error: err}, nil
+	}
+}
+
+type DeleteSubscriptionRequest struct {
+	SubscriptionID webhook.SubscriptionID `json:"subscription_id"`
+}
+
+func (r DeleteSubscriptionRequest) Build(req *pb.DeleteSubscriptionRequest) DeleteSubscriptionRequest {
+	return DeleteSubscriptionRequest{
+		SubscriptionID: webhook.SubscriptionID(req.GetSubscriptionId()),
+	}
+}
+
+type DeleteSubscriptionResponse struct {
+	Error error `json:"error,omitempty"`
+}
+
+func (r DeleteSubscriptionResponse) error() error { return r.Error }
+
+func (r DeleteSubscriptionResponse) Protobuf() *pb.DeleteSubscriptionResponse {
+	return &pb.DeleteSubscriptionResponse{Error: err2str(r.Error)}
+}
+
+func MakeDeleteSubscriptionEndpoint(ws services.WebhookServiceContract) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req, ok := request.(DeleteSubscriptionRequest)
+		if !ok {
+			return nil, errors.New("failed to convert request to DeleteSubscriptionRequest")
+		}
+
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+
+		err = ws.Unsubscribe(ctx, req.SubscriptionID)
+		return DeleteSubscriptionResponse{Error: err}, nil
+	}
+}
+
+type ListFailedDeliveriesRequest struct {
+	CustomerID customer.ID `json:"customer_id"`
+}
+
+func (r ListFailedDeliveriesRequest) Build(req *pb.ListFailedDeliveriesRequest) ListFailedDeliveriesRequest {
+	return ListFailedDeliveriesRequest{
+		CustomerID: customer.ID(req.GetCustomerId()),
+	}
+}
+
+type ListFailedDeliveriesResponse struct {
+	Deliveries []webhook.Delivery `json:"deliveries"`
+	Error      error              `json:"error,omitempty"`
+}
+
+func (r ListFailedDeliveriesResponse) error() error { return r.Error }
+
+func (r ListFailedDeliveriesResponse) Protobuf() *pb.ListFailedDeliveriesResponse {
+	res := &pb.ListFailedDeliveriesResponse{Error: err2str(r.Error)}
+	for _, d := range r.Deliveries {
+		res.Deliveries = append(res.Deliveries, WebhookDeliveryToProto(d))
+	}
+
+	return res
+}
+
+func MakeListFailedDeliveriesEndpoint(ws services.WebhookServiceContract) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req, ok := request.(ListFailedDeliveriesRequest)
+		if !ok {
+			return nil, errors.New("failed to convert request to ListFailedDeliveriesRequest")
+		}
+
+		deliveries, err := ws.FailedDeliveries(ctx, req.CustomerID)
+		return ListFailedDeliveriesResponse{Deliveries: deliveries, Error: err}, nil
+	}
+}
+
+type ReplayDeliveryRequest struct {
+	DeliveryID webhook.DeliveryID `json:"delivery_id"`
+}
+
+func (r ReplayDeliveryRequest) Build(req *pb.ReplayDeliveryRequest) ReplayDeliveryRequest {
+	return ReplayDeliveryRequest{
+		DeliveryID: webhook.DeliveryID(req.GetDeliveryId()),
+	}
+}
+
+type ReplayDeliveryResponse struct {
+	Delivery webhook.Delivery `json:"delivery"`
+	Error    error            `json:"error,omitempty"`
+}
+
+func (r ReplayDeliveryResponse) error() error { return r.Error }
+
+func (r ReplayDeliveryResponse) Protobuf() *pb.ReplayDeliveryResponse {
+	res := &pb.ReplayDeliveryResponse{Error: err2str(r.Error)}
+	if r.Error == nil {
+		res.Delivery = WebhookDeliveryToProto(r.Delivery)
+	}
+
+	return res
+}
+
+func MakeReplayDeliveryEndpoint(ws services.WebhookServiceContract) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req, ok := request.(ReplayDeliveryRequest)
+		if !ok {
+			return nil, errors.New("failed to convert request to ReplayDeliveryRequest")
+		}
+
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+
+		d, err := ws.ReplayDelivery(ctx, req.DeliveryID)
+		return ReplayDeliveryResponse{Delivery: d, Error: err}, nil
+	}
+}
+
+// SubscriptionToProto converts a subscription into its protobuf model.
+func SubscriptionToProto(s webhook.Subscription) *pb.SubscriptionModel {
+	return &pb.SubscriptionModel{
+		SubscriptionId: string(s.ID),
+		CustomerId:     string(s.CustomerID),
+		Url:            s.URL,
+		Events:         s.Filter.Names(),
+		Secret:         s.Secret,
+		Created:        timestamppb.New(s.Created),
+	}
+}
+
+// SubscriptionFromProto converts a protobuf subscription model into a
+// subscription, unknown events are dropped.
+func SubscriptionFromProto(m *pb.SubscriptionModel) webhook.Subscription {
+	s := webhook.Subscription{
+		ID:         webhook.SubscriptionID(m.GetSubscriptionId()),
+		CustomerID: customer.ID(m.GetCustomerId()),
+		URL:        m.GetUrl(),
+		Secret:     m.GetSecret(),
+		Created:    m.GetCreated().AsTime(),
+	}
+
+	for _, name := range m.GetEvents() {
+		if e, err := webhook.ParseEvent(name); err == nil {
+			s.Filter = append(s.Filter, e)
+		}
+	}
+
+	return s
+}
+
+// WebhookDeliveryToProto converts a delivery into its protobuf model.
+func WebhookDeliveryToProto(d webhook.Delivery) *pb.WebhookDeliveryModel {
+	return &pb.WebhookDeliveryModel{
+		DeliveryId:     string(d.ID),
+		SubscriptionId: string(d.SubscriptionID),
+		CustomerId:     string(d.CustomerID),
+		Event:          d.Event.String(),
+		Payload:        d.Payload,
+		Status:         d.Status.String(),
+		Attempts:       int32(d.Attempts),
+		NextAttempt:    timestamppb.New(d.NextAttempt),
+		LastError:      d.LastError,
+		Created:        timestamppb.New(d.Created),
+	}
+}
+
+// WebhookDeliveryFromProto converts a protobuf delivery model into a
+// delivery.
+func WebhookDeliveryFromProto(m *pb.WebhookDeliveryModel) webhook.Delivery {
+	d := webhook.Delivery{
+		ID:             webhook.DeliveryID(m.GetDeliveryId()),
+		SubscriptionID: webhook.SubscriptionID(m.GetSubscriptionId()),
+		CustomerID:     customer.ID(m.GetCustomerId()),
+		Payload:        m.GetPayload(),
+		Attempts:       int(m.GetAttempts()),
+		NextAttempt:    m.GetNextAttempt().AsTime(),
+		LastError:      m.GetLastError(),
+		Created:        m.GetCreated().AsTime(),
+	}
+
+	d.Event, _ = webhook.ParseEvent(m.GetEvent())
+	for _, s := range []webhook.Status{webhook.Pending, webhook.Delivered, webhook.Failed} {
+		if m.GetStatus() == s.String() {
+			d.Status = s
+		}
+	}
+
+	return d
+}
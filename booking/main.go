@@ -1,20 +1,40 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"os"
+	"time"
 
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	kitlog "github.com/go-kit/log"
 	consulapi "github.com/hashicorp/consul/api"
+	"github.com/mproyyan/grpc-shipping-microservice/billing"
 	"github.com/mproyyan/grpc-shipping-microservice/booking/endpoints"
 	"github.com/mproyyan/grpc-shipping-microservice/booking/services"
 	"github.com/mproyyan/grpc-shipping-microservice/booking/transports"
 	"github.com/mproyyan/grpc-shipping-microservice/cargo"
 	"github.com/mproyyan/grpc-shipping-microservice/config"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
 	"github.com/mproyyan/grpc-shipping-microservice/db"
+	"github.com/mproyyan/grpc-shipping-microservice/filedrop"
+	"github.com/mproyyan/grpc-shipping-microservice/inspection"
+	"github.com/mproyyan/grpc-shipping-microservice/location"
+	"github.com/mproyyan/grpc-shipping-microservice/logging"
+	"github.com/mproyyan/grpc-shipping-microservice/notification"
 	"github.com/mproyyan/grpc-shipping-microservice/pb"
+	"github.com/mproyyan/grpc-shipping-microservice/pricing"
+	"github.com/mproyyan/grpc-shipping-microservice/shipment"
+	"github.com/mproyyan/grpc-shipping-microservice/tracing"
+	"github.com/mproyyan/grpc-shipping-microservice/voyage"
+	"github.com/mproyyan/grpc-shipping-microservice/webhook"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
@@ -23,12 +43,32 @@ import (
 
 func main() {
 	var (
-		id       = flag.String("id", "", "service id")
-		grpcPort = flag.Int("grpcPort", 8888, "port for grpc server")
+		id          = flag.String("id", "", "service id")
+		grpcPort    = flag.Int("grpcPort", 8888, "port for grpc server")
+		metricsAddr = flag.String("metrics.addr", ":9100", "address for the prometheus /metrics endpoint")
+		logLevel    = flag.String("log.level", "info", "minimum log level: debug, info, warn or error")
+		traceCfg    tracing.Config
 	)
 
+	flag.StringVar(&traceCfg.Exporter, "tracing.exporter", tracing.ExporterNone, "span exporter: none, stdout, file or otlp")
+	flag.StringVar(&traceCfg.File, "tracing.file", "traces.json", "file spans are written to when using the file exporter")
+	flag.StringVar(&traceCfg.OTLPEndpoint, "tracing.otlp.endpoint", "localhost:4317", "OTLP collector address")
+	flag.Float64Var(&traceCfg.SampleRatio, "tracing.sample", 1, "fraction of traces to record")
 	flag.Parse()
 
+	logger, err := logging.NewLogger(os.Stderr, *logLevel)
+	if err != nil {
+		log.Print("failed to create logger :", err)
+		os.Exit(1)
+	}
+
+	shutdownTracing, err := tracing.Init(context.Background(), "bookingservice", traceCfg)
+	if err != nil {
+		log.Print("failed to initialize tracing :", err)
+		os.Exit(1)
+	}
+	defer shutdownTracing(context.Background())
+
 	grpcListener, err := net.Listen("tcp", fmt.Sprintf(":%d", *grpcPort))
 	if err != nil {
 		log.Print("cannot start grpc listener :", err)
@@ -47,23 +87,218 @@ func main() {
 		os.Exit(1)
 	}
 
+	prometheus.MustRegister(collectors.NewDBStatsCollector(db, "grpc_shipping"))
+
+	queryLatency := kitprometheus.NewHistogramFrom(prometheus.HistogramOpts{
+		Namespace: "api",
+		Subsystem: "repository",
+		Name:      "query_latency_seconds",
+		Help:      "Total duration of repository queries in seconds.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"repository", "method", "outcome"})
+
 	var (
-		itineraries = cargo.NewItineraryRepository()
-		deliveries  = cargo.NewDeliveryRepository()
-		cargos      = cargo.NewCargoRepository(itineraries, deliveries)
-		events      = cargo.NewEventRepository()
+		itineraries   = cargo.NewInstrumentingItineraryRepository(queryLatency, cargo.NewTracingItineraryRepository(cargo.NewItineraryRepository()))
+		deliveries    = cargo.NewInstrumentingDeliveryRepository(queryLatency, cargo.NewTracingDeliveryRepository(cargo.NewDeliveryRepository()))
+		cargos        = cargo.NewInstrumentingCargoRepository(queryLatency, cargo.NewTracingCargoRepository(cargo.NewCargoRepository(itineraries, deliveries)))
+		events        = cargo.NewInstrumentingEventRepository(queryLatency, cargo.NewTracingEventRepository(cargo.NewEventRepository()))
+		customers     = customer.NewInstrumentingCustomerRepository(queryLatency, customer.NewTracingCustomerRepository(customer.NewCustomerRepository()))
+		shipments     = shipment.NewInstrumentingShipmentRepository(queryLatency, shipment.NewTracingShipmentRepository(shipment.NewShipmentRepository()))
+		voyages       = voyage.NewInstrumentingVoyageRepository(queryLatency, voyage.NewTracingVoyageRepository(voyage.NewVoyageRepository()))
+		locations     = location.NewInstrumentingLocationRepository(queryLatency, location.NewTracingLocationRepository(location.NewLocationRepository()))
+		tariffs       = pricing.NewInstrumentingTariffRepository(queryLatency, pricing.NewTracingTariffRepository(pricing.NewTariffRepository()))
+		quotes        = pricing.NewInstrumentingQuoteRepository(queryLatency, pricing.NewTracingQuoteRepository(pricing.NewQuoteRepository()))
+		invoices      = billing.NewInstrumentingInvoiceRepository(queryLatency, billing.NewTracingInvoiceRepository(billing.NewInvoiceRepository()))
+		subscriptions = webhook.NewInstrumentingSubscriptionRepository(queryLatency, webhook.NewTracingSubscriptionRepository(webhook.NewSubscriptionRepository()))
+		posts         = webhook.NewInstrumentingDeliveryRepository(queryLatency, webhook.NewTracingDeliveryRepository(webhook.NewDeliveryRepository()))
+		imports       = filedrop.NewInstrumentingImportRepository(queryLatency, filedrop.NewTracingImportRepository(filedrop.NewImportRepository()))
 	)
 
+	plan := billing.Plan{Booked: env.BookedShare, Loaded: env.LoadedShare, Claimed: env.ClaimedShare}
+	if plan == (billing.Plan{}) {
+		plan = billing.DefaultPlan
+	}
+
+	if !plan.Valid() {
+		log.Print("billing shares must not be negative and add up to 100")
+		os.Exit(1)
+	}
+
+	templates := notification.DefaultTemplates()
+	if env.NotificationTemplates != "" {
+		templates, err = notification.LoadTemplates(env.NotificationTemplates)
+		if err != nil {
+			log.Print("failed to load notification templates :", err)
+			os.Exit(1)
+		}
+	}
+
+	channels := []notification.Channel{notification.NewWebhookChannel(webhook.NewClient(webhook.DefaultTimeout))}
+	if env.SMTPAddr != "" {
+		channels = append(channels, notification.NewSMTPChannel(env.SMTPAddr, env.SMTPFrom, env.SMTPUsername, env.SMTPPassword))
+	}
+
+	if env.NotificationFile != "" {
+		channels = append(channels, notification.NewFileChannel(env.NotificationFile))
+	}
+
+	alerts := notification.NewDispatcher(templates, channels, notification.DefaultQueueSize, 30*time.Second, kitlog.With(logger, "component", "notification"))
+	defer alerts.Close()
+
+	policy := webhook.DefaultPolicy
+	if env.WebhookInitialBackoff > 0 {
+		policy.Initial = env.WebhookInitialBackoff
+	}
+
+	if env.WebhookMaxBackoff > 0 {
+		policy.Max = env.WebhookMaxBackoff
+	}
+
+	if env.WebhookAttempts > 0 {
+		policy.Attempts = env.WebhookAttempts
+	}
+
+	pollInterval := env.WebhookPollInterval
+	if pollInterval == 0 {
+		pollInterval = 5 * time.Second
+	}
+
+	outbox := webhook.NewOutbox(subscriptions, posts)
+	sender := webhook.NewSender(db, subscriptions, posts, webhook.NewClient(webhook.DefaultTimeout), policy, kitlog.With(logger, "component", "webhook"))
+
+	senderCtx, stopSender := context.WithCancel(context.Background())
+	defer stopSender()
+	go sender.Run(senderCtx, pollInterval)
+
+	var service services.BookingServiceContract
+	{
+		service = services.NewBookingService(db, cargos, events, customers, voyages, locations, cargo.NewClearanceCountries(env.CustomsCountries...), quotes, invoices, plan, alerts, outbox)
+		service = services.NewInstrumentingService(
+			kitprometheus.NewCounterFrom(prometheus.CounterOpts{
+				Namespace: "api",
+				Subsystem: "booking_service",
+				Name:      "request_count",
+				Help:      "Number of requests received.",
+			}, []string{"method", "outcome"}),
+			kitprometheus.NewHistogramFrom(prometheus.HistogramOpts{
+				Namespace: "api",
+				Subsystem: "booking_service",
+				Name:      "request_latency_seconds",
+				Help:      "Total duration of requests in seconds.",
+				Buckets:   prometheus.DefBuckets,
+			}, []string{"method", "outcome"}),
+			service,
+		)
+		service = services.NewLoggingService(kitlog.With(logger, "component", "booking"), service)
+	}
+
 	var (
-		service    = services.NewBookingService(db, cargos, events)
-		ep         = endpoints.NewBookingEndpoints(service)
+		ep         = endpoints.NewBookingEndpoints(service, kitlog.With(logger, "component", "endpoints"))
 		grpcServer = transports.NewGRPCServer(ep)
 	)
 
-	baseServer := grpc.NewServer()
+	var (
+		customerService    = services.NewCustomerService(db, customers)
+		customerEndpoints  = endpoints.NewCustomerEndpoints(customerService, kitlog.With(logger, "component", "endpoints"))
+		customerGRPCServer = transports.NewCustomerGRPCServer(customerEndpoints)
+	)
+
+	retry := inspection.DefaultRetry
+	if env.InspectionAttempts > 0 {
+		retry.Attempts = env.InspectionAttempts
+	}
+
+	if env.InspectionRetryDelay > 0 {
+		retry.Delay = env.InspectionRetryDelay
+	}
+
+	workers := env.InspectionWorkers
+	if workers == 0 {
+		workers = inspection.DefaultWorkers
+	}
+
+	inspectionInterval := env.InspectionPollInterval
+	if inspectionInterval == 0 {
+		inspectionInterval = time.Second
+	}
+
+	queue := inspection.NewPostgresQueue(db, retry)
+
+	var (
+		handlingService    = services.NewHandlingService(db, cargos, events, shipments, customers, quotes, invoices, plan, alerts, outbox, imports, queue)
+		handlingEndpoints  = endpoints.NewHandlingEndpoints(handlingService, kitlog.With(logger, "component", "endpoints"))
+		handlingGRPCServer = transports.NewHandlingGRPCServer(handlingEndpoints)
+	)
+
+	pool := inspection.NewPool(queue, handlingService.Inspect, workers, kitlog.With(logger, "component", "inspection"))
+	poolCtx, stopPool := context.WithCancel(context.Background())
+	defer stopPool()
+	go pool.Run(poolCtx, inspectionInterval)
+
+	// handling reports dropped by ports are imported as staff
+	if env.HandlingImportDir != "" {
+		importInterval := env.HandlingImportInterval
+		if importInterval == 0 {
+			importInterval = time.Minute
+		}
+
+		watcher := filedrop.NewWatcher(env.HandlingImportDir, handlingService, kitlog.With(logger, "component", "filedrop"))
+		watcherCtx, stopWatcher := context.WithCancel(customer.NewContext(context.Background(), customer.Caller{Staff: true}))
+		defer stopWatcher()
+		go watcher.Run(watcherCtx, importInterval)
+	}
+
+	var (
+		shipmentService    = services.NewShipmentService(db, cargos, shipments, voyages, locations, customers, alerts, outbox)
+		shipmentEndpoints  = endpoints.NewShipmentEndpoints(shipmentService, kitlog.With(logger, "component", "endpoints"))
+		shipmentGRPCServer = transports.NewShipmentGRPCServer(shipmentEndpoints)
+	)
+
+	quoteValidity := env.QuoteValidity
+	if quoteValidity == 0 {
+		quoteValidity = pricing.DefaultValidity
+	}
+
+	surcharges := pricing.Surcharges{
+		DangerousGoods:     env.DangerousGoodsSurcharge,
+		TightDeadline:      env.TightDeadlineSurcharge,
+		TightDeadlineSlack: env.TightDeadlineSlack,
+	}
+
+	var (
+		pricingService    = services.NewPricingService(db, customers, voyages, locations, tariffs, quotes, surcharges, quoteValidity)
+		pricingEndpoints  = endpoints.NewPricingEndpoints(pricingService, kitlog.With(logger, "component", "endpoints"))
+		pricingGRPCServer = transports.NewPricingGRPCServer(pricingEndpoints)
+	)
+
+	var (
+		billingService    = services.NewBillingService(db, customers, invoices)
+		billingEndpoints  = endpoints.NewBillingEndpoints(billingService, kitlog.With(logger, "component", "endpoints"))
+		billingGRPCServer = transports.NewBillingGRPCServer(billingEndpoints)
+	)
+
+	var (
+		webhookService    = services.NewWebhookService(db, customers, subscriptions, posts)
+		webhookEndpoints  = endpoints.NewWebhookEndpoints(webhookService, kitlog.With(logger, "component", "endpoints"))
+		webhookGRPCServer = transports.NewWebhookGRPCServer(webhookEndpoints)
+	)
+
+	tokens, err := customer.NewTokens(env.AuthKey)
+	if err != nil {
+		log.Print("invalid AUTH_KEY :", err)
+		os.Exit(1)
+	}
+
+	baseServer := grpc.NewServer(transports.CallerServerOptions(tokens)...)
 	healthProbe := health.NewServer()
 	grpc_health_v1.RegisterHealthServer(baseServer, healthProbe)
 	pb.RegisterBookingServer(baseServer, grpcServer)
+	pb.RegisterCustomerServer(baseServer, customerGRPCServer)
+	pb.RegisterHandlingServer(baseServer, handlingGRPCServer)
+	pb.RegisterShipmentServer(baseServer, shipmentGRPCServer)
+	pb.RegisterPricingServer(baseServer, pricingGRPCServer)
+	pb.RegisterBillingServer(baseServer, billingGRPCServer)
+	pb.RegisterWebhooksServer(baseServer, webhookGRPCServer)
 
 	reflection.Register(baseServer)
 
@@ -91,10 +326,21 @@ func main() {
 		log.Fatal(err)
 	}
 
+	go func() {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.Handler())
+
+		log.Printf("metrics available on %s/metrics", *metricsAddr)
+		if err := http.ListenAndServe(*metricsAddr, mux); err != nil {
+			log.Print("cannot start metrics server :", err)
+		}
+	}()
+
 	log.Printf("server running on localhost:%d", *grpcPort)
 	err = baseServer.Serve(grpcListener)
 	if err != nil {
 		log.Print("cannot start grpc server :", err)
+		shutdownTracing(context.Background())
 		os.Exit(1)
 	}
 }
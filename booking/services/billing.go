@@ -0,0 +1,191 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/billing"
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+	"github.com/mproyyan/grpc-shipping-microservice/pricing"
+)
+
+type BillingServiceContract interface {
+	Invoices(ctx context.Context, customerID customer.ID) ([]billing.Invoice, error)
+	FinalizeInvoice(ctx context.Context, id billing.InvoiceID) (billing.Invoice, error)
+	RenderInvoice(ctx context.Context, id billing.InvoiceID, format string) ([]byte, error)
+}
+
+// BillingService gives access to the invoices filled in as cargos reach
+// their milestones. Customers see their own invoices, staff finalize them.
+type BillingService struct {
+	db        *sql.DB
+	customers customer.CustomerRepositoryContract
+	invoices  billing.InvoiceRepositoryContract
+}
+
+func NewBillingService(db *sql.DB, customers customer.CustomerRepositoryContract, invoices billing.InvoiceRepositoryContract) BillingService {
+	return BillingService{
+		db:        db,
+		customers: customers,
+		invoices:  invoices,
+	}
+}
+
+// Invoices returns the invoices of the customer, newest first. An empty
+// customer ID stands for the calling customer.
+func (bs BillingService) Invoices(ctx context.Context, customerID customer.ID) ([]billing.Invoice, error) {
+	caller, ok := customer.FromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+
+	if customerID == "" {
+		customerID = caller.CustomerID
+	}
+
+	if customerID == "" {
+		return nil, ErrInvalidArgument
+	}
+
+	if !caller.CanAccess(customerID) {
+		return nil, ErrPermissionDenied
+	}
+
+	invoices, err := bs.invoices.FindByCustomer(ctx, bs.db, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []billing.Invoice
+	for _, i := range invoices {
+		results = append(results, *i)
+	}
+
+	return results, nil
+}
+
+// FinalizeInvoice closes a draft invoice, lines billed afterwards go to a
+// new draft. Only staff can finalize invoices.
+func (bs BillingService) FinalizeInvoice(ctx context.Context, id billing.InvoiceID) (billing.Invoice, error) {
+	if id == "" {
+		return billing.Invoice{}, ErrInvalidArgument
+	}
+
+	if err := requireStaff(ctx); err != nil {
+		return billing.Invoice{}, err
+	}
+
+	var result billing.Invoice
+	err := db.WithTx(ctx, bs.db, func(tx *sql.Tx) error {
+		i, err := bs.invoices.Find(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := i.Finalize(time.Now()); err != nil {
+			return err
+		}
+
+		result = *i
+		return bs.invoices.Store(ctx, tx, i)
+	})
+	if err != nil {
+		return billing.Invoice{}, err
+	}
+
+	return result, nil
+}
+
+// RenderInvoice renders the invoice addressed to its customer. Invoices of
+// other customers are reported as unknown.
+func (bs BillingService) RenderInvoice(ctx context.Context, id billing.InvoiceID, format string) ([]byte, error) {
+	if id == "" || !billing.Supported(format) {
+		return nil, ErrInvalidArgument
+	}
+
+	caller, ok := customer.FromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+
+	i, err := bs.invoices.Find(ctx, bs.db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !caller.CanAccess(i.CustomerID) {
+		return nil, billing.ErrUnknownInvoice
+	}
+
+	c, err := bs.customers.Find(ctx, bs.db, i.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := billing.Render(&buf, format, *i, *c); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// biller bills the cargos booked at a quote their share of the agreed
+// price as they reach the milestones of the plan.
+type biller struct {
+	quotes   pricing.QuoteRepositoryContract
+	invoices billing.InvoiceRepositoryContract
+	plan     billing.Plan
+}
+
+func newBiller(quotes pricing.QuoteRepositoryContract, invoices billing.InvoiceRepositoryContract, plan billing.Plan) biller {
+	return biller{quotes: quotes, invoices: invoices, plan: plan}
+}
+
+// bill adds the line of the milestone to the draft invoice of the customer
+// the cargo was quoted to, starting a new draft when there is none. Cargos
+// booked without a quote have no agreed price and are not billed, nor is a
+// milestone billed twice.
+func (b biller) bill(ctx context.Context, dbtx db.DBTX, id cargo.TrackingID, m billing.Milestone, occurred time.Time) error {
+	q, err := b.quotes.FindByCargo(ctx, dbtx, id)
+	if err != nil {
+		if err == pricing.ErrUnknownQuote {
+			return nil
+		}
+
+		return err
+	}
+
+	agreed, ok := q.Agreed()
+	if !ok {
+		return nil
+	}
+
+	line, ok := b.plan.Line(id, m, agreed.Price, occurred)
+	if !ok {
+		return nil
+	}
+
+	billed, err := b.invoices.Billed(ctx, dbtx, id, m)
+	if err != nil || billed {
+		return err
+	}
+
+	i, err := b.invoices.FindDraft(ctx, dbtx, q.CustomerID, agreed.Price.Currency)
+	if err == billing.ErrUnknownInvoice {
+		i, err = billing.NewInvoice(billing.NextInvoiceID(), q.CustomerID, agreed.Price.Currency, time.Now()), nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := i.Add(line); err != nil {
+		return err
+	}
+
+	return b.invoices.Store(ctx, dbtx, i)
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+	"github.com/mproyyan/grpc-shipping-microservice/location"
+	"github.com/mproyyan/grpc-shipping-microservice/webhook"
+)
+
+// Booking is a single row of a bulk booking.
+type Booking struct {
+	Origin      location.UNLocode
+	Destination location.UNLocode
+	Deadline    time.Time
+	Attributes  cargo.Attributes
+}
+
+// BookingResult is the outcome of booking a single row. TrackingID is empty
+// for failed rows and for dry runs.
+type BookingResult struct {
+	TrackingID cargo.TrackingID
+	Err        error
+}
+
+// BookCargos books all bookings for the caller in a single transaction, so
+// either every valid row of the batch is booked or none is. Rows failing
+// the basic checks are reported individually and do not abort the batch.
+// With dryRun set the rows are checked but nothing is written.
+func (bs BookingService) BookCargos(ctx context.Context, bookings []Booking, dryRun bool) ([]BookingResult, error) {
+	caller, ok := customer.FromContext(ctx)
+	if !ok || caller.CustomerID == "" {
+		return nil, ErrUnauthenticated
+	}
+
+	if _, err := bs.customers.Find(ctx, bs.db, caller.CustomerID); err != nil {
+		return nil, err
+	}
+
+	results := make([]BookingResult, len(bookings))
+	var valid []int
+	for i, b := range bookings {
+		if b.Origin == "" || b.Destination == "" || b.Deadline.IsZero() || !validAttributes(b.Attributes) {
+			results[i].Err = ErrInvalidArgument
+			continue
+		}
+
+		valid = append(valid, i)
+	}
+
+	if dryRun || len(valid) == 0 {
+		return results, nil
+	}
+
+	err := db.WithTx(ctx, bs.db, func(tx *sql.Tx) error {
+		for _, i := range valid {
+			c := cargo.New(cargo.NextTrackingID(), cargo.RouteSpecification{
+				Origin:          bookings[i].Origin,
+				Destination:     bookings[i].Destination,
+				ArrivalDeadline: bookings[i].Deadline,
+			})
+			c.CustomerID = caller.CustomerID
+			c.Attributes = bookings[i].Attributes
+			c.RequireClearance(bs.clearance)
+
+			c, err := bs.cargos.Upsert(ctx, tx, c)
+			if err != nil {
+				return err
+			}
+
+			if err := bs.webhooks.Publish(ctx, tx, c, nil, webhook.Booked); err != nil {
+				return err
+			}
+
+			results[i].TrackingID = c.TrackingID
+		}
+
+		return nil
+	})
+
+	// the transaction was rolled back, none of the rows were booked
+	if err != nil {
+		for _, i := range valid {
+			results[i] = BookingResult{Err: err}
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+)
+
+type CustomerServiceContract interface {
+	RegisterCustomer(ctx context.Context, name string, contact customer.Contact, preferences customer.NotificationPreferences) (customer.ID, error)
+	LoadCustomer(ctx context.Context, id customer.ID) (customer.Customer, error)
+	UpdateCustomer(ctx context.Context, c customer.Customer) error
+	Customers(ctx context.Context) ([]customer.Customer, error)
+}
+
+type CustomerService struct {
+	db        *sql.DB
+	customers customer.CustomerRepositoryContract
+}
+
+func NewCustomerService(db *sql.DB, customers customer.CustomerRepositoryContract) CustomerService {
+	return CustomerService{
+		db:        db,
+		customers: customers,
+	}
+}
+
+func (cs CustomerService) RegisterCustomer(ctx context.Context, name string, contact customer.Contact, preferences customer.NotificationPreferences) (customer.ID, error) {
+	if name == "" || contact.Email == "" {
+		return "", ErrInvalidArgument
+	}
+
+	if err := requireStaff(ctx); err != nil {
+		return "", err
+	}
+
+	c := customer.New(customer.NextID(), name, contact, preferences)
+	c, err := cs.customers.Upsert(ctx, cs.db, c)
+	if err != nil {
+		return "", err
+	}
+
+	return c.ID, nil
+}
+
+func (cs CustomerService) LoadCustomer(ctx context.Context, id customer.ID) (customer.Customer, error) {
+	if id == "" {
+		return customer.Customer{}, ErrInvalidArgument
+	}
+
+	if err := requireAccess(ctx, id); err != nil {
+		return customer.Customer{}, err
+	}
+
+	c, err := cs.customers.Find(ctx, cs.db, id)
+	if err != nil {
+		return customer.Customer{}, err
+	}
+
+	return *c, nil
+}
+
+func (cs CustomerService) UpdateCustomer(ctx context.Context, c customer.Customer) error {
+	if c.ID == "" || c.Name == "" || c.Contact.Email == "" {
+		return ErrInvalidArgument
+	}
+
+	if err := requireAccess(ctx, c.ID); err != nil {
+		return err
+	}
+
+	// only existing customers can be updated, registration goes through RegisterCustomer
+	if _, err := cs.customers.Find(ctx, cs.db, c.ID); err != nil {
+		return err
+	}
+
+	_, err := cs.customers.Upsert(ctx, cs.db, &c)
+	return err
+}
+
+func (cs CustomerService) Customers(ctx context.Context) ([]customer.Customer, error) {
+	var results []customer.Customer
+	if err := requireStaff(ctx); err != nil {
+		return results, err
+	}
+
+	customers, err := cs.customers.FindAll(ctx, cs.db)
+	if err != nil {
+		return results, err
+	}
+
+	for _, c := range customers {
+		results = append(results, *c)
+	}
+
+	return results, nil
+}
+
+func requireStaff(ctx context.Context) error {
+	caller, ok := customer.FromContext(ctx)
+	if !ok {
+		return ErrUnauthenticated
+	}
+
+	if !caller.Staff {
+		return ErrPermissionDenied
+	}
+
+	return nil
+}
+
+func requireAccess(ctx context.Context, id customer.ID) error {
+	caller, ok := customer.FromContext(ctx)
+	if !ok {
+		return ErrUnauthenticated
+	}
+
+	if !caller.CanAccess(id) {
+		return ErrPermissionDenied
+	}
+
+	return nil
+}
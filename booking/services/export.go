@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/export"
+)
+
+// exportPageSize is the number of cargos loaded per query while exporting.
+const exportPageSize = 500
+
+// ExportCargos passes every cargo matching filter to yield, together with
+// its delivery state and, with history set, its handling history. Cargos
+// are loaded a page at a time so exports do not have to fit in memory. An
+// error returned by yield stops the export and is returned as-is.
+func (bs BookingService) ExportCargos(ctx context.Context, filter cargo.Filter, history bool, yield func(export.Record) error) error {
+	filter, err := scope(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	filter.Limit = exportPageSize
+	for {
+		cargos, err := bs.cargos.FindMatching(ctx, bs.db, filter)
+		if err != nil {
+			return err
+		}
+
+		for _, c := range cargos {
+			r := record(c)
+			if history {
+				h, err := bs.events.QueryHandlingHistory(ctx, bs.db, c.TrackingID)
+				if err != nil {
+					return err
+				}
+
+				for _, e := range h.HandlingEvents {
+					event := exportEvent(e.Activity)
+					event.Completed = e.Completed
+					r.History = append(r.History, event)
+				}
+			}
+
+			if err := yield(r); err != nil {
+				return err
+			}
+		}
+
+		if len(cargos) < exportPageSize {
+			return nil
+		}
+
+		filter.After = cargos[len(cargos)-1].TrackingID
+	}
+}
+
+func record(c *cargo.Cargo) export.Record {
+	d := c.Delivery
+	r := export.Record{
+		TrackingID:            string(c.TrackingID),
+		CustomerID:            string(c.CustomerID),
+		Origin:                string(c.Origin),
+		Destination:           string(c.RouteSpecification.Destination),
+		ArrivalDeadline:       c.RouteSpecification.ArrivalDeadline,
+		RoutingStatus:         d.RoutingStatus.String(),
+		TransportStatus:       d.TransportStatus.String(),
+		LastKnownLocation:     string(d.LastKnownLocation),
+		CurrentVoyage:         string(d.CurrentVoyage),
+		Misdirected:           d.IsMisdirected,
+		UnloadedAtDestination: d.IsUnloadedAtDestination,
+	}
+
+	if !d.ETA.IsZero() {
+		eta := d.ETA
+		r.ETA = &eta
+	}
+
+	if d.NextExpectedActivity.Type != cargo.NotHandled {
+		r.NextExpectedActivity = exportEvent(d.NextExpectedActivity).String()
+	}
+
+	return r
+}
+
+func exportEvent(a cargo.HandlingActivity) export.Event {
+	return export.Event{
+		Type:         a.Type.String(),
+		Location:     string(a.Location),
+		VoyageNumber: string(a.VoyageNumber),
+	}
+}
@@ -0,0 +1,371 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/billing"
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+	"github.com/mproyyan/grpc-shipping-microservice/edifact"
+	"github.com/mproyyan/grpc-shipping-microservice/filedrop"
+	"github.com/mproyyan/grpc-shipping-microservice/inspection"
+	"github.com/mproyyan/grpc-shipping-microservice/location"
+	"github.com/mproyyan/grpc-shipping-microservice/notification"
+	"github.com/mproyyan/grpc-shipping-microservice/pricing"
+	"github.com/mproyyan/grpc-shipping-microservice/shipment"
+	"github.com/mproyyan/grpc-shipping-microservice/voyage"
+	"github.com/mproyyan/grpc-shipping-microservice/webhook"
+)
+
+type HandlingServiceContract interface {
+	RegisterHandlingEvent(ctx context.Context, completed time.Time, id cargo.TrackingID, voyageNumber voyage.Number, loc location.UNLocode, eventType cargo.HandlingEventType) error
+	RegisterContainerHandlingEvent(ctx context.Context, completed time.Time, container shipment.ContainerID, voyageNumber voyage.Number, loc location.UNLocode, eventType cargo.HandlingEventType) ([]cargo.TrackingID, error)
+	IngestEDIFACT(ctx context.Context, interchange []byte) (IngestionReport, error)
+}
+
+// MessageResult is the outcome of ingesting a single EDIFACT message. Events
+// counts the handling events registered, Err is set for rejected messages.
+type MessageResult struct {
+	Reference string
+	Type      string
+	Events    int
+	Err       error
+}
+
+// IngestionReport holds one result per message of an interchange, in order.
+type IngestionReport struct {
+	Interchange string
+	Sender      string
+	Results     []MessageResult
+}
+
+// Rejected returns the results of the messages that were not registered.
+func (r IngestionReport) Rejected() []MessageResult {
+	var rejected []MessageResult
+	for _, result := range r.Results {
+		if result.Err != nil {
+			rejected = append(rejected, result)
+		}
+	}
+
+	return rejected
+}
+
+type HandlingService struct {
+	db        *sql.DB
+	cargos    cargo.CargoRepositoryContract
+	events    cargo.EventRepositoryContract
+	shipments shipment.ShipmentRepositoryContract
+	billing   biller
+	notifier  notifier
+	webhooks  webhook.Outbox
+	imports   filedrop.ImportRepositoryContract
+	queue     inspection.Queue
+}
+
+func NewHandlingService(db *sql.DB, cargos cargo.CargoRepositoryContract, events cargo.EventRepositoryContract, shipments shipment.ShipmentRepositoryContract, customers customer.CustomerRepositoryContract, quotes pricing.QuoteRepositoryContract, invoices billing.InvoiceRepositoryContract, plan billing.Plan, alerts notification.Notifier, webhooks webhook.Outbox, imports filedrop.ImportRepositoryContract, queue inspection.Queue) HandlingService {
+	return HandlingService{
+		db:        db,
+		cargos:    cargos,
+		events:    events,
+		shipments: shipments,
+		billing:   newBiller(quotes, invoices, plan),
+		notifier:  newNotifier(customers, alerts),
+		webhooks:  webhooks,
+		imports:   imports,
+		queue:     queue,
+	}
+}
+
+// RegisterHandlingEvent records that the cargo was handled and queues the
+// cargo for inspection, which derives its new delivery state. Only staff can
+// register events, a zero completion time means the event happened now.
+func (hs HandlingService) RegisterHandlingEvent(ctx context.Context, completed time.Time, id cargo.TrackingID, voyageNumber voyage.Number, loc location.UNLocode, eventType cargo.HandlingEventType) error {
+	if id == "" || loc == "" || eventType == cargo.NotHandled {
+		return ErrInvalidArgument
+	}
+
+	if err := requireStaff(ctx); err != nil {
+		return err
+	}
+
+	if completed.IsZero() {
+		completed = time.Now()
+	}
+
+	return db.WithTx(ctx, hs.db, func(tx *sql.Tx) error {
+		return hs.register(ctx, tx, completed, id, voyageNumber, loc, eventType)
+	})
+}
+
+// RegisterContainerHandlingEvent registers the event for every cargo in the
+// container, in one transaction. It returns the cargos that were handled.
+func (hs HandlingService) RegisterContainerHandlingEvent(ctx context.Context, completed time.Time, container shipment.ContainerID, voyageNumber voyage.Number, loc location.UNLocode, eventType cargo.HandlingEventType) ([]cargo.TrackingID, error) {
+	if container == "" || loc == "" || eventType == cargo.NotHandled {
+		return nil, ErrInvalidArgument
+	}
+
+	if err := requireStaff(ctx); err != nil {
+		return nil, err
+	}
+
+	if completed.IsZero() {
+		completed = time.Now()
+	}
+
+	var ids []cargo.TrackingID
+	err := db.WithTx(ctx, hs.db, func(tx *sql.Tx) (err error) {
+		ids, err = hs.registerContainer(ctx, tx, completed, container, voyageNumber, loc, eventType)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// IngestEDIFACT registers the handling events reported by the IFTSTA and
+// CODECO messages of an interchange. Every message is registered in its own
+// transaction, so a rejected message does not hold back the others; the
+// report tells which were rejected and why. An interchange that cannot be
+// read at all is an error.
+func (hs HandlingService) IngestEDIFACT(ctx context.Context, interchange []byte) (IngestionReport, error) {
+	if err := requireStaff(ctx); err != nil {
+		return IngestionReport{}, err
+	}
+
+	ic, err := edifact.Parse(interchange)
+	if err != nil {
+		return IngestionReport{}, err
+	}
+
+	report := IngestionReport{Interchange: ic.Reference, Sender: ic.Sender}
+	for _, m := range ic.Messages {
+		result := MessageResult{Reference: m.Reference, Type: m.Type}
+
+		handlings, err := edifact.Handlings(m)
+		if err == nil {
+			result.Events, err = hs.ingest(ctx, handlings)
+		}
+
+		result.Err = err
+		report.Results = append(report.Results, result)
+	}
+
+	return report, nil
+}
+
+// ImportHandlingEvents registers the lines of a handling report dropped by
+// a port, each in its own transaction. A line imported before, from this or
+// any other report, is reported as a duplicate and not registered again.
+func (hs HandlingService) ImportHandlingEvents(ctx context.Context, source string, lines []filedrop.Line) ([]filedrop.Outcome, error) {
+	if err := requireStaff(ctx); err != nil {
+		return nil, err
+	}
+
+	outcomes := make([]filedrop.Outcome, len(lines))
+	for i, l := range lines {
+		if l.Err != nil {
+			outcomes[i].Err = l.Err
+			continue
+		}
+
+		var o filedrop.Outcome
+		err := db.WithTx(ctx, hs.db, func(tx *sql.Tx) error {
+			fresh, err := hs.imports.Claim(ctx, tx, l.Key, source, time.Now())
+			if err != nil || !fresh {
+				o.Duplicate = !fresh
+				return err
+			}
+
+			a := l.Activity
+			if l.TrackingID != "" {
+				o.TrackingIDs = []cargo.TrackingID{l.TrackingID}
+				return hs.register(ctx, tx, l.Completed, l.TrackingID, a.VoyageNumber, a.Location, a.Type)
+			}
+
+			o.TrackingIDs, err = hs.registerContainer(ctx, tx, l.Completed, l.ContainerID, a.VoyageNumber, a.Location, a.Type)
+			return err
+		})
+		if err != nil {
+			outcomes[i].Err = err
+			continue
+		}
+
+		outcomes[i] = o
+	}
+
+	return outcomes, nil
+}
+
+// ingest registers the events of one message together and returns how many
+// cargos were handled.
+func (hs HandlingService) ingest(ctx context.Context, handlings []edifact.Handling) (int, error) {
+	var events int
+	err := db.WithTx(ctx, hs.db, func(tx *sql.Tx) error {
+		for _, h := range handlings {
+			a := h.Activity
+			if h.TrackingID != "" {
+				if err := hs.register(ctx, tx, h.Completed, h.TrackingID, a.VoyageNumber, a.Location, a.Type); err != nil {
+					return err
+				}
+
+				events++
+				continue
+			}
+
+			ids, err := hs.registerContainer(ctx, tx, h.Completed, h.ContainerID, a.VoyageNumber, a.Location, a.Type)
+			if err != nil {
+				return err
+			}
+
+			events += len(ids)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return events, nil
+}
+
+// registerContainer registers the event for every cargo in the container.
+func (hs HandlingService) registerContainer(ctx context.Context, tx *sql.Tx, completed time.Time, container shipment.ContainerID, voyageNumber voyage.Number, loc location.UNLocode, eventType cargo.HandlingEventType) ([]cargo.TrackingID, error) {
+	s, err := hs.shipments.FindByContainer(ctx, tx, container)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := s.TrackingIDs(container)
+	if len(ids) == 0 {
+		return nil, shipment.ErrEmptyContainer
+	}
+
+	for _, id := range ids {
+		if err := hs.register(ctx, tx, completed, id, voyageNumber, loc, eventType); err != nil {
+			return nil, err
+		}
+	}
+
+	return ids, nil
+}
+
+// register records the event and queues the cargo for inspection in the
+// same transaction. Inspections lag behind, so the status and clearance the
+// event is checked against are derived from the events registered so far
+// rather than taken from the stored cargo, which stays locked until the
+// transaction ends.
+func (hs HandlingService) register(ctx context.Context, tx *sql.Tx, completed time.Time, id cargo.TrackingID, voyageNumber voyage.Number, loc location.UNLocode, eventType cargo.HandlingEventType) error {
+	c, err := hs.cargos.FindForUpdate(ctx, tx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return cargo.ErrUnknown
+		}
+
+		return err
+	}
+
+	history, err := hs.events.QueryHandlingHistory(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	c.DeriveDeliveryProgress(history)
+	if !c.Status.Active() {
+		return cargo.ErrInactive
+	}
+
+	if eventType == cargo.Claim {
+		if err := c.Clearance.CheckClaim(); err != nil {
+			return err
+		}
+	}
+
+	event, err := hs.events.Store(ctx, tx, cargo.HandlingEvent{
+		TrackingID: id,
+		Activity: cargo.HandlingActivity{
+			Type:         eventType,
+			Location:     loc,
+			VoyageNumber: voyageNumber,
+		},
+		Completed: completed,
+	})
+	if err != nil {
+		return err
+	}
+
+	return hs.queue.Enqueue(ctx, tx, inspection.NewJob(id, event.ID, time.Now()))
+}
+
+// Inspect handles a job of the inspection queue: it derives the delivery of
+// the cargo from its complete handling history, bills the milestone reached
+// by the event and publishes what changed. The owner is alerted once the
+// transaction commits if the cargo became misdirected, was unloaded at its
+// destination or started running late. Without a transaction from the
+// queue, the inspection runs in its own. The cargo stays locked until the
+// transaction ends, so inspections are serialized with the other writers.
+func (hs HandlingService) Inspect(ctx context.Context, dbtx db.DBTX, job inspection.Job) (func(), error) {
+	if dbtx == nil {
+		var after func()
+		err := db.WithTx(ctx, hs.db, func(tx *sql.Tx) (err error) {
+			after, err = hs.Inspect(ctx, tx, job)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return after, nil
+	}
+
+	c, err := hs.cargos.FindForUpdate(ctx, dbtx, job.TrackingID)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := hs.events.QueryHandlingHistory(ctx, dbtx, job.TrackingID)
+	if err != nil {
+		return nil, err
+	}
+
+	event, ok := history.Event(job.EventID)
+	if !ok {
+		return nil, fmt.Errorf("handling event %d of cargo %s not found", job.EventID, job.TrackingID)
+	}
+
+	before := c.Delivery
+	c.DeriveDeliveryProgress(history)
+
+	if _, err := hs.cargos.Upsert(ctx, dbtx, c); err != nil {
+		return nil, err
+	}
+
+	// the cargo is billed at its first load and when it is claimed
+	switch event.Activity.Type {
+	case cargo.Load:
+		err = hs.billing.bill(ctx, dbtx, c.TrackingID, billing.Loaded, event.Completed)
+	case cargo.Claim:
+		err = hs.billing.bill(ctx, dbtx, c.TrackingID, billing.Claimed, event.Completed)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := hs.webhooks.Publish(ctx, dbtx, c, &event, webhookEvents(before, c.Delivery, webhook.Handled)...); err != nil {
+		return nil, err
+	}
+
+	alerts, err := hs.notifier.changed(ctx, dbtx, c, before)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() { hs.notifier.send(alerts) }, nil
+}
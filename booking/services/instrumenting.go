@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/export"
+	"github.com/mproyyan/grpc-shipping-microservice/location"
+	"github.com/mproyyan/grpc-shipping-microservice/pricing"
+)
+
+type instrumentingService struct {
+	requestCount   metrics.Counter
+	requestLatency metrics.Histogram
+	BookingServiceContract
+}
+
+// NewInstrumentingService returns an instance of an instrumenting booking
+// service. Every call is counted and timed, labeled by method and outcome.
+func NewInstrumentingService(counter metrics.Counter, latency metrics.Histogram, s BookingServiceContract) BookingServiceContract {
+	return &instrumentingService{
+		requestCount:           counter,
+		requestLatency:         latency,
+		BookingServiceContract: s,
+	}
+}
+
+func (s *instrumentingService) observe(method string, begin time.Time, err error) {
+	lvs := []string{"method", method, "outcome", Outcome(err)}
+	s.requestCount.With(lvs...).Add(1)
+	s.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+}
+
+func (s *instrumentingService) BookNewCargo(ctx context.Context, origin location.UNLocode, destination location.UNLocode, deadline time.Time, attributes cargo.Attributes, quote pricing.Acceptance) (id cargo.TrackingID, err error) {
+	defer func(begin time.Time) {
+		s.observe("book", begin, err)
+	}(time.Now())
+
+	return s.BookingServiceContract.BookNewCargo(ctx, origin, destination, deadline, attributes, quote)
+}
+
+func (s *instrumentingService) LoadCargo(ctx context.Context, id cargo.TrackingID) (c Cargo, err error) {
+	defer func(begin time.Time) {
+		s.observe("load", begin, err)
+	}(time.Now())
+
+	return s.BookingServiceContract.LoadCargo(ctx, id)
+}
+
+func (s *instrumentingService) AssignCargoToRoute(ctx context.Context, id cargo.TrackingID, itinerary cargo.Itinerary) (err error) {
+	defer func(begin time.Time) {
+		s.observe("assign_to_route", begin, err)
+	}(time.Now())
+
+	return s.BookingServiceContract.AssignCargoToRoute(ctx, id, itinerary)
+}
+
+func (s *instrumentingService) ChangeDestination(ctx context.Context, id cargo.TrackingID, destination location.UNLocode) (err error) {
+	defer func(begin time.Time) {
+		s.observe("change_destination", begin, err)
+	}(time.Now())
+
+	return s.BookingServiceContract.ChangeDestination(ctx, id, destination)
+}
+
+func (s *instrumentingService) UpdateRouteSpecification(ctx context.Context, id cargo.TrackingID, rs cargo.RouteSpecification) (satisfied bool, err error) {
+	defer func(begin time.Time) {
+		s.observe("update_route_specification", begin, err)
+	}(time.Now())
+
+	return s.BookingServiceContract.UpdateRouteSpecification(ctx, id, rs)
+}
+
+func (s *instrumentingService) CancelBooking(ctx context.Context, id cargo.TrackingID) (err error) {
+	defer func(begin time.Time) {
+		s.observe("cancel_booking", begin, err)
+	}(time.Now())
+
+	return s.BookingServiceContract.CancelBooking(ctx, id)
+}
+
+func (s *instrumentingService) HoldCargo(ctx context.Context, id cargo.TrackingID, reason string) (err error) {
+	defer func(begin time.Time) {
+		s.observe("hold_cargo", begin, err)
+	}(time.Now())
+
+	return s.BookingServiceContract.HoldCargo(ctx, id, reason)
+}
+
+func (s *instrumentingService) ReleaseCargo(ctx context.Context, id cargo.TrackingID) (err error) {
+	defer func(begin time.Time) {
+		s.observe("release_cargo", begin, err)
+	}(time.Now())
+
+	return s.BookingServiceContract.ReleaseCargo(ctx, id)
+}
+
+func (s *instrumentingService) HandlingHistory(ctx context.Context, id cargo.TrackingID) (events []HandlingEvent, err error) {
+	defer func(begin time.Time) {
+		s.observe("handling_history", begin, err)
+	}(time.Now())
+
+	return s.BookingServiceContract.HandlingHistory(ctx, id)
+}
+
+func (s *instrumentingService) Cargos(ctx context.Context, filter cargo.Filter) (cargos []Cargo, err error) {
+	defer func(begin time.Time) {
+		s.observe("list_cargos", begin, err)
+	}(time.Now())
+
+	return s.BookingServiceContract.Cargos(ctx, filter)
+}
+
+// Outcome turns an error into a low cardinality metric label.
+func Outcome(err error) string {
+	if err != nil {
+		return "error"
+	}
+
+	return "success"
+}
+
+func (s *instrumentingService) BookCargos(ctx context.Context, bookings []Booking, dryRun bool) (results []BookingResult, err error) {
+	defer func(begin time.Time) {
+		s.observe("book_bulk", begin, err)
+	}(time.Now())
+
+	return s.BookingServiceContract.BookCargos(ctx, bookings, dryRun)
+}
+
+func (s *instrumentingService) ExportCargos(ctx context.Context, filter cargo.Filter, history bool, yield func(export.Record) error) (err error) {
+	defer func(begin time.Time) {
+		s.observe("export_cargos", begin, err)
+	}(time.Now())
+
+	return s.BookingServiceContract.ExportCargos(ctx, filter, history, yield)
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/export"
+	"github.com/mproyyan/grpc-shipping-microservice/location"
+	"github.com/mproyyan/grpc-shipping-microservice/logging"
+	"github.com/mproyyan/grpc-shipping-microservice/pricing"
+)
+
+type loggingService struct {
+	logger log.Logger
+	BookingServiceContract
+}
+
+// NewLoggingService returns a new instance of a logging booking service. Only
+// identifiers and locations are logged, cargo and customer details are not.
+func NewLoggingService(logger log.Logger, s BookingServiceContract) BookingServiceContract {
+	return &loggingService{logger: logger, BookingServiceContract: s}
+}
+
+func (s *loggingService) log(ctx context.Context, begin time.Time, err error, keyvals ...interface{}) {
+	logger := level.Debug(s.logger)
+	if err != nil {
+		logger = level.Error(s.logger)
+	}
+
+	keyvals = append(keyvals,
+		"request_id", logging.RequestID(ctx),
+		"took", time.Since(begin),
+		"err", err,
+	)
+	logger.Log(keyvals...)
+}
+
+func (s *loggingService) BookNewCargo(ctx context.Context, origin location.UNLocode, destination location.UNLocode, deadline time.Time, attributes cargo.Attributes, quote pricing.Acceptance) (id cargo.TrackingID, err error) {
+	defer func(begin time.Time) {
+		s.log(ctx, begin, err,
+			"method", "book",
+			"origin", origin,
+			"destination", destination,
+			"arrival_deadline", deadline,
+			"weight", attributes.Weight,
+			"volume", attributes.Volume,
+			"quote_id", quote.QuoteID,
+			"tracking_id", id,
+		)
+	}(time.Now())
+
+	return s.BookingServiceContract.BookNewCargo(ctx, origin, destination, deadline, attributes, quote)
+}
+
+func (s *loggingService) LoadCargo(ctx context.Context, id cargo.TrackingID) (c Cargo, err error) {
+	defer func(begin time.Time) {
+		s.log(ctx, begin, err, "method", "load", "tracking_id", id)
+	}(time.Now())
+
+	return s.BookingServiceContract.LoadCargo(ctx, id)
+}
+
+func (s *loggingService) AssignCargoToRoute(ctx context.Context, id cargo.TrackingID, itinerary cargo.Itinerary) (err error) {
+	defer func(begin time.Time) {
+		s.log(ctx, begin, err,
+			"method", "assign_to_route",
+			"tracking_id", id,
+			"itinerary_id", itinerary.ID,
+			"legs", len(itinerary.Legs),
+		)
+	}(time.Now())
+
+	return s.BookingServiceContract.AssignCargoToRoute(ctx, id, itinerary)
+}
+
+func (s *loggingService) ChangeDestination(ctx context.Context, id cargo.TrackingID, destination location.UNLocode) (err error) {
+	defer func(begin time.Time) {
+		s.log(ctx, begin, err,
+			"method", "change_destination",
+			"tracking_id", id,
+			"destination", destination,
+		)
+	}(time.Now())
+
+	return s.BookingServiceContract.ChangeDestination(ctx, id, destination)
+}
+
+func (s *loggingService) UpdateRouteSpecification(ctx context.Context, id cargo.TrackingID, rs cargo.RouteSpecification) (satisfied bool, err error) {
+	defer func(begin time.Time) {
+		s.log(ctx, begin, err,
+			"method", "update_route_specification",
+			"tracking_id", id,
+			"origin", rs.Origin,
+			"destination", rs.Destination,
+			"arrival_deadline", rs.ArrivalDeadline,
+			"satisfied", satisfied,
+		)
+	}(time.Now())
+
+	return s.BookingServiceContract.UpdateRouteSpecification(ctx, id, rs)
+}
+
+func (s *loggingService) CancelBooking(ctx context.Context, id cargo.TrackingID) (err error) {
+	defer func(begin time.Time) {
+		s.log(ctx, begin, err, "method", "cancel_booking", "tracking_id", id)
+	}(time.Now())
+
+	return s.BookingServiceContract.CancelBooking(ctx, id)
+}
+
+func (s *loggingService) HoldCargo(ctx context.Context, id cargo.TrackingID, reason string) (err error) {
+	defer func(begin time.Time) {
+		s.log(ctx, begin, err, "method", "hold_cargo", "tracking_id", id, "reason", reason)
+	}(time.Now())
+
+	return s.BookingServiceContract.HoldCargo(ctx, id, reason)
+}
+
+func (s *loggingService) ReleaseCargo(ctx context.Context, id cargo.TrackingID) (err error) {
+	defer func(begin time.Time) {
+		s.log(ctx, begin, err, "method", "release_cargo", "tracking_id", id)
+	}(time.Now())
+
+	return s.BookingServiceContract.ReleaseCargo(ctx, id)
+}
+
+func (s *loggingService) HandlingHistory(ctx context.Context, id cargo.TrackingID) (events []HandlingEvent, err error) {
+	defer func(begin time.Time) {
+		s.log(ctx, begin, err, "method", "handling_history", "tracking_id", id, "count", len(events))
+	}(time.Now())
+
+	return s.BookingServiceContract.HandlingHistory(ctx, id)
+}
+
+func (s *loggingService) Cargos(ctx context.Context, filter cargo.Filter) (cargos []Cargo, err error) {
+	defer func(begin time.Time) {
+		s.log(ctx, begin, err, "method", "list_cargos", "count", len(cargos))
+	}(time.Now())
+
+	return s.BookingServiceContract.Cargos(ctx, filter)
+}
+
+func (s *loggingService) BookCargos(ctx context.Context, bookings []Booking, dryRun bool) (results []BookingResult, err error) {
+	defer func(begin time.Time) {
+		var failed int
+		for _, r := range results {
+			if r.Err != nil {
+				failed++
+			}
+		}
+
+		s.log(ctx, begin, err,
+			"method", "book_bulk",
+			"rows", len(bookings),
+			"failed", failed,
+			"dry_run", dryRun,
+		)
+	}(time.Now())
+
+	return s.BookingServiceContract.BookCargos(ctx, bookings, dryRun)
+}
+
+func (s *loggingService) ExportCargos(ctx context.Context, filter cargo.Filter, history bool, yield func(export.Record) error) (err error) {
+	var count int
+	defer func(begin time.Time) {
+		s.log(ctx, begin, err,
+			"method", "export_cargos",
+			"history", history,
+			"count", count,
+		)
+	}(time.Now())
+
+	return s.BookingServiceContract.ExportCargos(ctx, filter, history, func(r export.Record) error {
+		count++
+		return yield(r)
+	})
+}
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+	"github.com/mproyyan/grpc-shipping-microservice/notification"
+)
+
+// notifier alerts the owners of cargos whose delivery changed in a way they
+// subscribed to. Alerts are computed inside the transaction changing the
+// cargo and sent once it is committed.
+type notifier struct {
+	customers customer.CustomerRepositoryContract
+	alerts    notification.Notifier
+}
+
+func newNotifier(customers customer.CustomerRepositoryContract, alerts notification.Notifier) notifier {
+	return notifier{customers: customers, alerts: alerts}
+}
+
+// changed returns the alerts raised by the delivery of the cargo changing
+// from before. Cargos without a known owner raise none.
+func (n notifier) changed(ctx context.Context, dbtx db.DBTX, c *cargo.Cargo, before cargo.Delivery) ([]notification.Alert, error) {
+	events := notification.Changes(before, c.Delivery)
+	if n.alerts == nil || len(events) == 0 || c.CustomerID == "" {
+		return nil, nil
+	}
+
+	owner, err := n.customers.Find(ctx, dbtx, c.CustomerID)
+	if err != nil {
+		if err == customer.ErrUnknown {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return notification.Alerts(*owner, c, events, time.Now()), nil
+}
+
+func (n notifier) send(alerts []notification.Alert) {
+	if n.alerts != nil && len(alerts) > 0 {
+		n.alerts.Notify(alerts...)
+	}
+}
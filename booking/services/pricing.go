@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/location"
+	"github.com/mproyyan/grpc-shipping-microservice/pricing"
+	"github.com/mproyyan/grpc-shipping-microservice/voyage"
+)
+
+type PricingServiceContract interface {
+	Quote(ctx context.Context, rs cargo.RouteSpecification, attributes cargo.Attributes) (pricing.Quote, error)
+}
+
+// PricingService prices the itineraries a cargo could take before it is
+// booked. Quotes are given to customers only, who accept them when
+// booking.
+type PricingService struct {
+	db         *sql.DB
+	customers  customer.CustomerRepositoryContract
+	voyages    voyage.VoyageRepositoryContract
+	locations  location.LocationRepositoryContract
+	tariffs    pricing.TariffRepositoryContract
+	quotes     pricing.QuoteRepositoryContract
+	surcharges pricing.Surcharges
+	validity   time.Duration
+}
+
+func NewPricingService(db *sql.DB, customers customer.CustomerRepositoryContract, voyages voyage.VoyageRepositoryContract, locations location.LocationRepositoryContract, tariffs pricing.TariffRepositoryContract, quotes pricing.QuoteRepositoryContract, surcharges pricing.Surcharges, validity time.Duration) PricingService {
+	return PricingService{
+		db:         db,
+		customers:  customers,
+		voyages:    voyages,
+		locations:  locations,
+		tariffs:    tariffs,
+		quotes:     quotes,
+		surcharges: surcharges,
+		validity:   validity,
+	}
+}
+
+// Quote prices every itinerary found on the scheduled voyages that
+// satisfies the route specification and may carry the dangerous goods of
+// the cargo. Itineraries with a leg no tariff applies to are left out. The
+// quote is stored so that the customer can accept one of its options when
+// booking.
+func (ps PricingService) Quote(ctx context.Context, rs cargo.RouteSpecification, attributes cargo.Attributes) (pricing.Quote, error) {
+	if rs.Origin == "" || rs.Destination == "" || rs.ArrivalDeadline.IsZero() || !validAttributes(attributes) {
+		return pricing.Quote{}, ErrInvalidArgument
+	}
+
+	caller, ok := customer.FromContext(ctx)
+	if !ok || caller.CustomerID == "" {
+		return pricing.Quote{}, ErrUnauthenticated
+	}
+
+	if _, err := ps.customers.Find(ctx, ps.db, caller.CustomerID); err != nil {
+		return pricing.Quote{}, err
+	}
+
+	now := time.Now()
+	routes, err := ps.routes(ctx, rs, attributes, now)
+	if err != nil {
+		return pricing.Quote{}, err
+	}
+
+	tariffs, err := ps.tariffs.FindAll(ctx, ps.db)
+	if err != nil {
+		return pricing.Quote{}, err
+	}
+
+	var options []pricing.Option
+	for _, r := range routes {
+		o, err := pricing.Price(r, attributes, rs.ArrivalDeadline, tariffs, ps.surcharges)
+		if err != nil {
+			continue
+		}
+
+		options = append(options, o)
+	}
+
+	if len(options) == 0 {
+		return pricing.Quote{}, pricing.ErrNoRoute
+	}
+
+	q := pricing.NewQuote(pricing.NextQuoteID(), caller.CustomerID, rs, attributes, options, now, ps.validity)
+	if err := ps.quotes.Store(ctx, ps.db, q); err != nil {
+		return pricing.Quote{}, err
+	}
+
+	return *q, nil
+}
+
+// routes returns the candidate itineraries, leaving out those taking
+// dangerous goods where their class is not allowed.
+func (ps PricingService) routes(ctx context.Context, rs cargo.RouteSpecification, attributes cargo.Attributes, now time.Time) ([]cargo.Itinerary, error) {
+	departing, err := ps.voyages.FindDeparting(ctx, ps.db, now)
+	if err != nil {
+		return nil, err
+	}
+
+	routes := cargo.Routes(rs, departing, now)
+	goods := attributes.DangerousGoods
+	if !goods.IsDangerous() {
+		return routes, nil
+	}
+
+	voyages := make(map[voyage.Number]*voyage.Voyage)
+	for _, v := range departing {
+		voyages[v.Number] = v
+	}
+
+	r, err := findRestrictions(ctx, ps.db, ps.locations, voyages, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var permitted []cargo.Itinerary
+	for _, route := range routes {
+		if r.Check(route, goods) == nil {
+			permitted = append(permitted, route)
+		}
+	}
+
+	return permitted, nil
+}
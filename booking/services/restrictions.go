@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+	"github.com/mproyyan/grpc-shipping-microservice/imdg"
+	"github.com/mproyyan/grpc-shipping-microservice/location"
+	"github.com/mproyyan/grpc-shipping-microservice/voyage"
+)
+
+// findVoyages returns the stored voyages the itinerary uses. Within a
+// transaction they stay locked until it ends. Itineraries using voyages
+// that are not stored, or legs off their voyage schedule, are invalid.
+func findVoyages(ctx context.Context, dbtx db.DBTX, repository voyage.VoyageRepositoryContract, itinerary cargo.Itinerary) (map[voyage.Number]*voyage.Voyage, error) {
+	// voyages are locked in the same order by every transaction, so
+	// concurrent routings cannot deadlock on them
+	var numbers []voyage.Number
+	for _, leg := range itinerary.Legs {
+		numbers = append(numbers, leg.VoyageNumber)
+	}
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] < numbers[j] })
+
+	voyages := make(map[voyage.Number]*voyage.Voyage)
+	for _, n := range numbers {
+		if _, ok := voyages[n]; ok {
+			continue
+		}
+
+		v, err := repository.Find(ctx, dbtx, n)
+		if err == voyage.ErrUnknown {
+			return nil, ErrInvalidArgument
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		voyages[v.Number] = v
+	}
+
+	if itinerary.CheckSchedule(voyages) != nil {
+		return nil, ErrInvalidArgument
+	}
+
+	return voyages, nil
+}
+
+// checkCapacity rejects itineraries that would overbook a carrier movement
+// of their voyages once the combined load of the routed cargos is added to
+// the cargos already booked on them.
+func checkCapacity(ctx context.Context, tx *sql.Tx, repository cargo.CargoRepositoryContract, voyages map[voyage.Number]*voyage.Voyage, itinerary cargo.Itinerary, routed ...*cargo.Cargo) error {
+	var load cargo.Attributes
+	for _, c := range routed {
+		load.Weight += c.Attributes.Weight
+		load.Volume += c.Attributes.Volume
+	}
+
+	var booked []*cargo.Cargo
+	for n := range voyages {
+		cargos, err := repository.FindOnVoyage(ctx, tx, n)
+		if err != nil {
+			return err
+		}
+
+		for _, other := range cargos {
+			if !containsCargo(routed, other.TrackingID) && !containsCargo(booked, other.TrackingID) {
+				booked = append(booked, other)
+			}
+		}
+	}
+
+	return cargo.CheckCapacity(itinerary, load, voyages, booked)
+}
+
+func containsCargo(cargos []*cargo.Cargo, id cargo.TrackingID) bool {
+	for _, c := range cargos {
+		if c.TrackingID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkRestrictions rejects itineraries taking dangerous goods through a
+// port or on a voyage that does not accept their class. Ports that are not
+// stored accept every class.
+func checkRestrictions(ctx context.Context, dbtx db.DBTX, repository location.LocationRepositoryContract, voyages map[voyage.Number]*voyage.Voyage, itinerary cargo.Itinerary, goods imdg.Goods) error {
+	if !goods.IsDangerous() {
+		return nil
+	}
+
+	var ports []location.UNLocode
+	for _, leg := range itinerary.Legs {
+		ports = append(ports, leg.LoadLocation, leg.UnloadLocation)
+	}
+
+	r, err := findRestrictions(ctx, dbtx, repository, voyages, ports)
+	if err != nil {
+		return err
+	}
+
+	return r.Check(itinerary, goods)
+}
+
+// findRestrictions returns the policies of the voyages, of the given ports
+// and of every port the voyages call at.
+func findRestrictions(ctx context.Context, dbtx db.DBTX, repository location.LocationRepositoryContract, voyages map[voyage.Number]*voyage.Voyage, ports []location.UNLocode) (cargo.Restrictions, error) {
+	for _, v := range voyages {
+		for _, m := range v.Schedule.CarrierMovements {
+			ports = append(ports, m.DepartureLocation, m.ArrivalLocation)
+		}
+	}
+
+	r := cargo.Restrictions{
+		Locations: make(map[location.UNLocode]*location.Location),
+		Voyages:   voyages,
+	}
+
+	for _, port := range ports {
+		if _, ok := r.Locations[port]; ok {
+			continue
+		}
+
+		l, err := repository.Find(ctx, dbtx, port)
+		if err == location.ErrUnknown {
+			continue
+		}
+		if err != nil {
+			return cargo.Restrictions{}, err
+		}
+
+		r.Locations[port] = l
+	}
+
+	return r, nil
+}
@@ -4,42 +4,92 @@ import (
 	"context"
 	"database/sql"
 	"errors"
-	"fmt"
 	"time"
 
+	"github.com/mproyyan/grpc-shipping-microservice/billing"
 	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+	"github.com/mproyyan/grpc-shipping-microservice/export"
+	"github.com/mproyyan/grpc-shipping-microservice/imdg"
 	"github.com/mproyyan/grpc-shipping-microservice/location"
+	"github.com/mproyyan/grpc-shipping-microservice/notification"
+	"github.com/mproyyan/grpc-shipping-microservice/pricing"
+	"github.com/mproyyan/grpc-shipping-microservice/voyage"
+	"github.com/mproyyan/grpc-shipping-microservice/webhook"
 )
 
-var ErrInvalidArgument = errors.New("invalid argument")
+var (
+	ErrInvalidArgument  = errors.New("invalid argument")
+	ErrUnauthenticated  = errors.New("unauthenticated caller")
+	ErrPermissionDenied = errors.New("permission denied")
+)
 
 type BookingServiceContract interface {
-	BookNewCargo(ctx context.Context, origin location.UNLocode, destination location.UNLocode, deadline time.Time) (cargo.TrackingID, error)
+	BookNewCargo(ctx context.Context, origin location.UNLocode, destination location.UNLocode, deadline time.Time, attributes cargo.Attributes, quote pricing.Acceptance) (cargo.TrackingID, error)
 	LoadCargo(ctx context.Context, id cargo.TrackingID) (Cargo, error)
 	AssignCargoToRoute(ctx context.Context, id cargo.TrackingID, itinerary cargo.Itinerary) error
 	ChangeDestination(ctx context.Context, id cargo.TrackingID, destination location.UNLocode) error
-	Cargos(ctx context.Context) ([]Cargo, error)
+	UpdateRouteSpecification(ctx context.Context, id cargo.TrackingID, rs cargo.RouteSpecification) (bool, error)
+	CancelBooking(ctx context.Context, id cargo.TrackingID) error
+	HoldCargo(ctx context.Context, id cargo.TrackingID, reason string) error
+	ReleaseCargo(ctx context.Context, id cargo.TrackingID) error
+	HandlingHistory(ctx context.Context, id cargo.TrackingID) ([]HandlingEvent, error)
+	Cargos(ctx context.Context, filter cargo.Filter) ([]Cargo, error)
+	BookCargos(ctx context.Context, bookings []Booking, dryRun bool) ([]BookingResult, error)
+	ExportCargos(ctx context.Context, filter cargo.Filter, history bool, yield func(export.Record) error) error
 }
 
 type BookingService struct {
-	db     *sql.DB
-	cargos cargo.CargoRepositoryContract
-	events cargo.EventRepositoryContract
+	db        *sql.DB
+	cargos    cargo.CargoRepositoryContract
+	events    cargo.EventRepositoryContract
+	customers customer.CustomerRepositoryContract
+	voyages   voyage.VoyageRepositoryContract
+	locations location.LocationRepositoryContract
+	clearance cargo.ClearanceCountries
+	quotes    pricing.QuoteRepositoryContract
+	billing   biller
+	notifier  notifier
+	webhooks  webhook.Outbox
 }
 
-func NewBookingService(db *sql.DB, cargos cargo.CargoRepositoryContract, events cargo.EventRepositoryContract) BookingService {
+func NewBookingService(db *sql.DB, cargos cargo.CargoRepositoryContract, events cargo.EventRepositoryContract, customers customer.CustomerRepositoryContract, voyages voyage.VoyageRepositoryContract, locations location.LocationRepositoryContract, clearance cargo.ClearanceCountries, quotes pricing.QuoteRepositoryContract, invoices billing.InvoiceRepositoryContract, plan billing.Plan, alerts notification.Notifier, webhooks webhook.Outbox) BookingService {
 	return BookingService{
-		db:     db,
-		cargos: cargos,
-		events: events,
+		db:        db,
+		cargos:    cargos,
+		events:    events,
+		customers: customers,
+		voyages:   voyages,
+		locations: locations,
+		clearance: clearance,
+		quotes:    quotes,
+		billing:   newBiller(quotes, invoices, plan),
+		notifier:  newNotifier(customers, alerts),
+		webhooks:  webhooks,
 	}
 }
 
-func (bs BookingService) BookNewCargo(ctx context.Context, origin location.UNLocode, destination location.UNLocode, deadline time.Time) (cargo.TrackingID, error) {
-	if origin == "" || destination == "" || deadline.IsZero() {
+// BookNewCargo books a cargo for the calling customer. When a quote is
+// given, the cargo is booked at the price of the chosen option, provided
+// the quote belongs to the customer and was given for the same route
+// specification and attributes, and its share of the price for the booking
+// is billed.
+func (bs BookingService) BookNewCargo(ctx context.Context, origin location.UNLocode, destination location.UNLocode, deadline time.Time, attributes cargo.Attributes, quote pricing.Acceptance) (cargo.TrackingID, error) {
+	if origin == "" || destination == "" || deadline.IsZero() || !validAttributes(attributes) {
 		return "", ErrInvalidArgument
 	}
 
+	caller, ok := customer.FromContext(ctx)
+	if !ok || caller.CustomerID == "" {
+		return "", ErrUnauthenticated
+	}
+
+	// make sure the booking customer actually exists before recording it
+	if _, err := bs.customers.Find(ctx, bs.db, caller.CustomerID); err != nil {
+		return "", err
+	}
+
 	id := cargo.NextTrackingID()
 	rs := cargo.RouteSpecification{
 		Origin:          origin,
@@ -48,12 +98,54 @@ func (bs BookingService) BookNewCargo(ctx context.Context, origin location.UNLoc
 	}
 
 	c := cargo.New(id, rs)
-	c, err := bs.cargos.Upsert(ctx, bs.db, c)
+	c.CustomerID = caller.CustomerID
+	c.Attributes = attributes
+	c.RequireClearance(bs.clearance)
+
+	err := db.WithTx(ctx, bs.db, func(tx *sql.Tx) error {
+		if quote.QuoteID != "" {
+			if err := bs.bookAtQuote(ctx, tx, caller.CustomerID, c, quote); err != nil {
+				return err
+			}
+		} else if _, err := bs.cargos.Upsert(ctx, tx, c); err != nil {
+			return err
+		}
+
+		return bs.webhooks.Publish(ctx, tx, c, nil, webhook.Booked)
+	})
 	if err != nil {
 		return "", err
 	}
 
-	return c.TrackingID, nil
+	return id, nil
+}
+
+// bookAtQuote stores the cargo booked at the chosen option of the quote,
+// records the acceptance of the quote and bills the booking.
+func (bs BookingService) bookAtQuote(ctx context.Context, tx *sql.Tx, customerID customer.ID, c *cargo.Cargo, quote pricing.Acceptance) error {
+	q, err := bs.quotes.Find(ctx, tx, quote.QuoteID)
+	if err != nil {
+		return err
+	}
+
+	if q.CustomerID != customerID {
+		return pricing.ErrUnknownQuote
+	}
+
+	now := time.Now()
+	if err := q.Accept(c.TrackingID, quote.Option, c.RouteSpecification, c.Attributes, now); err != nil {
+		return err
+	}
+
+	if _, err := bs.cargos.Upsert(ctx, tx, c); err != nil {
+		return err
+	}
+
+	if err := bs.quotes.Store(ctx, tx, q); err != nil {
+		return err
+	}
+
+	return bs.billing.bill(ctx, tx, c.TrackingID, billing.Booked, now)
 }
 
 func (bs BookingService) LoadCargo(ctx context.Context, id cargo.TrackingID) (Cargo, error) {
@@ -61,95 +153,419 @@ func (bs BookingService) LoadCargo(ctx context.Context, id cargo.TrackingID) (Ca
 		return Cargo{}, ErrInvalidArgument
 	}
 
-	c, err := bs.cargos.Find(ctx, bs.db, id)
+	c, err := bs.findOwned(ctx, id)
 	if err != nil {
 		return Cargo{}, err
 	}
 
-	return assemble(c, bs.events), nil
+	result := assemble(c)
+	q, err := bs.quotes.FindByCargo(ctx, bs.db, id)
+	switch {
+	case err == nil:
+		if agreed, ok := q.Agreed(); ok {
+			result.QuoteID = string(q.ID)
+			result.Price = &agreed.Price
+		}
+	case err != pricing.ErrUnknownQuote:
+		return Cargo{}, err
+	}
+
+	return result, nil
 }
 
+// AssignCargoToRoute routes the cargo along the itinerary. Its owner is
+// alerted when the new itinerary makes it late. The cargo and the voyages
+// stay locked from the capacity check until the cargo is stored.
 func (bs BookingService) AssignCargoToRoute(ctx context.Context, id cargo.TrackingID, itinerary cargo.Itinerary) error {
 	if id == "" || len(itinerary.Legs) == 0 {
 		return ErrInvalidArgument
 	}
-	fmt.Println("service iti :", itinerary)
-	c, err := bs.cargos.Find(ctx, bs.db, id)
+
+	var alerts []notification.Alert
+	err := db.WithTx(ctx, bs.db, func(tx *sql.Tx) error {
+		c, err := bs.lockOwned(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if !c.Status.Active() {
+			return cargo.ErrInactive
+		}
+
+		// check given itinerary id and cargo.itinerary.id
+		if c.Itinerary.ID != itinerary.ID {
+			return ErrInvalidArgument
+		}
+
+		voyages, err := findVoyages(ctx, tx, bs.voyages, itinerary)
+		if err != nil {
+			return err
+		}
+
+		if err := checkCapacity(ctx, tx, bs.cargos, voyages, itinerary, c); err != nil {
+			return err
+		}
+
+		if err := checkRestrictions(ctx, tx, bs.locations, voyages, itinerary, c.Attributes.DangerousGoods); err != nil {
+			return err
+		}
+
+		before := c.Delivery
+		c.AssignToRoute(itinerary)
+		if _, err := bs.cargos.Upsert(ctx, tx, c); err != nil {
+			return err
+		}
+
+		if err := bs.webhooks.Publish(ctx, tx, c, nil, webhookEvents(before, c.Delivery, webhook.Routed)...); err != nil {
+			return err
+		}
+
+		alerts, err = bs.notifier.changed(ctx, tx, c, before)
+		return err
+	})
 	if err != nil {
 		return err
 	}
-	fmt.Println("cargo before assign :", c)
-	// check given itinerary id and cargo.itinerary.id
-	if c.Itinerary.ID != itinerary.ID {
-		fmt.Println("error id not same")
+
+	bs.notifier.send(alerts)
+	return nil
+}
+
+// validAttributes checks the attributes declared at booking time, they are
+// all optional.
+func validAttributes(a cargo.Attributes) bool {
+	return a.Weight >= 0 && a.Volume >= 0 && a.Packages >= 0 && (a.HSCode == "" || cargo.IsValidHSCode(a.HSCode)) &&
+		validDangerousGoods(a.DangerousGoods)
+}
+
+// validDangerousGoods checks that dangerous goods have both a class and a
+// UN number.
+func validDangerousGoods(g imdg.Goods) bool {
+	if g == (imdg.Goods{}) {
+		return true
+	}
+
+	return g.Class.IsValid() && g.UNNumber.IsValid()
+}
+
+func (bs BookingService) ChangeDestination(ctx context.Context, id cargo.TrackingID, destination location.UNLocode) error {
+	if id == "" || destination == "" {
 		return ErrInvalidArgument
 	}
 
-	c.AssignToRoute(itinerary)
-	_, err = bs.cargos.Upsert(ctx, bs.db, c)
+	_, err := bs.UpdateRouteSpecification(ctx, id, cargo.RouteSpecification{Destination: destination})
+	return err
+}
+
+// UpdateRouteSpecification changes the origin, destination or arrival
+// deadline of a cargo, unset fields of rs are kept. It reports whether the
+// current itinerary satisfies the new specification, a cargo without an
+// itinerary never does. Its owner is alerted when the new specification
+// makes the cargo misdirected or late.
+func (bs BookingService) UpdateRouteSpecification(ctx context.Context, id cargo.TrackingID, rs cargo.RouteSpecification) (bool, error) {
+	if id == "" || (rs.Origin == "" && rs.Destination == "" && rs.ArrivalDeadline.IsZero()) {
+		return false, ErrInvalidArgument
+	}
+
+	c, err := bs.findOwned(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	history, err := bs.events.QueryHandlingHistory(ctx, bs.db, id)
 	if err != nil {
+		return false, err
+	}
+
+	before := c.Delivery
+	if err := c.UpdateRouteSpecification(rs, history); err != nil {
+		if err == cargo.ErrSameLocation {
+			return false, ErrInvalidArgument
+		}
+
+		return false, err
+	}
+
+	c.RequireClearance(bs.clearance)
+
+	var alerts []notification.Alert
+	err = db.WithTx(ctx, bs.db, func(tx *sql.Tx) error {
+		if _, err := bs.cargos.Upsert(ctx, tx, c); err != nil {
+			return err
+		}
+
+		if err := bs.webhooks.Publish(ctx, tx, c, nil, webhookEvents(before, c.Delivery)...); err != nil {
+			return err
+		}
+
+		alerts, err = bs.notifier.changed(ctx, tx, c, before)
 		return err
+	})
+	if err != nil {
+		return false, err
 	}
-	fmt.Println("cargo after assign :", c)
-	return nil
+
+	bs.notifier.send(alerts)
+	return c.RouteSpecification.IsSatisfiedBy(c.Itinerary), nil
 }
 
-func (bs BookingService) ChangeDestination(ctx context.Context, id cargo.TrackingID, destination location.UNLocode) error {
-	if id == "" || destination == "" {
+// CancelBooking cancels the booking of a cargo which has not been loaded
+// yet. The cargo is kept, its status tells it was cancelled.
+func (bs BookingService) CancelBooking(ctx context.Context, id cargo.TrackingID) error {
+	if id == "" {
 		return ErrInvalidArgument
 	}
 
-	c, err := bs.cargos.Find(ctx, bs.db, id)
-	if err != nil {
+	// The cargo stays locked while its history is checked, so an event
+	// registered concurrently is either seen or registered after the
+	// cancellation.
+	return db.WithTx(ctx, bs.db, func(tx *sql.Tx) error {
+		c, err := bs.lockOwned(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		history, err := bs.events.QueryHandlingHistory(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := c.Cancel(history); err != nil {
+			return err
+		}
+
+		if _, err := bs.cargos.Upsert(ctx, tx, c); err != nil {
+			return err
+		}
+
+		return bs.webhooks.Publish(ctx, tx, c, nil, webhook.Cancelled)
+	})
+}
+
+// HoldCargo places a cargo on customs hold, it cannot be claimed until it
+// is released. Holds are placed and released by staff only.
+func (bs BookingService) HoldCargo(ctx context.Context, id cargo.TrackingID, reason string) error {
+	if id == "" || reason == "" {
+		return ErrInvalidArgument
+	}
+
+	return bs.updateClearance(ctx, id, func(c *cargo.Cargo) error { return c.Hold(reason) })
+}
+
+// ReleaseCargo lifts the customs hold of a cargo.
+func (bs BookingService) ReleaseCargo(ctx context.Context, id cargo.TrackingID) error {
+	if id == "" {
+		return ErrInvalidArgument
+	}
+
+	return bs.updateClearance(ctx, id, (*cargo.Cargo).Release)
+}
+
+// updateClearance applies update to the cargo, which stays locked until it
+// is stored so concurrent registrations and cancellations are not lost.
+func (bs BookingService) updateClearance(ctx context.Context, id cargo.TrackingID, update func(c *cargo.Cargo) error) error {
+	if err := requireStaff(ctx); err != nil {
 		return err
 	}
 
-	c.SpecifyNewRoute(cargo.RouteSpecification{
-		Origin:          c.Origin,
-		Destination:     destination,
-		ArrivalDeadline: c.RouteSpecification.ArrivalDeadline,
+	return db.WithTx(ctx, bs.db, func(tx *sql.Tx) error {
+		c, err := bs.lockOwned(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := update(c); err != nil {
+			return err
+		}
+
+		_, err = bs.cargos.Upsert(ctx, tx, c)
+		return err
 	})
+}
+
+// HandlingHistory returns the handling events of the cargo, oldest first.
+// Every event is checked against the current itinerary, so rerouting a
+// misdirected cargo can turn its past events expected.
+func (bs BookingService) HandlingHistory(ctx context.Context, id cargo.TrackingID) ([]HandlingEvent, error) {
+	if id == "" {
+		return nil, ErrInvalidArgument
+	}
 
-	_, err = bs.cargos.Upsert(ctx, bs.db, c)
+	c, err := bs.findOwned(ctx, id)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	h, err := bs.events.QueryHandlingHistory(ctx, bs.db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[location.UNLocode]string{}
+	events := make([]HandlingEvent, 0, len(h.HandlingEvents))
+	for _, e := range h.HandlingEvents {
+		loc := e.Activity.Location
+		name, ok := names[loc]
+		if !ok {
+			l, err := bs.locations.Find(ctx, bs.db, loc)
+			switch {
+			case err == nil:
+				name = l.Name
+			case err != location.ErrUnknown:
+				return nil, err
+			}
+
+			names[loc] = name
+		}
+
+		events = append(events, HandlingEvent{
+			Type:         e.Activity.Type.String(),
+			Location:     string(loc),
+			LocationName: name,
+			VoyageNumber: string(e.Activity.VoyageNumber),
+			Completed:    e.Completed,
+			Expected:     c.Itinerary.IsExpected(e),
+		})
+	}
+
+	return events, nil
 }
 
-func (bs BookingService) Cargos(ctx context.Context) ([]Cargo, error) {
+func (bs BookingService) Cargos(ctx context.Context, filter cargo.Filter) ([]Cargo, error) {
 	var results []Cargo
-	cargos, err := bs.cargos.FindAll(ctx, bs.db)
+	filter, err := scope(ctx, filter)
+	if err != nil {
+		return results, err
+	}
+
+	cargos, err := bs.cargos.FindMatching(ctx, bs.db, filter)
 	if err != nil {
 		return results, err
 	}
 
 	for _, c := range cargos {
-		results = append(results, assemble(c, bs.events))
+		results = append(results, assemble(c))
 	}
 
 	return results, nil
 }
 
+// scope restricts filter to the cargos the caller is allowed to see. Staff
+// see every cargo, customers only their own and asking for the cargos of
+// another customer is denied.
+func scope(ctx context.Context, filter cargo.Filter) (cargo.Filter, error) {
+	caller, ok := customer.FromContext(ctx)
+	if !ok {
+		return filter, ErrUnauthenticated
+	}
+
+	if caller.Staff {
+		return filter, nil
+	}
+
+	// an empty customer ID would lift the restriction altogether
+	if caller.CustomerID == "" {
+		return filter, ErrUnauthenticated
+	}
+
+	if filter.CustomerID != "" && filter.CustomerID != caller.CustomerID {
+		return filter, ErrPermissionDenied
+	}
+
+	filter.CustomerID = caller.CustomerID
+	return filter, nil
+}
+
+// findOwned loads the cargo and makes sure the caller is allowed to see it.
+// Cargos owned by another customer are reported as unknown so their existence
+// is not leaked.
+func (bs BookingService) findOwned(ctx context.Context, id cargo.TrackingID) (*cargo.Cargo, error) {
+	return bs.owned(ctx, id, bs.db, bs.cargos.Find)
+}
+
+// lockOwned is findOwned within tx, the cargo stays locked until tx ends.
+func (bs BookingService) lockOwned(ctx context.Context, tx *sql.Tx, id cargo.TrackingID) (*cargo.Cargo, error) {
+	return bs.owned(ctx, id, tx, bs.cargos.FindForUpdate)
+}
+
+func (bs BookingService) owned(ctx context.Context, id cargo.TrackingID, dbtx db.DBTX, find func(context.Context, db.DBTX, cargo.TrackingID) (*cargo.Cargo, error)) (*cargo.Cargo, error) {
+	caller, ok := customer.FromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+
+	c, err := find(ctx, dbtx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, cargo.ErrUnknown
+		}
+
+		return nil, err
+	}
+
+	if !caller.CanAccess(c.CustomerID) {
+		return nil, cargo.ErrUnknown
+	}
+
+	return c, nil
+}
+
+// HandlingEvent is a handling event on the timeline of a cargo. Expected
+// tells whether the current itinerary plans for it, LocationName is empty
+// for locations that are not registered.
+type HandlingEvent struct {
+	Type         string    `json:"type"`
+	Location     string    `json:"location"`
+	LocationName string    `json:"location_name,omitempty"`
+	VoyageNumber string    `json:"voyage_number,omitempty"`
+	Completed    time.Time `json:"completed"`
+	Expected     bool      `json:"expected"`
+}
+
 type Cargo struct {
 	ArrivalDeadline time.Time   `json:"arrival_deadline"`
+	CustomerID      string      `json:"customer_id,omitempty"`
 	Destination     string      `json:"destination"`
 	Legs            []cargo.Leg `json:"legs,omitempty"`
 	Misrouted       bool        `json:"misrouted"`
 	Origin          string      `json:"origin"`
 	Routed          bool        `json:"routed"`
+	Status          string      `json:"status"`
 	TrackingID      string      `json:"tracking_id"`
+	Weight          float64     `json:"weight,omitempty"`
+	Volume          float64     `json:"volume,omitempty"`
+	Packages        int         `json:"packages,omitempty"`
+	Commodity       string      `json:"commodity,omitempty"`
+	HSCode          string      `json:"hs_code,omitempty"`
+	Clearance       string      `json:"clearance"`
+	HoldReason      string      `json:"hold_reason,omitempty"`
+	IMDGClass       string      `json:"imdg_class,omitempty"`
+	UNNumber        string      `json:"un_number,omitempty"`
+	// QuoteID and Price are the quote the cargo was booked from and the
+	// price agreed, they are only set when loading a single cargo.
+	QuoteID string         `json:"quote_id,omitempty"`
+	Price   *pricing.Money `json:"price,omitempty"`
 }
 
-func assemble(c *cargo.Cargo, events cargo.EventRepositoryContract) Cargo {
+func assemble(c *cargo.Cargo) Cargo {
 	return Cargo{
 		TrackingID:      string(c.TrackingID),
+		CustomerID:      string(c.CustomerID),
 		Origin:          string(c.Origin),
 		Destination:     string(c.RouteSpecification.Destination),
 		Misrouted:       c.Delivery.RoutingStatus == cargo.Misrouted,
 		Routed:          !c.Itinerary.IsEmpty(),
 		ArrivalDeadline: c.RouteSpecification.ArrivalDeadline,
 		Legs:            c.Itinerary.Legs,
+		Status:          c.Status.String(),
+		Weight:          c.Attributes.Weight,
+		Volume:          c.Attributes.Volume,
+		Packages:        c.Attributes.Packages,
+		Commodity:       c.Attributes.Commodity,
+		HSCode:          c.Attributes.HSCode,
+		IMDGClass:       string(c.Attributes.DangerousGoods.Class),
+		UNNumber:        string(c.Attributes.DangerousGoods.UNNumber),
+		Clearance:       c.Clearance.Status().String(),
+		HoldReason:      c.Clearance.HoldReason,
 	}
 }
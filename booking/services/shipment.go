@@ -0,0 +1,291 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+	"github.com/mproyyan/grpc-shipping-microservice/location"
+	"github.com/mproyyan/grpc-shipping-microservice/notification"
+	"github.com/mproyyan/grpc-shipping-microservice/shipment"
+	"github.com/mproyyan/grpc-shipping-microservice/voyage"
+	"github.com/mproyyan/grpc-shipping-microservice/webhook"
+)
+
+type ShipmentServiceContract interface {
+	CreateShipment(ctx context.Context, origin, destination location.UNLocode, containers []shipment.Container) (shipment.ID, error)
+	LoadShipment(ctx context.Context, id shipment.ID) (shipment.Shipment, error)
+	StuffCargo(ctx context.Context, container shipment.ContainerID, id cargo.TrackingID, weight, volume float64) error
+	UnstuffCargo(ctx context.Context, container shipment.ContainerID, id cargo.TrackingID) error
+	AssignShipmentToRoute(ctx context.Context, id shipment.ID, itinerary cargo.Itinerary) error
+}
+
+// ShipmentService consolidates cargos into containers. Every operation is
+// reserved to staff. Cargos routed along with their shipment are published
+// and alerted on like cargos routed on their own.
+type ShipmentService struct {
+	db        *sql.DB
+	cargos    cargo.CargoRepositoryContract
+	shipments shipment.ShipmentRepositoryContract
+	voyages   voyage.VoyageRepositoryContract
+	locations location.LocationRepositoryContract
+	notifier  notifier
+	webhooks  webhook.Outbox
+}
+
+func NewShipmentService(db *sql.DB, cargos cargo.CargoRepositoryContract, shipments shipment.ShipmentRepositoryContract, voyages voyage.VoyageRepositoryContract, locations location.LocationRepositoryContract, customers customer.CustomerRepositoryContract, alerts notification.Notifier, webhooks webhook.Outbox) ShipmentService {
+	return ShipmentService{
+		db:        db,
+		cargos:    cargos,
+		shipments: shipments,
+		voyages:   voyages,
+		locations: locations,
+		notifier:  newNotifier(customers, alerts),
+		webhooks:  webhooks,
+	}
+}
+
+func (ss ShipmentService) CreateShipment(ctx context.Context, origin, destination location.UNLocode, containers []shipment.Container) (shipment.ID, error) {
+	if origin == "" || destination == "" || len(containers) == 0 {
+		return "", ErrInvalidArgument
+	}
+
+	for _, c := range containers {
+		if c.ID == "" || c.MaxWeight <= 0 || c.MaxVolume <= 0 || len(c.Cargos) > 0 {
+			return "", ErrInvalidArgument
+		}
+	}
+
+	if err := requireStaff(ctx); err != nil {
+		return "", err
+	}
+
+	s := shipment.New(shipment.NextID(), origin, destination, containers)
+	err := db.WithTx(ctx, ss.db, func(tx *sql.Tx) error {
+		return ss.shipments.Store(ctx, tx, s)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return s.ID, nil
+}
+
+func (ss ShipmentService) LoadShipment(ctx context.Context, id shipment.ID) (shipment.Shipment, error) {
+	if id == "" {
+		return shipment.Shipment{}, ErrInvalidArgument
+	}
+
+	if err := requireStaff(ctx); err != nil {
+		return shipment.Shipment{}, err
+	}
+
+	s, err := ss.shipments.Find(ctx, ss.db, id)
+	if err != nil {
+		return shipment.Shipment{}, err
+	}
+
+	return *s, nil
+}
+
+// StuffCargo packs a cargo into a container. A zero weight or volume is
+// taken from the attributes declared when the cargo was booked. A cargo
+// stuffed into a routed shipment takes the itinerary of the shipment, as
+// long as its dangerous goods are allowed along it and it fits on the
+// voyages.
+func (ss ShipmentService) StuffCargo(ctx context.Context, container shipment.ContainerID, id cargo.TrackingID, weight, volume float64) error {
+	if container == "" || id == "" || weight < 0 || volume < 0 {
+		return ErrInvalidArgument
+	}
+
+	if err := requireStaff(ctx); err != nil {
+		return err
+	}
+
+	var alerts []notification.Alert
+	err := db.WithTx(ctx, ss.db, func(tx *sql.Tx) error {
+		if _, err := ss.shipments.FindByCargo(ctx, tx, id); err != shipment.ErrUnknown {
+			if err == nil {
+				return shipment.ErrAlreadyStuffed
+			}
+
+			return err
+		}
+
+		s, err := ss.shipments.FindByContainer(ctx, tx, container)
+		if err != nil {
+			return err
+		}
+
+		c, err := ss.findCargo(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if weight == 0 {
+			weight = c.Attributes.Weight
+		}
+		if volume == 0 {
+			volume = c.Attributes.Volume
+		}
+		if weight <= 0 || volume <= 0 {
+			return ErrInvalidArgument
+		}
+
+		if err := s.Stuff(container, c, weight, volume); err != nil {
+			return err
+		}
+
+		if !s.Itinerary.IsEmpty() {
+			voyages, err := findVoyages(ctx, tx, ss.voyages, s.Itinerary)
+			if err != nil {
+				return err
+			}
+
+			if err := checkCapacity(ctx, tx, ss.cargos, voyages, s.Itinerary, c); err != nil {
+				return err
+			}
+
+			if err := checkRestrictions(ctx, tx, ss.locations, voyages, s.Itinerary, c.Attributes.DangerousGoods); err != nil {
+				return err
+			}
+
+			alerts, err = ss.route(ctx, tx, s, c)
+			if err != nil {
+				return err
+			}
+		}
+
+		return ss.shipments.Store(ctx, tx, s)
+	})
+	if err != nil {
+		return err
+	}
+
+	ss.notifier.send(alerts)
+	return nil
+}
+
+// UnstuffCargo takes a cargo out of its container. The cargo keeps the
+// itinerary it got from the shipment.
+func (ss ShipmentService) UnstuffCargo(ctx context.Context, container shipment.ContainerID, id cargo.TrackingID) error {
+	if container == "" || id == "" {
+		return ErrInvalidArgument
+	}
+
+	if err := requireStaff(ctx); err != nil {
+		return err
+	}
+
+	return db.WithTx(ctx, ss.db, func(tx *sql.Tx) error {
+		s, err := ss.shipments.FindByContainer(ctx, tx, container)
+		if err != nil {
+			return err
+		}
+
+		if err := s.Unstuff(container, id); err != nil {
+			return err
+		}
+
+		return ss.shipments.Store(ctx, tx, s)
+	})
+}
+
+// AssignShipmentToRoute routes the shipment and every cargo in its
+// containers along the itinerary. It fails when the itinerary is not allowed
+// for the dangerous goods of any of the cargos, or has no room for their
+// combined load.
+func (ss ShipmentService) AssignShipmentToRoute(ctx context.Context, id shipment.ID, itinerary cargo.Itinerary) error {
+	if id == "" || len(itinerary.Legs) == 0 {
+		return ErrInvalidArgument
+	}
+
+	if err := requireStaff(ctx); err != nil {
+		return err
+	}
+
+	var alerts []notification.Alert
+	err := db.WithTx(ctx, ss.db, func(tx *sql.Tx) error {
+		s, err := ss.shipments.Find(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		// cargos are locked before voyages, as when they are routed on
+		// their own
+		var cargos []*cargo.Cargo
+		for _, trackingID := range s.TrackingIDs() {
+			c, err := ss.findCargo(ctx, tx, trackingID)
+			if err != nil {
+				return err
+			}
+
+			if !c.Status.Active() {
+				return cargo.ErrInactive
+			}
+
+			cargos = append(cargos, c)
+		}
+
+		voyages, err := findVoyages(ctx, tx, ss.voyages, itinerary)
+		if err != nil {
+			return err
+		}
+
+		for _, c := range cargos {
+			if err := checkRestrictions(ctx, tx, ss.locations, voyages, itinerary, c.Attributes.DangerousGoods); err != nil {
+				return err
+			}
+		}
+
+		if err := checkCapacity(ctx, tx, ss.cargos, voyages, itinerary, cargos...); err != nil {
+			return err
+		}
+
+		s.AssignToRoute(itinerary)
+		for _, c := range cargos {
+			routed, err := ss.route(ctx, tx, s, c)
+			if err != nil {
+				return err
+			}
+
+			alerts = append(alerts, routed...)
+		}
+
+		return ss.shipments.Store(ctx, tx, s)
+	})
+	if err != nil {
+		return err
+	}
+
+	ss.notifier.send(alerts)
+	return nil
+}
+
+// route gives the cargo the itinerary of the shipment and publishes the
+// change. It returns the alerts to send once the transaction commits.
+func (ss ShipmentService) route(ctx context.Context, tx *sql.Tx, s *shipment.Shipment, c *cargo.Cargo) ([]notification.Alert, error) {
+	before := c.Delivery
+	s.Route(c)
+	if _, err := ss.cargos.Upsert(ctx, tx, c); err != nil {
+		return nil, err
+	}
+
+	if err := ss.webhooks.Publish(ctx, tx, c, nil, webhookEvents(before, c.Delivery, webhook.Routed)...); err != nil {
+		return nil, err
+	}
+
+	return ss.notifier.changed(ctx, tx, c, before)
+}
+
+// findCargo returns the cargo, locked until the transaction ends.
+func (ss ShipmentService) findCargo(ctx context.Context, tx *sql.Tx, id cargo.TrackingID) (*cargo.Cargo, error) {
+	c, err := ss.cargos.FindForUpdate(ctx, tx, id)
+	if err == sql.ErrNoRows {
+		return nil, cargo.ErrUnknown
+	}
+
+	return c, err
+}
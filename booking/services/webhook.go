@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+	"github.com/mproyyan/grpc-shipping-microservice/notification"
+	"github.com/mproyyan/grpc-shipping-microservice/webhook"
+)
+
+type WebhookServiceContract interface {
+	Subscribe(ctx context.Context, customerID customer.ID, url string, filter webhook.Filter, secret string) (webhook.Subscription, error)
+	Subscriptions(ctx context.Context, customerID customer.ID) ([]webhook.Subscription, error)
+	Unsubscribe(ctx context.Context, id webhook.SubscriptionID) error
+	FailedDeliveries(ctx context.Context, customerID customer.ID) ([]webhook.Delivery, error)
+	ReplayDelivery(ctx context.Context, id webhook.DeliveryID) (webhook.Delivery, error)
+}
+
+// WebhookService manages the webhook subscriptions of customers and their
+// dead-letter list. Customers manage their own subscriptions, staff those
+// of any customer.
+type WebhookService struct {
+	db            *sql.DB
+	customers     customer.CustomerRepositoryContract
+	subscriptions webhook.SubscriptionRepositoryContract
+	deliveries    webhook.DeliveryRepositoryContract
+}
+
+func NewWebhookService(db *sql.DB, customers customer.CustomerRepositoryContract, subscriptions webhook.SubscriptionRepositoryContract, deliveries webhook.DeliveryRepositoryContract) WebhookService {
+	return WebhookService{
+		db:            db,
+		customers:     customers,
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+	}
+}
+
+// Subscribe posts the events of the cargos of the customer passing the
+// filter to the URL. An empty customer ID stands for the calling customer,
+// a secret is generated unless one is given. The subscription is returned
+// with its secret, which is never returned afterwards.
+func (ws WebhookService) Subscribe(ctx context.Context, customerID customer.ID, url string, filter webhook.Filter, secret string) (webhook.Subscription, error) {
+	customerID, err := ws.customer(ctx, customerID)
+	if err != nil {
+		return webhook.Subscription{}, err
+	}
+
+	if url == "" {
+		return webhook.Subscription{}, ErrInvalidArgument
+	}
+
+	if _, err := ws.customers.Find(ctx, ws.db, customerID); err != nil {
+		return webhook.Subscription{}, err
+	}
+
+	if secret == "" {
+		secret = webhook.NewSecret()
+	}
+
+	s := webhook.NewSubscription(webhook.NextSubscriptionID(), customerID, url, filter, secret, time.Now())
+	if err := ws.subscriptions.Store(ctx, ws.db, s); err != nil {
+		return webhook.Subscription{}, err
+	}
+
+	return *s, nil
+}
+
+// Subscriptions returns the subscriptions of the customer without their
+// secret. An empty customer ID stands for the calling customer.
+func (ws WebhookService) Subscriptions(ctx context.Context, customerID customer.ID) ([]webhook.Subscription, error) {
+	customerID, err := ws.customer(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions, err := ws.subscriptions.FindByCustomer(ctx, ws.db, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []webhook.Subscription
+	for _, s := range subscriptions {
+		s.Secret = ""
+		results = append(results, *s)
+	}
+
+	return results, nil
+}
+
+// Unsubscribe deletes the subscription and its pending and failed
+// deliveries. Subscriptions of other customers are reported as unknown.
+func (ws WebhookService) Unsubscribe(ctx context.Context, id webhook.SubscriptionID) error {
+	if id == "" {
+		return ErrInvalidArgument
+	}
+
+	caller, ok := customer.FromContext(ctx)
+	if !ok {
+		return ErrUnauthenticated
+	}
+
+	return db.WithTx(ctx, ws.db, func(tx *sql.Tx) error {
+		s, err := ws.subscriptions.Find(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if !caller.CanAccess(s.CustomerID) {
+			return webhook.ErrUnknownSubscription
+		}
+
+		return ws.subscriptions.Delete(ctx, tx, id)
+	})
+}
+
+// FailedDeliveries returns the dead-letter list of the customer, newest
+// first. An empty customer ID stands for the calling customer.
+func (ws WebhookService) FailedDeliveries(ctx context.Context, customerID customer.ID) ([]webhook.Delivery, error) {
+	customerID, err := ws.customer(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, err := ws.deliveries.FindFailed(ctx, ws.db, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []webhook.Delivery
+	for _, d := range deliveries {
+		results = append(results, *d)
+	}
+
+	return results, nil
+}
+
+// ReplayDelivery takes a failed delivery out of the dead-letter list, it
+// is posted again shortly. Deliveries of other customers are reported as
+// unknown.
+func (ws WebhookService) ReplayDelivery(ctx context.Context, id webhook.DeliveryID) (webhook.Delivery, error) {
+	if id == "" {
+		return webhook.Delivery{}, ErrInvalidArgument
+	}
+
+	caller, ok := customer.FromContext(ctx)
+	if !ok {
+		return webhook.Delivery{}, ErrUnauthenticated
+	}
+
+	var result webhook.Delivery
+	err := db.WithTx(ctx, ws.db, func(tx *sql.Tx) error {
+		d, err := ws.deliveries.Find(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if !caller.CanAccess(d.CustomerID) {
+			return webhook.ErrUnknownDelivery
+		}
+
+		if err := d.Replay(time.Now()); err != nil {
+			return err
+		}
+
+		result = *d
+		return ws.deliveries.Store(ctx, tx, d)
+	})
+	if err != nil {
+		return webhook.Delivery{}, err
+	}
+
+	return result, nil
+}
+
+// customer resolves the customer a request is about, the caller unless
+// staff name another one.
+func (ws WebhookService) customer(ctx context.Context, customerID customer.ID) (customer.ID, error) {
+	caller, ok := customer.FromContext(ctx)
+	if !ok {
+		return "", ErrUnauthenticated
+	}
+
+	if customerID == "" {
+		customerID = caller.CustomerID
+	}
+
+	if customerID == "" {
+		return "", ErrInvalidArgument
+	}
+
+	if !caller.CanAccess(customerID) {
+		return "", ErrPermissionDenied
+	}
+
+	return customerID, nil
+}
+
+// webhookEvents returns the webhook events raised by the delivery of a
+// cargo changing from before, along with the given ones.
+func webhookEvents(before, after cargo.Delivery, events ...webhook.Event) []webhook.Event {
+	for _, e := range notification.Changes(before, after) {
+		switch e {
+		case notification.Misdirected:
+			events = append(events, webhook.Misdirected)
+		case notification.Unloaded:
+			events = append(events, webhook.Unloaded)
+		case notification.Late:
+			events = append(events, webhook.Late)
+		}
+	}
+
+	return events
+}
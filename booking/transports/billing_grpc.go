@@ -0,0 +1,245 @@
+package transports
+
+import (
+	"context"
+	"errors"
+
+	gt "github.com/go-kit/kit/transport/grpc"
+	"github.com/mproyyan/grpc-shipping-microservice/booking/endpoints"
+	"github.com/mproyyan/grpc-shipping-microservice/booking/services"
+	"github.com/mproyyan/grpc-shipping-microservice/pb"
+	"google.golang.org/grpc"
+)
+
+type billingGRPCServer struct {
+	pb.UnimplementedBillingServer
+	listInvoices    gt.Handler
+	finalizeInvoice gt.Handler
+	renderInvoice   gt.Handler
+}
+
+func NewBillingGRPCServer(endpoints endpoints.BillingSet) pb.BillingServer {
+	options := []gt.ServerOption{
+		gt.ServerBefore(serverBefore...),
+	}
+
+	return billingGRPCServer{
+		listInvoices: gt.NewServer(
+			endpoints.ListInvoicesEndpoint,
+			decodeGRPCListInvoicesRequest,
+			encodeGRPCListInvoicesResponse,
+			options...,
+		),
+		finalizeInvoice: gt.NewServer(
+			endpoints.FinalizeInvoiceEndpoint,
+			decodeGRPCFinalizeInvoiceRequest,
+			encodeGRPCFinalizeInvoiceResponse,
+			options...,
+		),
+		renderInvoice: gt.NewServer(
+			endpoints.RenderInvoiceEndpoint,
+			decodeGRPCRenderInvoiceRequest,
+			encodeGRPCRenderInvoiceResponse,
+			options...,
+		),
+	}
+}
+
+func NewBillingGRPCClient(conn *grpc.ClientConn) services.BillingServiceContract {
+	options := []gt.ClientOption{
+		gt.ClientBefore(clientBefore...),
+	}
+
+	listInvoicesEndpoint := gt.NewClient(
+		conn,
+		"pb.Billing",
+		"ListInvoices",
+		encodeGRPCListInvoicesRequest,
+		decodeGRPCListInvoicesResponse,
+		pb.ListInvoicesResponse{},
+		options...,
+	).Endpoint()
+
+	finalizeInvoiceEndpoint := gt.NewClient(
+		conn,
+		"pb.Billing",
+		"FinalizeInvoice",
+		encodeGRPCFinalizeInvoiceRequest,
+		decodeGRPCFinalizeInvoiceResponse,
+		pb.FinalizeInvoiceResponse{},
+		options...,
+	).Endpoint()
+
+	renderInvoiceEndpoint := gt.NewClient(
+		conn,
+		"pb.Billing",
+		"RenderInvoice",
+		encodeGRPCRenderInvoiceRequest,
+		decodeGRPCRenderInvoiceResponse,
+		pb.RenderInvoiceResponse{},
+		options...,
+	).Endpoint()
+
+	return endpoints.BillingSet{
+		ListInvoicesEndpoint:    listInvoicesEndpoint,
+		FinalizeInvoiceEndpoint: finalizeInvoiceEndpoint,
+		RenderInvoiceEndpoint:   renderInvoiceEndpoint,
+	}
+}
+
+func (bgs billingGRPCServer) ListInvoices(ctx context.Context, req *pb.ListInvoicesRequest) (*pb.ListInvoicesResponse, error) {
+	_, resp, err := bgs.listInvoices.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*pb.ListInvoicesResponse), nil
+}
+
+func (bgs billingGRPCServer) FinalizeInvoice(ctx context.Context, req *pb.FinalizeInvoiceRequest) (*pb.FinalizeInvoiceResponse, error) {
+	_, resp, err := bgs.finalizeInvoice.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*pb.FinalizeInvoiceResponse), nil
+}
+
+func (bgs billingGRPCServer) RenderInvoice(ctx context.Context, req *pb.RenderInvoiceRequest) (*pb.RenderInvoiceResponse, error) {
+	_, resp, err := bgs.renderInvoice.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*pb.RenderInvoiceResponse), nil
+}
+
+// list invoices
+func decodeGRPCListInvoicesRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req, ok := grpcReq.(*pb.ListInvoicesRequest)
+	if !ok {
+		return nil, errors.New("failed to convert grpc request to *pb.ListInvoicesRequest")
+	}
+
+	r := endpoints.ListInvoicesRequest{}
+	return r.Build(req), nil
+}
+
+func encodeGRPCListInvoicesResponse(ctx context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(endpoints.ListInvoicesResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to endpoints.ListInvoicesResponse")
+	}
+
+	return res.Protobuf(), nil
+}
+
+// finalize invoice
+func decodeGRPCFinalizeInvoiceRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req, ok := grpcReq.(*pb.FinalizeInvoiceRequest)
+	if !ok {
+		return nil, errors.New("failed to convert grpc request to *pb.FinalizeInvoiceRequest")
+	}
+
+	r := endpoints.FinalizeInvoiceRequest{}
+	return r.Build(req), nil
+}
+
+func encodeGRPCFinalizeInvoiceResponse(ctx context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(endpoints.FinalizeInvoiceResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to endpoints.FinalizeInvoiceResponse")
+	}
+
+	return res.Protobuf(), nil
+}
+
+// render invoice
+func decodeGRPCRenderInvoiceRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req, ok := grpcReq.(*pb.RenderInvoiceRequest)
+	if !ok {
+		return nil, errors.New("failed to convert grpc request to *pb.RenderInvoiceRequest")
+	}
+
+	r := endpoints.RenderInvoiceRequest{}
+	return r.Build(req), nil
+}
+
+func encodeGRPCRenderInvoiceResponse(ctx context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(endpoints.RenderInvoiceResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to endpoints.RenderInvoiceResponse")
+	}
+
+	return res.Protobuf(), nil
+}
+
+// billing client
+// list invoices
+func encodeGRPCListInvoicesRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(endpoints.ListInvoicesRequest)
+	if !ok {
+		return nil, errors.New("failed to convert request to endpoints.ListInvoicesRequest")
+	}
+
+	return &pb.ListInvoicesRequest{CustomerId: string(req.CustomerID)}, nil
+}
+
+func decodeGRPCListInvoicesResponse(ctx context.Context, grpcReply interface{}) (interface{}, error) {
+	reply, ok := grpcReply.(*pb.ListInvoicesResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to *pb.ListInvoicesResponse")
+	}
+
+	res := endpoints.ListInvoicesResponse{Error: str2err(reply.Error)}
+	for _, i := range reply.GetInvoices() {
+		res.Invoices = append(res.Invoices, endpoints.InvoiceFromProto(i))
+	}
+
+	return res, nil
+}
+
+// finalize invoice
+func encodeGRPCFinalizeInvoiceRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(endpoints.FinalizeInvoiceRequest)
+	if !ok {
+		return nil, errors.New("failed to convert request to endpoints.FinalizeInvoiceRequest")
+	}
+
+	return &pb.FinalizeInvoiceRequest{InvoiceId: string(req.InvoiceID)}, nil
+}
+
+func decodeGRPCFinalizeInvoiceResponse(ctx context.Context, grpcReply interface{}) (interface{}, error) {
+	reply, ok := grpcReply.(*pb.FinalizeInvoiceResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to *pb.FinalizeInvoiceResponse")
+	}
+
+	return endpoints.FinalizeInvoiceResponse{
+		Invoice: endpoints.InvoiceFromProto(reply.GetInvoice()),
+		Error:   str2err(reply.Error),
+	}, nil
+}
+
+// render invoice
+func encodeGRPCRenderInvoiceRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(endpoints.RenderInvoiceRequest)
+	if !ok {
+		return nil, errors.New("failed to convert request to endpoints.RenderInvoiceRequest")
+	}
+
+	return &pb.RenderInvoiceRequest{InvoiceId: string(req.InvoiceID), Format: req.Format}, nil
+}
+
+func decodeGRPCRenderInvoiceResponse(ctx context.Context, grpcReply interface{}) (interface{}, error) {
+	reply, ok := grpcReply.(*pb.RenderInvoiceResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to *pb.RenderInvoiceResponse")
+	}
+
+	return endpoints.RenderInvoiceResponse{
+		Document:    reply.GetDocument(),
+		ContentType: reply.GetContentType(),
+		Error:       str2err(reply.Error),
+	}, nil
+}
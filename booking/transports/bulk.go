@@ -0,0 +1,471 @@
+package transports
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/mproyyan/grpc-shipping-microservice/booking/endpoints"
+	"github.com/mproyyan/grpc-shipping-microservice/booking/services"
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/imdg"
+	"github.com/mproyyan/grpc-shipping-microservice/location"
+	"github.com/mproyyan/grpc-shipping-microservice/pb"
+	"github.com/mproyyan/grpc-shipping-microservice/validation"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// bulkBatchSize is the number of rows booked per transaction.
+	bulkBatchSize = 100
+
+	// bulkMaxRows and bulkMaxBytes bound a single upload.
+	bulkMaxRows  = 10000
+	bulkMaxBytes = 10 << 20
+)
+
+// BookCargos collects the streamed rows into batches and books every batch
+// through the BookCargos endpoint. Every batch is committed on its own, so
+// when one fails the upload stops there and the response carries the error
+// along with the results of the batches booked before it.
+func (bgs bookingGRPCServer) BookCargos(stream pb.Booking_BookCargosServer) error {
+	ctx := stream.Context()
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, f := range serverBefore {
+		ctx = f(ctx, md)
+	}
+
+	var (
+		res    pb.BookCargosResponse
+		batch  endpoints.BookCargosRequest
+		offset int
+		first  = true
+	)
+
+	flush := func() {
+		if len(batch.Rows) == 0 {
+			return
+		}
+
+		resp, err := bgs.bookCargos(ctx, batch)
+		if err != nil {
+			res.Error = err.Error()
+			return
+		}
+
+		r := resp.(endpoints.BookCargosResponse)
+		if r.Error != nil {
+			res.Error = r.Error.Error()
+			return
+		}
+
+		res.Results = append(res.Results, r.Protobuf(offset)...)
+		offset += len(batch.Rows)
+		batch.Rows = batch.Rows[:0]
+	}
+
+	for res.Error == "" {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if first {
+			batch.DryRun = req.GetDryRun()
+			first = false
+		}
+
+		batch.Rows = append(batch.Rows, endpoints.BookingRow{}.Build(req))
+		if len(batch.Rows) == bulkBatchSize {
+			flush()
+		}
+	}
+
+	if res.Error == "" {
+		flush()
+	}
+
+	return stream.SendAndClose(&res)
+}
+
+// makeBookCargosClientEndpoint streams the rows of the request to the
+// BookCargos RPC. When the upload stops at a failed batch, the response
+// holds the results of the rows before it along with the error.
+func makeBookCargosClientEndpoint(conn *grpc.ClientConn) endpoint.Endpoint {
+	client := pb.NewBookingClient(conn)
+
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(endpoints.BookCargosRequest)
+		if !ok {
+			return nil, errors.New("failed to convert request to endpoints.BookCargosRequest")
+		}
+
+		md := metadata.MD{}
+		for _, f := range clientBefore {
+			ctx = f(ctx, &md)
+		}
+
+		stream, err := client.BookCargos(metadata.NewOutgoingContext(ctx, md))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range req.Rows {
+			err := stream.Send(&pb.BookCargosRequest{
+				Origin:      string(row.Origin),
+				Destination: string(row.Destination),
+				Deadline:    timestamppb.New(row.Deadline),
+				DryRun:      req.DryRun,
+				Weight:      row.Attributes.Weight,
+				Volume:      row.Attributes.Volume,
+				Packages:    int32(row.Attributes.Packages),
+				Commodity:   row.Attributes.Commodity,
+				HsCode:      row.Attributes.HSCode,
+				ImdgClass:   string(row.Attributes.DangerousGoods.Class),
+				UnNumber:    string(row.Attributes.DangerousGoods.UNNumber),
+			})
+			// The server stops reading at the first failed batch, the
+			// reply tells what was booked until then.
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		reply, err := stream.CloseAndRecv()
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			results = make([]services.BookingResult, len(req.Rows))
+			done    int
+		)
+		for _, r := range reply.GetResults() {
+			i := int(r.GetRow()) - 1
+			if i < 0 || i >= len(results) {
+				return nil, fmt.Errorf("unexpected result for row %d", r.GetRow())
+			}
+
+			results[i] = services.BookingResult{
+				TrackingID: cargo.TrackingID(r.GetTrackingId()),
+				Err:        str2err(r.GetError()),
+			}
+			if i >= done {
+				done = i + 1
+			}
+		}
+
+		if reply.GetError() != "" {
+			return endpoints.BookCargosResponse{Results: results[:done], Error: str2err(reply.GetError())}, nil
+		}
+
+		return endpoints.BookCargosResponse{Results: results}, nil
+	}
+}
+
+// bulkRow is a parsed row of an upload. Rows that could not be parsed carry
+// the parse error and are not sent to the service.
+type bulkRow struct {
+	row endpoints.BookingRow
+	err error
+}
+
+type bulkResult struct {
+	Row        int    `json:"row"`
+	TrackingID string `json:"tracking_id,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+type bulkResponse struct {
+	DryRun  bool         `json:"dry_run"`
+	Booked  int          `json:"booked"`
+	Failed  int          `json:"failed"`
+	Error   string       `json:"error,omitempty"`
+	Results []bulkResult `json:"results"`
+}
+
+// bulkHandler serves POST /booking/cargos:bulk. The body is CSV with a
+// header row naming the origin, destination and deadline columns, or JSON
+// Lines with one object per row. The weight, volume, packages, commodity,
+// hs_code, imdg_class and un_number columns are optional. dry_run=true validates without booking.
+func bulkHandler(ep endpoint.Endpoint) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := headersToContext(w, r)
+		md, _ := metadata.FromIncomingContext(ctx)
+		for _, f := range serverBefore {
+			ctx = f(ctx, md)
+		}
+
+		var dryRun bool
+		if s := r.URL.Query().Get("dry_run"); s != "" {
+			var err error
+			if dryRun, err = strconv.ParseBool(s); err != nil {
+				encodeError(ctx, validation.Errors{{Field: "dry_run", Description: "must be a boolean"}}, w)
+				return
+			}
+		}
+
+		rows, err := decodeBulkRows(r.Header.Get("Content-Type"), http.MaxBytesReader(w, r.Body, bulkMaxBytes))
+		if err != nil {
+			encodeError(ctx, err, w)
+			return
+		}
+
+		req := endpoints.BookCargosRequest{DryRun: dryRun}
+		for _, row := range rows {
+			if row.err == nil {
+				req.Rows = append(req.Rows, row.row)
+			}
+		}
+
+		var (
+			booked  []services.BookingResult
+			stopped error
+		)
+		if len(req.Rows) > 0 {
+			resp, err := ep(ctx, req)
+			if err != nil {
+				encodeError(ctx, err, w)
+				return
+			}
+
+			res := resp.(endpoints.BookCargosResponse)
+			if res.Error != nil && len(res.Results) == 0 {
+				encodeError(ctx, res.Error, w)
+				return
+			}
+			booked, stopped = res.Results, res.Error
+		}
+
+		// When the upload stopped at a failed batch, the rows booked before
+		// it are still reported and the rest fail with the error.
+		out := bulkResponse{DryRun: dryRun, Results: make([]bulkResult, 0, len(rows))}
+		if stopped != nil {
+			out.Error = stopped.Error()
+		}
+
+		for i, row := range rows {
+			result := bulkResult{Row: i + 1}
+			if row.err == nil && len(booked) == 0 {
+				row.err = stopped
+			} else if row.err == nil {
+				result.TrackingID = string(booked[0].TrackingID)
+				row.err = booked[0].Err
+				booked = booked[1:]
+			}
+
+			if row.err != nil {
+				result.Error = row.err.Error()
+				out.Failed++
+			} else if !dryRun {
+				out.Booked++
+			}
+
+			out.Results = append(out.Results, result)
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(w).Encode(out)
+	})
+}
+
+func decodeBulkRows(contentType string, body io.Reader) ([]bulkRow, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, validation.Errors{{Field: "Content-Type", Description: "must be text/csv or application/x-ndjson"}}
+	}
+
+	var rows []bulkRow
+	switch mediaType {
+	case "text/csv":
+		rows, err = decodeCSVRows(body)
+	case "application/x-ndjson", "application/jsonl":
+		rows, err = decodeJSONLRows(body)
+	default:
+		return nil, validation.Errors{{Field: "Content-Type", Description: "must be text/csv or application/x-ndjson"}}
+	}
+
+	if err != nil {
+		return nil, malformedBody(err)
+	}
+
+	if len(rows) > bulkMaxRows {
+		return nil, validation.Errors{{Field: "body", Description: fmt.Sprintf("must not contain more than %d rows", bulkMaxRows)}}
+	}
+
+	return rows, nil
+}
+
+func decodeCSVRows(body io.Reader) ([]bulkRow, error) {
+	reader := csv.NewReader(body)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	for _, name := range []string{"origin", "destination", "deadline"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("header is missing the %s column", name)
+		}
+	}
+
+	var rows []bulkRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var row bulkRow
+		row.row.Origin = location.UNLocode(strings.TrimSpace(record[columns["origin"]]))
+		row.row.Destination = location.UNLocode(strings.TrimSpace(record[columns["destination"]]))
+		row.row.Deadline, row.err = parseDeadline(strings.TrimSpace(record[columns["deadline"]]))
+		if row.err == nil {
+			row.row.Attributes, row.err = parseAttributes(columns, record)
+		}
+
+		rows = append(rows, row)
+	}
+}
+
+func decodeJSONLRows(body io.Reader) ([]bulkRow, error) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), bulkMaxBytes)
+
+	var rows []bulkRow
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var raw struct {
+			Origin      string  `json:"origin"`
+			Destination string  `json:"destination"`
+			Deadline    string  `json:"deadline"`
+			Weight      float64 `json:"weight"`
+			Volume      float64 `json:"volume"`
+			Packages    int     `json:"packages"`
+			Commodity   string  `json:"commodity"`
+			HSCode      string  `json:"hs_code"`
+			IMDGClass   string  `json:"imdg_class"`
+			UNNumber    string  `json:"un_number"`
+		}
+
+		var row bulkRow
+		if err := json.Unmarshal([]byte(line), &raw); err != nil {
+			row.err = validation.Errors{{Field: "row", Description: err.Error()}}
+		} else {
+			row.row.Origin = location.UNLocode(raw.Origin)
+			row.row.Destination = location.UNLocode(raw.Destination)
+			row.row.Deadline, row.err = parseDeadline(raw.Deadline)
+			row.row.Attributes = cargo.Attributes{
+				Weight:    raw.Weight,
+				Volume:    raw.Volume,
+				Packages:  raw.Packages,
+				Commodity: raw.Commodity,
+				HSCode:    raw.HSCode,
+				DangerousGoods: imdg.Goods{
+					Class:    imdg.Class(raw.IMDGClass),
+					UNNumber: imdg.UNNumber(raw.UNNumber),
+				},
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, scanner.Err()
+}
+
+// parseAttributes reads the optional attribute columns of a CSV record,
+// missing columns and empty cells are left zero.
+func parseAttributes(columns map[string]int, record []string) (cargo.Attributes, error) {
+	cell := func(name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+
+		return ""
+	}
+
+	var (
+		a    cargo.Attributes
+		errs validation.Errors
+	)
+
+	for _, f := range []struct {
+		name string
+		dst  *float64
+	}{{"weight", &a.Weight}, {"volume", &a.Volume}} {
+		if s := cell(f.name); s != "" {
+			n, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				errs = append(errs, validation.FieldViolation{Field: f.name, Description: "must be a number"})
+			}
+			*f.dst = n
+		}
+	}
+
+	if s := cell("packages"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			errs = append(errs, validation.FieldViolation{Field: "packages", Description: "must be an integer"})
+		}
+		a.Packages = n
+	}
+
+	a.Commodity = cell("commodity")
+	a.HSCode = cell("hs_code")
+	a.DangerousGoods = imdg.Goods{Class: imdg.Class(cell("imdg_class")), UNNumber: imdg.UNNumber(cell("un_number"))}
+
+	if len(errs) > 0 {
+		return cargo.Attributes{}, errs
+	}
+
+	return a, nil
+}
+
+// parseDeadline accepts RFC 3339 timestamps and plain dates, the format
+// spreadsheets usually export.
+func parseDeadline(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, validation.Errors{{Field: "deadline", Description: "must be an RFC 3339 timestamp or a YYYY-MM-DD date"}}
+}
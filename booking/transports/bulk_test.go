@@ -0,0 +1,215 @@
+package transports
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/booking/endpoints"
+	"github.com/mproyyan/grpc-shipping-microservice/booking/services"
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/pb"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// bulkService books every row it is given and records the batches. The
+// batch numbered failAt, counting from 1, fails.
+type bulkService struct {
+	services.BookingServiceContract
+	batches  []int
+	callers  []customer.Caller
+	dryRuns  []bool
+	bookings []services.Booking
+	failAt   int
+}
+
+func (s *bulkService) BookCargos(ctx context.Context, bookings []services.Booking, dryRun bool) ([]services.BookingResult, error) {
+	caller, _ := customer.FromContext(ctx)
+	s.batches = append(s.batches, len(bookings))
+	if len(s.batches) == s.failAt {
+		return nil, cargo.ErrOverbooked
+	}
+
+	s.callers = append(s.callers, caller)
+	s.dryRuns = append(s.dryRuns, dryRun)
+	s.bookings = append(s.bookings, bookings...)
+
+	results := make([]services.BookingResult, len(bookings))
+	for i := range bookings {
+		if !dryRun {
+			results[i].TrackingID = cargo.NextTrackingID()
+		}
+	}
+
+	return results, nil
+}
+
+// serveBulk serves svc over an in-memory gRPC connection.
+func serveBulk(t *testing.T, svc *bulkService) *grpc.ClientConn {
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(CallerServerOptions(testTokens)...)
+	pb.RegisterBookingServer(server, NewGRPCServer(endpoints.Set{BookCargosEndpoint: endpoints.MakeBookCargosEndpoint(svc)}))
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet", append(CallerDialOptions(testTokens),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithInsecure(),
+	)...)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func validBookings(n int) []services.Booking {
+	deadline := time.Now().Add(24 * time.Hour)
+	bookings := make([]services.Booking, n)
+	for i := range bookings {
+		bookings[i] = services.Booking{Origin: "SESTO", Destination: "AUMEL", Deadline: deadline}
+	}
+
+	return bookings
+}
+
+func TestBookCargosStreamsInBatches(t *testing.T) {
+	svc := &bulkService{}
+	conn := serveBulk(t, svc)
+
+	bookings := validBookings(250)
+	bookings[120].Origin = "nowhere"
+
+	ctx := customer.NewContext(context.Background(), customer.Caller{CustomerID: "acme"})
+	results, err := NewGRPCClient(conn).BookCargos(ctx, bookings, false)
+	require.NoError(t, err)
+	require.Len(t, results, 250)
+
+	require.Equal(t, []int{100, 99, 50}, svc.batches)
+	require.Equal(t, customer.ID("acme"), svc.callers[0].CustomerID)
+	require.Error(t, results[120].Err)
+	require.Empty(t, results[120].TrackingID)
+	require.NotEmpty(t, results[121].TrackingID)
+	require.NoError(t, results[249].Err)
+}
+
+func TestBookCargosReportsBookedBatchesOnFailure(t *testing.T) {
+	svc := &bulkService{failAt: 2}
+	conn := serveBulk(t, svc)
+
+	ctx := customer.NewContext(context.Background(), customer.Caller{CustomerID: "acme"})
+	results, err := NewGRPCClient(conn).BookCargos(ctx, validBookings(250), false)
+	require.Equal(t, cargo.ErrOverbooked, err)
+	require.Len(t, results, bulkBatchSize)
+	require.NotEmpty(t, results[0].TrackingID)
+	require.NotEmpty(t, results[99].TrackingID)
+
+	var body strings.Builder
+	body.WriteString("origin,destination,deadline\n")
+	for i := 0; i < 250; i++ {
+		body.WriteString("SESTO,AUMEL,2099-01-01\n")
+	}
+
+	svc.batches = nil
+	handler := NewHttpHandler(endpoints.Set{BookCargosEndpoint: makeBookCargosClientEndpoint(conn)})
+	handler.Use(NewAuthenticator(testTokens))
+
+	req := httptest.NewRequest("POST", "/booking/cargos:bulk", strings.NewReader(body.String()))
+	req.Header.Set("Content-Type", "text/csv")
+	authorize(req, customer.Caller{CustomerID: "acme"})
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var res bulkResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&res))
+	require.Equal(t, cargo.ErrOverbooked.Error(), res.Error)
+	require.Equal(t, 100, res.Booked)
+	require.Equal(t, 150, res.Failed)
+	require.NotEmpty(t, res.Results[99].TrackingID)
+	require.Empty(t, res.Results[100].TrackingID)
+	require.Equal(t, cargo.ErrOverbooked.Error(), res.Results[100].Error)
+}
+
+func TestBulkHandler(t *testing.T) {
+	svc := &bulkService{}
+	handler := NewHttpHandler(endpoints.Set{BookCargosEndpoint: endpoints.MakeBookCargosEndpoint(svc)})
+	handler.Use(NewAuthenticator(testTokens))
+
+	tests := []struct {
+		name, contentType, body string
+	}{
+		{"csv", "text/csv", "Origin,Destination,Deadline\nSESTO,AUMEL,2099-01-01\nSESTO,SESTO,2099-01-01T00:00:00Z\nSESTO,AUMEL,soon\n"},
+		{"jsonl", "application/x-ndjson", `{"origin":"SESTO","destination":"AUMEL","deadline":"2099-01-01"}
+{"origin":"SESTO","destination":"SESTO","deadline":"2099-01-01T00:00:00Z"}
+
+{"origin":"SESTO","destination":"AUMEL","deadline":"soon"}
+`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/booking/cargos:bulk?dry_run=true", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			authorize(req, customer.Caller{CustomerID: "acme"})
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+			var res bulkResponse
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&res))
+			require.True(t, res.DryRun)
+			require.Equal(t, 0, res.Booked)
+			require.Equal(t, 2, res.Failed)
+			require.Len(t, res.Results, 3)
+			require.Empty(t, res.Results[0].Error)
+			require.Contains(t, res.Results[1].Error, "destination")
+			require.Contains(t, res.Results[2].Error, "deadline")
+			require.Equal(t, 3, res.Results[2].Row)
+		})
+	}
+
+	require.Equal(t, []bool{true, true}, svc.dryRuns)
+	require.Equal(t, customer.ID("acme"), svc.callers[0].CustomerID)
+
+	req := httptest.NewRequest("POST", "/booking/cargos:bulk", strings.NewReader("origin,deadline\n"))
+	req.Header.Set("Content-Type", "text/csv")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestBulkHandlerReadsAttributes(t *testing.T) {
+	svc := &bulkService{}
+	handler := NewHttpHandler(endpoints.Set{BookCargosEndpoint: endpoints.MakeBookCargosEndpoint(svc)})
+	handler.Use(NewAuthenticator(testTokens))
+
+	body := "origin,destination,deadline,weight,volume,packages,commodity,hs_code\n" +
+		"SESTO,AUMEL,2099-01-01,1200,4.5,12,Coffee,090111\n" +
+		"SESTO,AUMEL,2099-01-01,heavy,,,,\n" +
+		"SESTO,AUMEL,2099-01-01,,,,,0901\n"
+	req := httptest.NewRequest("POST", "/booking/cargos:bulk?dry_run=true", strings.NewReader(body))
+	req.Header.Set("Content-Type", "text/csv")
+	authorize(req, customer.Caller{CustomerID: "acme"})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var res bulkResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&res))
+	require.Empty(t, res.Results[0].Error)
+	require.Contains(t, res.Results[1].Error, "weight")
+	require.Contains(t, res.Results[2].Error, "hs_code")
+
+	require.Len(t, svc.bookings, 1)
+	require.Equal(t, cargo.Attributes{Weight: 1200, Volume: 4.5, Packages: 12, Commodity: "Coffee", HSCode: "090111"}, svc.bookings[0].Attributes)
+}
@@ -0,0 +1,137 @@
+package transports
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/mproyyan/grpc-shipping-microservice/booking/services"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Callers prove their identity with a bearer token signed by customer.Tokens.
+// Clients present one to the gateway, which authenticates it and keeps the
+// caller in the request context. Between the gateway, or another internal
+// caller holding the key, and the booking service the caller is asserted
+// again with a short lived token, so the booking service never trusts an
+// identity it cannot verify.
+const (
+	authorizationHeader   = "Authorization"
+	authorizationMetadata = "authorization"
+	bearerPrefix          = "Bearer "
+
+	// callerTokenTTL bounds the tokens asserting the caller to the booking
+	// service, they only have to outlive a single call.
+	callerTokenTTL = time.Minute
+)
+
+// NewAuthenticator returns a middleware authenticating the bearer token of
+// every request and storing its caller in the request context. Requests
+// without a token carry no caller, those with an invalid token are answered
+// with 401.
+func NewAuthenticator(tokens customer.Tokens) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			if header := r.Header.Get(authorizationHeader); header != "" {
+				caller, err := verifyBearer(tokens, header)
+				if err != nil {
+					encodeError(ctx, services.ErrUnauthenticated, w)
+					return
+				}
+
+				ctx = customer.NewContext(ctx, caller)
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// CallerDialOptions returns the options asserting the caller found in the
+// context of every call made over the connection.
+func CallerDialOptions(tokens customer.Tokens) []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			return invoker(withCallerToken(ctx, tokens), method, req, reply, cc, opts...)
+		}),
+		grpc.WithChainStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			return streamer(withCallerToken(ctx, tokens), desc, cc, method, opts...)
+		}),
+	}
+}
+
+// CallerServerOptions returns the options verifying the caller asserted by
+// incoming calls. Calls without a token carry no caller, those with an
+// invalid token are rejected.
+func CallerServerOptions(tokens customer.Tokens) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			ctx, err := callerFromGRPC(ctx, tokens)
+			if err != nil {
+				return nil, err
+			}
+
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			ctx, err := callerFromGRPC(ss.Context(), tokens)
+			if err != nil {
+				return err
+			}
+
+			return handler(srv, callerStream{ss, ctx})
+		}),
+	}
+}
+
+func withCallerToken(ctx context.Context, tokens customer.Tokens) context.Context {
+	caller, ok := customer.FromContext(ctx)
+	if !ok {
+		return ctx
+	}
+
+	token := tokens.Issue(caller, time.Now().Add(callerTokenTTL))
+	return metadata.AppendToOutgoingContext(ctx, authorizationMetadata, bearerPrefix+token)
+}
+
+func callerFromGRPC(ctx context.Context, tokens customer.Tokens) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(authorizationMetadata)
+	if len(values) == 0 {
+		return ctx, nil
+	}
+
+	caller, err := verifyBearer(tokens, values[0])
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	return customer.NewContext(ctx, caller), nil
+}
+
+func verifyBearer(tokens customer.Tokens, header string) (customer.Caller, error) {
+	token := strings.TrimPrefix(header, bearerPrefix)
+	if token == header {
+		return customer.Caller{}, customer.ErrInvalidToken
+	}
+
+	return tokens.Verify(token, time.Now())
+}
+
+// callerStream overrides the context of a server stream with the one
+// carrying the verified caller.
+type callerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s callerStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,74 @@
+package transports
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var testTokens, _ = customer.NewTokens("0123456789abcdef0123456789abcdef")
+
+// authorize makes req present a token for c.
+func authorize(req *http.Request, c customer.Caller) {
+	req.Header.Set("Authorization", "Bearer "+testTokens.Issue(c, time.Now().Add(time.Minute)))
+}
+
+func TestAuthenticator(t *testing.T) {
+	var (
+		caller customer.Caller
+		found  bool
+	)
+
+	handler := NewAuthenticator(testTokens)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		caller, found = customer.FromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest("GET", "/booking/cargos", nil)
+	req.Header.Set("X-Customer-ID", "acme")
+	req.Header.Set("X-Staff", "true")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.False(t, found)
+
+	req = httptest.NewRequest("GET", "/booking/cargos", nil)
+	authorize(req, customer.Caller{CustomerID: "acme"})
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, customer.Caller{CustomerID: "acme"}, caller)
+
+	forged, _ := customer.NewTokens("fedcba9876543210fedcba9876543210")
+	req = httptest.NewRequest("GET", "/booking/cargos", nil)
+	req.Header.Set("Authorization", "Bearer "+forged.Issue(customer.Caller{Staff: true}, time.Now().Add(time.Minute)))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestCallerOverGRPC(t *testing.T) {
+	ctx := customer.NewContext(context.Background(), customer.Caller{CustomerID: "acme"})
+	md, _ := metadata.FromOutgoingContext(withCallerToken(ctx, testTokens))
+
+	got, err := callerFromGRPC(metadata.NewIncomingContext(context.Background(), md), testTokens)
+	require.NoError(t, err)
+	caller, ok := customer.FromContext(got)
+	require.True(t, ok)
+	require.Equal(t, customer.Caller{CustomerID: "acme"}, caller)
+
+	got, err = callerFromGRPC(metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-staff", "true")), testTokens)
+	require.NoError(t, err)
+	_, ok = customer.FromContext(got)
+	require.False(t, ok)
+
+	_, err = callerFromGRPC(metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer forged")), testTokens)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
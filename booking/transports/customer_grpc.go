@@ -0,0 +1,325 @@
+package transports
+
+import (
+	"context"
+	"errors"
+
+	gt "github.com/go-kit/kit/transport/grpc"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/mproyyan/grpc-shipping-microservice/booking/endpoints"
+	"github.com/mproyyan/grpc-shipping-microservice/booking/services"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/pb"
+	"google.golang.org/grpc"
+)
+
+type customerGRPCServer struct {
+	pb.UnimplementedCustomerServer
+	registerCustomer gt.Handler
+	loadCustomer     gt.Handler
+	updateCustomer   gt.Handler
+	listCustomers    gt.Handler
+}
+
+func NewCustomerGRPCServer(endpoints endpoints.CustomerSet) pb.CustomerServer {
+	options := []gt.ServerOption{
+		gt.ServerBefore(serverBefore...),
+	}
+
+	return customerGRPCServer{
+		registerCustomer: gt.NewServer(
+			endpoints.RegisterCustomerEndpoint,
+			decodeGRPCRegisterCustomerRequest,
+			encodeGRPCRegisterCustomerResponse,
+			options...,
+		),
+		loadCustomer: gt.NewServer(
+			endpoints.LoadCustomerEndpoint,
+			decodeGRPCLoadCustomerRequest,
+			encodeGRPCLoadCustomerResponse,
+			options...,
+		),
+		updateCustomer: gt.NewServer(
+			endpoints.UpdateCustomerEndpoint,
+			decodeGRPCUpdateCustomerRequest,
+			encodeGRPCUpdateCustomerResponse,
+			options...,
+		),
+		listCustomers: gt.NewServer(
+			endpoints.CustomersEndpoint,
+			decodeGRPCListCustomersRequest,
+			encodeGRPCListCustomersResponse,
+			options...,
+		),
+	}
+}
+
+func NewCustomerGRPCClient(conn *grpc.ClientConn) services.CustomerServiceContract {
+	options := []gt.ClientOption{
+		gt.ClientBefore(clientBefore...),
+	}
+
+	registerCustomerEndpoint := gt.NewClient(
+		conn,
+		"pb.Customer",
+		"RegisterCustomer",
+		encodeGRPCRegisterCustomerRequest,
+		decodeGRPCRegisterCustomerResponse,
+		pb.RegisterCustomerResponse{},
+		options...,
+	).Endpoint()
+
+	loadCustomerEndpoint := gt.NewClient(
+		conn,
+		"pb.Customer",
+		"LoadCustomer",
+		encodeGRPCLoadCustomerRequest,
+		decodeGRPCLoadCustomerResponse,
+		pb.LoadCustomerResponse{},
+		options...,
+	).Endpoint()
+
+	updateCustomerEndpoint := gt.NewClient(
+		conn,
+		"pb.Customer",
+		"UpdateCustomer",
+		encodeGRPCUpdateCustomerRequest,
+		decodeGRPCUpdateCustomerResponse,
+		pb.UpdateCustomerResponse{},
+		options...,
+	).Endpoint()
+
+	listCustomersEndpoint := gt.NewClient(
+		conn,
+		"pb.Customer",
+		"Customers",
+		encodeGRPCListCustomersRequest,
+		decodeGRPCListCustomersResponse,
+		pb.CustomersResponse{},
+		options...,
+	).Endpoint()
+
+	return endpoints.CustomerSet{
+		RegisterCustomerEndpoint: registerCustomerEndpoint,
+		LoadCustomerEndpoint:     loadCustomerEndpoint,
+		UpdateCustomerEndpoint:   updateCustomerEndpoint,
+		CustomersEndpoint:        listCustomersEndpoint,
+	}
+}
+
+func (cgs customerGRPCServer) RegisterCustomer(ctx context.Context, req *pb.RegisterCustomerRequest) (*pb.RegisterCustomerResponse, error) {
+	_, resp, err := cgs.registerCustomer.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*pb.RegisterCustomerResponse), nil
+}
+
+func (cgs customerGRPCServer) LoadCustomer(ctx context.Context, req *pb.LoadCustomerRequest) (*pb.LoadCustomerResponse, error) {
+	_, resp, err := cgs.loadCustomer.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*pb.LoadCustomerResponse), nil
+}
+
+func (cgs customerGRPCServer) UpdateCustomer(ctx context.Context, req *pb.UpdateCustomerRequest) (*pb.UpdateCustomerResponse, error) {
+	_, resp, err := cgs.updateCustomer.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*pb.UpdateCustomerResponse), nil
+}
+
+func (cgs customerGRPCServer) Customers(ctx context.Context, _ *empty.Empty) (*pb.CustomersResponse, error) {
+	_, resp, err := cgs.listCustomers.ServeGRPC(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*pb.CustomersResponse), nil
+}
+
+// customer server
+// register customer
+func decodeGRPCRegisterCustomerRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req, ok := grpcReq.(*pb.RegisterCustomerRequest)
+	if !ok {
+		return nil, errors.New("failed to convert grpc request to *pb.RegisterCustomerRequest")
+	}
+
+	cr := endpoints.RegisterCustomerRequest{}
+	return cr.Build(req), nil
+}
+
+func encodeGRPCRegisterCustomerResponse(ctx context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(endpoints.RegisterCustomerResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to endpoints.RegisterCustomerResponse")
+	}
+
+	return res.Protobuf(), nil
+}
+
+// load customer
+func decodeGRPCLoadCustomerRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req, ok := grpcReq.(*pb.LoadCustomerRequest)
+	if !ok {
+		return nil, errors.New("failed to convert grpc request to *pb.LoadCustomerRequest")
+	}
+
+	cr := endpoints.LoadCustomerRequest{}
+	return cr.Build(req), nil
+}
+
+func encodeGRPCLoadCustomerResponse(ctx context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(endpoints.LoadCustomerResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to endpoints.LoadCustomerResponse")
+	}
+
+	return res.Protobuf(), nil
+}
+
+// update customer
+func decodeGRPCUpdateCustomerRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req, ok := grpcReq.(*pb.UpdateCustomerRequest)
+	if !ok {
+		return nil, errors.New("failed to convert grpc request to *pb.UpdateCustomerRequest")
+	}
+
+	cr := endpoints.UpdateCustomerRequest{}
+	return cr.Build(req), nil
+}
+
+func encodeGRPCUpdateCustomerResponse(ctx context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(endpoints.UpdateCustomerResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to endpoints.UpdateCustomerResponse")
+	}
+
+	return res.Protobuf(), nil
+}
+
+// list customers
+func decodeGRPCListCustomersRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	return endpoints.ListCustomersRequest{}, nil
+}
+
+func encodeGRPCListCustomersResponse(ctx context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(endpoints.ListCustomersResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to endpoints.ListCustomersResponse")
+	}
+
+	return res.Protobuf(), nil
+}
+
+// customer client
+// register customer
+func encodeGRPCRegisterCustomerRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(endpoints.RegisterCustomerRequest)
+	if !ok {
+		return nil, errors.New("failed to convert request to endpoints.RegisterCustomerRequest")
+	}
+
+	c := endpoints.CustomerToProto(customer.Customer{
+		Contact:     req.Contact,
+		Preferences: req.Preferences,
+	})
+
+	return &pb.RegisterCustomerRequest{
+		Name:        req.Name,
+		Contact:     c.Contact,
+		Preferences: c.Preferences,
+	}, nil
+}
+
+func decodeGRPCRegisterCustomerResponse(ctx context.Context, grpcReply interface{}) (interface{}, error) {
+	reply, ok := grpcReply.(*pb.RegisterCustomerResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to *pb.RegisterCustomerResponse")
+	}
+
+	return endpoints.RegisterCustomerResponse{
+		CustomerID: customer.ID(reply.CustomerId),
+		Error:      str2err(reply.Error),
+	}, nil
+}
+
+// load customer
+func encodeGRPCLoadCustomerRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(endpoints.LoadCustomerRequest)
+	if !ok {
+		return nil, errors.New("failed to convert request to endpoints.LoadCustomerRequest")
+	}
+
+	return &pb.LoadCustomerRequest{
+		CustomerId: string(req.CustomerID),
+	}, nil
+}
+
+func decodeGRPCLoadCustomerResponse(ctx context.Context, grpcReply interface{}) (interface{}, error) {
+	reply, ok := grpcReply.(*pb.LoadCustomerResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to *pb.LoadCustomerResponse")
+	}
+
+	return endpoints.LoadCustomerResponse{
+		Customer: endpoints.CustomerFromProto(reply.Customer),
+		Error:    str2err(reply.Error),
+	}, nil
+}
+
+// update customer
+func encodeGRPCUpdateCustomerRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(endpoints.UpdateCustomerRequest)
+	if !ok {
+		return nil, errors.New("failed to convert request to endpoints.UpdateCustomerRequest")
+	}
+
+	return &pb.UpdateCustomerRequest{
+		Customer: endpoints.CustomerToProto(req.Customer),
+	}, nil
+}
+
+func decodeGRPCUpdateCustomerResponse(ctx context.Context, grpcReply interface{}) (interface{}, error) {
+	reply, ok := grpcReply.(*pb.UpdateCustomerResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to *pb.UpdateCustomerResponse")
+	}
+
+	return endpoints.UpdateCustomerResponse{
+		Error: str2err(reply.Error),
+	}, nil
+}
+
+// list customers
+func encodeGRPCListCustomersRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	_, ok := request.(endpoints.ListCustomersRequest)
+	if !ok {
+		return nil, errors.New("failed to convert request to endpoints.ListCustomersRequest")
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func decodeGRPCListCustomersResponse(ctx context.Context, grpcReply interface{}) (interface{}, error) {
+	reply, ok := grpcReply.(*pb.CustomersResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to *pb.CustomersResponse")
+	}
+
+	var customers []customer.Customer
+	for _, c := range reply.Customers {
+		customers = append(customers, endpoints.CustomerFromProto(c))
+	}
+
+	return endpoints.ListCustomersResponse{
+		Customers: customers,
+		Error:     str2err(reply.Error),
+	}, nil
+}
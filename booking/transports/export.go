@@ -0,0 +1,228 @@
+package transports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/mproyyan/grpc-shipping-microservice/booking/endpoints"
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/export"
+	"github.com/mproyyan/grpc-shipping-microservice/location"
+	"github.com/mproyyan/grpc-shipping-microservice/pb"
+	"github.com/mproyyan/grpc-shipping-microservice/validation"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ExportCargos sends every exported cargo down the stream. Business errors
+// end the stream with an Unknown status carrying the error message.
+func (bgs bookingGRPCServer) ExportCargos(req *pb.ExportCargosRequest, stream pb.Booking_ExportCargosServer) error {
+	ctx := stream.Context()
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, f := range serverBefore {
+		ctx = f(ctx, md)
+	}
+
+	request := endpoints.ExportCargosRequest{}.Build(req)
+	request.Yield = func(r export.Record) error {
+		return stream.Send(endpoints.ExportedCargo(r))
+	}
+
+	resp, err := bgs.exportCargos(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	if err := resp.(endpoints.ExportCargosResponse).Error; err != nil {
+		return status.Error(codes.Unknown, err.Error())
+	}
+
+	return nil
+}
+
+// makeExportCargosClientEndpoint calls the ExportCargos RPC and yields the
+// received cargos one by one.
+func makeExportCargosClientEndpoint(conn *grpc.ClientConn) endpoint.Endpoint {
+	client := pb.NewBookingClient(conn)
+
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(endpoints.ExportCargosRequest)
+		if !ok {
+			return nil, errors.New("failed to convert request to endpoints.ExportCargosRequest")
+		}
+
+		md := metadata.MD{}
+		for _, f := range clientBefore {
+			ctx = f(ctx, &md)
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		stream, err := client.ExportCargos(metadata.NewOutgoingContext(ctx, md), &pb.ExportCargosRequest{
+			Origin:         string(req.Filter.Origin),
+			Destination:    string(req.Filter.Destination),
+			CustomerId:     string(req.Filter.CustomerID),
+			DeadlineFrom:   optionalTimestamp(req.Filter.DeadlineFrom),
+			DeadlineTo:     optionalTimestamp(req.Filter.DeadlineTo),
+			IncludeHistory: req.History,
+			ExcludeClosed:  req.Filter.ExcludeClosed,
+			Misdirected:    req.Filter.Misdirected,
+			Late:           req.Filter.Late,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for {
+			c, err := stream.Recv()
+			if err == io.EOF {
+				return endpoints.ExportCargosResponse{}, nil
+			}
+			if err != nil {
+				if s, ok := status.FromError(err); ok && s.Code() == codes.Unknown {
+					return endpoints.ExportCargosResponse{Error: str2err(s.Message())}, nil
+				}
+
+				return nil, err
+			}
+
+			if err := req.Yield(endpoints.ExportRecord(c)); err != nil {
+				return endpoints.ExportCargosResponse{Error: err}, nil
+			}
+		}
+	}
+}
+
+func optionalTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
+
+// exportHandler serves GET /booking/cargos:export. It takes the filters of
+// the cargo listing, exclude_closed, misdirected and late included, plus format (csv, jsonl or parquet, csv by default) and
+// history to include the handling history of every cargo.
+//
+// Nothing is written before the first cargo arrives, so failures up to that
+// point are answered with problem details. A failure after that aborts the
+// connection, the client sees a truncated download rather than a file that
+// looks complete.
+func exportHandler(ep endpoint.Endpoint) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := headersToContext(w, r)
+		md, _ := metadata.FromIncomingContext(ctx)
+		for _, f := range serverBefore {
+			ctx = f(ctx, md)
+		}
+
+		req, format, err := decodeExportRequest(r)
+		if err != nil {
+			encodeError(ctx, err, w)
+			return
+		}
+
+		var out export.Writer
+		start := func() error {
+			if out != nil {
+				return nil
+			}
+
+			w.Header().Set("Content-Type", export.ContentType(format))
+			w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="cargos.%s"`, format))
+
+			var err error
+			out, err = export.NewWriter(format, w)
+			return err
+		}
+
+		req.Yield = func(rec export.Record) error {
+			if err := start(); err != nil {
+				return err
+			}
+
+			return out.Write(rec)
+		}
+
+		resp, err := ep(ctx, req)
+		if err == nil {
+			err = resp.(endpoints.ExportCargosResponse).Error
+		}
+
+		if err != nil {
+			if out == nil {
+				encodeError(ctx, err, w)
+				return
+			}
+
+			panic(http.ErrAbortHandler)
+		}
+
+		// an empty export still gets a CSV header or an empty Parquet file
+		if err := start(); err != nil {
+			encodeError(ctx, err, w)
+			return
+		}
+
+		if err := out.Close(); err != nil {
+			panic(http.ErrAbortHandler)
+		}
+	})
+}
+
+func decodeExportRequest(r *http.Request) (endpoints.ExportCargosRequest, string, error) {
+	var (
+		v     validation.Validator
+		req   endpoints.ExportCargosRequest
+		query = r.URL.Query()
+	)
+
+	format := query.Get("format")
+	if format == "" {
+		format = export.FormatCSV
+	}
+	v.Check(export.Supported(format), "format", "must be one of "+strings.Join(export.Formats, ", "))
+
+	if s := query.Get("history"); s != "" {
+		var err error
+		req.History, err = strconv.ParseBool(s)
+		v.Check(err == nil, "history", "must be a boolean")
+	}
+
+	req.Filter = cargo.Filter{
+		Origin:      location.UNLocode(query.Get("origin")),
+		Destination: location.UNLocode(query.Get("destination")),
+		CustomerID:  customer.ID(query.Get("customer_id")),
+	}
+
+	for field, b := range map[string]*bool{"exclude_closed": &req.Filter.ExcludeClosed, "misdirected": &req.Filter.Misdirected, "late": &req.Filter.Late} {
+		if s := query.Get(field); s != "" {
+			var err error
+			*b, err = strconv.ParseBool(s)
+			v.Check(err == nil, field, "must be a boolean")
+		}
+	}
+
+	for field, t := range map[string]*time.Time{"deadline_from": &req.Filter.DeadlineFrom, "deadline_to": &req.Filter.DeadlineTo} {
+		if s := query.Get(field); s != "" {
+			var err error
+			*t, err = time.Parse(time.RFC3339, s)
+			v.Check(err == nil, field, "must be an RFC 3339 timestamp")
+		}
+	}
+
+	return req, format, v.Err()
+}
@@ -0,0 +1,160 @@
+package transports
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/booking/endpoints"
+	"github.com/mproyyan/grpc-shipping-microservice/booking/services"
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/export"
+	"github.com/mproyyan/grpc-shipping-microservice/pb"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// exportService yields count records and records the arguments it got.
+type exportService struct {
+	services.BookingServiceContract
+	count   int
+	err     error
+	filter  cargo.Filter
+	history bool
+	caller  customer.Caller
+}
+
+func (s *exportService) ExportCargos(ctx context.Context, filter cargo.Filter, history bool, yield func(export.Record) error) error {
+	s.filter, s.history = filter, history
+	s.caller, _ = customer.FromContext(ctx)
+
+	eta := time.Date(2030, 1, 5, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < s.count; i++ {
+		r := export.Record{
+			TrackingID:      string(cargo.NextTrackingID()),
+			Origin:          "SESTO",
+			Destination:     "AUMEL",
+			ArrivalDeadline: time.Date(2030, 1, 10, 0, 0, 0, 0, time.UTC),
+			RoutingStatus:   "Routed",
+			ETA:             &eta,
+		}
+		if history {
+			r.History = []export.Event{{Type: "Receive", Location: "SESTO"}}
+		}
+
+		if err := yield(r); err != nil {
+			return err
+		}
+	}
+
+	return s.err
+}
+
+func TestExportCargosStreamsRecords(t *testing.T) {
+	svc := &exportService{count: 3}
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(CallerServerOptions(testTokens)...)
+	pb.RegisterBookingServer(server, NewGRPCServer(endpoints.Set{ExportCargosEndpoint: endpoints.MakeExportCargosEndpoint(svc)}))
+	go server.Serve(lis)
+	defer server.Stop()
+
+	conn, err := grpc.Dial("bufnet", append(CallerDialOptions(testTokens),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithInsecure(),
+	)...)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	from := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	filter := cargo.Filter{Origin: "SESTO", DeadlineFrom: from, Misdirected: true, Late: true}
+	ctx := customer.NewContext(context.Background(), customer.Caller{CustomerID: "acme"})
+
+	var records []export.Record
+	err = NewGRPCClient(conn).ExportCargos(ctx, filter, true, func(r export.Record) error {
+		records = append(records, r)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	require.Equal(t, "Receive@SESTO", records[0].History[0].String())
+	require.True(t, records[0].ETA.Equal(time.Date(2030, 1, 5, 0, 0, 0, 0, time.UTC)))
+
+	require.True(t, svc.history)
+	require.EqualValues(t, "SESTO", svc.filter.Origin)
+	require.True(t, svc.filter.DeadlineFrom.Equal(from))
+	require.True(t, svc.filter.DeadlineTo.IsZero())
+	require.True(t, svc.filter.Misdirected)
+	require.True(t, svc.filter.Late)
+	require.Equal(t, customer.ID("acme"), svc.caller.CustomerID)
+
+	svc.err = services.ErrPermissionDenied
+	err = NewGRPCClient(conn).ExportCargos(ctx, cargo.Filter{}, false, func(export.Record) error { return nil })
+	require.Equal(t, services.ErrPermissionDenied, err)
+}
+
+func TestExportHandler(t *testing.T) {
+	svc := &exportService{count: 2}
+	handler := NewHttpHandler(endpoints.Set{ExportCargosEndpoint: endpoints.MakeExportCargosEndpoint(svc)})
+	handler.Use(NewAuthenticator(testTokens))
+
+	get := func(target string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", target, nil)
+		authorize(req, customer.Caller{CustomerID: "acme"})
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get("/booking/cargos:export?destination=AUMEL&deadline_to=2031-01-01T00:00:00Z")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.Equal(t, "text/csv", rec.Header().Get("Content-Type"))
+	require.Contains(t, rec.Header().Get("Content-Disposition"), "cargos.csv")
+
+	rows, err := csv.NewReader(rec.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	require.Equal(t, "tracking_id", rows[0][0])
+	require.EqualValues(t, "AUMEL", svc.filter.Destination)
+	require.False(t, svc.history)
+
+	rec = get("/booking/cargos:export?misdirected=true&late=1")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.True(t, svc.filter.Misdirected)
+	require.True(t, svc.filter.Late)
+
+	rec = get("/booking/cargos:export?format=jsonl&history=true")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var r export.Record
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &r))
+	require.Len(t, r.History, 1)
+
+	svc.count = 0
+	rec = get("/booking/cargos:export")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.True(t, strings.HasPrefix(rec.Body.String(), "tracking_id,"))
+	require.Equal(t, 1, strings.Count(rec.Body.String(), "\n"))
+
+	rec = get("/booking/cargos:export?format=xlsx")
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+	require.Contains(t, rec.Body.String(), `"name":"format"`)
+
+	rec = get("/booking/cargos:export?origin=stockholm")
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), `"name":"origin"`)
+
+	svc.err = services.ErrPermissionDenied
+	rec = get("/booking/cargos:export?customer_id=someone-else")
+	require.Equal(t, http.StatusForbidden, rec.Code)
+}
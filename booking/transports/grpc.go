@@ -3,60 +3,126 @@ package transports
 import (
 	"context"
 	"errors"
-	"fmt"
 
+	"github.com/go-kit/kit/endpoint"
 	gt "github.com/go-kit/kit/transport/grpc"
-	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/mproyyan/grpc-shipping-microservice/billing"
 	"github.com/mproyyan/grpc-shipping-microservice/booking/endpoints"
 	"github.com/mproyyan/grpc-shipping-microservice/booking/services"
 	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
 	"github.com/mproyyan/grpc-shipping-microservice/location"
+	"github.com/mproyyan/grpc-shipping-microservice/logging"
 	"github.com/mproyyan/grpc-shipping-microservice/pb"
+	"github.com/mproyyan/grpc-shipping-microservice/pricing"
+	"github.com/mproyyan/grpc-shipping-microservice/shipment"
+	"github.com/mproyyan/grpc-shipping-microservice/tracing"
 	"github.com/mproyyan/grpc-shipping-microservice/voyage"
+	"github.com/mproyyan/grpc-shipping-microservice/webhook"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// serverBefore and clientBefore propagate the trace and request ID across
+// the gRPC hop, the caller is asserted by the interceptors of caller.go.
+// Streaming RPCs, which go-kit does not cover, apply them by hand.
+var (
+	serverBefore = []gt.ServerRequestFunc{tracing.GRPCServerBefore, logging.GRPCServerBefore}
+	clientBefore = []gt.ClientRequestFunc{tracing.GRPCClientBefore, logging.GRPCClientBefore}
+)
+
 type bookingGRPCServer struct {
 	pb.UnimplementedBookingServer
 	bookNewCargo       gt.Handler
 	loadCargo          gt.Handler
 	assignCargoToRoute gt.Handler
 	changeDestination  gt.Handler
+	updateRouteSpec    gt.Handler
+	cancelBooking      gt.Handler
+	holdCargo          gt.Handler
+	releaseCargo       gt.Handler
+	handlingHistory    gt.Handler
 	listCargos         gt.Handler
+	bookCargos         endpoint.Endpoint
+	exportCargos       endpoint.Endpoint
 }
 
 func NewGRPCServer(endpoints endpoints.Set) pb.BookingServer {
+	options := []gt.ServerOption{
+		gt.ServerBefore(serverBefore...),
+	}
+
 	return bookingGRPCServer{
 		bookNewCargo: gt.NewServer(
 			endpoints.BookNewCargoEndpoint,
 			decodeGRPCBookNewCargoRequest,
 			encodeGRPCBookNewCargoResponse,
+			options...,
 		),
 		loadCargo: gt.NewServer(
 			endpoints.LoadCargoEndpoint,
 			decodeGRPCLoadCargoRequest,
 			encodeGRPCLoadCargoResponse,
+			options...,
 		),
 		assignCargoToRoute: gt.NewServer(
 			endpoints.AssignCargoToRouteEndpoint,
 			decodeGRPCAssignCargoToRouteRequest,
 			encodeGRPCAssignCargoToRouteResponse,
+			options...,
 		),
 		changeDestination: gt.NewServer(
 			endpoints.ChangeDestinationEndpoint,
 			decodeGRPCChangeDestinationRequest,
 			encodeGRPCChangeDestinationResponse,
+			options...,
+		),
+		updateRouteSpec: gt.NewServer(
+			endpoints.UpdateRouteSpecificationEndpoint,
+			decodeGRPCUpdateRouteSpecificationRequest,
+			encodeGRPCUpdateRouteSpecificationResponse,
+			options...,
+		),
+		cancelBooking: gt.NewServer(
+			endpoints.CancelBookingEndpoint,
+			decodeGRPCCancelBookingRequest,
+			encodeGRPCCancelBookingResponse,
+			options...,
+		),
+		holdCargo: gt.NewServer(
+			endpoints.HoldCargoEndpoint,
+			decodeGRPCHoldCargoRequest,
+			encodeGRPCHoldCargoResponse,
+			options...,
+		),
+		releaseCargo: gt.NewServer(
+			endpoints.ReleaseCargoEndpoint,
+			decodeGRPCReleaseCargoRequest,
+			encodeGRPCReleaseCargoResponse,
+			options...,
+		),
+		handlingHistory: gt.NewServer(
+			endpoints.HandlingHistoryEndpoint,
+			decodeGRPCHandlingHistoryRequest,
+			encodeGRPCHandlingHistoryResponse,
+			options...,
 		),
 		listCargos: gt.NewServer(
 			endpoints.CargosEndpoint,
 			decodeGRPCListCargosRequest,
 			encodeGRPCListCargosResponse,
+			options...,
 		),
+		bookCargos:   endpoints.BookCargosEndpoint,
+		exportCargos: endpoints.ExportCargosEndpoint,
 	}
 }
 
 func NewGRPCClient(conn *grpc.ClientConn) services.BookingServiceContract {
+	options := []gt.ClientOption{
+		gt.ClientBefore(clientBefore...),
+	}
+
 	bookNewCargoEndpoint := gt.NewClient(
 		conn,
 		"pb.Booking",
@@ -64,6 +130,7 @@ func NewGRPCClient(conn *grpc.ClientConn) services.BookingServiceContract {
 		encodeGRPCBookNewCargoRequest,
 		decodeGRPCBookNewCargoResponse,
 		pb.BookNewCargoResponse{},
+		options...,
 	).Endpoint()
 
 	loadCargoEndpoint := gt.NewClient(
@@ -73,6 +140,7 @@ func NewGRPCClient(conn *grpc.ClientConn) services.BookingServiceContract {
 		encodeGRPCLoadCargoRequest,
 		decodeGRPCLoadCargoResponse,
 		pb.LoadCargoResponse{},
+		options...,
 	).Endpoint()
 
 	assignCargoToRouteEndpoint := gt.NewClient(
@@ -82,6 +150,7 @@ func NewGRPCClient(conn *grpc.ClientConn) services.BookingServiceContract {
 		encodeGRPCAssignCargoToRouteRequest,
 		decodeGRPCAssignCargoToRouteResponse,
 		pb.AssignCargoToRouteResponse{},
+		options...,
 	).Endpoint()
 
 	changeDestinationEndpoint := gt.NewClient(
@@ -91,6 +160,57 @@ func NewGRPCClient(conn *grpc.ClientConn) services.BookingServiceContract {
 		encodeGRPCChangeDestinationRequest,
 		decodeGRPCChangeDestinationResponse,
 		pb.ChangeDestinationResponse{},
+		options...,
+	).Endpoint()
+
+	updateRouteSpecificationEndpoint := gt.NewClient(
+		conn,
+		"pb.Booking",
+		"UpdateRouteSpecification",
+		encodeGRPCUpdateRouteSpecificationRequest,
+		decodeGRPCUpdateRouteSpecificationResponse,
+		pb.UpdateRouteSpecificationResponse{},
+		options...,
+	).Endpoint()
+
+	cancelBookingEndpoint := gt.NewClient(
+		conn,
+		"pb.Booking",
+		"CancelBooking",
+		encodeGRPCCancelBookingRequest,
+		decodeGRPCCancelBookingResponse,
+		pb.CancelBookingResponse{},
+		options...,
+	).Endpoint()
+
+	holdCargoEndpoint := gt.NewClient(
+		conn,
+		"pb.Booking",
+		"HoldCargo",
+		encodeGRPCHoldCargoRequest,
+		decodeGRPCHoldCargoResponse,
+		pb.HoldCargoResponse{},
+		options...,
+	).Endpoint()
+
+	releaseCargoEndpoint := gt.NewClient(
+		conn,
+		"pb.Booking",
+		"ReleaseCargo",
+		encodeGRPCReleaseCargoRequest,
+		decodeGRPCReleaseCargoResponse,
+		pb.ReleaseCargoResponse{},
+		options...,
+	).Endpoint()
+
+	handlingHistoryEndpoint := gt.NewClient(
+		conn,
+		"pb.Booking",
+		"HandlingHistory",
+		encodeGRPCHandlingHistoryRequest,
+		decodeGRPCHandlingHistoryResponse,
+		pb.HandlingHistoryResponse{},
+		options...,
 	).Endpoint()
 
 	listCargosEndpoint := gt.NewClient(
@@ -100,14 +220,22 @@ func NewGRPCClient(conn *grpc.ClientConn) services.BookingServiceContract {
 		encodeGRPCListCargosRequest,
 		decodeGRPCListCargosResponse,
 		pb.CargosResponse{},
+		options...,
 	).Endpoint()
 
 	return endpoints.Set{
-		BookNewCargoEndpoint:       bookNewCargoEndpoint,
-		LoadCargoEndpoint:          loadCargoEndpoint,
-		AssignCargoToRouteEndpoint: assignCargoToRouteEndpoint,
-		ChangeDestinationEndpoint:  changeDestinationEndpoint,
-		CargosEndpoint:             listCargosEndpoint,
+		BookNewCargoEndpoint:             bookNewCargoEndpoint,
+		LoadCargoEndpoint:                loadCargoEndpoint,
+		AssignCargoToRouteEndpoint:       assignCargoToRouteEndpoint,
+		ChangeDestinationEndpoint:        changeDestinationEndpoint,
+		UpdateRouteSpecificationEndpoint: updateRouteSpecificationEndpoint,
+		CancelBookingEndpoint:            cancelBookingEndpoint,
+		HoldCargoEndpoint:                holdCargoEndpoint,
+		ReleaseCargoEndpoint:             releaseCargoEndpoint,
+		HandlingHistoryEndpoint:          handlingHistoryEndpoint,
+		CargosEndpoint:                   listCargosEndpoint,
+		BookCargosEndpoint:               makeBookCargosClientEndpoint(conn),
+		ExportCargosEndpoint:             makeExportCargosClientEndpoint(conn),
 	}
 }
 
@@ -147,8 +275,53 @@ func (bgs bookingGRPCServer) ChangeDestination(ctx context.Context, req *pb.Chan
 	return resp.(*pb.ChangeDestinationResponse), nil
 }
 
-func (bgs bookingGRPCServer) Cargos(ctx context.Context, _ *empty.Empty) (*pb.CargosResponse, error) {
-	_, resp, err := bgs.listCargos.ServeGRPC(ctx, nil)
+func (bgs bookingGRPCServer) UpdateRouteSpecification(ctx context.Context, req *pb.UpdateRouteSpecificationRequest) (*pb.UpdateRouteSpecificationResponse, error) {
+	_, resp, err := bgs.updateRouteSpec.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*pb.UpdateRouteSpecificationResponse), nil
+}
+
+func (bgs bookingGRPCServer) CancelBooking(ctx context.Context, req *pb.CancelBookingRequest) (*pb.CancelBookingResponse, error) {
+	_, resp, err := bgs.cancelBooking.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*pb.CancelBookingResponse), nil
+}
+
+func (bgs bookingGRPCServer) HoldCargo(ctx context.Context, req *pb.HoldCargoRequest) (*pb.HoldCargoResponse, error) {
+	_, resp, err := bgs.holdCargo.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*pb.HoldCargoResponse), nil
+}
+
+func (bgs bookingGRPCServer) ReleaseCargo(ctx context.Context, req *pb.ReleaseCargoRequest) (*pb.ReleaseCargoResponse, error) {
+	_, resp, err := bgs.releaseCargo.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*pb.ReleaseCargoResponse), nil
+}
+
+func (bgs bookingGRPCServer) HandlingHistory(ctx context.Context, req *pb.HandlingHistoryRequest) (*pb.HandlingHistoryResponse, error) {
+	_, resp, err := bgs.handlingHistory.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*pb.HandlingHistoryResponse), nil
+}
+
+func (bgs bookingGRPCServer) Cargos(ctx context.Context, req *pb.CargosRequest) (*pb.CargosResponse, error) {
+	_, resp, err := bgs.listCargos.ServeGRPC(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -217,6 +390,106 @@ func encodeGRPCAssignCargoToRouteResponse(ctx context.Context, response interfac
 	return res.Protobuf(), nil
 }
 
+// update route specification
+func decodeGRPCUpdateRouteSpecificationRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req, ok := grpcReq.(*pb.UpdateRouteSpecificationRequest)
+	if !ok {
+		return nil, errors.New("failed to convert grpc request to *pb.UpdateRouteSpecificationRequest")
+	}
+
+	ur := endpoints.UpdateRouteSpecificationRequest{}
+	return ur.Build(req), nil
+}
+
+func encodeGRPCUpdateRouteSpecificationResponse(ctx context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(endpoints.UpdateRouteSpecificationResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to endpoints.UpdateRouteSpecificationResponse")
+	}
+
+	return res.Protobuf(), nil
+}
+
+// cancel booking
+func decodeGRPCCancelBookingRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req, ok := grpcReq.(*pb.CancelBookingRequest)
+	if !ok {
+		return nil, errors.New("failed to convert grpc request to *pb.CancelBookingRequest")
+	}
+
+	cr := endpoints.CancelBookingRequest{}
+	return cr.Build(req), nil
+}
+
+func encodeGRPCCancelBookingResponse(ctx context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(endpoints.CancelBookingResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to endpoints.CancelBookingResponse")
+	}
+
+	return res.Protobuf(), nil
+}
+
+// hold cargo
+func decodeGRPCHoldCargoRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req, ok := grpcReq.(*pb.HoldCargoRequest)
+	if !ok {
+		return nil, errors.New("failed to convert grpc request to *pb.HoldCargoRequest")
+	}
+
+	cr := endpoints.HoldCargoRequest{}
+	return cr.Build(req), nil
+}
+
+func encodeGRPCHoldCargoResponse(ctx context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(endpoints.HoldCargoResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to endpoints.HoldCargoResponse")
+	}
+
+	return res.Protobuf(), nil
+}
+
+// release cargo
+func decodeGRPCReleaseCargoRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req, ok := grpcReq.(*pb.ReleaseCargoRequest)
+	if !ok {
+		return nil, errors.New("failed to convert grpc request to *pb.ReleaseCargoRequest")
+	}
+
+	cr := endpoints.ReleaseCargoRequest{}
+	return cr.Build(req), nil
+}
+
+func encodeGRPCReleaseCargoResponse(ctx context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(endpoints.ReleaseCargoResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to endpoints.ReleaseCargoResponse")
+	}
+
+	return res.Protobuf(), nil
+}
+
+// handling history
+func decodeGRPCHandlingHistoryRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req, ok := grpcReq.(*pb.HandlingHistoryRequest)
+	if !ok {
+		return nil, errors.New("failed to convert grpc request to *pb.HandlingHistoryRequest")
+	}
+
+	hr := endpoints.HandlingHistoryRequest{}
+	return hr.Build(req), nil
+}
+
+func encodeGRPCHandlingHistoryResponse(ctx context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(endpoints.HandlingHistoryResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to endpoints.HandlingHistoryResponse")
+	}
+
+	return res.Protobuf(), nil
+}
+
 // change cargo destination
 func decodeGRPCChangeDestinationRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
 	req, ok := grpcReq.(*pb.ChangeDestinationRequest)
@@ -237,9 +510,15 @@ func encodeGRPCChangeDestinationResponse(ctx context.Context, response interface
 	return res.Protobuf(), nil
 }
 
-// change cargo destination
+// list cargos
 func decodeGRPCListCargosRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
-	return endpoints.ListCargosRequest{}, nil
+	req, ok := grpcReq.(*pb.CargosRequest)
+	if !ok {
+		return nil, errors.New("failed to convert grpc request to *pb.CargosRequest")
+	}
+
+	cr := endpoints.ListCargosRequest{}
+	return cr.Build(req), nil
 }
 
 func encodeGRPCListCargosResponse(ctx context.Context, response interface{}) (interface{}, error) {
@@ -263,6 +542,15 @@ func encodeGRPCBookNewCargoRequest(ctx context.Context, request interface{}) (in
 		Origin:      string(req.Origin),
 		Destination: string(req.Destination),
 		Deadline:    timestamppb.New(req.Deadline),
+		Weight:      req.Attributes.Weight,
+		Volume:      req.Attributes.Volume,
+		Packages:    int32(req.Attributes.Packages),
+		Commodity:   req.Attributes.Commodity,
+		HsCode:      req.Attributes.HSCode,
+		ImdgClass:   string(req.Attributes.DangerousGoods.Class),
+		UnNumber:    string(req.Attributes.DangerousGoods.UNNumber),
+		QuoteId:     string(req.Quote.QuoteID),
+		QuoteOption: int32(req.Quote.Option),
 	}, nil
 }
 
@@ -318,7 +606,21 @@ func decodeGRPCLoadCargoResponse(ctx context.Context, grpcReply interface{}) (in
 			Origin:          reply.Cargo.Origin,
 			Routed:          reply.Cargo.Routed,
 			TrackingID:      reply.Cargo.TrackingId,
+			CustomerID:      reply.Cargo.CustomerId,
+			Status:          reply.Cargo.Status,
+			Weight:          reply.Cargo.Weight,
+			Volume:          reply.Cargo.Volume,
+			Packages:        int(reply.Cargo.Packages),
+			Commodity:       reply.Cargo.Commodity,
+			HSCode:          reply.Cargo.HsCode,
+			Clearance:       reply.Cargo.Clearance,
+			HoldReason:      reply.Cargo.HoldReason,
+			IMDGClass:       reply.Cargo.ImdgClass,
+			UNNumber:        reply.Cargo.UnNumber,
+			QuoteID:         reply.Cargo.QuoteId,
+			Price:           endpoints.MoneyFromProto(reply.Cargo.Price),
 		},
+		Error: str2err(reply.Error),
 	}, nil
 }
 
@@ -328,7 +630,6 @@ func encodeGRPCAssignCargoToRouteRequest(ctx context.Context, request interface{
 	if !ok {
 		return nil, errors.New("failed to convert request to endpoints.AssignCargoToRouteRequest")
 	}
-	fmt.Println(req)
 	var legs []*pb.Leg
 	for _, l := range req.Itinerary.Legs {
 		leg := &pb.Leg{
@@ -362,6 +663,139 @@ func decodeGRPCAssignCargoToRouteResponse(ctx context.Context, grpcReply interfa
 	}, nil
 }
 
+// update route specification
+func encodeGRPCUpdateRouteSpecificationRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(endpoints.UpdateRouteSpecificationRequest)
+	if !ok {
+		return nil, errors.New("failed to convert request to endpoints.UpdateRouteSpecificationRequest")
+	}
+
+	return &pb.UpdateRouteSpecificationRequest{
+		TrackingId:      string(req.TrackingID),
+		Origin:          string(req.Origin),
+		Destination:     string(req.Destination),
+		ArrivalDeadline: optionalTimestamp(req.ArrivalDeadline),
+	}, nil
+}
+
+func decodeGRPCUpdateRouteSpecificationResponse(ctx context.Context, grpcReply interface{}) (interface{}, error) {
+	reply, ok := grpcReply.(*pb.UpdateRouteSpecificationResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to *pb.UpdateRouteSpecificationResponse")
+	}
+
+	return endpoints.UpdateRouteSpecificationResponse{
+		ItinerarySatisfied: reply.ItinerarySatisfied,
+		Error:              str2err(reply.Error),
+	}, nil
+}
+
+// cancel booking
+func encodeGRPCCancelBookingRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(endpoints.CancelBookingRequest)
+	if !ok {
+		return nil, errors.New("failed to convert request to endpoints.CancelBookingRequest")
+	}
+
+	return &pb.CancelBookingRequest{
+		TrackingId: string(req.TrackingID),
+	}, nil
+}
+
+func decodeGRPCCancelBookingResponse(ctx context.Context, grpcReply interface{}) (interface{}, error) {
+	reply, ok := grpcReply.(*pb.CancelBookingResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to *pb.CancelBookingResponse")
+	}
+
+	return endpoints.CancelBookingResponse{
+		Error: str2err(reply.Error),
+	}, nil
+}
+
+// hold cargo
+func encodeGRPCHoldCargoRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(endpoints.HoldCargoRequest)
+	if !ok {
+		return nil, errors.New("failed to convert request to endpoints.HoldCargoRequest")
+	}
+
+	return &pb.HoldCargoRequest{
+		TrackingId: string(req.TrackingID),
+		Reason:     req.Reason,
+	}, nil
+}
+
+func decodeGRPCHoldCargoResponse(ctx context.Context, grpcReply interface{}) (interface{}, error) {
+	reply, ok := grpcReply.(*pb.HoldCargoResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to *pb.HoldCargoResponse")
+	}
+
+	return endpoints.HoldCargoResponse{
+		Error: str2err(reply.Error),
+	}, nil
+}
+
+// release cargo
+func encodeGRPCReleaseCargoRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(endpoints.ReleaseCargoRequest)
+	if !ok {
+		return nil, errors.New("failed to convert request to endpoints.ReleaseCargoRequest")
+	}
+
+	return &pb.ReleaseCargoRequest{
+		TrackingId: string(req.TrackingID),
+	}, nil
+}
+
+func decodeGRPCReleaseCargoResponse(ctx context.Context, grpcReply interface{}) (interface{}, error) {
+	reply, ok := grpcReply.(*pb.ReleaseCargoResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to *pb.ReleaseCargoResponse")
+	}
+
+	return endpoints.ReleaseCargoResponse{
+		Error: str2err(reply.Error),
+	}, nil
+}
+
+// handling history
+func encodeGRPCHandlingHistoryRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(endpoints.HandlingHistoryRequest)
+	if !ok {
+		return nil, errors.New("failed to convert request to endpoints.HandlingHistoryRequest")
+	}
+
+	return &pb.HandlingHistoryRequest{
+		TrackingId: string(req.TrackingID),
+	}, nil
+}
+
+func decodeGRPCHandlingHistoryResponse(ctx context.Context, grpcReply interface{}) (interface{}, error) {
+	reply, ok := grpcReply.(*pb.HandlingHistoryResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to *pb.HandlingHistoryResponse")
+	}
+
+	events := make([]services.HandlingEvent, 0, len(reply.Events))
+	for _, e := range reply.Events {
+		events = append(events, services.HandlingEvent{
+			Type:         e.Type,
+			Location:     e.Location,
+			LocationName: e.LocationName,
+			VoyageNumber: e.VoyageNumber,
+			Completed:    e.Completed.AsTime(),
+			Expected:     e.Expected,
+		})
+	}
+
+	return endpoints.HandlingHistoryResponse{
+		Events: events,
+		Error:  str2err(reply.Error),
+	}, nil
+}
+
 // change destination
 func encodeGRPCChangeDestinationRequest(ctx context.Context, request interface{}) (interface{}, error) {
 	req, ok := request.(endpoints.ChangeDestinationRequest)
@@ -389,12 +823,21 @@ func decodeGRPCChangeDestinationResponse(ctx context.Context, grpcReply interfac
 // list cargos
 // book new cargo
 func encodeGRPCListCargosRequest(ctx context.Context, request interface{}) (interface{}, error) {
-	_, ok := request.(endpoints.ListCargosRequest)
+	req, ok := request.(endpoints.ListCargosRequest)
 	if !ok {
 		return nil, errors.New("failed to convert request to endpoints.ListCargosRequest")
 	}
 
-	return nil, nil
+	return &pb.CargosRequest{
+		Origin:        string(req.Filter.Origin),
+		Destination:   string(req.Filter.Destination),
+		CustomerId:    string(req.Filter.CustomerID),
+		DeadlineFrom:  optionalTimestamp(req.Filter.DeadlineFrom),
+		DeadlineTo:    optionalTimestamp(req.Filter.DeadlineTo),
+		ExcludeClosed: req.Filter.ExcludeClosed,
+		Misdirected:   req.Filter.Misdirected,
+		Late:          req.Filter.Late,
+	}, nil
 }
 
 func decodeGRPCListCargosResponse(ctx context.Context, grpcReply interface{}) (interface{}, error) {
@@ -426,6 +869,17 @@ func decodeGRPCListCargosResponse(ctx context.Context, grpcReply interface{}) (i
 			Origin:          c.Origin,
 			Routed:          c.Routed,
 			TrackingID:      c.TrackingId,
+			CustomerID:      c.CustomerId,
+			Status:          c.Status,
+			Weight:          c.Weight,
+			Volume:          c.Volume,
+			Packages:        int(c.Packages),
+			Commodity:       c.Commodity,
+			HSCode:          c.HsCode,
+			Clearance:       c.Clearance,
+			HoldReason:      c.HoldReason,
+			IMDGClass:       c.ImdgClass,
+			UNNumber:        c.UnNumber,
 		}
 
 		cargos = append(cargos, cargo)
@@ -433,12 +887,59 @@ func decodeGRPCListCargosResponse(ctx context.Context, grpcReply interface{}) (i
 
 	return endpoints.ListCargosResponse{
 		Cargos: cargos,
+		Error:  str2err(reply.Error),
 	}, nil
 }
 
+// knownErrors are sentinel errors that are restored on the client side, so
+// callers can keep comparing against them after a network hop.
+var knownErrors = []error{
+	billing.ErrUnknownInvoice,
+	billing.ErrInvoiceFinalized,
+	billing.ErrEmptyInvoice,
+	cargo.ErrUnknown,
+	cargo.ErrInvalidTransition,
+	cargo.ErrInactive,
+	cargo.ErrOriginFixed,
+	cargo.ErrOverbooked,
+	cargo.ErrRestricted,
+	cargo.ErrOnHold,
+	cargo.ErrNotCleared,
+	cargo.ErrNotHeld,
+	customer.ErrUnknown,
+	pricing.ErrUnknownQuote,
+	pricing.ErrQuoteExpired,
+	pricing.ErrQuoteAccepted,
+	pricing.ErrQuoteMismatch,
+	pricing.ErrUnknownOption,
+	pricing.ErrNoRoute,
+	shipment.ErrUnknown,
+	shipment.ErrUnknownContainer,
+	shipment.ErrContainerInUse,
+	shipment.ErrCapacityExceeded,
+	shipment.ErrAlreadyStuffed,
+	shipment.ErrNotStuffed,
+	shipment.ErrEmptyContainer,
+	shipment.ErrRouteMismatch,
+	webhook.ErrUnknownSubscription,
+	webhook.ErrUnknownEvent,
+	webhook.ErrUnknownDelivery,
+	webhook.ErrNotFailed,
+	services.ErrInvalidArgument,
+	services.ErrUnauthenticated,
+	services.ErrPermissionDenied,
+}
+
 func str2err(s string) error {
 	if s == "" {
 		return nil
 	}
+
+	for _, err := range knownErrors {
+		if err.Error() == s {
+			return err
+		}
+	}
+
 	return errors.New(s)
 }
@@ -0,0 +1,273 @@
+package transports
+
+import (
+	"context"
+	"errors"
+
+	gt "github.com/go-kit/kit/transport/grpc"
+	"github.com/mproyyan/grpc-shipping-microservice/booking/endpoints"
+	"github.com/mproyyan/grpc-shipping-microservice/booking/services"
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/pb"
+	"google.golang.org/grpc"
+)
+
+type handlingGRPCServer struct {
+	pb.UnimplementedHandlingServer
+	registerHandlingEvent          gt.Handler
+	registerContainerHandlingEvent gt.Handler
+	ingestEdifact                  gt.Handler
+}
+
+func NewHandlingGRPCServer(endpoints endpoints.HandlingSet) pb.HandlingServer {
+	options := []gt.ServerOption{
+		gt.ServerBefore(serverBefore...),
+	}
+
+	return handlingGRPCServer{
+		registerHandlingEvent: gt.NewServer(
+			endpoints.RegisterHandlingEventEndpoint,
+			decodeGRPCRegisterHandlingEventRequest,
+			encodeGRPCRegisterHandlingEventResponse,
+			options...,
+		),
+		registerContainerHandlingEvent: gt.NewServer(
+			endpoints.RegisterContainerHandlingEventEndpoint,
+			decodeGRPCRegisterContainerHandlingEventRequest,
+			encodeGRPCRegisterContainerHandlingEventResponse,
+			options...,
+		),
+		ingestEdifact: gt.NewServer(
+			endpoints.IngestEdifactEndpoint,
+			decodeGRPCIngestEdifactRequest,
+			encodeGRPCIngestEdifactResponse,
+			options...,
+		),
+	}
+}
+
+func NewHandlingGRPCClient(conn *grpc.ClientConn) services.HandlingServiceContract {
+	options := []gt.ClientOption{
+		gt.ClientBefore(clientBefore...),
+	}
+
+	registerHandlingEventEndpoint := gt.NewClient(
+		conn,
+		"pb.Handling",
+		"RegisterHandlingEvent",
+		encodeGRPCRegisterHandlingEventRequest,
+		decodeGRPCRegisterHandlingEventResponse,
+		pb.RegisterHandlingEventResponse{},
+		options...,
+	).Endpoint()
+
+	registerContainerHandlingEventEndpoint := gt.NewClient(
+		conn,
+		"pb.Handling",
+		"RegisterContainerHandlingEvent",
+		encodeGRPCRegisterContainerHandlingEventRequest,
+		decodeGRPCRegisterContainerHandlingEventResponse,
+		pb.RegisterContainerHandlingEventResponse{},
+		options...,
+	).Endpoint()
+
+	ingestEdifactEndpoint := gt.NewClient(
+		conn,
+		"pb.Handling",
+		"IngestEdifact",
+		encodeGRPCIngestEdifactRequest,
+		decodeGRPCIngestEdifactResponse,
+		pb.IngestEdifactResponse{},
+		options...,
+	).Endpoint()
+
+	return endpoints.HandlingSet{
+		RegisterHandlingEventEndpoint:          registerHandlingEventEndpoint,
+		RegisterContainerHandlingEventEndpoint: registerContainerHandlingEventEndpoint,
+		IngestEdifactEndpoint:                  ingestEdifactEndpoint,
+	}
+}
+
+func (hgs handlingGRPCServer) RegisterHandlingEvent(ctx context.Context, req *pb.RegisterHandlingEventRequest) (*pb.RegisterHandlingEventResponse, error) {
+	_, resp, err := hgs.registerHandlingEvent.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*pb.RegisterHandlingEventResponse), nil
+}
+
+func (hgs handlingGRPCServer) RegisterContainerHandlingEvent(ctx context.Context, req *pb.RegisterContainerHandlingEventRequest) (*pb.RegisterContainerHandlingEventResponse, error) {
+	_, resp, err := hgs.registerContainerHandlingEvent.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*pb.RegisterContainerHandlingEventResponse), nil
+}
+
+func (hgs handlingGRPCServer) IngestEdifact(ctx context.Context, req *pb.IngestEdifactRequest) (*pb.IngestEdifactResponse, error) {
+	_, resp, err := hgs.ingestEdifact.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*pb.IngestEdifactResponse), nil
+}
+
+// handling server
+// register handling event
+func decodeGRPCRegisterHandlingEventRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req, ok := grpcReq.(*pb.RegisterHandlingEventRequest)
+	if !ok {
+		return nil, errors.New("failed to convert grpc request to *pb.RegisterHandlingEventRequest")
+	}
+
+	hr := endpoints.RegisterHandlingEventRequest{}
+	return hr.Build(req), nil
+}
+
+func encodeGRPCRegisterHandlingEventResponse(ctx context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(endpoints.RegisterHandlingEventResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to endpoints.RegisterHandlingEventResponse")
+	}
+
+	return res.Protobuf(), nil
+}
+
+// register container handling event
+func decodeGRPCRegisterContainerHandlingEventRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req, ok := grpcReq.(*pb.RegisterContainerHandlingEventRequest)
+	if !ok {
+		return nil, errors.New("failed to convert grpc request to *pb.RegisterContainerHandlingEventRequest")
+	}
+
+	r := endpoints.RegisterContainerHandlingEventRequest{}
+	return r.Build(req), nil
+}
+
+func encodeGRPCRegisterContainerHandlingEventResponse(ctx context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(endpoints.RegisterContainerHandlingEventResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to endpoints.RegisterContainerHandlingEventResponse")
+	}
+
+	return res.Protobuf(), nil
+}
+
+// ingest edifact
+func decodeGRPCIngestEdifactRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req, ok := grpcReq.(*pb.IngestEdifactRequest)
+	if !ok {
+		return nil, errors.New("failed to convert grpc request to *pb.IngestEdifactRequest")
+	}
+
+	r := endpoints.IngestEdifactRequest{}
+	return r.Build(req), nil
+}
+
+func encodeGRPCIngestEdifactResponse(ctx context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(endpoints.IngestEdifactResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to endpoints.IngestEdifactResponse")
+	}
+
+	return res.Protobuf(), nil
+}
+
+// handling client
+// register handling event
+func encodeGRPCRegisterHandlingEventRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(endpoints.RegisterHandlingEventRequest)
+	if !ok {
+		return nil, errors.New("failed to convert request to endpoints.RegisterHandlingEventRequest")
+	}
+
+	return &pb.RegisterHandlingEventRequest{
+		TrackingId:   string(req.TrackingID),
+		Type:         req.Type,
+		Location:     string(req.Location),
+		VoyageNumber: string(req.VoyageNumber),
+		Completed:    optionalTimestamp(req.Completed),
+	}, nil
+}
+
+func decodeGRPCRegisterHandlingEventResponse(ctx context.Context, grpcReply interface{}) (interface{}, error) {
+	reply, ok := grpcReply.(*pb.RegisterHandlingEventResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to *pb.RegisterHandlingEventResponse")
+	}
+
+	return endpoints.RegisterHandlingEventResponse{
+		Error: str2err(reply.Error),
+	}, nil
+}
+
+// register container handling event
+func encodeGRPCRegisterContainerHandlingEventRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(endpoints.RegisterContainerHandlingEventRequest)
+	if !ok {
+		return nil, errors.New("failed to convert request to endpoints.RegisterContainerHandlingEventRequest")
+	}
+
+	return &pb.RegisterContainerHandlingEventRequest{
+		ContainerId:  string(req.ContainerID),
+		Type:         req.Type,
+		Location:     string(req.Location),
+		VoyageNumber: string(req.VoyageNumber),
+		Completed:    optionalTimestamp(req.Completed),
+	}, nil
+}
+
+func decodeGRPCRegisterContainerHandlingEventResponse(ctx context.Context, grpcReply interface{}) (interface{}, error) {
+	reply, ok := grpcReply.(*pb.RegisterContainerHandlingEventResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to *pb.RegisterContainerHandlingEventResponse")
+	}
+
+	var ids []cargo.TrackingID
+	for _, id := range reply.TrackingIds {
+		ids = append(ids, cargo.TrackingID(id))
+	}
+
+	return endpoints.RegisterContainerHandlingEventResponse{
+		TrackingIDs: ids,
+		Error:       str2err(reply.Error),
+	}, nil
+}
+
+// ingest edifact
+func encodeGRPCIngestEdifactRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(endpoints.IngestEdifactRequest)
+	if !ok {
+		return nil, errors.New("failed to convert request to endpoints.IngestEdifactRequest")
+	}
+
+	return &pb.IngestEdifactRequest{Interchange: req.Interchange}, nil
+}
+
+func decodeGRPCIngestEdifactResponse(ctx context.Context, grpcReply interface{}) (interface{}, error) {
+	reply, ok := grpcReply.(*pb.IngestEdifactResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to *pb.IngestEdifactResponse")
+	}
+
+	report := services.IngestionReport{
+		Interchange: reply.GetInterchange(),
+		Sender:      reply.GetSender(),
+	}
+	for _, r := range reply.GetResults() {
+		report.Results = append(report.Results, services.MessageResult{
+			Reference: r.GetReference(),
+			Type:      r.GetType(),
+			Events:    int(r.GetEvents()),
+			Err:       str2err(r.GetError()),
+		})
+	}
+
+	return endpoints.IngestEdifactResponse{
+		Report: report,
+		Error:  str2err(reply.GetError()),
+	}, nil
+}
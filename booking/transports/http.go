@@ -3,148 +3,71 @@ package transports
 import (
 	"context"
 	"encoding/json"
-	"errors"
-	"fmt"
 	"net/http"
 
-	ht "github.com/go-kit/kit/transport/http"
 	"github.com/gorilla/mux"
 	"github.com/mproyyan/grpc-shipping-microservice/booking/endpoints"
 	"github.com/mproyyan/grpc-shipping-microservice/booking/services"
 	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/pb"
+	"github.com/mproyyan/grpc-shipping-microservice/pricing"
+	"github.com/mproyyan/grpc-shipping-microservice/validation"
 )
 
-var errBadRoute = errors.New("bad route")
-
-func NewHttpHandler(ep endpoints.Set) http.Handler {
-	bookNewCargoHandler := ht.NewServer(
-		ep.BookNewCargoEndpoint,
-		decodeHttpBookNewCargoRequest,
-		encodeGenericResponse,
-	)
-
-	loadCargoHandler := ht.NewServer(
-		ep.LoadCargoEndpoint,
-		decodeLoadCargoRequest,
-		encodeGenericResponse,
-	)
-
-	assignCargoToRouteHandler := ht.NewServer(
-		ep.AssignCargoToRouteEndpoint,
-		decodeAssignCargoToRouteRequest,
-		encodeGenericResponse,
-	)
-
-	changeDestinationHandler := ht.NewServer(
-		ep.ChangeDestinationEndpoint,
-		decodeChangeDestinationRequest,
-		encodeGenericResponse,
-	)
-
-	listCargoHandler := ht.NewServer(
-		ep.CargosEndpoint,
-		decodeListCargoRequest,
-		encodeGenericResponse,
-	)
-
+// NewHttpHandler serves the REST routes declared by the google.api.http
+// annotations of the Booking service, plus the bulk upload and the export
+// which take and produce CSV, JSON Lines or Parquet rather than protojson.
+func NewHttpHandler(ep endpoints.Set) *mux.Router {
 	r := mux.NewRouter()
-	r.Handle("/booking/cargos", bookNewCargoHandler).Methods("POST")
-	r.Handle("/booking/cargos", listCargoHandler).Methods("GET")
-	r.Handle("/booking/cargos/{id}", loadCargoHandler).Methods("GET")
-	r.Handle("/booking/cargos/{id}/assign_route", assignCargoToRouteHandler).Methods("POST")
-	r.Handle("/booking/cargos/{id}/change_destination", changeDestinationHandler).Methods("POST")
-
+	r.Handle("/booking/cargos:bulk", bulkHandler(ep.BookCargosEndpoint)).Methods("POST")
+	r.Handle("/booking/cargos:export", exportHandler(ep.ExportCargosEndpoint)).Methods("GET")
+	registerREST(r, &pb.Booking_ServiceDesc, NewGRPCServer(ep))
 	return r
 }
 
-// server
-// book new cargo
-func decodeHttpBookNewCargoRequest(_ context.Context, r *http.Request) (interface{}, error) {
-	var req endpoints.BookNewCargoRequest
-	err := json.NewDecoder(r.Body).Decode(&req)
-	return req, err
+// malformedBody reports a body that could not be decoded as a violation of
+// the body itself, so it is answered with 400 instead of 500.
+func malformedBody(err error) error {
+	return validation.Errors{{Field: "body", Description: err.Error()}}
 }
 
-// Load cargo
-func decodeLoadCargoRequest(_ context.Context, r *http.Request) (interface{}, error) {
-	vars := mux.Vars(r)
-	id, ok := vars["id"]
-	if !ok {
-		return nil, errBadRoute
-	}
-
-	return endpoints.LoadCargoRequest{TrackingID: cargo.TrackingID(id)}, nil
-}
-
-// assign cargo to route
-func decodeAssignCargoToRouteRequest(_ context.Context, r *http.Request) (interface{}, error) {
-	vars := mux.Vars(r)
-	id, ok := vars["id"]
-	if !ok {
-		return nil, errBadRoute
-	}
-
-	var itinerary cargo.Itinerary
-	if err := json.NewDecoder(r.Body).Decode(&itinerary); err != nil {
-		return nil, err
-	}
-	fmt.Println(itinerary)
-	return endpoints.AssignCargoToRouteRequest{
-		TrackingID: cargo.TrackingID(id),
-		Itinerary:  itinerary,
-	}, nil
-}
-
-// change destination
-func decodeChangeDestinationRequest(_ context.Context, r *http.Request) (interface{}, error) {
-	vars := mux.Vars(r)
-	id, ok := vars["id"]
-	if !ok {
-		return nil, errBadRoute
-	}
-
-	var req endpoints.ChangeDestinationRequest
-	err := json.NewDecoder(r.Body).Decode(&req)
-	if err != nil {
-		return nil, err
-	}
-
-	req.TrackingID = cargo.TrackingID(id)
-	return req, nil
-}
-
-// list cargo
-func decodeListCargoRequest(_ context.Context, r *http.Request) (interface{}, error) {
-	return endpoints.ListCargosRequest{}, nil
-}
-
-type errorer interface {
-	error() error
-}
-
-func encodeGenericResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
-	fmt.Println(response)
-	if e, ok := response.(errorer); ok && e.error() != nil {
-		encodeError(ctx, e.error(), w)
-		return nil
-	}
-
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	return json.NewEncoder(w).Encode(response)
+// problem is an RFC 7807 problem details body.
+type problem struct {
+	Type          string                      `json:"type"`
+	Title         string                      `json:"title"`
+	Status        int                         `json:"status"`
+	Detail        string                      `json:"detail"`
+	InvalidParams []validation.FieldViolation `json:"invalid-params,omitempty"`
 }
 
 // encode errors from business-logic
 func encodeError(_ context.Context, err error, w http.ResponseWriter) {
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	switch err {
-	case cargo.ErrUnknown:
-		w.WriteHeader(http.StatusNotFound)
-	case services.ErrInvalidArgument:
-		w.WriteHeader(http.StatusBadRequest)
-	default:
-		w.WriteHeader(http.StatusInternalServerError)
+	p := problem{Type: "about:blank", Detail: err.Error()}
+	if violations, ok := validation.FromError(err); ok {
+		p.Status = http.StatusBadRequest
+		p.InvalidParams = violations
+	} else {
+		switch err {
+		case cargo.ErrUnknown, customer.ErrUnknown, pricing.ErrUnknownQuote:
+			p.Status = http.StatusNotFound
+		case services.ErrInvalidArgument:
+			p.Status = http.StatusBadRequest
+		case services.ErrUnauthenticated:
+			p.Status = http.StatusUnauthorized
+		case services.ErrPermissionDenied:
+			p.Status = http.StatusForbidden
+		case cargo.ErrInvalidTransition, cargo.ErrInactive, cargo.ErrOriginFixed, cargo.ErrOverbooked, cargo.ErrRestricted,
+			cargo.ErrOnHold, cargo.ErrNotCleared, cargo.ErrNotHeld,
+			pricing.ErrQuoteExpired, pricing.ErrQuoteAccepted, pricing.ErrQuoteMismatch, pricing.ErrUnknownOption:
+			p.Status = http.StatusConflict
+		default:
+			p.Status = http.StatusInternalServerError
+		}
 	}
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": err.Error(),
-	})
+
+	p.Title = http.StatusText(p.Status)
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
 }
@@ -0,0 +1,149 @@
+package transports
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gorilla/mux"
+	"github.com/mproyyan/grpc-shipping-microservice/validation"
+)
+
+// openAPISpec documents the routes registered by NewHttpHandler. The
+// handler test fails when a route is added to one but not the other.
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+// fileContentTypes are the media types of uploads and downloads. Their
+// bodies are validated as opaque files, upload rows are checked while being
+// parsed.
+var fileContentTypes = []string{"text/csv", "application/x-ndjson", "application/jsonl", "application/vnd.apache.parquet"}
+
+// LoadOpenAPI parses and validates the embedded OpenAPI document. It also
+// registers the body decoders the document relies on, which openapi3filter
+// keeps globally.
+func LoadOpenAPI(ctx context.Context) (*openapi3.T, error) {
+	for _, contentType := range fileContentTypes {
+		openapi3filter.RegisterBodyDecoder(contentType, openapi3filter.FileBodyDecoder)
+	}
+
+	doc, err := openapi3.NewLoader().LoadFromData(openAPISpec)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := doc.Validate(ctx); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// NewOpenAPIHandler serves the OpenAPI document as JSON.
+func NewOpenAPIHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(openAPISpec)
+	})
+}
+
+// NewOpenAPIValidator returns a middleware rejecting requests that do not
+// match doc. Rejected requests are answered like any other validation
+// failure, with every violation listed in the problem details body. Paths
+// unknown to doc are passed through so the router can answer them.
+func NewOpenAPIValidator(doc *openapi3.T) (mux.MiddlewareFunc, error) {
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	options := &openapi3filter.Options{
+		MultiError: true,
+		// Bearer tokens are verified by NewAuthenticator.
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, params, err := router.FindRoute(r)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			err = openapi3filter.ValidateRequest(r.Context(), &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: params,
+				Route:      route,
+				Options:    options,
+			})
+			if err != nil {
+				encodeError(r.Context(), openAPIViolations(err), w)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}, nil
+}
+
+// openAPIViolations flattens the errors reported by openapi3filter into
+// field violations.
+func openAPIViolations(err error) validation.Errors {
+	if reqErr, ok := err.(*openapi3filter.RequestError); ok {
+		return requestViolations(reqErr)
+	}
+
+	var violations validation.Errors
+	if multi, ok := err.(openapi3.MultiError); ok {
+		for _, e := range multi {
+			violations = append(violations, openAPIViolations(e)...)
+		}
+
+		return violations
+	}
+
+	return validation.Errors{{Field: "request", Description: err.Error()}}
+}
+
+func requestViolations(err *openapi3filter.RequestError) validation.Errors {
+	field := "body"
+	if err.Parameter != nil {
+		field = err.Parameter.Name
+	}
+
+	if err.Err == nil {
+		return validation.Errors{{Field: field, Description: err.Reason}}
+	}
+
+	multi, ok := err.Err.(openapi3.MultiError)
+	if !ok {
+		return validation.Errors{schemaViolation(field, err.Err)}
+	}
+
+	var violations validation.Errors
+	for _, e := range multi {
+		violations = append(violations, schemaViolation(field, e))
+	}
+
+	return violations
+}
+
+func schemaViolation(field string, err error) validation.FieldViolation {
+	var schemaErr *openapi3.SchemaError
+	if !errors.As(err, &schemaErr) {
+		return validation.FieldViolation{Field: field, Description: err.Error()}
+	}
+
+	if path := schemaErr.JSONPointer(); len(path) > 0 && field == "body" {
+		field = strings.Join(path, ".")
+	}
+
+	return validation.FieldViolation{Field: field, Description: schemaErr.Reason}
+}
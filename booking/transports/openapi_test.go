@@ -0,0 +1,219 @@
+package transports
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gorilla/mux"
+	"github.com/mproyyan/grpc-shipping-microservice/booking/endpoints"
+	"github.com/mproyyan/grpc-shipping-microservice/booking/services"
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/export"
+	"github.com/mproyyan/grpc-shipping-microservice/pricing"
+	"github.com/stretchr/testify/require"
+)
+
+func stubEndpoints() endpoints.Set {
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := services.Cargo{
+		TrackingID:      "ABC123",
+		CustomerID:      "0F5E2A1C-8C3B-4C1E-9F3A-6B1D2E4F7A90",
+		Origin:          "SESTO",
+		Destination:     "AUMEL",
+		ArrivalDeadline: now,
+		Routed:          true,
+		Status:          cargo.InTransit.String(),
+		Weight:          1200,
+		HSCode:          "090111",
+		Clearance:       cargo.OnHold.String(),
+		HoldReason:      "inspection",
+		QuoteID:         "Q1W2E3R4",
+		Price:           &pricing.Money{Amount: 125005, Currency: "EUR"},
+		Legs: []cargo.Leg{
+			{VoyageNumber: "V100", LoadLocation: "SESTO", UnloadLocation: "AUMEL", LoadTime: now, UnloadTime: now.Add(time.Hour)},
+		},
+	}
+
+	return endpoints.Set{
+		BookNewCargoEndpoint: func(context.Context, interface{}) (interface{}, error) {
+			return endpoints.BookNewCargoResponse{TrackingID: "ABC123"}, nil
+		},
+		LoadCargoEndpoint: func(context.Context, interface{}) (interface{}, error) {
+			return endpoints.LoadCargoResponse{Cargo: c}, nil
+		},
+		AssignCargoToRouteEndpoint: func(context.Context, interface{}) (interface{}, error) {
+			return endpoints.AssignCargoToRouteResponse{Status: "success"}, nil
+		},
+		ChangeDestinationEndpoint: func(context.Context, interface{}) (interface{}, error) {
+			return endpoints.ChangeDestinationResponse{Status: "success"}, nil
+		},
+		UpdateRouteSpecificationEndpoint: func(context.Context, interface{}) (interface{}, error) {
+			return endpoints.UpdateRouteSpecificationResponse{ItinerarySatisfied: true}, nil
+		},
+		CancelBookingEndpoint: func(context.Context, interface{}) (interface{}, error) {
+			return endpoints.CancelBookingResponse{Status: "success"}, nil
+		},
+		HoldCargoEndpoint: func(context.Context, interface{}) (interface{}, error) {
+			return endpoints.HoldCargoResponse{Status: "success"}, nil
+		},
+		ReleaseCargoEndpoint: func(context.Context, interface{}) (interface{}, error) {
+			return endpoints.ReleaseCargoResponse{Status: "success"}, nil
+		},
+		HandlingHistoryEndpoint: func(context.Context, interface{}) (interface{}, error) {
+			return endpoints.HandlingHistoryResponse{Events: []services.HandlingEvent{
+				{Type: cargo.Receive.String(), Location: "SESTO", LocationName: "Stockholm", Completed: now, Expected: true},
+				{Type: cargo.Load.String(), Location: "NLRTM", VoyageNumber: "V100", Completed: now.Add(time.Hour)},
+			}}, nil
+		},
+		CargosEndpoint: func(context.Context, interface{}) (interface{}, error) {
+			return endpoints.ListCargosResponse{Cargos: []services.Cargo{c}}, nil
+		},
+		ExportCargosEndpoint: func(_ context.Context, request interface{}) (interface{}, error) {
+			err := request.(endpoints.ExportCargosRequest).Yield(export.Record{
+				TrackingID:      c.TrackingID,
+				Origin:          c.Origin,
+				Destination:     c.Destination,
+				ArrivalDeadline: c.ArrivalDeadline,
+			})
+			return endpoints.ExportCargosResponse{Error: err}, nil
+		},
+	}
+}
+
+func loadSpec(t *testing.T) *openapi3.T {
+	t.Helper()
+	doc, err := LoadOpenAPI(context.Background())
+	require.NoError(t, err)
+	return doc
+}
+
+func TestOpenAPIDocumentsEveryRoute(t *testing.T) {
+	doc := loadSpec(t)
+
+	var documented []string
+	for path, item := range doc.Paths {
+		for method := range item.Operations() {
+			documented = append(documented, method+" "+path)
+		}
+	}
+
+	var registered []string
+	err := NewHttpHandler(endpoints.Set{}).Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+
+		methods, err := route.GetMethods()
+		if err != nil {
+			return err
+		}
+
+		for _, method := range methods {
+			registered = append(registered, method+" "+path)
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	sort.Strings(documented)
+	sort.Strings(registered)
+	require.Equal(t, registered, documented)
+}
+
+func TestHandlersConformToOpenAPI(t *testing.T) {
+	doc := loadSpec(t)
+	router, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+
+	handler := NewHttpHandler(stubEndpoints())
+	handler.Use(NewAuthenticator(testTokens))
+
+	tests := []struct {
+		method, path, body string
+	}{
+		{"POST", "/booking/cargos", `{"origin":"SESTO","destination":"AUMEL","deadline":"2030-01-01T00:00:00Z"}`},
+		{"POST", "/booking/cargos", `{"origin":"SESTO","destination":"AUMEL","deadline":"2030-01-01T00:00:00Z","weight":1200,"volume":4.5,"packages":12,"commodity":"Coffee","hs_code":"090111"}`},
+		{"POST", "/booking/cargos", `{"origin":"SESTO","destination":"AUMEL","deadline":"2030-01-01T00:00:00Z","commodity":"Petrol","imdg_class":"3","un_number":"1203"}`},
+		{"GET", "/booking/cargos", ""},
+		{"GET", "/booking/cargos?origin=SESTO&deadline_from=2030-01-01T00:00:00Z", ""},
+		{"GET", "/booking/cargos?exclude_closed=true", ""},
+		{"GET", "/booking/cargos?misdirected=true&late=true", ""},
+		{"GET", "/booking/cargos:export?format=jsonl&history=true&destination=AUMEL", ""},
+		{"GET", "/booking/cargos/ABC123", ""},
+		{"POST", "/booking/cargos/ABC123/assign_route", `{"legs":[{"voyage_number":"V100","load_location":"SESTO","unload_location":"AUMEL","load_time":"2030-01-01T00:00:00Z","unload_time":"2030-01-02T00:00:00Z"}]}`},
+		{"POST", "/booking/cargos/ABC123/change_destination", `{"destination":"CNHKG"}`},
+		{"POST", "/booking/cargos/ABC123/route_specification", `{"arrival_deadline":"2031-01-01T00:00:00Z"}`},
+		{"POST", "/booking/cargos/ABC123/cancel", ""},
+		{"POST", "/booking/cargos/ABC123/hold", `{"reason":"inspection"}`},
+		{"POST", "/booking/cargos/ABC123/release", ""},
+		{"GET", "/booking/cargos/ABC123/history", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			authorize(req, customer.Caller{CustomerID: "0F5E2A1C-8C3B-4C1E-9F3A-6B1D2E4F7A90"})
+
+			route, params, err := router.FindRoute(req)
+			require.NoError(t, err)
+
+			input := &openapi3filter.RequestValidationInput{Request: req, PathParams: params, Route: route, Options: &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc}}
+			require.NoError(t, openapi3filter.ValidateRequest(context.Background(), input))
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+			err = openapi3filter.ValidateResponse(context.Background(), &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: input,
+				Status:                 rec.Code,
+				Header:                 rec.Header(),
+				Body:                   io.NopCloser(rec.Body),
+			})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestOpenAPIValidatorRejectsInvalidRequests(t *testing.T) {
+	validate, err := NewOpenAPIValidator(loadSpec(t))
+	require.NoError(t, err)
+
+	handler := NewHttpHandler(stubEndpoints())
+	handler.Use(validate)
+
+	req := httptest.NewRequest("POST", "/booking/cargos", strings.NewReader(`{"origin":"stockholm","deadline":"tomorrow"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+
+	var p problem
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&p))
+
+	var fields []string
+	for _, v := range p.InvalidParams {
+		fields = append(fields, v.Field)
+	}
+	require.ElementsMatch(t, []string{"origin", "destination", "deadline"}, fields)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/booking/cargos", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+}
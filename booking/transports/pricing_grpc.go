@@ -0,0 +1,113 @@
+package transports
+
+import (
+	"context"
+	"errors"
+
+	gt "github.com/go-kit/kit/transport/grpc"
+	"github.com/mproyyan/grpc-shipping-microservice/booking/endpoints"
+	"github.com/mproyyan/grpc-shipping-microservice/booking/services"
+	"github.com/mproyyan/grpc-shipping-microservice/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type pricingGRPCServer struct {
+	pb.UnimplementedPricingServer
+	quote gt.Handler
+}
+
+func NewPricingGRPCServer(endpoints endpoints.PricingSet) pb.PricingServer {
+	options := []gt.ServerOption{
+		gt.ServerBefore(serverBefore...),
+	}
+
+	return pricingGRPCServer{
+		quote: gt.NewServer(
+			endpoints.QuoteEndpoint,
+			decodeGRPCQuoteRequest,
+			encodeGRPCQuoteResponse,
+			options...,
+		),
+	}
+}
+
+func NewPricingGRPCClient(conn *grpc.ClientConn) services.PricingServiceContract {
+	options := []gt.ClientOption{
+		gt.ClientBefore(clientBefore...),
+	}
+
+	quoteEndpoint := gt.NewClient(
+		conn,
+		"pb.Pricing",
+		"Quote",
+		encodeGRPCQuoteRequest,
+		decodeGRPCQuoteResponse,
+		pb.QuoteResponse{},
+		options...,
+	).Endpoint()
+
+	return endpoints.PricingSet{
+		QuoteEndpoint: quoteEndpoint,
+	}
+}
+
+func (pgs pricingGRPCServer) Quote(ctx context.Context, req *pb.QuoteRequest) (*pb.QuoteResponse, error) {
+	_, resp, err := pgs.quote.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*pb.QuoteResponse), nil
+}
+
+// quote
+func decodeGRPCQuoteRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req, ok := grpcReq.(*pb.QuoteRequest)
+	if !ok {
+		return nil, errors.New("failed to convert grpc request to *pb.QuoteRequest")
+	}
+
+	r := endpoints.QuoteRequest{}
+	return r.Build(req), nil
+}
+
+func encodeGRPCQuoteResponse(ctx context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(endpoints.QuoteResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to endpoints.QuoteResponse")
+	}
+
+	return res.Protobuf(), nil
+}
+
+// pricing client
+// quote
+func encodeGRPCQuoteRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(endpoints.QuoteRequest)
+	if !ok {
+		return nil, errors.New("failed to convert request to endpoints.QuoteRequest")
+	}
+
+	return &pb.QuoteRequest{
+		Origin:          string(req.Origin),
+		Destination:     string(req.Destination),
+		ArrivalDeadline: timestamppb.New(req.ArrivalDeadline),
+		Weight:          req.Attributes.Weight,
+		Volume:          req.Attributes.Volume,
+		ImdgClass:       string(req.Attributes.DangerousGoods.Class),
+		UnNumber:        string(req.Attributes.DangerousGoods.UNNumber),
+	}, nil
+}
+
+func decodeGRPCQuoteResponse(ctx context.Context, grpcReply interface{}) (interface{}, error) {
+	reply, ok := grpcReply.(*pb.QuoteResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to *pb.QuoteResponse")
+	}
+
+	return endpoints.QuoteResponse{
+		Quote: endpoints.QuoteFromProto(reply.GetQuote()),
+		Error: str2err(reply.Error),
+	}, nil
+}
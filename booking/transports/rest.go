@@ -0,0 +1,292 @@
+package transports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/mproyyan/grpc-shipping-microservice/logging"
+	"github.com/mproyyan/grpc-shipping-microservice/validation"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// The REST surface is derived from the google.api.http annotations of a gRPC
+// service. Requests are transcoded to the RPC's request message and served
+// by the same handler gRPC clients reach, so there is a single mapping from
+// the wire format to domain types and protojson is the canonical JSON.
+
+var (
+	restUnmarshal = protojson.UnmarshalOptions{}
+	restMarshal   = protojson.MarshalOptions{UseProtoNames: true}
+)
+
+// pathParam matches the variables of an annotation path template, e.g.
+// "{tracking_id}" in "/booking/cargos/{tracking_id}".
+var pathParam = regexp.MustCompile(`\{([a-z_.]+)\}`)
+
+// registerREST adds a route to r for every method of service that carries a
+// google.api.http annotation. srv is the implementation registered for
+// service on the gRPC server. It panics on an invalid annotation, those are
+// fixed at compile time of the proto.
+func registerREST(r *mux.Router, service *grpc.ServiceDesc, srv interface{}) {
+	d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(service.ServiceName))
+	if err != nil {
+		panic(fmt.Sprintf("transports: %v", err))
+	}
+
+	methods := d.(protoreflect.ServiceDescriptor).Methods()
+	for _, m := range service.Methods {
+		md := methods.ByName(protoreflect.Name(m.MethodName))
+		if md == nil {
+			panic(fmt.Sprintf("transports: no descriptor for %s/%s", service.ServiceName, m.MethodName))
+		}
+
+		rule, _ := proto.GetExtension(md.Options(), annotations.E_Http).(*annotations.HttpRule)
+		if rule == nil {
+			continue
+		}
+
+		for _, binding := range append([]*annotations.HttpRule{rule}, rule.GetAdditionalBindings()...) {
+			method, path := httpPattern(binding)
+			if method == "" {
+				panic(fmt.Sprintf("transports: unsupported http rule on %s", md.FullName()))
+			}
+
+			h := restHandler{srv: srv, method: m, rule: binding, params: pathParam.FindAllStringSubmatch(path, -1)}
+			r.Handle(path, h).Methods(method)
+		}
+	}
+}
+
+func httpPattern(rule *annotations.HttpRule) (method, path string) {
+	switch p := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		return http.MethodGet, p.Get
+	case *annotations.HttpRule_Post:
+		return http.MethodPost, p.Post
+	case *annotations.HttpRule_Put:
+		return http.MethodPut, p.Put
+	case *annotations.HttpRule_Delete:
+		return http.MethodDelete, p.Delete
+	case *annotations.HttpRule_Patch:
+		return http.MethodPatch, p.Patch
+	case *annotations.HttpRule_Custom:
+		return p.Custom.GetKind(), p.Custom.GetPath()
+	}
+
+	return "", ""
+}
+
+type restHandler struct {
+	srv    interface{}
+	method grpc.MethodDesc
+	rule   *annotations.HttpRule
+	params [][]string
+}
+
+func (h restHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := headersToContext(w, r)
+	resp, err := h.method.Handler(h.srv, ctx, func(in interface{}) error {
+		return h.decode(r, in.(proto.Message))
+	}, nil)
+	if err != nil {
+		encodeError(ctx, err, w)
+		return
+	}
+
+	out := resp.(proto.Message).ProtoReflect()
+	if fd := out.Descriptor().Fields().ByName("error"); fd != nil && fd.Kind() == protoreflect.StringKind {
+		if s := out.Get(fd).String(); s != "" {
+			encodeError(ctx, str2err(s), w)
+			return
+		}
+	}
+
+	if name := h.rule.GetResponseBody(); name != "" {
+		out = out.Get(out.Descriptor().Fields().ByName(protoreflect.Name(name))).Message()
+	}
+
+	b, err := restMarshal.Marshal(out.Interface())
+	if err != nil {
+		encodeError(ctx, err, w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Write(b)
+}
+
+// restHeaders are the request headers passed on to the gRPC handlers as
+// incoming metadata, like a gRPC client would send them. Anything else a
+// client sends is dropped: the caller comes from the context the gateway
+// authenticated, the trace context from the span the gateway started and
+// the request ID from the logging hooks.
+var restHeaders = []string{"User-Agent"}
+
+// headersToContext turns the allowed request headers into incoming gRPC
+// metadata, so the gRPC server hooks read the request ID as usual. The
+// request ID is echoed back to the client.
+func headersToContext(w http.ResponseWriter, r *http.Request) context.Context {
+	ctx := logging.HTTPToContext(r.Context(), r)
+	logging.ContextToHTTPResponse(ctx, w)
+
+	md := metadata.MD{}
+	for _, name := range restHeaders {
+		if values := r.Header.Values(name); len(values) > 0 {
+			md.Append(strings.ToLower(name), values...)
+		}
+	}
+	logging.GRPCClientBefore(ctx, &md)
+
+	return metadata.NewIncomingContext(ctx, md)
+}
+
+// decode fills in from the request body, the path variables and, for the
+// fields not bound to either, the query string.
+func (h restHandler) decode(r *http.Request, in proto.Message) error {
+	msg := in.ProtoReflect()
+	bound := map[string]bool{}
+
+	if body := h.rule.GetBody(); body != "" {
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			return malformedBody(err)
+		}
+
+		target := msg
+		if body != "*" {
+			fd := msg.Descriptor().Fields().ByName(protoreflect.Name(body))
+			if fd == nil || fd.Message() == nil {
+				return fmt.Errorf("transports: body field %q is not a message", body)
+			}
+			target = msg.Mutable(fd).Message()
+			bound[body] = true
+		}
+
+		if len(b) > 0 {
+			if err := restUnmarshal.Unmarshal(b, target.Interface()); err != nil {
+				return malformedBody(err)
+			}
+		}
+	}
+
+	vars := mux.Vars(r)
+	for _, p := range h.params {
+		if err := setField(msg, p[1], []string{vars[p[1]]}); err != nil {
+			return err
+		}
+		bound[p[1]] = true
+	}
+
+	if h.rule.GetBody() == "*" {
+		return nil
+	}
+
+	for name, values := range r.URL.Query() {
+		if bound[name] {
+			continue
+		}
+
+		if err := setField(msg, name, values); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setField assigns values to the scalar or timestamp field at the dot
+// separated path.
+func setField(msg protoreflect.Message, path string, values []string) error {
+	names := strings.Split(path, ".")
+	for _, name := range names[:len(names)-1] {
+		fd := msg.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if fd == nil || fd.Message() == nil || fd.IsList() {
+			return validation.Errors{{Field: path, Description: "is not a known parameter"}}
+		}
+		msg = msg.Mutable(fd).Message()
+	}
+
+	fd := msg.Descriptor().Fields().ByName(protoreflect.Name(names[len(names)-1]))
+	if fd == nil || fd.IsMap() || (fd.Message() != nil && fd.Message().FullName() != timestampName) {
+		return validation.Errors{{Field: path, Description: "is not a known parameter"}}
+	}
+
+	if !fd.IsList() {
+		values = values[len(values)-1:]
+	}
+
+	for _, s := range values {
+		v, err := scalarValue(fd, s)
+		if err != nil {
+			return validation.Errors{{Field: path, Description: err.Error()}}
+		}
+
+		if fd.IsList() {
+			msg.Mutable(fd).List().Append(v)
+		} else {
+			msg.Set(fd, v)
+		}
+	}
+
+	return nil
+}
+
+// timestampName is the one message type accepted as a parameter, written as
+// an RFC 3339 timestamp like in protojson.
+const timestampName = "google.protobuf.Timestamp"
+
+func scalarValue(fd protoreflect.FieldDescriptor, s string) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.MessageKind:
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return protoreflect.Value{}, errors.New("must be an RFC 3339 timestamp")
+		}
+		return protoreflect.ValueOfMessage(timestamppb.New(t).ProtoReflect()), nil
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(s), nil
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(s)
+		return protoreflect.ValueOfBool(b), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		n, err := strconv.ParseInt(s, 10, 32)
+		return protoreflect.ValueOfInt32(int32(n)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n, err := strconv.ParseInt(s, 10, 64)
+		return protoreflect.ValueOfInt64(n), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		n, err := strconv.ParseUint(s, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(n)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		n, err := strconv.ParseUint(s, 10, 64)
+		return protoreflect.ValueOfUint64(n), err
+	case protoreflect.DoubleKind:
+		f, err := strconv.ParseFloat(s, 64)
+		return protoreflect.ValueOfFloat64(f), err
+	case protoreflect.FloatKind:
+		f, err := strconv.ParseFloat(s, 32)
+		return protoreflect.ValueOfFloat32(float32(f)), err
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByName(protoreflect.Name(s)); ev != nil {
+			return protoreflect.ValueOfEnum(ev.Number()), nil
+		}
+		return protoreflect.Value{}, fmt.Errorf("must be one of the %s values", fd.Enum().Name())
+	}
+
+	return protoreflect.Value{}, fmt.Errorf("unsupported parameter type %s", fd.Kind())
+}
@@ -0,0 +1,129 @@
+package transports
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mproyyan/grpc-shipping-microservice/booking/endpoints"
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/logging"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestRESTTranscodesRequests(t *testing.T) {
+	var (
+		got    endpoints.AssignCargoToRouteRequest
+		caller customer.Caller
+		reqID  string
+	)
+
+	ep := stubEndpoints()
+	ep.AssignCargoToRouteEndpoint = func(ctx context.Context, request interface{}) (interface{}, error) {
+		got = request.(endpoints.AssignCargoToRouteRequest)
+		caller, _ = customer.FromContext(ctx)
+		reqID = logging.RequestID(ctx)
+		return endpoints.AssignCargoToRouteResponse{Status: "success"}, nil
+	}
+
+	body := `{"id":"7","legs":[{"voyage_number":"V100","load_location":"SESTO","unload_location":"AUMEL","load_time":"2030-01-01T00:00:00Z","unload_time":"2030-01-02T00:00:00Z"}]}`
+	req := httptest.NewRequest("POST", "/booking/cargos/ABC123/assign_route", strings.NewReader(body))
+	authorize(req, customer.Caller{CustomerID: "acme"})
+	req.Header.Set("X-Staff", "true")
+	req.Header.Set("X-Request-ID", "req-1")
+
+	handler := NewHttpHandler(ep)
+	handler.Use(NewAuthenticator(testTokens))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.Equal(t, "req-1", rec.Header().Get("X-Request-ID"))
+
+	require.Equal(t, cargo.TrackingID("ABC123"), got.TrackingID)
+	require.Equal(t, int64(7), got.Itinerary.ID)
+	require.Len(t, got.Itinerary.Legs, 1)
+	require.EqualValues(t, "AUMEL", got.Itinerary.Legs[0].UnloadLocation)
+	require.Equal(t, customer.Caller{CustomerID: "acme"}, caller)
+	require.Equal(t, "req-1", reqID)
+}
+
+func TestRESTKeepsGatewaySpan(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	var got trace.SpanContext
+	ep := stubEndpoints()
+	ep.LoadCargoEndpoint = func(ctx context.Context, request interface{}) (interface{}, error) {
+		got = trace.SpanContextFromContext(ctx)
+		return endpoints.LoadCargoResponse{}, nil
+	}
+
+	gateway := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x0a},
+		SpanID:     trace.SpanID{0x0b},
+		TraceFlags: trace.FlagsSampled,
+	})
+	req := httptest.NewRequest("GET", "/booking/cargos/ABC123", nil)
+	req.Header.Set("traceparent", "00-01020300000000000000000000000000-0405000000000000-01")
+	req = req.WithContext(trace.ContextWithSpanContext(req.Context(), gateway))
+
+	rec := httptest.NewRecorder()
+	NewHttpHandler(ep).ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.Equal(t, gateway, got)
+}
+
+func TestRESTForwardsAllowedHeaders(t *testing.T) {
+	req := httptest.NewRequest("GET", "/booking/cargos/ABC123", nil)
+	req.Header.Set("User-Agent", "curl/8.0")
+	req.Header.Set("X-Request-ID", "req-1")
+	req.Header.Set("X-Staff", "true")
+	req.Header.Set("X-Customer-ID", "acme")
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("Traceparent", "00-01020300000000000000000000000000-0405000000000000-01")
+
+	md, _ := metadata.FromIncomingContext(headersToContext(httptest.NewRecorder(), req))
+	require.Equal(t, metadata.Pairs("user-agent", "curl/8.0", "x-request-id", "req-1"), md)
+}
+
+func TestRESTEncodesCanonicalJSON(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewHttpHandler(stubEndpoints()).ServeHTTP(rec, httptest.NewRequest("GET", "/booking/cargos/ABC123", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `"load_location":"SESTO"`)
+	require.Contains(t, rec.Body.String(), `"arrival_deadline":"2030-01-01T00:00:00Z"`)
+	require.NotContains(t, rec.Body.String(), `"error"`)
+}
+
+func TestRESTReportsErrors(t *testing.T) {
+	ep := stubEndpoints()
+	ep.LoadCargoEndpoint = func(context.Context, interface{}) (interface{}, error) {
+		return endpoints.LoadCargoResponse{Error: cargo.ErrUnknown}, nil
+	}
+	ep.CancelBookingEndpoint = func(context.Context, interface{}) (interface{}, error) {
+		return endpoints.CancelBookingResponse{Error: cargo.ErrInvalidTransition}, nil
+	}
+	handler := NewHttpHandler(ep)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/booking/cargos/ABC123", nil))
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("POST", "/booking/cargos/ABC123/cancel", nil))
+	require.Equal(t, http.StatusConflict, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("POST", "/booking/cargos", strings.NewReader(`{"origin":`)))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), `"name":"body"`)
+}
@@ -0,0 +1,396 @@
+package transports
+
+import (
+	"context"
+	"errors"
+
+	gt "github.com/go-kit/kit/transport/grpc"
+	"github.com/mproyyan/grpc-shipping-microservice/booking/endpoints"
+	"github.com/mproyyan/grpc-shipping-microservice/booking/services"
+	"github.com/mproyyan/grpc-shipping-microservice/pb"
+	"github.com/mproyyan/grpc-shipping-microservice/shipment"
+	"google.golang.org/grpc"
+)
+
+type shipmentGRPCServer struct {
+	pb.UnimplementedShipmentServer
+	createShipment        gt.Handler
+	loadShipment          gt.Handler
+	stuffCargo            gt.Handler
+	unstuffCargo          gt.Handler
+	assignShipmentToRoute gt.Handler
+}
+
+func NewShipmentGRPCServer(endpoints endpoints.ShipmentSet) pb.ShipmentServer {
+	options := []gt.ServerOption{
+		gt.ServerBefore(serverBefore...),
+	}
+
+	return shipmentGRPCServer{
+		createShipment: gt.NewServer(
+			endpoints.CreateShipmentEndpoint,
+			decodeGRPCCreateShipmentRequest,
+			encodeGRPCCreateShipmentResponse,
+			options...,
+		),
+		loadShipment: gt.NewServer(
+			endpoints.LoadShipmentEndpoint,
+			decodeGRPCLoadShipmentRequest,
+			encodeGRPCLoadShipmentResponse,
+			options...,
+		),
+		stuffCargo: gt.NewServer(
+			endpoints.StuffCargoEndpoint,
+			decodeGRPCStuffCargoRequest,
+			encodeGRPCStuffCargoResponse,
+			options...,
+		),
+		unstuffCargo: gt.NewServer(
+			endpoints.UnstuffCargoEndpoint,
+			decodeGRPCUnstuffCargoRequest,
+			encodeGRPCUnstuffCargoResponse,
+			options...,
+		),
+		assignShipmentToRoute: gt.NewServer(
+			endpoints.AssignShipmentToRouteEndpoint,
+			decodeGRPCAssignShipmentToRouteRequest,
+			encodeGRPCAssignShipmentToRouteResponse,
+			options...,
+		),
+	}
+}
+
+func NewShipmentGRPCClient(conn *grpc.ClientConn) services.ShipmentServiceContract {
+	options := []gt.ClientOption{
+		gt.ClientBefore(clientBefore...),
+	}
+
+	createShipmentEndpoint := gt.NewClient(
+		conn,
+		"pb.Shipment",
+		"CreateShipment",
+		encodeGRPCCreateShipmentRequest,
+		decodeGRPCCreateShipmentResponse,
+		pb.CreateShipmentResponse{},
+		options...,
+	).Endpoint()
+
+	loadShipmentEndpoint := gt.NewClient(
+		conn,
+		"pb.Shipment",
+		"LoadShipment",
+		encodeGRPCLoadShipmentRequest,
+		decodeGRPCLoadShipmentResponse,
+		pb.LoadShipmentResponse{},
+		options...,
+	).Endpoint()
+
+	stuffCargoEndpoint := gt.NewClient(
+		conn,
+		"pb.Shipment",
+		"StuffCargo",
+		encodeGRPCStuffCargoRequest,
+		decodeGRPCStuffCargoResponse,
+		pb.StuffCargoResponse{},
+		options...,
+	).Endpoint()
+
+	unstuffCargoEndpoint := gt.NewClient(
+		conn,
+		"pb.Shipment",
+		"UnstuffCargo",
+		encodeGRPCUnstuffCargoRequest,
+		decodeGRPCUnstuffCargoResponse,
+		pb.UnstuffCargoResponse{},
+		options...,
+	).Endpoint()
+
+	assignShipmentToRouteEndpoint := gt.NewClient(
+		conn,
+		"pb.Shipment",
+		"AssignShipmentToRoute",
+		encodeGRPCAssignShipmentToRouteRequest,
+		decodeGRPCAssignShipmentToRouteResponse,
+		pb.AssignShipmentToRouteResponse{},
+		options...,
+	).Endpoint()
+
+	return endpoints.ShipmentSet{
+		CreateShipmentEndpoint:        createShipmentEndpoint,
+		LoadShipmentEndpoint:          loadShipmentEndpoint,
+		StuffCargoEndpoint:            stuffCargoEndpoint,
+		UnstuffCargoEndpoint:          unstuffCargoEndpoint,
+		AssignShipmentToRouteEndpoint: assignShipmentToRouteEndpoint,
+	}
+}
+
+func (sgs shipmentGRPCServer) CreateShipment(ctx context.Context, req *pb.CreateShipmentRequest) (*pb.CreateShipmentResponse, error) {
+	_, resp, err := sgs.createShipment.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*pb.CreateShipmentResponse), nil
+}
+
+func (sgs shipmentGRPCServer) LoadShipment(ctx context.Context, req *pb.LoadShipmentRequest) (*pb.LoadShipmentResponse, error) {
+	_, resp, err := sgs.loadShipment.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*pb.LoadShipmentResponse), nil
+}
+
+func (sgs shipmentGRPCServer) StuffCargo(ctx context.Context, req *pb.StuffCargoRequest) (*pb.StuffCargoResponse, error) {
+	_, resp, err := sgs.stuffCargo.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*pb.StuffCargoResponse), nil
+}
+
+func (sgs shipmentGRPCServer) UnstuffCargo(ctx context.Context, req *pb.UnstuffCargoRequest) (*pb.UnstuffCargoResponse, error) {
+	_, resp, err := sgs.unstuffCargo.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*pb.UnstuffCargoResponse), nil
+}
+
+func (sgs shipmentGRPCServer) AssignShipmentToRoute(ctx context.Context, req *pb.AssignShipmentToRouteRequest) (*pb.AssignShipmentToRouteResponse, error) {
+	_, resp, err := sgs.assignShipmentToRoute.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*pb.AssignShipmentToRouteResponse), nil
+}
+
+// shipment server
+// create shipment
+func decodeGRPCCreateShipmentRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req, ok := grpcReq.(*pb.CreateShipmentRequest)
+	if !ok {
+		return nil, errors.New("failed to convert grpc request to *pb.CreateShipmentRequest")
+	}
+
+	r := endpoints.CreateShipmentRequest{}
+	return r.Build(req), nil
+}
+
+func encodeGRPCCreateShipmentResponse(ctx context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(endpoints.CreateShipmentResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to endpoints.CreateShipmentResponse")
+	}
+
+	return res.Protobuf(), nil
+}
+
+// load shipment
+func decodeGRPCLoadShipmentRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req, ok := grpcReq.(*pb.LoadShipmentRequest)
+	if !ok {
+		return nil, errors.New("failed to convert grpc request to *pb.LoadShipmentRequest")
+	}
+
+	r := endpoints.LoadShipmentRequest{}
+	return r.Build(req), nil
+}
+
+func encodeGRPCLoadShipmentResponse(ctx context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(endpoints.LoadShipmentResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to endpoints.LoadShipmentResponse")
+	}
+
+	return res.Protobuf(), nil
+}
+
+// stuff cargo
+func decodeGRPCStuffCargoRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req, ok := grpcReq.(*pb.StuffCargoRequest)
+	if !ok {
+		return nil, errors.New("failed to convert grpc request to *pb.StuffCargoRequest")
+	}
+
+	r := endpoints.StuffCargoRequest{}
+	return r.Build(req), nil
+}
+
+func encodeGRPCStuffCargoResponse(ctx context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(endpoints.StuffCargoResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to endpoints.StuffCargoResponse")
+	}
+
+	return res.Protobuf(), nil
+}
+
+// unstuff cargo
+func decodeGRPCUnstuffCargoRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req, ok := grpcReq.(*pb.UnstuffCargoRequest)
+	if !ok {
+		return nil, errors.New("failed to convert grpc request to *pb.UnstuffCargoRequest")
+	}
+
+	r := endpoints.UnstuffCargoRequest{}
+	return r.Build(req), nil
+}
+
+func encodeGRPCUnstuffCargoResponse(ctx context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(endpoints.UnstuffCargoResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to endpoints.UnstuffCargoResponse")
+	}
+
+	return res.Protobuf(), nil
+}
+
+// assign shipment to route
+func decodeGRPCAssignShipmentToRouteRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req, ok := grpcReq.(*pb.AssignShipmentToRouteRequest)
+	if !ok {
+		return nil, errors.New("failed to convert grpc request to *pb.AssignShipmentToRouteRequest")
+	}
+
+	r := endpoints.AssignShipmentToRouteRequest{}
+	return r.Build(req), nil
+}
+
+func encodeGRPCAssignShipmentToRouteResponse(ctx context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(endpoints.AssignShipmentToRouteResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to endpoints.AssignShipmentToRouteResponse")
+	}
+
+	return res.Protobuf(), nil
+}
+
+// shipment client
+// create shipment
+func encodeGRPCCreateShipmentRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(endpoints.CreateShipmentRequest)
+	if !ok {
+		return nil, errors.New("failed to convert request to endpoints.CreateShipmentRequest")
+	}
+
+	return &pb.CreateShipmentRequest{
+		Origin:      string(req.Origin),
+		Destination: string(req.Destination),
+		Containers:  endpoints.ContainersToProto(req.Containers),
+	}, nil
+}
+
+func decodeGRPCCreateShipmentResponse(ctx context.Context, grpcReply interface{}) (interface{}, error) {
+	reply, ok := grpcReply.(*pb.CreateShipmentResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to *pb.CreateShipmentResponse")
+	}
+
+	return endpoints.CreateShipmentResponse{
+		ShipmentID: shipment.ID(reply.ShipmentId),
+		Error:      str2err(reply.Error),
+	}, nil
+}
+
+// load shipment
+func encodeGRPCLoadShipmentRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(endpoints.LoadShipmentRequest)
+	if !ok {
+		return nil, errors.New("failed to convert request to endpoints.LoadShipmentRequest")
+	}
+
+	return &pb.LoadShipmentRequest{
+		ShipmentId: string(req.ShipmentID),
+	}, nil
+}
+
+func decodeGRPCLoadShipmentResponse(ctx context.Context, grpcReply interface{}) (interface{}, error) {
+	reply, ok := grpcReply.(*pb.LoadShipmentResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to *pb.LoadShipmentResponse")
+	}
+
+	return endpoints.LoadShipmentResponse{
+		Shipment: endpoints.ShipmentFromProto(reply.Shipment),
+		Error:    str2err(reply.Error),
+	}, nil
+}
+
+// stuff cargo
+func encodeGRPCStuffCargoRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(endpoints.StuffCargoRequest)
+	if !ok {
+		return nil, errors.New("failed to convert request to endpoints.StuffCargoRequest")
+	}
+
+	return &pb.StuffCargoRequest{
+		ContainerId: string(req.ContainerID),
+		TrackingId:  string(req.TrackingID),
+		Weight:      req.Weight,
+		Volume:      req.Volume,
+	}, nil
+}
+
+func decodeGRPCStuffCargoResponse(ctx context.Context, grpcReply interface{}) (interface{}, error) {
+	reply, ok := grpcReply.(*pb.StuffCargoResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to *pb.StuffCargoResponse")
+	}
+
+	return endpoints.StuffCargoResponse{
+		Error: str2err(reply.Error),
+	}, nil
+}
+
+// unstuff cargo
+func encodeGRPCUnstuffCargoRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(endpoints.UnstuffCargoRequest)
+	if !ok {
+		return nil, errors.New("failed to convert request to endpoints.UnstuffCargoRequest")
+	}
+
+	return &pb.UnstuffCargoRequest{
+		ContainerId: string(req.ContainerID),
+		TrackingId:  string(req.TrackingID),
+	}, nil
+}
+
+func decodeGRPCUnstuffCargoResponse(ctx context.Context, grpcReply interface{}) (interface{}, error) {
+	reply, ok := grpcReply.(*pb.UnstuffCargoResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to *pb.UnstuffCargoResponse")
+	}
+
+	return endpoints.UnstuffCargoResponse{
+		Error: str2err(reply.Error),
+	}, nil
+}
+
+// assign shipment to route
+func encodeGRPCAssignShipmentToRouteRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(endpoints.AssignShipmentToRouteRequest)
+	if !ok {
+		return nil, errors.New("failed to convert request to endpoints.AssignShipmentToRouteRequest")
+	}
+
+	return &pb.AssignShipmentToRouteRequest{
+		ShipmentId: string(req.ShipmentID),
+		Legs:       endpoints.LegsToProto(req.Legs),
+	}, nil
+}
+
+func decodeGRPCAssignShipmentToRouteResponse(ctx context.Context, grpcReply interface{}) (interface{}, error) {
+	reply, ok := grpcReply.(*pb.AssignShipmentToRouteResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to *pb.AssignShipmentToRouteResponse")
+	}
+
+	return endpoints.AssignShipmentToRouteResponse{
+		Error: str2err(reply.Error),
+	}, nil
+}
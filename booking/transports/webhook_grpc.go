@@ -0,0 +1,386 @@
+package transports
+
+import (
+	"context"
+	"errors"
+
+	gt "github.com/go-kit/kit/transport/grpc"
+	"github.com/mproyyan/grpc-shipping-microservice/booking/endpoints"
+	"github.com/mproyyan/grpc-shipping-microservice/booking/services"
+	"github.com/mproyyan/grpc-shipping-microservice/pb"
+	"google.golang.org/grpc"
+)
+
+type webhookGRPCServer struct {
+	pb.UnimplementedWebhooksServer
+	createSubscription   gt.Handler
+	listSubscriptions    gt.Handler
+	deleteSubscription   gt.Handler
+	listFailedDeliveries gt.Handler
+	replayDelivery       gt.Handler
+}
+
+func NewWebhookGRPCServer(endpoints endpoints.WebhookSet) pb.WebhooksServer {
+	options := []gt.ServerOption{
+		gt.ServerBefore(serverBefore...),
+	}
+
+	return webhookGRPCServer{
+		createSubscription: gt.NewServer(
+			endpoints.CreateSubscriptionEndpoint,
+			decodeGRPCCreateSubscriptionRequest,
+			encodeGRPCCreateSubscriptionResponse,
+			options...,
+		),
+		listSubscriptions: gt.NewServer(
+			endpoints.ListSubscriptionsEndpoint,
+			decodeGRPCListSubscriptionsRequest,
+			encodeGRPCListSubscriptionsResponse,
+			options...,
+		),
+		deleteSubscription: gt.NewServer(
+			endpoints.DeleteSubscriptionEndpoint,
+			decodeGRPCDeleteSubscriptionRequest,
+			encodeGRPCDeleteSubscriptionResponse,
+			options...,
+		),
+		listFailedDeliveries: gt.NewServer(
+			endpoints.ListFailedDeliveriesEndpoint,
+			decodeGRPCListFailedDeliveriesRequest,
+			encodeGRPCListFailedDeliveriesResponse,
+			options...,
+		),
+		replayDelivery: gt.NewServer(
+			endpoints.ReplayDeliveryEndpoint,
+			decodeGRPCReplayDeliveryRequest,
+			encodeGRPCReplayDeliveryResponse,
+			options...,
+		),
+	}
+}
+
+func NewWebhookGRPCClient(conn *grpc.ClientConn) services.WebhookServiceContract {
+	options := []gt.ClientOption{
+		gt.ClientBefore(clientBefore...),
+	}
+
+	createSubscriptionEndpoint := gt.NewClient(
+		conn,
+		"pb.Webhooks",
+		"CreateSubscription",
+		encodeGRPCCreateSubscriptionRequest,
+		decodeGRPCCreateSubscriptionResponse,
+		pb.CreateSubscriptionResponse{},
+		options...,
+	).Endpoint()
+
+	listSubscriptionsEndpoint := gt.NewClient(
+		conn,
+		"pb.Webhooks",
+		"ListSubscriptions",
+		encodeGRPCListSubscriptionsRequest,
+		decodeGRPCListSubscriptionsResponse,
+		pb.ListSubscriptionsResponse{},
+		options...,
+	).Endpoint()
+
+	deleteSubscriptionEndpoint := gt.NewClient(
+		conn,
+		"pb.Webhooks",
+		"DeleteSubscription",
+		encodeGRPCDeleteSubscriptionRequest,
+		decodeGRPCDeleteSubscriptionResponse,
+		pb.DeleteSubscriptionResponse{},
+		options...,
+	).Endpoint()
+
+	listFailedDeliveriesEndpoint := gt.NewClient(
+		conn,
+		"pb.Webhooks",
+		"ListFailedDeliveries",
+		encodeGRPCListFailedDeliveriesRequest,
+		decodeGRPCListFailedDeliveriesResponse,
+		pb.ListFailedDeliveriesResponse{},
+		options...,
+	).Endpoint()
+
+	replayDeliveryEndpoint := gt.NewClient(
+		conn,
+		"pb.Webhooks",
+		"ReplayDelivery",
+		encodeGRPCReplayDeliveryRequest,
+		decodeGRPCReplayDeliveryResponse,
+		pb.ReplayDeliveryResponse{},
+		options...,
+	).Endpoint()
+
+	return endpoints.WebhookSet{
+		CreateSubscriptionEndpoint:   createSubscriptionEndpoint,
+		ListSubscriptionsEndpoint:    listSubscriptionsEndpoint,
+		DeleteSubscriptionEndpoint:   deleteSubscriptionEndpoint,
+		ListFailedDeliveriesEndpoint: listFailedDeliveriesEndpoint,
+		ReplayDeliveryEndpoint:       replayDeliveryEndpoint,
+	}
+}
+
+func (wgs webhookGRPCServer) CreateSubscription(ctx context.Context, req *pb.CreateSubscriptionRequest) (*pb.CreateSubscriptionResponse, error) {
+	_, resp, err := wgs.createSubscription.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*pb.CreateSubscriptionResponse), nil
+}
+
+func (wgs webhookGRPCServer) ListSubscriptions(ctx context.Context, req *pb.ListSubscriptionsRequest) (*pb.ListSubscriptionsResponse, error) {
+	_, resp, err := wgs.listSubscriptions.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*pb.ListSubscriptionsResponse), nil
+}
+
+func (wgs webhookGRPCServer) DeleteSubscription(ctx context.Context, req *pb.DeleteSubscriptionRequest) (*pb.DeleteSubscriptionResponse, error) {
+	_, resp, err := wgs.deleteSubscription.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*pb.DeleteSubscriptionResponse), nil
+}
+
+func (wgs webhookGRPCServer) ListFailedDeliveries(ctx context.Context, req *pb.ListFailedDeliveriesRequest) (*pb.ListFailedDeliveriesResponse, error) {
+	_, resp, err := wgs.listFailedDeliveries.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*pb.ListFailedDeliveriesResponse), nil
+}
+
+func (wgs webhookGRPCServer) ReplayDelivery(ctx context.Context, req *pb.ReplayDeliveryRequest) (*pb.ReplayDeliveryResponse, error) {
+	_, resp, err := wgs.replayDelivery.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*pb.ReplayDeliveryResponse), nil
+}
+
+// create subscription
+func decodeGRPCCreateSubscriptionRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req, ok := grpcReq.(*pb.CreateSubscriptionRequest)
+	if !ok {
+		return nil, errors.New("failed to convert grpc request to *pb.CreateSubscriptionRequest")
+	}
+
+	r := endpoints.CreateSubscriptionRequest{}
+	return r.Build(req), nil
+}
+
+func encodeGRPCCreateSubscriptionResponse(ctx context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(endpoints.CreateSubscriptionResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to endpoints.CreateSubscriptionResponse")
+	}
+
+	return res.Protobuf(), nil
+}
+
+// list subscriptions
+func decodeGRPCListSubscriptionsRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req, ok := grpcReq.(*pb.ListSubscriptionsRequest)
+	if !ok {
+		return nil, errors.New("failed to convert grpc request to *pb.ListSubscriptionsRequest")
+	}
+
+	r := endpoints.ListSubscriptionsRequest{}
+	return r.Build(req), nil
+}
+
+func encodeGRPCListSubscriptionsResponse(ctx context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(endpoints.ListSubscriptionsResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to endpoints.ListSubscriptionsResponse")
+	}
+
+	return res.Protobuf(), nil
+}
+
+// delete subscription
+func decodeGRPCDeleteSubscriptionRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req, ok := grpcReq.(*pb.DeleteSubscriptionRequest)
+	if !ok {
+		return nil, errors.New("failed to convert grpc request to *pb.DeleteSubscriptionRequest")
+	}
+
+	r := endpoints.DeleteSubscriptionRequest{}
+	return r.Build(req), nil
+}
+
+func encodeGRPCDeleteSubscriptionResponse(ctx context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(endpoints.DeleteSubscriptionResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to endpoints.DeleteSubscriptionResponse")
+	}
+
+	return res.Protobuf(), nil
+}
+
+// list failed deliveries
+func decodeGRPCListFailedDeliveriesRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req, ok := grpcReq.(*pb.ListFailedDeliveriesRequest)
+	if !ok {
+		return nil, errors.New("failed to convert grpc request to *pb.ListFailedDeliveriesRequest")
+	}
+
+	r := endpoints.ListFailedDeliveriesRequest{}
+	return r.Build(req), nil
+}
+
+func encodeGRPCListFailedDeliveriesResponse(ctx context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(endpoints.ListFailedDeliveriesResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to endpoints.ListFailedDeliveriesResponse")
+	}
+
+	return res.Protobuf(), nil
+}
+
+// replay delivery
+func decodeGRPCReplayDeliveryRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req, ok := grpcReq.(*pb.ReplayDeliveryRequest)
+	if !ok {
+		return nil, errors.New("failed to convert grpc request to *pb.ReplayDeliveryRequest")
+	}
+
+	r := endpoints.ReplayDeliveryRequest{}
+	return r.Build(req), nil
+}
+
+func encodeGRPCReplayDeliveryResponse(ctx context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(endpoints.ReplayDeliveryResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to endpoints.ReplayDeliveryResponse")
+	}
+
+	return res.Protobuf(), nil
+}
+
+// webhook client
+// create subscription
+func encodeGRPCCreateSubscriptionRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(endpoints.CreateSubscriptionRequest)
+	if !ok {
+		return nil, errors.New("failed to convert request to endpoints.CreateSubscriptionRequest")
+	}
+
+	return &pb.CreateSubscriptionRequest{
+		CustomerId: string(req.CustomerID),
+		Url:        req.URL,
+		Events:     req.Events,
+		Secret:     req.Secret,
+	}, nil
+}
+
+func decodeGRPCCreateSubscriptionResponse(ctx context.Context, grpcReply interface{}) (interface{}, error) {
+	reply, ok := grpcReply.(*pb.CreateSubscriptionResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to *pb.CreateSubscriptionResponse")
+	}
+
+	return endpoints.CreateSubscriptionResponse{
+		Subscription: endpoints.SubscriptionFromProto(reply.GetSubscription()),
+		Error:        str2err(reply.Error),
+	}, nil
+}
+
+// list subscriptions
+func encodeGRPCListSubscriptionsRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(endpoints.ListSubscriptionsRequest)
+	if !ok {
+		return nil, errors.New("failed to convert request to endpoints.ListSubscriptionsRequest")
+	}
+
+	return &pb.ListSubscriptionsRequest{CustomerId: string(req.CustomerID)}, nil
+}
+
+func decodeGRPCListSubscriptionsResponse(ctx context.Context, grpcReply interface{}) (interface{}, error) {
+	reply, ok := grpcReply.(*pb.ListSubscriptionsResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to *pb.ListSubscriptionsResponse")
+	}
+
+	res := endpoints.ListSubscriptionsResponse{Error: str2err(reply.Error)}
+	for _, s := range reply.GetSubscriptions() {
+		res.Subscriptions = append(res.Subscriptions, endpoints.SubscriptionFromProto(s))
+	}
+
+	return res, nil
+}
+
+// delete subscription
+func encodeGRPCDeleteSubscriptionRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(endpoints.DeleteSubscriptionRequest)
+	if !ok {
+		return nil, errors.New("failed to convert request to endpoints.DeleteSubscriptionRequest")
+	}
+
+	return &pb.DeleteSubscriptionRequest{SubscriptionId: string(req.SubscriptionID)}, nil
+}
+
+func decodeGRPCDeleteSubscriptionResponse(ctx context.Context, grpcReply interface{}) (interface{}, error) {
+	reply, ok := grpcReply.(*pb.DeleteSubscriptionResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to *pb.DeleteSubscriptionResponse")
+	}
+
+	return endpoints.DeleteSubscriptionResponse{Error: str2err(reply.Error)}, nil
+}
+
+// list failed deliveries
+func encodeGRPCListFailedDeliveriesRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(endpoints.ListFailedDeliveriesRequest)
+	if !ok {
+		return nil, errors.New("failed to convert request to endpoints.ListFailedDeliveriesRequest")
+	}
+
+	return &pb.ListFailedDeliveriesRequest{CustomerId: string(req.CustomerID)}, nil
+}
+
+func decodeGRPCListFailedDeliveriesResponse(ctx context.Context, grpcReply interface{}) (interface{}, error) {
+	reply, ok := grpcReply.(*pb.ListFailedDeliveriesResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to *pb.ListFailedDeliveriesResponse")
+	}
+
+	res := endpoints.ListFailedDeliveriesResponse{Error: str2err(reply.Error)}
+	for _, d := range reply.GetDeliveries() {
+		res.Deliveries = append(res.Deliveries, endpoints.WebhookDeliveryFromProto(d))
+	}
+
+	return res, nil
+}
+
+// replay delivery
+func encodeGRPCReplayDeliveryRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(endpoints.ReplayDeliveryRequest)
+	if !ok {
+		return nil, errors.New("failed to convert request to endpoints.ReplayDeliveryRequest")
+	}
+
+	return &pb.ReplayDeliveryRequest{DeliveryId: string(req.DeliveryID)}, nil
+}
+
+func decodeGRPCReplayDeliveryResponse(ctx context.Context, grpcReply interface{}) (interface{}, error) {
+	reply, ok := grpcReply.(*pb.ReplayDeliveryResponse)
+	if !ok {
+		return nil, errors.New("failed to convert response to *pb.ReplayDeliveryResponse")
+	}
+
+	return endpoints.ReplayDeliveryResponse{
+		Delivery: endpoints.WebhookDeliveryFromProto(reply.GetDelivery()),
+		Error:    str2err(reply.Error),
+	}, nil
+}
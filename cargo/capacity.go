@@ -0,0 +1,81 @@
+package cargo
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/mproyyan/grpc-shipping-microservice/imdg"
+	"github.com/mproyyan/grpc-shipping-microservice/voyage"
+)
+
+// ErrOverbooked is returned when routing a cargo would exceed the weight or
+// volume a carrier movement can take.
+var ErrOverbooked = errors.New("itinerary would overbook a carrier movement")
+
+// Attributes are the physical attributes and the commodity of a cargo as
+// declared when it is booked. Weight is in kilograms and volume in cubic
+// metres, zero values are unknown.
+type Attributes struct {
+	Weight         float64
+	Volume         float64
+	Packages       int
+	Commodity      string
+	HSCode         string
+	DangerousGoods imdg.Goods
+}
+
+var hsCodePattern = regexp.MustCompile(`^[0-9]{6}([0-9]{2}){0,2}$`)
+
+// IsValidHSCode checks that s is a Harmonized System code of 6, 8 or 10
+// digits, without separators.
+func IsValidHSCode(s string) bool {
+	return hsCodePattern.MatchString(s)
+}
+
+// CheckCapacity checks that the cargo with the given attributes fits on
+// every carrier movement the itinerary uses, next to the cargos already
+// booked on the same voyages. It returns ErrUnscheduled when a leg is on a
+// voyage missing from voyages or does not match its schedule.
+func CheckCapacity(itinerary Itinerary, attributes Attributes, voyages map[voyage.Number]*voyage.Voyage, booked []*Cargo) error {
+	if err := itinerary.CheckSchedule(voyages); err != nil {
+		return err
+	}
+
+	for _, leg := range itinerary.Legs {
+		v := voyages[leg.VoyageNumber]
+		first, last, _ := v.Schedule.Span(leg.LoadLocation, leg.UnloadLocation)
+
+		for i := first; i <= last; i++ {
+			weight, volume := attributes.Weight, attributes.Volume
+			for _, c := range booked {
+				if c.occupies(v, i) {
+					weight += c.Attributes.Weight
+					volume += c.Attributes.Volume
+				}
+			}
+
+			if !v.Schedule.CarrierMovements[i].Capacity.Fits(weight, volume) {
+				return ErrOverbooked
+			}
+		}
+	}
+
+	return nil
+}
+
+// occupies reports whether the itinerary of the cargo uses the i-th carrier
+// movement of the voyage.
+func (c *Cargo) occupies(v *voyage.Voyage, i int) bool {
+	for _, leg := range c.Itinerary.Legs {
+		if leg.VoyageNumber != v.Number {
+			continue
+		}
+
+		first, last, ok := v.Schedule.Span(leg.LoadLocation, leg.UnloadLocation)
+		if ok && first <= i && i <= last {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,50 @@
+package cargo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/location"
+	"github.com/mproyyan/grpc-shipping-microservice/voyage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidHSCode(t *testing.T) {
+	for _, code := range []string{"090111", "09011100", "0901110000"} {
+		require.True(t, IsValidHSCode(code), code)
+	}
+
+	for _, code := range []string{"", "0901", "0901.11", "0901110", "09011100001"} {
+		require.False(t, IsValidHSCode(code), code)
+	}
+}
+
+func TestCheckCapacity(t *testing.T) {
+	now := time.Now()
+	v := voyage.New("V100", voyage.Schedule{CarrierMovements: []voyage.CarrierMovement{
+		{DepartureLocation: "SESTO", ArrivalLocation: "DEHAM", DepartureTime: now, ArrivalTime: now.Add(time.Hour), Capacity: voyage.Capacity{Weight: 1000, Volume: 10}},
+		{DepartureLocation: "DEHAM", ArrivalLocation: "CNHKG", DepartureTime: now.Add(2 * time.Hour), ArrivalTime: now.Add(3 * time.Hour), Capacity: voyage.Capacity{Weight: 500}},
+	}})
+	voyages := map[voyage.Number]*voyage.Voyage{v.Number: v}
+
+	route := func(from, to location.UNLocode) Itinerary {
+		return Itinerary{Legs: []Leg{{VoyageNumber: "V100", LoadLocation: from, UnloadLocation: to}}}
+	}
+
+	booked := []*Cargo{
+		{TrackingID: "A", Itinerary: route("SESTO", "DEHAM"), Attributes: Attributes{Weight: 600, Volume: 5}},
+		{TrackingID: "B", Itinerary: route("DEHAM", "CNHKG"), Attributes: Attributes{Weight: 300}},
+	}
+
+	require.NoError(t, CheckCapacity(route("SESTO", "DEHAM"), Attributes{Weight: 400, Volume: 5}, voyages, booked))
+	require.Equal(t, ErrOverbooked, CheckCapacity(route("SESTO", "DEHAM"), Attributes{Weight: 400, Volume: 6}, voyages, booked))
+
+	// spans both carrier movements, the second one only has 200 kg left
+	require.Equal(t, ErrOverbooked, CheckCapacity(route("SESTO", "CNHKG"), Attributes{Weight: 250}, voyages, booked))
+	require.NoError(t, CheckCapacity(route("SESTO", "CNHKG"), Attributes{Weight: 200}, voyages, booked))
+
+	// legs on unknown voyages or off the schedule cannot be checked
+	unknown := Itinerary{Legs: []Leg{{VoyageNumber: "V999", LoadLocation: "SESTO", UnloadLocation: "DEHAM"}}}
+	require.Equal(t, ErrUnscheduled, CheckCapacity(unknown, Attributes{Weight: 1}, voyages, booked))
+	require.Equal(t, ErrUnscheduled, CheckCapacity(route("CNHKG", "SESTO"), Attributes{Weight: 1}, voyages, booked))
+}
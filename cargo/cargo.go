@@ -8,13 +8,23 @@ import (
 	"strings"
 	"time"
 
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
 	"github.com/mproyyan/grpc-shipping-microservice/db"
 	"github.com/mproyyan/grpc-shipping-microservice/location"
+	"github.com/mproyyan/grpc-shipping-microservice/voyage"
 	"github.com/pborman/uuid"
 )
 
 var ErrUnknown = errors.New("unknown cargo")
 
+// ErrOriginFixed is returned when changing the origin of a cargo which has
+// already been handled.
+var ErrOriginFixed = errors.New("origin cannot change once the cargo is received")
+
+// ErrSameLocation is returned when a route specification would take a cargo
+// to the location it leaves from.
+var ErrSameLocation = errors.New("destination must differ from origin")
+
 // TrackingID uniquely identifies a particular cargo.
 type TrackingID string
 
@@ -27,31 +37,81 @@ func NextTrackingID() TrackingID {
 // Cargo is the central class in the domain model.
 type Cargo struct {
 	TrackingID         TrackingID
+	CustomerID         customer.ID
 	Origin             location.UNLocode
 	RouteSpecification RouteSpecification
 	Itinerary          Itinerary
 	Delivery           Delivery
+	Status             BookingStatus
+	Attributes         Attributes
+	Clearance          Clearance
 }
 
 // SpecifyNewRoute specifies a new route for this cargo.
 func (c *Cargo) SpecifyNewRoute(rs RouteSpecification) {
 	c.RouteSpecification = rs
-	c.Delivery = c.Delivery.UpdateOnRouting(c.RouteSpecification, c.Itinerary)
+	c.setDelivery(c.Delivery.UpdateOnRouting(c.RouteSpecification, c.Itinerary))
+}
+
+// UpdateRouteSpecification changes the route specification to rs, zero
+// fields of rs keep their current value. The origin can only change as long
+// as the cargo has not been handled, and never to the destination.
+// Customs clearance is derived again for the new destination.
+func (c *Cargo) UpdateRouteSpecification(rs RouteSpecification, history HandlingHistory) error {
+	if !c.Status.Active() {
+		return ErrInactive
+	}
+
+	next := c.RouteSpecification
+	if rs.Origin != "" && rs.Origin != next.Origin {
+		if len(history.HandlingEvents) > 0 {
+			return ErrOriginFixed
+		}
+
+		next.Origin = rs.Origin
+	}
+
+	if rs.Destination != "" {
+		next.Destination = rs.Destination
+	}
+
+	if !rs.ArrivalDeadline.IsZero() {
+		next.ArrivalDeadline = rs.ArrivalDeadline
+	}
+
+	if next.Origin == next.Destination {
+		return ErrSameLocation
+	}
+
+	c.Origin = next.Origin
+	c.RouteSpecification = next
+	c.deriveClearance(history)
+	c.SpecifyNewRoute(next)
+	return nil
 }
 
 // AssignToRoute attaches a new itinerary to this cargo.
 func (c *Cargo) AssignToRoute(itinerary Itinerary) {
 	c.Itinerary = itinerary
-	c.Delivery = c.Delivery.UpdateOnRouting(c.RouteSpecification, c.Itinerary)
+	c.setDelivery(c.Delivery.UpdateOnRouting(c.RouteSpecification, c.Itinerary))
 }
 
 // DeriveDeliveryProgress updates all aspects of the cargo aggregate status
 // based on the current route specification, itinerary and handling of the cargo.
 func (c *Cargo) DeriveDeliveryProgress(history HandlingHistory) {
-	c.Delivery = DeriveDeliveryFrom(c.RouteSpecification, c.Itinerary, history)
+	c.deriveClearance(history)
+	c.setDelivery(DeriveDeliveryFrom(c.RouteSpecification, c.Itinerary, history))
+	c.deriveStatus(history)
 }
 
-// New creates a new, unrouted cargo.
+// setDelivery replaces the delivery snapshot. The snapshot keeps the ID of
+// the one it replaces so it is stored in place rather than as a new row.
+func (c *Cargo) setDelivery(d Delivery) {
+	d.ID = c.Delivery.ID
+	c.Delivery = d.withClearance(c.Clearance)
+}
+
+// New creates a new, unrouted and booked cargo.
 func New(id TrackingID, rs RouteSpecification) *Cargo {
 	itinerary := Itinerary{}
 	history := HandlingHistory{make([]HandlingEvent, 0)}
@@ -67,7 +127,94 @@ func New(id TrackingID, rs RouteSpecification) *Cargo {
 type CargoRepositoryContract interface {
 	Upsert(ctx context.Context, dbtx db.DBTX, cargo *Cargo) (*Cargo, error)
 	Find(ctx context.Context, dbtx db.DBTX, trackingID TrackingID) (*Cargo, error)
+	FindForUpdate(ctx context.Context, dbtx db.DBTX, trackingID TrackingID) (*Cargo, error)
 	FindAll(ctx context.Context, dbtx db.DBTX) ([]*Cargo, error)
+	FindMatching(ctx context.Context, dbtx db.DBTX, filter Filter) ([]*Cargo, error)
+	FindOnVoyage(ctx context.Context, dbtx db.DBTX, n voyage.Number) ([]*Cargo, error)
+}
+
+// Filter narrows down the cargos returned by FindMatching. Zero fields match
+// every cargo. ExcludeClosed leaves out the bookings that are final, closed
+// or cancelled ones. Misdirected and Late keep the cargos whose delivery is
+// misdirected or expected after the arrival deadline. Results are ordered by
+// tracking ID, After and Limit page through them.
+type Filter struct {
+	CustomerID    customer.ID
+	Origin        location.UNLocode
+	Destination   location.UNLocode
+	DeadlineFrom  time.Time
+	DeadlineTo    time.Time
+	ExcludeClosed bool
+	Misdirected   bool
+	Late          bool
+	After         TrackingID
+	Limit         int
+}
+
+func (f Filter) where() (string, []interface{}) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if f.CustomerID != "" {
+		add("customer_id = $%d", f.CustomerID)
+	}
+	if f.Origin != "" {
+		add("origin = $%d", f.Origin)
+	}
+	if f.Destination != "" {
+		add("destination = $%d", f.Destination)
+	}
+	if !f.DeadlineFrom.IsZero() {
+		add("arrival_deadline >= $%d", f.DeadlineFrom)
+	}
+	if !f.DeadlineTo.IsZero() {
+		add("arrival_deadline < $%d", f.DeadlineTo)
+	}
+	if f.ExcludeClosed {
+		var placeholders []string
+		for _, status := range bookingStatuses {
+			if !status.Active() {
+				args = append(args, status.String())
+				placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+			}
+		}
+		conditions = append(conditions, "status NOT IN ("+strings.Join(placeholders, ", ")+")")
+	}
+	if f.Misdirected {
+		conditions = append(conditions, "delivery_id IN (SELECT id FROM deliveries WHERE is_misdirected)")
+	}
+	if f.Late {
+		// the same rule as Delivery.IsLate, the estimate alone misses
+		// misdirected cargos, which have none
+		add(`delivery_id IN (
+			SELECT d.id FROM deliveries AS d JOIN itineraries AS i ON d.itinerary_id = i.id
+			WHERE NOT d.is_unloaded_at_destination AND d.arrival_deadline > $%d
+			AND (i.legs -> -1 ->> 'unload_time')::timestamptz > d.arrival_deadline
+		)`, time.Time{})
+	}
+	if f.After != "" {
+		add("tracking_id > $%d", f.After)
+	}
+
+	var clause string
+	if len(conditions) > 0 {
+		clause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	clause += " ORDER BY tracking_id"
+	if f.Limit > 0 {
+		args = append(args, f.Limit)
+		clause += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	return clause, args
 }
 
 type CargoRepository struct {
@@ -84,25 +231,49 @@ func NewCargoRepository(itineraryRepository ItineraryRepositoryContract, deliver
 
 type cargoResult struct {
 	trackingID      string
+	customerID      sql.NullString
 	origin          string
 	destination     string
 	arrivalDeadline time.Time
 	itineraryID     int64
 	deliveryID      int64
+	status          string
+	attributes      Attributes
+	clearance       Clearance
 }
 
-func (cr cargoResult) build(itinerary Itinerary, delivery Delivery) *Cargo {
+// fields returns the scan destinations of the columns selected by the find
+// queries, in order.
+func (cr *cargoResult) fields() []interface{} {
+	return []interface{}{
+		&cr.trackingID, &cr.customerID, &cr.origin, &cr.destination, &cr.arrivalDeadline, &cr.itineraryID, &cr.deliveryID, &cr.status,
+		&cr.attributes.Weight, &cr.attributes.Volume, &cr.attributes.Packages, &cr.attributes.Commodity, &cr.attributes.HSCode,
+		&cr.attributes.DangerousGoods.Class, &cr.attributes.DangerousGoods.UNNumber,
+		&cr.clearance.Required, &cr.clearance.Cleared, &cr.clearance.OnHold, &cr.clearance.HoldReason,
+	}
+}
+
+func (cr cargoResult) build(itinerary Itinerary, delivery Delivery) (*Cargo, error) {
+	status, err := ParseBookingStatus(cr.status)
+	if err != nil {
+		return nil, fmt.Errorf("cargo %s: %w %q", cr.trackingID, err, cr.status)
+	}
+
 	return &Cargo{
 		TrackingID: TrackingID(cr.trackingID),
+		CustomerID: customer.ID(cr.customerID.String),
 		Origin:     location.UNLocode(cr.origin),
 		RouteSpecification: RouteSpecification{
 			Origin:          location.UNLocode(cr.origin),
 			Destination:     location.UNLocode(cr.destination),
 			ArrivalDeadline: cr.arrivalDeadline,
 		},
-		Itinerary: itinerary,
-		Delivery:  delivery,
-	}
+		Itinerary:  itinerary,
+		Delivery:   delivery.withClearance(cr.clearance),
+		Status:     status,
+		Attributes: cr.attributes,
+		Clearance:  cr.clearance,
+	}, nil
 }
 
 func (cr CargoRepository) Upsert(ctx context.Context, dbtx db.DBTX, cargo *Cargo) (*Cargo, error) {
@@ -121,11 +292,18 @@ func (cr CargoRepository) Upsert(ctx context.Context, dbtx db.DBTX, cargo *Cargo
 	var row *sql.Row
 	if cargo.Itinerary.ID == 0 && cargo.Delivery.ID == 0 {
 		query := `
-			INSERT INTO cargos (tracking_id, origin, destination, arrival_deadline, itinerary_id, delivery_id)
-			VALUES ($1, $2, $3, $4, $5, $6)
-			RETURNING tracking_id, origin, destination, arrival_deadline
+			INSERT INTO cargos (tracking_id, origin, destination, arrival_deadline, itinerary_id, delivery_id, customer_id, status,
+			weight, volume, packages, commodity, hs_code, imdg_class, un_number, customs_required, customs_cleared, customs_hold, customs_hold_reason)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+			RETURNING tracking_id, customer_id, origin, destination, arrival_deadline, status, weight, volume, packages, commodity, hs_code, imdg_class, un_number,
+			customs_required, customs_cleared, customs_hold, customs_hold_reason
 		`
 
+		var customerID *customer.ID
+		if cargo.CustomerID != "" {
+			customerID = &cargo.CustomerID
+		}
+
 		row = dbtx.QueryRowContext(
 			ctx,
 			query,
@@ -135,11 +313,29 @@ func (cr CargoRepository) Upsert(ctx context.Context, dbtx db.DBTX, cargo *Cargo
 			cargo.RouteSpecification.ArrivalDeadline,
 			itinerary.ID,
 			delivery.ID,
+			customerID,
+			cargo.Status.String(),
+			cargo.Attributes.Weight,
+			cargo.Attributes.Volume,
+			cargo.Attributes.Packages,
+			cargo.Attributes.Commodity,
+			cargo.Attributes.HSCode,
+			cargo.Attributes.DangerousGoods.Class,
+			cargo.Attributes.DangerousGoods.UNNumber,
+			cargo.Clearance.Required,
+			cargo.Clearance.Cleared,
+			cargo.Clearance.OnHold,
+			cargo.Clearance.HoldReason,
 		)
 	} else {
 		query := `
-			UPDATE cargos SET origin = $2, destination = $3, arrival_deadline = $4
-			WHERE tracking_id = $1 RETURNING tracking_id, origin, destination, arrival_deadline
+			UPDATE cargos SET origin = $2, destination = $3, arrival_deadline = $4, status = $5,
+			weight = $6, volume = $7, packages = $8, commodity = $9, hs_code = $10,
+			imdg_class = $11, un_number = $12,
+			customs_required = $13, customs_cleared = $14, customs_hold = $15, customs_hold_reason = $16
+			WHERE tracking_id = $1
+			RETURNING tracking_id, customer_id, origin, destination, arrival_deadline, status, weight, volume, packages, commodity, hs_code, imdg_class, un_number,
+			customs_required, customs_cleared, customs_hold, customs_hold_reason
 		`
 
 		row = dbtx.QueryRowContext(
@@ -149,28 +345,56 @@ func (cr CargoRepository) Upsert(ctx context.Context, dbtx db.DBTX, cargo *Cargo
 			cargo.RouteSpecification.Origin,
 			cargo.RouteSpecification.Destination,
 			cargo.RouteSpecification.ArrivalDeadline,
+			cargo.Status.String(),
+			cargo.Attributes.Weight,
+			cargo.Attributes.Volume,
+			cargo.Attributes.Packages,
+			cargo.Attributes.Commodity,
+			cargo.Attributes.HSCode,
+			cargo.Attributes.DangerousGoods.Class,
+			cargo.Attributes.DangerousGoods.UNNumber,
+			cargo.Clearance.Required,
+			cargo.Clearance.Cleared,
+			cargo.Clearance.OnHold,
+			cargo.Clearance.HoldReason,
 		)
 	}
 
 	var result cargoResult
-	err = row.Scan(&result.trackingID, &result.origin, &result.destination, &result.arrivalDeadline)
+	err = row.Scan(
+		&result.trackingID, &result.customerID, &result.origin, &result.destination, &result.arrivalDeadline, &result.status,
+		&result.attributes.Weight, &result.attributes.Volume, &result.attributes.Packages, &result.attributes.Commodity, &result.attributes.HSCode,
+		&result.attributes.DangerousGoods.Class, &result.attributes.DangerousGoods.UNNumber,
+		&result.clearance.Required, &result.clearance.Cleared, &result.clearance.OnHold, &result.clearance.HoldReason,
+	)
 	if err != nil {
-		fmt.Println("err :", err)
 		return nil, err
 	}
 
-	return result.build(itinerary, delivery), nil
+	return result.build(itinerary, delivery)
 }
 
 func (cr CargoRepository) Find(ctx context.Context, dbtx db.DBTX, trackingID TrackingID) (*Cargo, error) {
+	return cr.find(ctx, dbtx, trackingID, "")
+}
+
+// FindForUpdate returns the cargo locked until the end of the transaction
+// dbtx belongs to, so that changes derived from its state are not made
+// concurrently.
+func (cr CargoRepository) FindForUpdate(ctx context.Context, dbtx db.DBTX, trackingID TrackingID) (*Cargo, error) {
+	return cr.find(ctx, dbtx, trackingID, " FOR UPDATE")
+}
+
+func (cr CargoRepository) find(ctx context.Context, dbtx db.DBTX, trackingID TrackingID, lock string) (*Cargo, error) {
 	query := `
-		SELECT tracking_id, origin, destination, arrival_deadline, itinerary_id, delivery_id
+		SELECT tracking_id, customer_id, origin, destination, arrival_deadline, itinerary_id, delivery_id, status,
+		weight, volume, packages, commodity, hs_code, imdg_class, un_number, customs_required, customs_cleared, customs_hold, customs_hold_reason
 		FROM cargos WHERE tracking_id = $1 LIMIT 1
-	`
+	` + lock
 
 	var result cargoResult
 	row := dbtx.QueryRowContext(ctx, query, trackingID)
-	err := row.Scan(&result.trackingID, &result.origin, &result.destination, &result.arrivalDeadline, &result.itineraryID, &result.deliveryID)
+	err := row.Scan(result.fields()...)
 	if err != nil {
 		return nil, err
 	}
@@ -186,12 +410,13 @@ func (cr CargoRepository) Find(ctx context.Context, dbtx db.DBTX, trackingID Tra
 		return nil, err
 	}
 
-	return result.build(itinerary, delivery), nil
+	return result.build(itinerary, delivery)
 }
 
 func (cr CargoRepository) FindAll(ctx context.Context, dbtx db.DBTX) ([]*Cargo, error) {
 	query := `
-		SELECT tracking_id, origin, destination, arrival_deadline, itinerary_id, delivery_id
+		SELECT tracking_id, customer_id, origin, destination, arrival_deadline, itinerary_id, delivery_id, status,
+		weight, volume, packages, commodity, hs_code, imdg_class, un_number, customs_required, customs_cleared, customs_hold, customs_hold_reason
 		FROM cargos
 	`
 
@@ -200,14 +425,65 @@ func (cr CargoRepository) FindAll(ctx context.Context, dbtx db.DBTX) ([]*Cargo,
 		return nil, err
 	}
 
-	var result cargoResult
-	var cargos []*Cargo
+	return cr.scanAll(ctx, dbtx, rows)
+}
+
+func (cr CargoRepository) FindMatching(ctx context.Context, dbtx db.DBTX, filter Filter) ([]*Cargo, error) {
+	where, args := filter.where()
+	query := `
+		SELECT tracking_id, customer_id, origin, destination, arrival_deadline, itinerary_id, delivery_id, status,
+		weight, volume, packages, commodity, hs_code, imdg_class, un_number, customs_required, customs_cleared, customs_hold, customs_hold_reason
+		FROM cargos
+	` + where
+
+	rows, err := dbtx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return cr.scanAll(ctx, dbtx, rows)
+}
+
+// FindOnVoyage returns the booked and in transit cargos whose itinerary has
+// a leg on the voyage.
+func (cr CargoRepository) FindOnVoyage(ctx context.Context, dbtx db.DBTX, n voyage.Number) ([]*Cargo, error) {
+	query := `
+		SELECT c.tracking_id, c.customer_id, c.origin, c.destination, c.arrival_deadline, c.itinerary_id, c.delivery_id, c.status,
+		c.weight, c.volume, c.packages, c.commodity, c.hs_code, c.imdg_class, c.un_number,
+		c.customs_required, c.customs_cleared, c.customs_hold, c.customs_hold_reason
+		FROM cargos AS c JOIN itineraries AS i ON c.itinerary_id = i.id
+		WHERE c.status IN ($1, $2) AND i.legs::jsonb @> jsonb_build_array(jsonb_build_object('voyage_number', $3::text))
+		ORDER BY c.tracking_id
+	`
+
+	rows, err := dbtx.QueryContext(ctx, query, Booked.String(), InTransit.String(), n)
+	if err != nil {
+		return nil, err
+	}
+
+	return cr.scanAll(ctx, dbtx, rows)
+}
+
+func (cr CargoRepository) scanAll(ctx context.Context, dbtx db.DBTX, rows *sql.Rows) ([]*Cargo, error) {
+	defer rows.Close()
+
+	var results []cargoResult
 	for rows.Next() {
-		err = rows.Scan(&result.trackingID, &result.origin, &result.destination, &result.arrivalDeadline, &result.itineraryID, &result.deliveryID)
+		var result cargoResult
+		err := rows.Scan(result.fields()...)
 		if err != nil {
 			return nil, err
 		}
 
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var cargos []*Cargo
+	for _, result := range results {
 		// is it better to use multiple joins instead of calling each repository?
 		itinerary, err := cr.ItineraryRepository.Find(ctx, dbtx, result.itineraryID)
 		if err != nil {
@@ -219,8 +495,12 @@ func (cr CargoRepository) FindAll(ctx context.Context, dbtx db.DBTX) ([]*Cargo,
 			return nil, err
 		}
 
-		cargo := result.build(itinerary, delivery)
-		cargos = append(cargos, cargo)
+		c, err := result.build(itinerary, delivery)
+		if err != nil {
+			return nil, err
+		}
+
+		cargos = append(cargos, c)
 	}
 
 	return cargos, nil
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
 	"github.com/mproyyan/grpc-shipping-microservice/location"
 	"github.com/stretchr/testify/require"
 )
@@ -19,10 +20,12 @@ func createNewCargo(t *testing.T) *Cargo {
 
 	trackingID := NextTrackingID()
 	c := New(trackingID, rs)
+	c.Attributes = Attributes{Weight: 1200, Volume: 4.5, Packages: 12, Commodity: "Coffee", HSCode: "090111"}
 	nc, err := cargoTest.Upsert(context.Background(), dbTest, c)
 
 	require.NoError(t, err)
 	require.Equal(t, trackingID, nc.TrackingID)
+	require.Equal(t, c.Attributes, nc.Attributes)
 	require.Empty(t, c.Itinerary)
 	require.Equal(t, NotHandled, nc.Delivery.LastEvent.Activity.Type)
 
@@ -80,6 +83,26 @@ func TestFindCargo(t *testing.T) {
 	require.Equal(t, c.TrackingID, nc.TrackingID)
 }
 
+func TestFindCargoForUpdate(t *testing.T) {
+	c := createNewCargo(t)
+
+	tx, err := dbTest.Begin()
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	nc, err := cargoTest.FindForUpdate(context.Background(), tx, c.TrackingID)
+	require.NoError(t, err)
+	require.Equal(t, c.TrackingID, nc.TrackingID)
+
+	_, err = dbTest.Exec(`SELECT 1 FROM cargos WHERE tracking_id = $1 FOR UPDATE NOWAIT`, c.TrackingID)
+	require.Error(t, err)
+}
+
+func TestCargoResultRejectsUnknownStatus(t *testing.T) {
+	_, err := cargoResult{trackingID: "ABC123", status: "Lost"}.build(Itinerary{}, Delivery{})
+	require.ErrorIs(t, err, ErrUnknownBookingStatus)
+}
+
 func TestFindCargoNotFound(t *testing.T) {
 	c, err := cargoTest.Find(context.Background(), dbTest, "hfjhskjghshkj")
 	require.Error(t, err)
@@ -92,3 +115,120 @@ func TestFindAllCargo(t *testing.T) {
 	require.NoError(t, err)
 	require.GreaterOrEqual(t, len(cs), 1)
 }
+
+func TestFindMatchingCargo(t *testing.T) {
+	c := createNewCargo(t)
+	filter := Filter{
+		CustomerID:  c.CustomerID,
+		Origin:      c.Origin,
+		Destination: c.RouteSpecification.Destination,
+	}
+
+	cs, err := cargoTest.FindMatching(context.Background(), dbTest, filter)
+	require.NoError(t, err)
+
+	var ids []TrackingID
+	for _, found := range cs {
+		ids = append(ids, found.TrackingID)
+	}
+	require.Contains(t, ids, c.TrackingID)
+
+	// paging resumes after the given tracking ID
+	filter.After = c.TrackingID
+	cs, err = cargoTest.FindMatching(context.Background(), dbTest, filter)
+	require.NoError(t, err)
+	for _, found := range cs {
+		require.Greater(t, string(found.TrackingID), string(c.TrackingID))
+	}
+
+	cs, err = cargoTest.FindMatching(context.Background(), dbTest, Filter{Origin: "XXXXX"})
+	require.NoError(t, err)
+	require.Empty(t, cs)
+}
+
+func TestFindMatchingLateCargo(t *testing.T) {
+	c := createNewCargo(t)
+	arrival := c.RouteSpecification.ArrivalDeadline.AddDate(0, 0, 7)
+	c.Itinerary = Itinerary{
+		ID:   c.Itinerary.ID,
+		Legs: []Leg{NewLeg("V100", "IDJKT", "IDSLO", arrival.AddDate(0, 0, -1), arrival)},
+	}
+
+	// received away from the origin, the cargo is misdirected and has no
+	// estimate, but its itinerary still arrives too late
+	event, _ := createNewEvent(t, c, Receive, "IDBDG", "")
+	newDel := newDelivery(event, c.Itinerary, c.RouteSpecification)
+	newDel.ID = c.Delivery.ID
+	c.Delivery = newDel
+	require.True(t, c.Delivery.IsMisdirected)
+	require.True(t, c.Delivery.ETA.IsZero())
+	require.True(t, c.Delivery.IsLate())
+
+	c, err := cargoTest.Upsert(context.Background(), dbTest, c)
+	require.NoError(t, err)
+
+	cs, err := cargoTest.FindMatching(context.Background(), dbTest, Filter{Late: true, Origin: c.Origin, Destination: c.RouteSpecification.Destination})
+	require.NoError(t, err)
+
+	var ids []TrackingID
+	for _, found := range cs {
+		ids = append(ids, found.TrackingID)
+	}
+	require.Contains(t, ids, c.TrackingID)
+}
+
+func TestUpdateRouteSpecification(t *testing.T) {
+	deadline := time.Date(2030, 1, 10, 0, 0, 0, 0, time.UTC)
+	c := New("ABC", RouteSpecification{Origin: "SESTO", Destination: "AUMEL", ArrivalDeadline: deadline})
+	c.AssignToRoute(Itinerary{Legs: []Leg{
+		NewLeg("V100", "SESTO", "AUMEL", deadline.AddDate(0, 0, -5), deadline.AddDate(0, 0, -1)),
+	}})
+	require.Equal(t, Routed, c.Delivery.RoutingStatus)
+
+	require.NoError(t, c.UpdateRouteSpecification(RouteSpecification{ArrivalDeadline: deadline.AddDate(0, 0, -2)}, HandlingHistory{}))
+	require.EqualValues(t, "AUMEL", c.RouteSpecification.Destination)
+	require.Equal(t, Misrouted, c.Delivery.RoutingStatus)
+
+	require.NoError(t, c.UpdateRouteSpecification(RouteSpecification{Origin: "CNHKG"}, HandlingHistory{}))
+	require.EqualValues(t, "CNHKG", c.Origin)
+
+	history := HandlingHistory{[]HandlingEvent{{Activity: HandlingActivity{Type: Receive, Location: "CNHKG"}}}}
+	require.Equal(t, ErrOriginFixed, c.UpdateRouteSpecification(RouteSpecification{Origin: "SESTO"}, history))
+	require.NoError(t, c.UpdateRouteSpecification(RouteSpecification{Destination: "SGSIN"}, history))
+
+	require.NoError(t, c.Cancel(history))
+	require.Equal(t, ErrInactive, c.UpdateRouteSpecification(RouteSpecification{Destination: "AUMEL"}, history))
+}
+
+func TestUpdateRouteSpecificationToOrigin(t *testing.T) {
+	rs := RouteSpecification{Origin: "SESTO", Destination: "AUMEL", ArrivalDeadline: time.Date(2030, 1, 10, 0, 0, 0, 0, time.UTC)}
+
+	// the destination moved to the origin
+	c := New("ABC", rs)
+	require.Equal(t, ErrSameLocation, c.UpdateRouteSpecification(RouteSpecification{Destination: "SESTO"}, HandlingHistory{}))
+	require.Equal(t, rs, c.RouteSpecification)
+
+	// the origin moved to the destination
+	require.Equal(t, ErrSameLocation, c.UpdateRouteSpecification(RouteSpecification{Origin: "AUMEL"}, HandlingHistory{}))
+	require.Equal(t, rs, c.RouteSpecification)
+	require.EqualValues(t, "SESTO", c.Origin)
+}
+
+func TestFilterWhere(t *testing.T) {
+	where, args := Filter{Origin: "SESTO", After: "ABC", Limit: 10}.where()
+	require.Equal(t, "WHERE origin = $1 AND tracking_id > $2 ORDER BY tracking_id LIMIT $3", where)
+	require.Equal(t, []interface{}{location.UNLocode("SESTO"), TrackingID("ABC"), 10}, args)
+
+	where, args = Filter{Destination: "AUMEL", ExcludeClosed: true}.where()
+	require.Equal(t, "WHERE destination = $1 AND status NOT IN ($2, $3) ORDER BY tracking_id", where)
+	require.Equal(t, []interface{}{location.UNLocode("AUMEL"), "Cancelled", "Closed"}, args)
+
+	where, args = Filter{CustomerID: "ACME", Misdirected: true, Late: true}.where()
+	require.Contains(t, where, "WHERE customer_id = $1 AND delivery_id IN (SELECT id FROM deliveries WHERE is_misdirected) AND delivery_id IN (")
+	require.Contains(t, where, "d.arrival_deadline > $2")
+	require.Equal(t, []interface{}{customer.ID("ACME"), time.Time{}}, args)
+
+	where, args = Filter{}.where()
+	require.Equal(t, " ORDER BY tracking_id", where)
+	require.Empty(t, args)
+}
@@ -0,0 +1,170 @@
+package cargo
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/mproyyan/grpc-shipping-microservice/location"
+)
+
+var (
+	// ErrOnHold is returned when claiming a cargo held by customs.
+	ErrOnHold = errors.New("cargo is on customs hold")
+
+	// ErrNotCleared is returned when claiming a cargo that has not cleared
+	// customs at its destination.
+	ErrNotCleared = errors.New("cargo has not cleared customs")
+
+	// ErrNotHeld is returned when releasing a cargo that is not on hold.
+	ErrNotHeld = errors.New("cargo is not on customs hold")
+)
+
+// ClearanceCountries are the countries, by ISO 3166 code, where cargos have
+// to clear customs before they can be claimed.
+type ClearanceCountries map[string]bool
+
+// NewClearanceCountries returns the given countries, ignoring case and
+// blank codes.
+func NewClearanceCountries(codes ...string) ClearanceCountries {
+	countries := make(ClearanceCountries)
+	for _, code := range codes {
+		if code = strings.ToUpper(strings.TrimSpace(code)); code != "" {
+			countries[code] = true
+		}
+	}
+
+	return countries
+}
+
+// Requires reports whether cargos bound for destination have to clear
+// customs.
+func (c ClearanceCountries) Requires(destination location.UNLocode) bool {
+	return c[destination.Country()]
+}
+
+// Clearance is where a cargo stands with customs. Clearance is only required
+// when the destination country asks for it, a hold can be placed on any
+// cargo.
+type Clearance struct {
+	Required   bool
+	Cleared    bool
+	OnHold     bool
+	HoldReason string
+}
+
+// ClearanceStatus summarizes the clearance of a cargo.
+type ClearanceStatus int
+
+// Valid customs statuses.
+const (
+	ClearanceNotRequired ClearanceStatus = iota
+	ClearancePending
+	Cleared
+	OnHold
+)
+
+func (s ClearanceStatus) String() string {
+	switch s {
+	case ClearanceNotRequired:
+		return "Not required"
+	case ClearancePending:
+		return "Pending"
+	case Cleared:
+		return "Cleared"
+	case OnHold:
+		return "On hold"
+	}
+
+	return ""
+}
+
+// Status returns the customs status, a hold taking precedence over the
+// clearance.
+func (c Clearance) Status() ClearanceStatus {
+	switch {
+	case c.OnHold:
+		return OnHold
+	case !c.Required:
+		return ClearanceNotRequired
+	case c.Cleared:
+		return Cleared
+	}
+
+	return ClearancePending
+}
+
+// CheckClaim returns why the cargo cannot be claimed yet, if anything.
+func (c Clearance) CheckClaim() error {
+	if c.OnHold {
+		return ErrOnHold
+	}
+
+	if c.Required && !c.Cleared {
+		return ErrNotCleared
+	}
+
+	return nil
+}
+
+// RequireClearance records whether the destination of the cargo requires
+// customs clearance. It is called on booking and whenever the destination
+// changes.
+func (c *Cargo) RequireClearance(countries ClearanceCountries) {
+	c.Clearance.Required = countries.Requires(c.RouteSpecification.Destination)
+	c.setDelivery(c.Delivery)
+}
+
+// Hold places the cargo on customs hold, it cannot be claimed until it is
+// released.
+func (c *Cargo) Hold(reason string) error {
+	if !c.Status.Active() {
+		return ErrInactive
+	}
+
+	c.Clearance.OnHold = true
+	c.Clearance.HoldReason = reason
+	return nil
+}
+
+// Release lifts the customs hold of the cargo.
+func (c *Cargo) Release() error {
+	if !c.Status.Active() {
+		return ErrInactive
+	}
+
+	if !c.Clearance.OnHold {
+		return ErrNotHeld
+	}
+
+	c.Clearance.OnHold = false
+	c.Clearance.HoldReason = ""
+	return nil
+}
+
+// deriveClearance marks the cargo cleared once a customs event is
+// registered in the country of its destination. Clearance in any other
+// country, such as a former destination, does not count.
+func (c *Cargo) deriveClearance(history HandlingHistory) {
+	c.Clearance.Cleared = false
+
+	country := c.RouteSpecification.Destination.Country()
+	for _, e := range history.HandlingEvents {
+		if e.Activity.Type == Customs && e.Activity.Location.Country() == country {
+			c.Clearance.Cleared = true
+		}
+	}
+}
+
+// withClearance makes customs clearance the activity expected before the
+// cargo is claimed, as long as it has not cleared.
+func (d Delivery) withClearance(c Clearance) Delivery {
+	switch d.NextExpectedActivity.Type {
+	case Claim, Customs:
+		d.NextExpectedActivity.Type = Claim
+		if c.Required && !c.Cleared {
+			d.NextExpectedActivity.Type = Customs
+		}
+	}
+
+	return d
+}
@@ -0,0 +1,96 @@
+package cargo
+
+import (
+	"testing"
+
+	"github.com/mproyyan/grpc-shipping-microservice/location"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClearanceCountries(t *testing.T) {
+	countries := NewClearanceCountries("au", " US ", "")
+	require.True(t, countries.Requires("AUMEL"))
+	require.True(t, countries.Requires("USNYC"))
+	require.False(t, countries.Requires("SESTO"))
+}
+
+func TestCustomsClearance(t *testing.T) {
+	event := func(t HandlingEventType, loc location.UNLocode) HandlingEvent {
+		return HandlingEvent{Activity: HandlingActivity{Type: t, Location: loc, VoyageNumber: "V100"}}
+	}
+
+	c := New("ABC", RouteSpecification{Origin: "SESTO", Destination: "AUMEL"})
+	c.AssignToRoute(Itinerary{Legs: []Leg{{VoyageNumber: "V100", LoadLocation: "SESTO", UnloadLocation: "AUMEL"}}})
+	c.RequireClearance(NewClearanceCountries("AU"))
+	require.Equal(t, ClearancePending, c.Clearance.Status())
+
+	history := HandlingHistory{[]HandlingEvent{event(Receive, "SESTO"), event(Load, "SESTO"), event(Unload, "AUMEL")}}
+	c.DeriveDeliveryProgress(history)
+	require.Equal(t, HandlingActivity{Type: Customs, Location: "AUMEL"}, c.Delivery.NextExpectedActivity)
+	require.Equal(t, ErrNotCleared, c.Clearance.CheckClaim())
+
+	require.NoError(t, c.Hold("inspection"))
+	require.Equal(t, OnHold, c.Clearance.Status())
+	require.Equal(t, ErrOnHold, c.Clearance.CheckClaim())
+
+	history.HandlingEvents = append(history.HandlingEvents, event(Customs, "AUMEL"))
+	c.DeriveDeliveryProgress(history)
+	require.False(t, c.Delivery.IsMisdirected)
+	require.Equal(t, Claim, c.Delivery.NextExpectedActivity.Type)
+	require.Equal(t, ErrOnHold, c.Clearance.CheckClaim())
+
+	require.NoError(t, c.Release())
+	require.Equal(t, ErrNotHeld, c.Release())
+	require.Equal(t, Cleared, c.Clearance.Status())
+	require.NoError(t, c.Clearance.CheckClaim())
+
+	// cargos bound for countries without clearance are claimed right away
+	c = New("ABC", RouteSpecification{Origin: "SESTO", Destination: "AUMEL"})
+	c.AssignToRoute(Itinerary{Legs: []Leg{{VoyageNumber: "V100", LoadLocation: "SESTO", UnloadLocation: "AUMEL"}}})
+	c.RequireClearance(NewClearanceCountries("US"))
+	c.DeriveDeliveryProgress(HandlingHistory{[]HandlingEvent{event(Receive, "SESTO"), event(Load, "SESTO"), event(Unload, "AUMEL")}})
+	require.Equal(t, Claim, c.Delivery.NextExpectedActivity.Type)
+	require.Equal(t, ClearanceNotRequired, c.Clearance.Status())
+}
+
+func TestCustomsAtOrigin(t *testing.T) {
+	c := New("ABC", RouteSpecification{Origin: "SESTO", Destination: "AUMEL"})
+	c.AssignToRoute(Itinerary{Legs: []Leg{{VoyageNumber: "V100", LoadLocation: "SESTO", UnloadLocation: "AUMEL"}}})
+
+	history := HandlingHistory{[]HandlingEvent{
+		{Activity: HandlingActivity{Type: Receive, Location: "SESTO"}},
+		{Activity: HandlingActivity{Type: Customs, Location: "SESTO"}},
+	}}
+	c.DeriveDeliveryProgress(history)
+	require.Equal(t, InPort, c.Delivery.TransportStatus)
+	require.Equal(t, HandlingActivity{Type: Load, Location: "SESTO", VoyageNumber: "V100"}, c.Delivery.NextExpectedActivity)
+}
+
+func TestClearanceFollowsDestination(t *testing.T) {
+	countries := NewClearanceCountries("AU", "NZ")
+	c := New("ABC", RouteSpecification{Origin: "SESTO", Destination: "AUMEL"})
+	c.RequireClearance(countries)
+
+	history := HandlingHistory{[]HandlingEvent{{Activity: HandlingActivity{Type: Customs, Location: "AUSYD"}}}}
+	c.DeriveDeliveryProgress(history)
+	require.Equal(t, Cleared, c.Clearance.Status())
+
+	// clearance in Australia does not count for New Zealand
+	require.NoError(t, c.UpdateRouteSpecification(RouteSpecification{Destination: "NZAKL"}, history))
+	c.RequireClearance(countries)
+	require.Equal(t, ClearancePending, c.Clearance.Status())
+
+	c.DeriveDeliveryProgress(history)
+	require.Equal(t, ClearancePending, c.Clearance.Status())
+}
+
+func TestCustomsIsExpected(t *testing.T) {
+	i := Itinerary{Legs: []Leg{{VoyageNumber: "V100", LoadLocation: "SESTO", UnloadLocation: "AUMEL"}}}
+	customs := func(loc location.UNLocode) HandlingEvent {
+		return HandlingEvent{Activity: HandlingActivity{Type: Customs, Location: loc}}
+	}
+
+	require.True(t, i.IsExpected(customs("SEGOT")))
+	require.True(t, i.IsExpected(customs("AUSYD")))
+	require.False(t, i.IsExpected(customs("CNSHA")))
+}
@@ -39,6 +39,15 @@ func (d Delivery) IsOnTrack() bool {
 	return d.RoutingStatus == Routed && !d.IsMisdirected
 }
 
+// IsLate checks if the cargo is expected after its arrival deadline, that
+// is its itinerary arrives after the deadline. A cargo unloaded at its
+// destination is no longer late.
+func (d Delivery) IsLate() bool {
+	rs := d.RouteSpecification
+	return !d.IsUnloadedAtDestination && !d.Itinerary.IsEmpty() && !rs.ArrivalDeadline.IsZero() &&
+		d.Itinerary.FinalArrivalTime().After(rs.ArrivalDeadline)
+}
+
 // DeriveDeliveryFrom creates a new delivery snapshot based on the complete
 // handling history of a cargo, as well as its route specification and
 // itinerary.
@@ -118,6 +127,8 @@ func calculateTransportStatus(event HandlingEvent) TransportStatus {
 	case Receive:
 		return InPort
 	case Customs:
+		// customs inspect the cargo where it lies, at the port it was
+		// received or unloaded at, so it has not left the port
 		return InPort
 	case Claim:
 		return Claimed
@@ -146,7 +157,7 @@ func calculateNextExpectedActivity(d Delivery) HandlingActivity {
 				return HandlingActivity{Type: Unload, Location: l.UnloadLocation, VoyageNumber: l.VoyageNumber}
 			}
 		}
-	case Unload:
+	case Unload, Customs:
 		for i, l := range d.Itinerary.Legs {
 			if l.UnloadLocation == d.LastEvent.Activity.Location {
 				if i < len(d.Itinerary.Legs)-1 {
@@ -156,6 +167,11 @@ func calculateNextExpectedActivity(d Delivery) HandlingActivity {
 				return HandlingActivity{Type: Claim, Location: l.UnloadLocation}
 			}
 		}
+
+		// export customs are cleared where the cargo is first loaded
+		if l := d.Itinerary.Legs[0]; d.LastEvent.Activity.Type == Customs && l.LoadLocation == d.LastEvent.Activity.Location {
+			return HandlingActivity{Type: Load, Location: l.LoadLocation, VoyageNumber: l.VoyageNumber}
+		}
 	}
 
 	return HandlingActivity{}
@@ -180,6 +196,7 @@ func calculateETA(d Delivery) time.Time {
 type DeliveryRepositoryContract interface {
 	Upsert(ctx context.Context, dbtx db.DBTX, delivery Delivery) (Delivery, error)
 	Find(ctx context.Context, dbtx db.DBTX, id int64) (Delivery, error)
+	FindUnderived(ctx context.Context, dbtx db.DBTX, after TrackingID, limit int) ([]TrackingID, error)
 }
 
 type DeliveryRepository struct {
@@ -190,12 +207,25 @@ func NewDeliveryRepository() DeliveryRepository {
 }
 
 type deliveryResult struct {
-	id              int64
-	origin          string
-	destination     string
-	arrivalDeadline sql.NullTime
+	id                      int64
+	origin                  string
+	destination             string
+	arrivalDeadline         sql.NullTime
+	routingStatus           RoutingStatus
+	transportStatus         TransportStatus
+	lastKnownLocation       string
+	currentVoyage           string
+	eta                     sql.NullTime
+	isMisdirected           bool
+	isUnloadedAtDestination bool
+	nextActivityType        HandlingEventType
+	nextActivityLocation    string
+	nextActivityVoyage      string
+	derived                 bool
 }
 
+// build returns the stored delivery snapshot. Snapshots stored before they
+// were persisted in full are derived again until they are backfilled.
 func (dr deliveryResult) build(itinerary Itinerary, event HandlingEvent) Delivery {
 	rs := RouteSpecification{
 		Origin:          location.UNLocode(dr.origin),
@@ -203,65 +233,100 @@ func (dr deliveryResult) build(itinerary Itinerary, event HandlingEvent) Deliver
 		ArrivalDeadline: dr.arrivalDeadline.Time,
 	}
 
-	delivery := newDelivery(event, itinerary, rs)
-	delivery.ID = dr.id
+	if !dr.derived {
+		delivery := newDelivery(event, itinerary, rs)
+		delivery.ID = dr.id
+		return delivery
+	}
 
-	return delivery
+	return Delivery{
+		ID:                 dr.id,
+		Itinerary:          itinerary,
+		RouteSpecification: rs,
+		RoutingStatus:      dr.routingStatus,
+		TransportStatus:    dr.transportStatus,
+		NextExpectedActivity: HandlingActivity{
+			Type:         dr.nextActivityType,
+			Location:     location.UNLocode(dr.nextActivityLocation),
+			VoyageNumber: voyage.Number(dr.nextActivityVoyage),
+		},
+		LastEvent:               event,
+		LastKnownLocation:       location.UNLocode(dr.lastKnownLocation),
+		CurrentVoyage:           voyage.Number(dr.currentVoyage),
+		ETA:                     dr.eta.Time,
+		IsMisdirected:           dr.isMisdirected,
+		IsUnloadedAtDestination: dr.isUnloadedAtDestination,
+	}
 }
 
+// Upsert stores the delivery snapshot as is, so it can be queried without
+// deriving it again.
 func (dr DeliveryRepository) Upsert(ctx context.Context, dbtx db.DBTX, delivery Delivery) (Delivery, error) {
-	var row *sql.Row
+	var lastEvent *int64
+	if delivery.LastEvent.ID != 0 {
+		lastEvent = &delivery.LastEvent.ID
+	}
+
+	var eta *time.Time
+	if !delivery.ETA.IsZero() {
+		eta = &delivery.ETA
+	}
+
+	args := []interface{}{
+		delivery.RouteSpecification.Origin,
+		delivery.RouteSpecification.Destination,
+		delivery.RouteSpecification.ArrivalDeadline,
+		lastEvent,
+		delivery.RoutingStatus,
+		delivery.TransportStatus,
+		delivery.LastKnownLocation,
+		delivery.CurrentVoyage,
+		eta,
+		delivery.IsMisdirected,
+		delivery.IsUnloadedAtDestination,
+		delivery.NextExpectedActivity.Type,
+		delivery.NextExpectedActivity.Location,
+		delivery.NextExpectedActivity.VoyageNumber,
+	}
+
+	var query string
 	if delivery.ID == 0 {
-		query := `
-			INSERT INTO deliveries (itinerary_id, origin, destination, arrival_deadline)
-			VALUES ($1, $2, $3, $4)
-			RETURNING id, origin, destination, arrival_deadline
+		query = `
+			INSERT INTO deliveries (origin, destination, arrival_deadline, last_event,
+			routing_status, transport_status, last_known_location, current_voyage, eta, is_misdirected, is_unloaded_at_destination,
+			next_activity_type, next_activity_location, next_activity_voyage, derived, itinerary_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, true, $15)
+			RETURNING id
 		`
 
-		row = dbtx.QueryRowContext(
-			ctx,
-			query,
-			delivery.Itinerary.ID,
-			delivery.RouteSpecification.Origin,
-			delivery.RouteSpecification.Destination,
-			delivery.RouteSpecification.ArrivalDeadline,
-		)
+		args = append(args, delivery.Itinerary.ID)
 	} else {
-		query := `
-			UPDATE deliveries SET origin = $2, destination = $3, arrival_deadline = $4, last_event = $5
-			WHERE id = $1 RETURNING id, origin, destination, arrival_deadline
+		query = `
+			UPDATE deliveries SET origin = $1, destination = $2, arrival_deadline = $3, last_event = $4,
+			routing_status = $5, transport_status = $6, last_known_location = $7, current_voyage = $8, eta = $9,
+			is_misdirected = $10, is_unloaded_at_destination = $11,
+			next_activity_type = $12, next_activity_location = $13, next_activity_voyage = $14, derived = true
+			WHERE id = $15 RETURNING id
 		`
 
-		var eventType *int
-		if delivery.LastEvent.Activity.Type != NotHandled {
-			eventType = (*int)(&delivery.LastEvent.Activity.Type)
-		}
-
-		row = dbtx.QueryRowContext(
-			ctx,
-			query,
-			delivery.ID,
-			delivery.RouteSpecification.Origin,
-			delivery.RouteSpecification.Destination,
-			delivery.RouteSpecification.ArrivalDeadline,
-			eventType,
-		)
+		args = append(args, delivery.ID)
 	}
 
-	var result deliveryResult
-	err := row.Scan(&result.id, &result.origin, &result.destination, &result.arrivalDeadline)
-	if err != nil {
+	if err := dbtx.QueryRowContext(ctx, query, args...).Scan(&delivery.ID); err != nil {
 		return Delivery{}, err
 	}
 
-	return result.build(delivery.Itinerary, delivery.LastEvent), nil
+	return delivery, nil
 }
 
 func (dr DeliveryRepository) Find(ctx context.Context, dbtx db.DBTX, id int64) (Delivery, error) {
 	query := `
 		SELECT i.id AS itinerary_id, i.legs AS itinerary_legs, d.id AS delivery_id,
 		d.origin AS rs_origin, d.destination AS rs_destination, d.arrival_deadline AS rs_arrival_deadline,
-		e.id AS event_id, e.tracking_id AS event_tracking_id, e.event_type AS event_type, e.location AS event_location, e.voyage_number AS event_voyage_number
+		d.routing_status, d.transport_status, d.last_known_location, d.current_voyage, d.eta, d.is_misdirected, d.is_unloaded_at_destination,
+		d.next_activity_type, d.next_activity_location, d.next_activity_voyage, d.derived,
+		e.id AS event_id, e.tracking_id AS event_tracking_id, e.event_type AS event_type, e.location AS event_location, e.voyage_number AS event_voyage_number,
+		e.completed_at AS event_completed_at
 		FROM deliveries AS d
 		LEFT JOIN itineraries AS i ON d.itinerary_id = i.id
 		LEFT JOIN events AS e ON d.last_event = e.id
@@ -279,11 +344,23 @@ func (dr DeliveryRepository) Find(ctx context.Context, dbtx db.DBTX, id int64) (
 		&dResult.origin,
 		&dResult.destination,
 		&dResult.arrivalDeadline,
+		&dResult.routingStatus,
+		&dResult.transportStatus,
+		&dResult.lastKnownLocation,
+		&dResult.currentVoyage,
+		&dResult.eta,
+		&dResult.isMisdirected,
+		&dResult.isUnloadedAtDestination,
+		&dResult.nextActivityType,
+		&dResult.nextActivityLocation,
+		&dResult.nextActivityVoyage,
+		&dResult.derived,
 		&eResult.id,
 		&eResult.trackingId,
 		&eResult.eventType,
 		&eResult.location,
 		&eResult.voyageNumber,
+		&eResult.completed,
 	)
 
 	if err != nil {
@@ -298,3 +375,31 @@ func (dr DeliveryRepository) Find(ctx context.Context, dbtx db.DBTX, id int64) (
 	event := eResult.build()
 	return dResult.build(itinerary, event), nil
 }
+
+// FindUnderived returns the cargos after the tracking ID whose delivery was
+// stored before snapshots were persisted in full, ordered by tracking ID.
+func (dr DeliveryRepository) FindUnderived(ctx context.Context, dbtx db.DBTX, after TrackingID, limit int) ([]TrackingID, error) {
+	query := `
+		SELECT c.tracking_id FROM cargos AS c JOIN deliveries AS d ON c.delivery_id = d.id
+		WHERE NOT d.derived AND c.tracking_id > $1
+		ORDER BY c.tracking_id LIMIT $2
+	`
+
+	rows, err := dbtx.QueryContext(ctx, query, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []TrackingID
+	for rows.Next() {
+		var id TrackingID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
@@ -47,3 +47,62 @@ func TestFindDeliveryNotFound(t *testing.T) {
 	require.NoError(t, err)
 	require.Empty(t, nd)
 }
+
+func TestStoreDeliverySnapshot(t *testing.T) {
+	ctx := context.Background()
+	c := createNewCargo(t)
+
+	eta := c.RouteSpecification.ArrivalDeadline.Add(24 * time.Hour).UTC().Truncate(time.Second)
+	c.Delivery.TransportStatus = InPort
+	c.Delivery.LastKnownLocation = "SGSIN"
+	c.Delivery.IsMisdirected = true
+	c.Delivery.ETA = eta
+	c.Delivery.NextExpectedActivity = HandlingActivity{Type: Load, Location: "SGSIN", VoyageNumber: "V100"}
+
+	_, err := cargoTest.Upsert(ctx, dbTest, c)
+	require.NoError(t, err)
+
+	found, err := cargoTest.Find(ctx, dbTest, c.TrackingID)
+	require.NoError(t, err)
+	require.True(t, found.Delivery.IsMisdirected)
+	require.Equal(t, InPort, found.Delivery.TransportStatus)
+	require.Equal(t, location.UNLocode("SGSIN"), found.Delivery.LastKnownLocation)
+	require.True(t, eta.Equal(found.Delivery.ETA))
+	require.Equal(t, c.Delivery.NextExpectedActivity, found.Delivery.NextExpectedActivity)
+
+	matching, err := cargoTest.FindMatching(ctx, dbTest, Filter{Misdirected: true, Late: true})
+	require.NoError(t, err)
+
+	var ids []TrackingID
+	for _, m := range matching {
+		ids = append(ids, m.TrackingID)
+	}
+	require.Contains(t, ids, c.TrackingID)
+
+	// deliveries stored before snapshots are derived again until backfilled
+	_, err = dbTest.ExecContext(ctx, "UPDATE deliveries SET derived = false WHERE id = $1", c.Delivery.ID)
+	require.NoError(t, err)
+
+	underived, err := deliveryTest.FindUnderived(ctx, dbTest, "", 1000000)
+	require.NoError(t, err)
+	require.Contains(t, underived, c.TrackingID)
+
+	found, err = cargoTest.Find(ctx, dbTest, c.TrackingID)
+	require.NoError(t, err)
+	require.False(t, found.Delivery.IsMisdirected)
+	require.Equal(t, NotReceived, found.Delivery.TransportStatus)
+}
+
+func TestDeliveryIsLate(t *testing.T) {
+	now := time.Now()
+	itinerary := Itinerary{Legs: []Leg{{VoyageNumber: "V100", LoadLocation: "SESTO", UnloadLocation: "AUMEL", LoadTime: now, UnloadTime: now.Add(48 * time.Hour)}}}
+
+	d := Delivery{Itinerary: itinerary, RouteSpecification: RouteSpecification{Origin: "SESTO", Destination: "AUMEL", ArrivalDeadline: now.Add(24 * time.Hour)}}
+	require.True(t, d.IsLate())
+
+	d.IsUnloadedAtDestination = true
+	require.False(t, d.IsLate())
+
+	d = Delivery{RouteSpecification: d.RouteSpecification}
+	require.False(t, d.IsLate())
+}
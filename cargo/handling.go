@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/mproyyan/grpc-shipping-microservice/db"
 	"github.com/mproyyan/grpc-shipping-microservice/location"
@@ -25,6 +27,7 @@ type HandlingEvent struct {
 	ID         int64
 	TrackingID TrackingID
 	Activity   HandlingActivity
+	Completed  time.Time
 }
 
 // HandlingEventType describes type of a handling event.
@@ -59,6 +62,22 @@ func (t HandlingEventType) String() string {
 	return ""
 }
 
+// ErrUnknownEventType is returned when parsing an unknown event type.
+var ErrUnknownEventType = errors.New("unknown handling event type")
+
+// ParseHandlingEventType parses the name of a handling event type as
+// returned by String, ignoring case. NotHandled cannot be parsed, it is not
+// an event that can be registered.
+func ParseHandlingEventType(s string) (HandlingEventType, error) {
+	for _, t := range []HandlingEventType{Load, Unload, Receive, Claim, Customs} {
+		if strings.EqualFold(s, t.String()) {
+			return t, nil
+		}
+	}
+
+	return NotHandled, ErrUnknownEventType
+}
+
 // HandlingHistory is the handling history of a cargo.
 type HandlingHistory struct {
 	HandlingEvents []HandlingEvent
@@ -73,6 +92,17 @@ func (h HandlingHistory) MostRecentlyCompletedEvent() (HandlingEvent, error) {
 	return h.HandlingEvents[len(h.HandlingEvents)-1], nil
 }
 
+// Event returns the handling event with the id.
+func (h HandlingHistory) Event(id int64) (HandlingEvent, bool) {
+	for _, e := range h.HandlingEvents {
+		if e.ID == id {
+			return e, true
+		}
+	}
+
+	return HandlingEvent{}, false
+}
+
 type EventRepositoryContract interface {
 	Store(ctx context.Context, dbtx db.DBTX, e HandlingEvent) (HandlingEvent, error)
 	QueryHandlingHistory(ctx context.Context, dbtx db.DBTX, id TrackingID) (HandlingHistory, error)
@@ -91,6 +121,7 @@ type eventResult struct {
 	eventType    sql.NullInt32
 	location     sql.NullString
 	voyageNumber sql.NullString
+	completed    sql.NullTime
 }
 
 func (er eventResult) build() HandlingEvent {
@@ -106,19 +137,25 @@ func (er eventResult) build() HandlingEvent {
 			Location:     location.UNLocode(er.location.String),
 			VoyageNumber: voyage.Number(er.voyageNumber.String),
 		},
+		Completed: er.completed.Time,
 	}
 }
 
 func (er EventRepository) Store(ctx context.Context, dbtx db.DBTX, e HandlingEvent) (HandlingEvent, error) {
 	query := `
-		INSERT INTO events (tracking_id, event_type, location, voyage_number)
-		VALUES ($1, $2, $3, $4)
-		RETURNING id, tracking_id, event_type, location, voyage_number
+		INSERT INTO events (tracking_id, event_type, location, voyage_number, completed_at)
+		VALUES ($1, $2, $3, $4, COALESCE($5, now()))
+		RETURNING id, tracking_id, event_type, location, voyage_number, completed_at
 	`
 
+	var completed *time.Time
+	if !e.Completed.IsZero() {
+		completed = &e.Completed
+	}
+
 	var result eventResult
-	row := dbtx.QueryRowContext(ctx, query, e.TrackingID, e.Activity.Type, e.Activity.Location, e.Activity.VoyageNumber)
-	err := row.Scan(&result.id, &result.trackingId, &result.eventType, &result.location, &result.voyageNumber)
+	row := dbtx.QueryRowContext(ctx, query, e.TrackingID, e.Activity.Type, e.Activity.Location, e.Activity.VoyageNumber, completed)
+	err := row.Scan(&result.id, &result.trackingId, &result.eventType, &result.location, &result.voyageNumber, &result.completed)
 	if err != nil {
 		return HandlingEvent{}, err
 	}
@@ -128,8 +165,8 @@ func (er EventRepository) Store(ctx context.Context, dbtx db.DBTX, e HandlingEve
 
 func (er EventRepository) QueryHandlingHistory(ctx context.Context, dbtx db.DBTX, id TrackingID) (HandlingHistory, error) {
 	query := `
-		SELECT id, tracking_id, event_type, location, voyage_number FROM events
-		WHERE tracking_id = $1
+		SELECT id, tracking_id, event_type, location, voyage_number, completed_at FROM events
+		WHERE tracking_id = $1 ORDER BY completed_at, id
 	`
 
 	var result eventResult
@@ -146,6 +183,7 @@ func (er EventRepository) QueryHandlingHistory(ctx context.Context, dbtx db.DBTX
 			&result.eventType,
 			&result.location,
 			&result.voyageNumber,
+			&result.completed,
 		)
 
 		if err != nil {
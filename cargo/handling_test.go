@@ -44,3 +44,17 @@ func TestQueryEventHistory(t *testing.T) {
 	require.NoError(t, err)
 	require.GreaterOrEqual(t, len(h.HandlingEvents), 1)
 }
+
+func TestParseHandlingEventType(t *testing.T) {
+	for _, s := range []string{"Load", "load", "UNLOAD", "Receive", "claim", "Customs"} {
+		_, err := ParseHandlingEventType(s)
+		require.NoError(t, err, s)
+	}
+
+	typ, err := ParseHandlingEventType("unload")
+	require.NoError(t, err)
+	require.Equal(t, Unload, typ)
+
+	_, err = ParseHandlingEventType("Not Handled")
+	require.ErrorIs(t, err, ErrUnknownEventType)
+}
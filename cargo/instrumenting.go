@@ -0,0 +1,131 @@
+package cargo
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+	"github.com/mproyyan/grpc-shipping-microservice/voyage"
+)
+
+// Repository decorators below record the latency of every query, labeled by
+// repository, method and outcome.
+
+func observeQuery(latency metrics.Histogram, repository, method string, begin time.Time, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+
+	latency.With("repository", repository, "method", method, "outcome", outcome).Observe(time.Since(begin).Seconds())
+}
+
+type instrumentingCargoRepository struct {
+	latency metrics.Histogram
+	CargoRepositoryContract
+}
+
+// NewInstrumentingCargoRepository returns a cargo repository that records
+// query latency.
+func NewInstrumentingCargoRepository(latency metrics.Histogram, r CargoRepositoryContract) CargoRepositoryContract {
+	return &instrumentingCargoRepository{latency: latency, CargoRepositoryContract: r}
+}
+
+func (r *instrumentingCargoRepository) Upsert(ctx context.Context, dbtx db.DBTX, cargo *Cargo) (c *Cargo, err error) {
+	defer func(begin time.Time) { observeQuery(r.latency, "cargo", "upsert", begin, err) }(time.Now())
+	return r.CargoRepositoryContract.Upsert(ctx, dbtx, cargo)
+}
+
+func (r *instrumentingCargoRepository) Find(ctx context.Context, dbtx db.DBTX, trackingID TrackingID) (c *Cargo, err error) {
+	defer func(begin time.Time) { observeQuery(r.latency, "cargo", "find", begin, err) }(time.Now())
+	return r.CargoRepositoryContract.Find(ctx, dbtx, trackingID)
+}
+
+func (r *instrumentingCargoRepository) FindForUpdate(ctx context.Context, dbtx db.DBTX, trackingID TrackingID) (c *Cargo, err error) {
+	defer func(begin time.Time) { observeQuery(r.latency, "cargo", "find_for_update", begin, err) }(time.Now())
+	return r.CargoRepositoryContract.FindForUpdate(ctx, dbtx, trackingID)
+}
+
+func (r *instrumentingCargoRepository) FindAll(ctx context.Context, dbtx db.DBTX) (cs []*Cargo, err error) {
+	defer func(begin time.Time) { observeQuery(r.latency, "cargo", "find_all", begin, err) }(time.Now())
+	return r.CargoRepositoryContract.FindAll(ctx, dbtx)
+}
+
+func (r *instrumentingCargoRepository) FindMatching(ctx context.Context, dbtx db.DBTX, filter Filter) (cs []*Cargo, err error) {
+	defer func(begin time.Time) { observeQuery(r.latency, "cargo", "find_matching", begin, err) }(time.Now())
+	return r.CargoRepositoryContract.FindMatching(ctx, dbtx, filter)
+}
+
+func (r *instrumentingCargoRepository) FindOnVoyage(ctx context.Context, dbtx db.DBTX, n voyage.Number) (cs []*Cargo, err error) {
+	defer func(begin time.Time) { observeQuery(r.latency, "cargo", "find_on_voyage", begin, err) }(time.Now())
+	return r.CargoRepositoryContract.FindOnVoyage(ctx, dbtx, n)
+}
+
+type instrumentingItineraryRepository struct {
+	latency metrics.Histogram
+	ItineraryRepositoryContract
+}
+
+// NewInstrumentingItineraryRepository returns an itinerary repository that
+// records query latency.
+func NewInstrumentingItineraryRepository(latency metrics.Histogram, r ItineraryRepositoryContract) ItineraryRepositoryContract {
+	return &instrumentingItineraryRepository{latency: latency, ItineraryRepositoryContract: r}
+}
+
+func (r *instrumentingItineraryRepository) Upsert(ctx context.Context, dbtx db.DBTX, itinerary Itinerary) (i Itinerary, err error) {
+	defer func(begin time.Time) { observeQuery(r.latency, "itinerary", "upsert", begin, err) }(time.Now())
+	return r.ItineraryRepositoryContract.Upsert(ctx, dbtx, itinerary)
+}
+
+func (r *instrumentingItineraryRepository) Find(ctx context.Context, dbtx db.DBTX, id int64) (i Itinerary, err error) {
+	defer func(begin time.Time) { observeQuery(r.latency, "itinerary", "find", begin, err) }(time.Now())
+	return r.ItineraryRepositoryContract.Find(ctx, dbtx, id)
+}
+
+type instrumentingDeliveryRepository struct {
+	latency metrics.Histogram
+	DeliveryRepositoryContract
+}
+
+// NewInstrumentingDeliveryRepository returns a delivery repository that
+// records query latency.
+func NewInstrumentingDeliveryRepository(latency metrics.Histogram, r DeliveryRepositoryContract) DeliveryRepositoryContract {
+	return &instrumentingDeliveryRepository{latency: latency, DeliveryRepositoryContract: r}
+}
+
+func (r *instrumentingDeliveryRepository) Upsert(ctx context.Context, dbtx db.DBTX, delivery Delivery) (d Delivery, err error) {
+	defer func(begin time.Time) { observeQuery(r.latency, "delivery", "upsert", begin, err) }(time.Now())
+	return r.DeliveryRepositoryContract.Upsert(ctx, dbtx, delivery)
+}
+
+func (r *instrumentingDeliveryRepository) Find(ctx context.Context, dbtx db.DBTX, id int64) (d Delivery, err error) {
+	defer func(begin time.Time) { observeQuery(r.latency, "delivery", "find", begin, err) }(time.Now())
+	return r.DeliveryRepositoryContract.Find(ctx, dbtx, id)
+}
+
+func (r *instrumentingDeliveryRepository) FindUnderived(ctx context.Context, dbtx db.DBTX, after TrackingID, limit int) (ids []TrackingID, err error) {
+	defer func(begin time.Time) { observeQuery(r.latency, "delivery", "find_underived", begin, err) }(time.Now())
+	return r.DeliveryRepositoryContract.FindUnderived(ctx, dbtx, after, limit)
+}
+
+type instrumentingEventRepository struct {
+	latency metrics.Histogram
+	EventRepositoryContract
+}
+
+// NewInstrumentingEventRepository returns an event repository that records
+// query latency.
+func NewInstrumentingEventRepository(latency metrics.Histogram, r EventRepositoryContract) EventRepositoryContract {
+	return &instrumentingEventRepository{latency: latency, EventRepositoryContract: r}
+}
+
+func (r *instrumentingEventRepository) Store(ctx context.Context, dbtx db.DBTX, e HandlingEvent) (he HandlingEvent, err error) {
+	defer func(begin time.Time) { observeQuery(r.latency, "event", "store", begin, err) }(time.Now())
+	return r.EventRepositoryContract.Store(ctx, dbtx, e)
+}
+
+func (r *instrumentingEventRepository) QueryHandlingHistory(ctx context.Context, dbtx db.DBTX, id TrackingID) (h HandlingHistory, err error) {
+	defer func(begin time.Time) { observeQuery(r.latency, "event", "query_handling_history", begin, err) }(time.Now())
+	return r.EventRepositoryContract.QueryHandlingHistory(ctx, dbtx, id)
+}
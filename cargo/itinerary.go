@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/mproyyan/grpc-shipping-microservice/db"
@@ -11,6 +12,10 @@ import (
 	"github.com/mproyyan/grpc-shipping-microservice/voyage"
 )
 
+// ErrUnscheduled is returned when a leg of an itinerary is on an unknown
+// voyage or does not match the schedule of its voyage.
+var ErrUnscheduled = errors.New("itinerary leg is not on the schedule of its voyage")
+
 // Leg describes the transportation between two locations on a voyage.
 type Leg struct {
 	VoyageNumber   voyage.Number     `json:"voyage_number"`
@@ -92,11 +97,33 @@ func (i Itinerary) IsExpected(event HandlingEvent) bool {
 		return false
 	case Claim:
 		return i.FinalArrivalLocation() == event.Activity.Location
+	case Customs:
+		// cargos clear export customs in the country they leave and import
+		// customs in the country they are bound for
+		country := event.Activity.Location.Country()
+		return country == i.InitialDepartureLocation().Country() || country == i.FinalArrivalLocation().Country()
 	}
 
 	return true
 }
 
+// CheckSchedule returns ErrUnscheduled when a leg of the itinerary is on a
+// voyage missing from voyages, or does not match the voyage schedule.
+func (i Itinerary) CheckSchedule(voyages map[voyage.Number]*voyage.Voyage) error {
+	for _, leg := range i.Legs {
+		v, ok := voyages[leg.VoyageNumber]
+		if !ok {
+			return ErrUnscheduled
+		}
+
+		if _, _, ok := v.Schedule.Span(leg.LoadLocation, leg.UnloadLocation); !ok {
+			return ErrUnscheduled
+		}
+	}
+
+	return nil
+}
+
 type ItineraryRepositoryContract interface {
 	Upsert(ctx context.Context, dbtx db.DBTX, itinerary Itinerary) (Itinerary, error)
 	Find(ctx context.Context, dbtx db.DBTX, id int64) (Itinerary, error)
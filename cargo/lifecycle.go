@@ -0,0 +1,127 @@
+package cargo
+
+import (
+	"errors"
+	"strings"
+)
+
+// BookingStatus describes where a cargo is in the lifecycle of its booking.
+// A booking moves forward only: Booked, InTransit, Delivered and Closed as
+// the cargo is handled, or from Booked to Cancelled when it is cancelled
+// before being loaded.
+type BookingStatus int
+
+// Valid booking statuses.
+const (
+	Booked BookingStatus = iota
+	InTransit
+	Delivered
+	Cancelled
+	Closed
+)
+
+func (s BookingStatus) String() string {
+	switch s {
+	case Booked:
+		return "Booked"
+	case InTransit:
+		return "In transit"
+	case Delivered:
+		return "Delivered"
+	case Cancelled:
+		return "Cancelled"
+	case Closed:
+		return "Closed"
+	}
+
+	return ""
+}
+
+// Active reports whether the booking can still change, cancelled and closed
+// bookings are final.
+func (s BookingStatus) Active() bool {
+	return s != Cancelled && s != Closed
+}
+
+var (
+	// ErrUnknownBookingStatus is returned when parsing an unknown status.
+	ErrUnknownBookingStatus = errors.New("unknown booking status")
+
+	// ErrInvalidTransition is returned when the booking cannot move to the
+	// requested status from its current one.
+	ErrInvalidTransition = errors.New("invalid booking status transition")
+
+	// ErrInactive is returned when changing a cancelled or closed booking.
+	ErrInactive = errors.New("booking is cancelled or closed")
+)
+
+// bookingStatuses lists every booking status.
+var bookingStatuses = []BookingStatus{Booked, InTransit, Delivered, Cancelled, Closed}
+
+// ParseBookingStatus parses the name of a booking status as returned by
+// String, ignoring case.
+func ParseBookingStatus(s string) (BookingStatus, error) {
+	for _, status := range bookingStatuses {
+		if strings.EqualFold(s, status.String()) {
+			return status, nil
+		}
+	}
+
+	return Booked, ErrUnknownBookingStatus
+}
+
+// transitions lists the statuses a booking can move to from each status.
+var transitions = map[BookingStatus][]BookingStatus{
+	Booked:    {InTransit, Delivered, Closed, Cancelled},
+	InTransit: {Delivered, Closed},
+	Delivered: {Closed},
+}
+
+// CanTransition reports whether a booking can move from s to next.
+func (s BookingStatus) CanTransition(next BookingStatus) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Cancel cancels the booking. Only bookings whose cargo has not been loaded
+// yet can be cancelled.
+func (c *Cargo) Cancel(history HandlingHistory) error {
+	if !c.Status.CanTransition(Cancelled) {
+		return ErrInvalidTransition
+	}
+
+	for _, e := range history.HandlingEvents {
+		if e.Activity.Type == Load {
+			return ErrInvalidTransition
+		}
+	}
+
+	c.Status = Cancelled
+	return nil
+}
+
+// deriveStatus moves the booking forward to the status the handling
+// history reflects. Claiming the cargo closes the booking, unloading it at
+// its destination delivers it and loading it puts it in transit.
+func (c *Cargo) deriveStatus(history HandlingHistory) {
+	next := Booked
+	for _, e := range history.HandlingEvents {
+		switch {
+		case e.Activity.Type == Claim:
+			next = Closed
+		case next < Delivered && e.Activity.Type == Unload && e.Activity.Location == c.RouteSpecification.Destination:
+			next = Delivered
+		case next < InTransit && e.Activity.Type == Load:
+			next = InTransit
+		}
+	}
+
+	if c.Status.CanTransition(next) {
+		c.Status = next
+	}
+}
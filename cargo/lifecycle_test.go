@@ -0,0 +1,69 @@
+package cargo
+
+import (
+	"testing"
+
+	"github.com/mproyyan/grpc-shipping-microservice/location"
+	"github.com/stretchr/testify/require"
+)
+
+func handled(types ...HandlingEventType) HandlingHistory {
+	var h HandlingHistory
+	for _, t := range types {
+		loc := location.UNLocode("SESTO")
+		if t == Unload || t == Claim {
+			loc = "AUMEL"
+		}
+
+		h.HandlingEvents = append(h.HandlingEvents, HandlingEvent{Activity: HandlingActivity{Type: t, Location: loc}})
+	}
+
+	return h
+}
+
+func TestCancelCargo(t *testing.T) {
+	c := New("ABC", RouteSpecification{Origin: "SESTO", Destination: "AUMEL"})
+	require.Equal(t, Booked, c.Status)
+	require.NoError(t, c.Cancel(handled(Receive)))
+	require.Equal(t, Cancelled, c.Status)
+	require.Equal(t, ErrInvalidTransition, c.Cancel(handled()))
+
+	c = New("ABC", RouteSpecification{Origin: "SESTO", Destination: "AUMEL"})
+	require.Equal(t, ErrInvalidTransition, c.Cancel(handled(Receive, Load)))
+	require.Equal(t, Booked, c.Status)
+}
+
+func TestDeriveBookingStatus(t *testing.T) {
+	c := New("ABC", RouteSpecification{Origin: "SESTO", Destination: "AUMEL"})
+
+	c.DeriveDeliveryProgress(handled(Receive))
+	require.Equal(t, Booked, c.Status)
+
+	c.DeriveDeliveryProgress(handled(Receive, Load))
+	require.Equal(t, InTransit, c.Status)
+
+	c.DeriveDeliveryProgress(handled(Receive, Load, Unload))
+	require.Equal(t, Delivered, c.Status)
+
+	// the status never moves back
+	c.DeriveDeliveryProgress(handled(Receive))
+	require.Equal(t, Delivered, c.Status)
+
+	c.DeriveDeliveryProgress(handled(Receive, Load, Unload, Claim))
+	require.Equal(t, Closed, c.Status)
+	require.False(t, c.Status.Active())
+
+	c = New("ABC", RouteSpecification{Origin: "SESTO", Destination: "AUMEL"})
+	require.NoError(t, c.Cancel(handled()))
+	c.DeriveDeliveryProgress(handled(Load))
+	require.Equal(t, Cancelled, c.Status)
+}
+
+func TestParseBookingStatus(t *testing.T) {
+	s, err := ParseBookingStatus("in transit")
+	require.NoError(t, err)
+	require.Equal(t, InTransit, s)
+
+	_, err = ParseBookingStatus("lost")
+	require.Equal(t, ErrUnknownBookingStatus, err)
+}
@@ -0,0 +1,72 @@
+package cargo
+
+import (
+	"errors"
+
+	"github.com/mproyyan/grpc-shipping-microservice/imdg"
+	"github.com/mproyyan/grpc-shipping-microservice/location"
+	"github.com/mproyyan/grpc-shipping-microservice/voyage"
+)
+
+// ErrRestricted is returned when an itinerary would take dangerous goods
+// through a port or on a voyage that does not accept their class.
+var ErrRestricted = errors.New("itinerary takes dangerous goods where their class is not allowed")
+
+// Restrictions hold the dangerous goods policies of the ports and voyages
+// an itinerary goes through. Ports and voyages missing from them accept
+// every class.
+type Restrictions struct {
+	Locations map[location.UNLocode]*location.Location
+	Voyages   map[voyage.Number]*voyage.Voyage
+}
+
+// Permits reports whether the leg may carry the goods: the voyage, the load
+// and unload locations and every port the vessel calls at in between must
+// accept them. Route search skips the legs that are not permitted.
+func (r Restrictions) Permits(leg Leg, goods imdg.Goods) bool {
+	if !goods.IsDangerous() {
+		return true
+	}
+
+	for _, port := range r.ports(leg) {
+		if l, ok := r.Locations[port]; ok && !l.DangerousGoods.Permits(goods) {
+			return false
+		}
+	}
+
+	v, ok := r.Voyages[leg.VoyageNumber]
+	return !ok || v.DangerousGoods.Permits(goods)
+}
+
+// Check returns ErrRestricted when a leg of the itinerary is not permitted
+// to carry the goods, and ErrUnscheduled when a leg is on a voyage missing
+// from the restrictions or does not match its schedule.
+func (r Restrictions) Check(itinerary Itinerary, goods imdg.Goods) error {
+	if err := itinerary.CheckSchedule(r.Voyages); err != nil {
+		return err
+	}
+
+	for _, leg := range itinerary.Legs {
+		if !r.Permits(leg, goods) {
+			return ErrRestricted
+		}
+	}
+
+	return nil
+}
+
+// ports returns the locations the cargo goes through on the leg, the calls
+// in between being known from the schedule of the voyage only.
+func (r Restrictions) ports(leg Leg) []location.UNLocode {
+	ports := []location.UNLocode{leg.LoadLocation}
+
+	if v, ok := r.Voyages[leg.VoyageNumber]; ok {
+		if first, last, ok := v.Schedule.Span(leg.LoadLocation, leg.UnloadLocation); ok {
+			for _, m := range v.Schedule.CarrierMovements[first:last] {
+				ports = append(ports, m.ArrivalLocation)
+			}
+		}
+	}
+
+	return append(ports, leg.UnloadLocation)
+}
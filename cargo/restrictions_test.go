@@ -0,0 +1,54 @@
+package cargo
+
+import (
+	"testing"
+
+	"github.com/mproyyan/grpc-shipping-microservice/imdg"
+	"github.com/mproyyan/grpc-shipping-microservice/location"
+	"github.com/mproyyan/grpc-shipping-microservice/voyage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestrictions(t *testing.T) {
+	v := voyage.New("V100", voyage.Schedule{CarrierMovements: []voyage.CarrierMovement{
+		{DepartureLocation: "SESTO", ArrivalLocation: "DEHAM"},
+		{DepartureLocation: "DEHAM", ArrivalLocation: "CNHKG"},
+	}})
+	tanker := voyage.New("V200", voyage.Schedule{CarrierMovements: []voyage.CarrierMovement{
+		{DepartureLocation: "SESTO", ArrivalLocation: "CNHKG"},
+	}})
+	tanker.DangerousGoods = imdg.Only("2", "3")
+
+	r := Restrictions{
+		Locations: map[location.UNLocode]*location.Location{
+			"DEHAM": {UNLocode: "DEHAM", DangerousGoods: imdg.Only("3")},
+		},
+		Voyages: map[voyage.Number]*voyage.Voyage{v.Number: v, tanker.Number: tanker},
+	}
+
+	petrol := imdg.Goods{Class: "3", UNNumber: "1203"}
+	propane := imdg.Goods{Class: "2.1", UNNumber: "1978"}
+	explosives := imdg.Goods{Class: "1.1", UNNumber: "0081"}
+
+	// the vessel calls at Hamburg on its way to Hong Kong
+	throughHamburg := Leg{VoyageNumber: "V100", LoadLocation: "SESTO", UnloadLocation: "CNHKG"}
+	require.True(t, r.Permits(throughHamburg, imdg.Goods{}))
+	require.True(t, r.Permits(throughHamburg, petrol))
+	require.False(t, r.Permits(throughHamburg, propane))
+
+	onTanker := Leg{VoyageNumber: "V200", LoadLocation: "SESTO", UnloadLocation: "CNHKG"}
+	require.True(t, r.Permits(onTanker, propane))
+	require.False(t, r.Permits(onTanker, explosives))
+
+	// unknown voyages and ports are unrestricted
+	require.True(t, r.Permits(Leg{VoyageNumber: "V300", LoadLocation: "SESTO", UnloadLocation: "CNHKG"}, explosives))
+
+	require.NoError(t, r.Check(Itinerary{Legs: []Leg{onTanker}}, propane))
+	require.Equal(t, ErrRestricted, r.Check(Itinerary{Legs: []Leg{onTanker, throughHamburg}}, propane))
+
+	// legs the restrictions cannot follow on a schedule are rejected
+	unknown := Leg{VoyageNumber: "V300", LoadLocation: "SESTO", UnloadLocation: "CNHKG"}
+	require.Equal(t, ErrUnscheduled, r.Check(Itinerary{Legs: []Leg{unknown}}, propane))
+	backwards := Leg{VoyageNumber: "V100", LoadLocation: "CNHKG", UnloadLocation: "SESTO"}
+	require.Equal(t, ErrUnscheduled, r.Check(Itinerary{Legs: []Leg{backwards}}, propane))
+}
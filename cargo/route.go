@@ -15,11 +15,13 @@ type RouteSpecification struct {
 }
 
 // IsSatisfiedBy checks whether provided itinerary satisfies this
-// specification.
+// specification. An itinerary arriving after the deadline does not, unless
+// no deadline is set.
 func (s RouteSpecification) IsSatisfiedBy(itinerary Itinerary) bool {
 	return itinerary.Legs != nil &&
 		s.Origin == itinerary.InitialDepartureLocation() &&
-		s.Destination == itinerary.FinalArrivalLocation()
+		s.Destination == itinerary.FinalArrivalLocation() &&
+		(s.ArrivalDeadline.IsZero() || !itinerary.FinalArrivalTime().After(s.ArrivalDeadline))
 }
 
 // RoutingStatus describes status of cargo routing.
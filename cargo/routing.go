@@ -0,0 +1,96 @@
+package cargo
+
+import (
+	"sort"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/location"
+	"github.com/mproyyan/grpc-shipping-microservice/voyage"
+)
+
+// MaxRoutes is the most itineraries Routes returns.
+const MaxRoutes = 10
+
+// Routes returns the itineraries satisfying the route specification on the
+// given voyages, departing after the given time. They go either directly on
+// one voyage or with one transshipment between two voyages, and are ordered
+// by arrival time, then by number of legs.
+func Routes(rs RouteSpecification, voyages []*voyage.Voyage, after time.Time) []Itinerary {
+	var routes []Itinerary
+	for _, first := range voyages {
+		for _, leg := range legsFrom(first, rs.Origin, after) {
+			if leg.UnloadLocation == rs.Destination {
+				routes = append(routes, Itinerary{Legs: []Leg{leg}})
+				continue
+			}
+
+			for _, second := range voyages {
+				if second.Number == first.Number {
+					continue
+				}
+
+				if next, ok := legBetween(second, leg.UnloadLocation, rs.Destination, leg.UnloadTime); ok {
+					routes = append(routes, Itinerary{Legs: []Leg{leg, next}})
+				}
+			}
+		}
+	}
+
+	var satisfying []Itinerary
+	for _, r := range routes {
+		if rs.IsSatisfiedBy(r) {
+			satisfying = append(satisfying, r)
+		}
+	}
+
+	sort.SliceStable(satisfying, func(i, j int) bool {
+		a, b := satisfying[i], satisfying[j]
+		if !a.FinalArrivalTime().Equal(b.FinalArrivalTime()) {
+			return a.FinalArrivalTime().Before(b.FinalArrivalTime())
+		}
+
+		return len(a.Legs) < len(b.Legs)
+	})
+
+	if len(satisfying) > MaxRoutes {
+		satisfying = satisfying[:MaxRoutes]
+	}
+
+	return satisfying
+}
+
+// legsFrom returns every leg of the voyage loading at from after the given
+// time, one per port the vessel calls at afterwards.
+func legsFrom(v *voyage.Voyage, from location.UNLocode, after time.Time) []Leg {
+	var legs []Leg
+	movements := v.Schedule.CarrierMovements
+	for i, m := range movements {
+		if m.DepartureLocation != from || !m.DepartureTime.After(after) {
+			continue
+		}
+
+		for _, last := range movements[i:] {
+			if last.ArrivalLocation == from {
+				break
+			}
+
+			legs = append(legs, NewLeg(v.Number, from, last.ArrivalLocation, m.DepartureTime, last.ArrivalTime))
+		}
+
+		break
+	}
+
+	return legs
+}
+
+// legBetween returns the leg of the voyage from one location to another,
+// loading after the given time.
+func legBetween(v *voyage.Voyage, from, to location.UNLocode, after time.Time) (Leg, bool) {
+	for _, leg := range legsFrom(v, from, after) {
+		if leg.UnloadLocation == to {
+			return leg, true
+		}
+	}
+
+	return Leg{}, false
+}
@@ -0,0 +1,47 @@
+package cargo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/voyage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoutes(t *testing.T) {
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	direct := voyage.New("V100", voyage.Schedule{CarrierMovements: []voyage.CarrierMovement{
+		{DepartureLocation: "SESTO", ArrivalLocation: "DEHAM", DepartureTime: now.Add(day), ArrivalTime: now.Add(2 * day)},
+		{DepartureLocation: "DEHAM", ArrivalLocation: "AUMEL", DepartureTime: now.Add(3 * day), ArrivalTime: now.Add(20 * day)},
+	}})
+	feeder := voyage.New("V200", voyage.Schedule{CarrierMovements: []voyage.CarrierMovement{
+		{DepartureLocation: "SESTO", ArrivalLocation: "NLRTM", DepartureTime: now.Add(day), ArrivalTime: now.Add(3 * day)},
+	}})
+	mainline := voyage.New("V300", voyage.Schedule{CarrierMovements: []voyage.CarrierMovement{
+		{DepartureLocation: "NLRTM", ArrivalLocation: "AUMEL", DepartureTime: now.Add(4 * day), ArrivalTime: now.Add(15 * day)},
+	}})
+	early := voyage.New("V400", voyage.Schedule{CarrierMovements: []voyage.CarrierMovement{
+		{DepartureLocation: "NLRTM", ArrivalLocation: "AUMEL", DepartureTime: now.Add(2 * day), ArrivalTime: now.Add(10 * day)},
+	}})
+	voyages := []*voyage.Voyage{direct, feeder, mainline, early}
+
+	rs := RouteSpecification{Origin: "SESTO", Destination: "AUMEL", ArrivalDeadline: now.Add(30 * day)}
+	routes := Routes(rs, voyages, now)
+	require.Len(t, routes, 2)
+
+	// the transshipment arrives first, V400 leaves before the feeder arrives
+	require.Len(t, routes[0].Legs, 2)
+	require.Equal(t, voyage.Number("V200"), routes[0].Legs[0].VoyageNumber)
+	require.Equal(t, voyage.Number("V300"), routes[0].Legs[1].VoyageNumber)
+	require.Equal(t, now.Add(15*day), routes[0].FinalArrivalTime())
+
+	require.Len(t, routes[1].Legs, 1)
+	require.Equal(t, NewLeg("V100", "SESTO", "AUMEL", now.Add(day), now.Add(20*day)), routes[1].Legs[0])
+
+	rs.ArrivalDeadline = now.Add(16 * day)
+	require.Len(t, Routes(rs, voyages, now), 1)
+
+	require.Empty(t, Routes(rs, voyages, now.Add(day)))
+}
@@ -0,0 +1,139 @@
+package cargo
+
+import (
+	"context"
+
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+	"github.com/mproyyan/grpc-shipping-microservice/tracing"
+	"github.com/mproyyan/grpc-shipping-microservice/voyage"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Repository decorators below wrap every call in a client span, so slow
+// queries show up as children of the request that issued them.
+
+var tracer = tracing.Tracer("github.com/mproyyan/grpc-shipping-microservice/cargo")
+
+func startQuerySpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemPostgreSQL),
+	)
+}
+
+type tracingCargoRepository struct {
+	CargoRepositoryContract
+}
+
+// NewTracingCargoRepository returns a cargo repository that traces queries.
+func NewTracingCargoRepository(r CargoRepositoryContract) CargoRepositoryContract {
+	return &tracingCargoRepository{CargoRepositoryContract: r}
+}
+
+func (r *tracingCargoRepository) Upsert(ctx context.Context, dbtx db.DBTX, cargo *Cargo) (c *Cargo, err error) {
+	ctx, span := startQuerySpan(ctx, "CargoRepository.Upsert")
+	defer func() { tracing.End(span, err) }()
+	return r.CargoRepositoryContract.Upsert(ctx, dbtx, cargo)
+}
+
+func (r *tracingCargoRepository) Find(ctx context.Context, dbtx db.DBTX, trackingID TrackingID) (c *Cargo, err error) {
+	ctx, span := startQuerySpan(ctx, "CargoRepository.Find")
+	defer func() { tracing.End(span, err) }()
+	return r.CargoRepositoryContract.Find(ctx, dbtx, trackingID)
+}
+
+func (r *tracingCargoRepository) FindForUpdate(ctx context.Context, dbtx db.DBTX, trackingID TrackingID) (c *Cargo, err error) {
+	ctx, span := startQuerySpan(ctx, "CargoRepository.FindForUpdate")
+	defer func() { tracing.End(span, err) }()
+	return r.CargoRepositoryContract.FindForUpdate(ctx, dbtx, trackingID)
+}
+
+func (r *tracingCargoRepository) FindAll(ctx context.Context, dbtx db.DBTX) (cs []*Cargo, err error) {
+	ctx, span := startQuerySpan(ctx, "CargoRepository.FindAll")
+	defer func() { tracing.End(span, err) }()
+	return r.CargoRepositoryContract.FindAll(ctx, dbtx)
+}
+
+func (r *tracingCargoRepository) FindMatching(ctx context.Context, dbtx db.DBTX, filter Filter) (cs []*Cargo, err error) {
+	ctx, span := startQuerySpan(ctx, "CargoRepository.FindMatching")
+	defer func() { tracing.End(span, err) }()
+	return r.CargoRepositoryContract.FindMatching(ctx, dbtx, filter)
+}
+
+func (r *tracingCargoRepository) FindOnVoyage(ctx context.Context, dbtx db.DBTX, n voyage.Number) (cs []*Cargo, err error) {
+	ctx, span := startQuerySpan(ctx, "CargoRepository.FindOnVoyage")
+	defer func() { tracing.End(span, err) }()
+	return r.CargoRepositoryContract.FindOnVoyage(ctx, dbtx, n)
+}
+
+type tracingItineraryRepository struct {
+	ItineraryRepositoryContract
+}
+
+// NewTracingItineraryRepository returns an itinerary repository that traces
+// queries.
+func NewTracingItineraryRepository(r ItineraryRepositoryContract) ItineraryRepositoryContract {
+	return &tracingItineraryRepository{ItineraryRepositoryContract: r}
+}
+
+func (r *tracingItineraryRepository) Upsert(ctx context.Context, dbtx db.DBTX, itinerary Itinerary) (i Itinerary, err error) {
+	ctx, span := startQuerySpan(ctx, "ItineraryRepository.Upsert")
+	defer func() { tracing.End(span, err) }()
+	return r.ItineraryRepositoryContract.Upsert(ctx, dbtx, itinerary)
+}
+
+func (r *tracingItineraryRepository) Find(ctx context.Context, dbtx db.DBTX, id int64) (i Itinerary, err error) {
+	ctx, span := startQuerySpan(ctx, "ItineraryRepository.Find")
+	defer func() { tracing.End(span, err) }()
+	return r.ItineraryRepositoryContract.Find(ctx, dbtx, id)
+}
+
+type tracingDeliveryRepository struct {
+	DeliveryRepositoryContract
+}
+
+// NewTracingDeliveryRepository returns a delivery repository that traces
+// queries.
+func NewTracingDeliveryRepository(r DeliveryRepositoryContract) DeliveryRepositoryContract {
+	return &tracingDeliveryRepository{DeliveryRepositoryContract: r}
+}
+
+func (r *tracingDeliveryRepository) Upsert(ctx context.Context, dbtx db.DBTX, delivery Delivery) (d Delivery, err error) {
+	ctx, span := startQuerySpan(ctx, "DeliveryRepository.Upsert")
+	defer func() { tracing.End(span, err) }()
+	return r.DeliveryRepositoryContract.Upsert(ctx, dbtx, delivery)
+}
+
+func (r *tracingDeliveryRepository) Find(ctx context.Context, dbtx db.DBTX, id int64) (d Delivery, err error) {
+	ctx, span := startQuerySpan(ctx, "DeliveryRepository.Find")
+	defer func() { tracing.End(span, err) }()
+	return r.DeliveryRepositoryContract.Find(ctx, dbtx, id)
+}
+
+func (r *tracingDeliveryRepository) FindUnderived(ctx context.Context, dbtx db.DBTX, after TrackingID, limit int) (ids []TrackingID, err error) {
+	ctx, span := startQuerySpan(ctx, "DeliveryRepository.FindUnderived")
+	defer func() { tracing.End(span, err) }()
+	return r.DeliveryRepositoryContract.FindUnderived(ctx, dbtx, after, limit)
+}
+
+type tracingEventRepository struct {
+	EventRepositoryContract
+}
+
+// NewTracingEventRepository returns an event repository that traces queries.
+func NewTracingEventRepository(r EventRepositoryContract) EventRepositoryContract {
+	return &tracingEventRepository{EventRepositoryContract: r}
+}
+
+func (r *tracingEventRepository) Store(ctx context.Context, dbtx db.DBTX, e HandlingEvent) (he HandlingEvent, err error) {
+	ctx, span := startQuerySpan(ctx, "EventRepository.Store")
+	defer func() { tracing.End(span, err) }()
+	return r.EventRepositoryContract.Store(ctx, dbtx, e)
+}
+
+func (r *tracingEventRepository) QueryHandlingHistory(ctx context.Context, dbtx db.DBTX, id TrackingID) (h HandlingHistory, err error) {
+	ctx, span := startQuerySpan(ctx, "EventRepository.QueryHandlingHistory")
+	defer func() { tracing.End(span, err) }()
+	return r.EventRepositoryContract.QueryHandlingHistory(ctx, dbtx, id)
+}
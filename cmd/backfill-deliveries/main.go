@@ -0,0 +1,106 @@
+// Command backfill-deliveries persists the full delivery snapshot of cargos
+// stored before it was, so they can be queried by routing and transport
+// status, ETA or misdirection.
+//
+//	backfill-deliveries [-batch N]
+//
+// It reads the database settings from app.env like the booking service, and
+// can be run while the service is up: every batch is stored in its own
+// transaction and a run that was interrupted resumes where it stopped.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/config"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+)
+
+func main() {
+	batch := flag.Int("batch", 100, "number of cargos stored per transaction")
+	flag.Parse()
+
+	if *batch <= 0 {
+		log.Fatal("backfill-deliveries: -batch must be positive")
+	}
+
+	env, err := config.LoadEnv(".", "app")
+	if err != nil {
+		log.Fatal("failed to load environment file :", err)
+	}
+
+	conn, err := db.NewPostgreSQL(env).Connect()
+	if err != nil {
+		log.Fatal("failed to open database connection :", err)
+	}
+	defer conn.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	total, err := backfill(ctx, conn, *batch)
+	if err != nil {
+		log.Fatalf("backfill-deliveries: %v (%d cargos backfilled)", err, total)
+	}
+
+	log.Printf("backfill complete, %d cargos backfilled", total)
+}
+
+// backfill stores the delivery of every cargo without a derived snapshot,
+// batch cargos per transaction, and returns how many it stored. The stored
+// snapshots cannot be trusted, their last event column held the type of the
+// event rather than its id, so every delivery is derived again from the
+// handling history of its cargo.
+func backfill(ctx context.Context, conn *sql.DB, batch int) (int, error) {
+	var (
+		deliveries = cargo.NewDeliveryRepository()
+		cargos     = cargo.NewCargoRepository(cargo.NewItineraryRepository(), deliveries)
+		events     = cargo.NewEventRepository()
+		after      cargo.TrackingID
+		total      int
+	)
+	for {
+		ids, err := deliveries.FindUnderived(ctx, conn, after, batch)
+		if err != nil {
+			return total, err
+		}
+
+		if len(ids) == 0 {
+			return total, nil
+		}
+
+		err = db.WithTx(ctx, conn, func(tx *sql.Tx) error {
+			for _, id := range ids {
+				c, err := cargos.FindForUpdate(ctx, tx, id)
+				if err != nil {
+					return err
+				}
+
+				history, err := events.QueryHandlingHistory(ctx, tx, id)
+				if err != nil {
+					return err
+				}
+
+				c.DeriveDeliveryProgress(history)
+				if _, err := deliveries.Upsert(ctx, tx, c.Delivery); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return total, err
+		}
+
+		after = ids[len(ids)-1]
+		total += len(ids)
+		log.Printf("backfilled %d cargos, up to %s", total, after)
+	}
+}
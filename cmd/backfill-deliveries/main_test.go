@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/config"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackfillDerivesFromHistory(t *testing.T) {
+	ctx := context.Background()
+	conn, err := db.NewPostgreSQL(config.Environment{
+		DBUsername: "postgres",
+		DBPassword: "ligmaballs",
+		DBHost:     "localhost",
+		DBPort:     "5432",
+		DBName:     "grpc_shipping",
+	}).Connect()
+	require.NoError(t, err)
+	defer conn.Close()
+
+	deliveries := cargo.NewDeliveryRepository()
+	cargos := cargo.NewCargoRepository(cargo.NewItineraryRepository(), deliveries)
+	events := cargo.NewEventRepository()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	c := cargo.New(cargo.NextTrackingID(), cargo.RouteSpecification{Origin: "SESTO", Destination: "AUMEL", ArrivalDeadline: now.AddDate(0, 0, 30)})
+	c.AssignToRoute(cargo.Itinerary{Legs: []cargo.Leg{cargo.NewLeg("V100", "SESTO", "AUMEL", now.Add(time.Hour), now.AddDate(0, 0, 20))}})
+	c, err = cargos.Upsert(ctx, conn, c)
+	require.NoError(t, err)
+
+	var load cargo.HandlingEvent
+	for i, activity := range []cargo.HandlingActivity{
+		{Type: cargo.Receive, Location: "SESTO"},
+		{Type: cargo.Load, Location: "SESTO", VoyageNumber: "V100"},
+	} {
+		load, err = events.Store(ctx, conn, cargo.HandlingEvent{TrackingID: c.TrackingID, Activity: activity, Completed: now.Add(time.Duration(i) * time.Minute)})
+		require.NoError(t, err)
+	}
+
+	// rows stored before snapshots were derived hold the type of the last
+	// event in last_event, which points at another cargo's event, if any
+	_, err = conn.ExecContext(ctx, `
+		UPDATE deliveries SET derived = false, transport_status = 0,
+		last_event = (SELECT id FROM events WHERE id = $2)
+		WHERE id = $1
+	`, c.Delivery.ID, int64(cargo.Load))
+	require.NoError(t, err)
+
+	_, err = backfill(ctx, conn, 100)
+	require.NoError(t, err)
+
+	underived, err := deliveries.FindUnderived(ctx, conn, "", 1000000)
+	require.NoError(t, err)
+	require.NotContains(t, underived, c.TrackingID)
+
+	found, err := cargos.Find(ctx, conn, c.TrackingID)
+	require.NoError(t, err)
+	require.Equal(t, load.ID, found.Delivery.LastEvent.ID)
+	require.Equal(t, cargo.OnboardCarrier, found.Delivery.TransportStatus)
+	require.EqualValues(t, "V100", found.Delivery.CurrentVoyage)
+}
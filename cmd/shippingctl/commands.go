@@ -0,0 +1,703 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/billing"
+	"github.com/mproyyan/grpc-shipping-microservice/booking/services"
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/imdg"
+	"github.com/mproyyan/grpc-shipping-microservice/location"
+	"github.com/mproyyan/grpc-shipping-microservice/pricing"
+	"github.com/mproyyan/grpc-shipping-microservice/shipment"
+	"github.com/mproyyan/grpc-shipping-microservice/voyage"
+	"github.com/mproyyan/grpc-shipping-microservice/webhook"
+)
+
+// usageError reports wrong arguments, it is printed with the usage of the
+// command.
+type usageError string
+
+func (e usageError) Error() string { return string(e) }
+
+// timeFlag is an optional RFC 3339 timestamp flag.
+type timeFlag struct{ time.Time }
+
+func (t *timeFlag) String() string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+func (t *timeFlag) Set(s string) (err error) {
+	t.Time, err = time.Parse(time.RFC3339, s)
+	return err
+}
+
+// legsFlag collects repeated -leg VOYAGE,FROM,TO,LOAD,UNLOAD flags, LOAD and
+// UNLOAD being RFC 3339 timestamps.
+type legsFlag []cargo.Leg
+
+func (l *legsFlag) String() string { return fmt.Sprintf("%d legs", len(*l)) }
+
+func (l *legsFlag) Set(s string) error {
+	leg, err := parseLeg(s)
+	if err != nil {
+		return err
+	}
+
+	*l = append(*l, leg)
+	return nil
+}
+
+func parseLeg(s string) (cargo.Leg, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 5 {
+		return cargo.Leg{}, fmt.Errorf("leg %q: want VOYAGE,FROM,TO,LOAD,UNLOAD", s)
+	}
+
+	load, err := time.Parse(time.RFC3339, parts[3])
+	if err != nil {
+		return cargo.Leg{}, fmt.Errorf("leg %q: load time: %w", s, err)
+	}
+
+	unload, err := time.Parse(time.RFC3339, parts[4])
+	if err != nil {
+		return cargo.Leg{}, fmt.Errorf("leg %q: unload time: %w", s, err)
+	}
+
+	return cargo.NewLeg(voyage.Number(parts[0]), location.UNLocode(parts[1]), location.UNLocode(parts[2]), load, unload), nil
+}
+
+// parse parses the flags of a command and checks it got want positional
+// arguments, any number when want is negative.
+func parse(fs *flag.FlagSet, args []string, want int) ([]string, error) {
+	fs.SetOutput(os.Stderr)
+	if err := fs.Parse(args); err != nil {
+		return nil, usageError(err.Error())
+	}
+
+	if want >= 0 && fs.NArg() != want {
+		return nil, usageError(fmt.Sprintf("want %d arguments, got %d", want, fs.NArg()))
+	}
+
+	if want < 0 && fs.NArg() == 0 {
+		return nil, usageError("want at least one argument")
+	}
+
+	return fs.Args(), nil
+}
+
+func quote(e env, args []string) error {
+	var (
+		fs          = flag.NewFlagSet("quote", flag.ContinueOnError)
+		origin      = fs.String("origin", "", "origin UN/LOCODE")
+		destination = fs.String("destination", "", "destination UN/LOCODE")
+		weight      = fs.Float64("weight", 0, "weight in kilograms")
+		volume      = fs.Float64("volume", 0, "volume in cubic metres")
+		imdgClass   = fs.String("imdg-class", "", "IMDG class of dangerous goods, e.g. 3 or 2.1")
+		unNumber    = fs.String("un-number", "", "UN number of dangerous goods, e.g. 1203")
+		deadline    timeFlag
+	)
+	fs.Var(&deadline, "deadline", "arrival deadline")
+
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	rs := cargo.RouteSpecification{
+		Origin:          location.UNLocode(*origin),
+		Destination:     location.UNLocode(*destination),
+		ArrivalDeadline: deadline.Time,
+	}
+
+	attributes := cargo.Attributes{
+		Weight: *weight,
+		Volume: *volume,
+		DangerousGoods: imdg.Goods{
+			Class:    imdg.Class(*imdgClass),
+			UNNumber: imdg.UNNumber(*unNumber),
+		},
+	}
+
+	q, err := e.pricing.Quote(e.ctx, rs, attributes)
+	if err != nil {
+		return err
+	}
+
+	return e.out.quote(q)
+}
+
+func book(e env, args []string) error {
+	var (
+		fs          = flag.NewFlagSet("book", flag.ContinueOnError)
+		origin      = fs.String("origin", "", "origin UN/LOCODE")
+		destination = fs.String("destination", "", "destination UN/LOCODE")
+		weight      = fs.Float64("weight", 0, "weight in kilograms")
+		volume      = fs.Float64("volume", 0, "volume in cubic metres")
+		packages    = fs.Int("packages", 0, "number of packages")
+		commodity   = fs.String("commodity", "", "description of the goods")
+		hsCode      = fs.String("hs-code", "", "Harmonized System code of the goods")
+		imdgClass   = fs.String("imdg-class", "", "IMDG class of dangerous goods, e.g. 3 or 2.1")
+		unNumber    = fs.String("un-number", "", "UN number of dangerous goods, e.g. 1203")
+		quoteID     = fs.String("quote", "", "quote to book at, given for the same route and attributes")
+		option      = fs.Int("option", 0, "option of the quote to book at")
+		deadline    timeFlag
+	)
+	fs.Var(&deadline, "deadline", "arrival deadline")
+
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	attributes := cargo.Attributes{
+		Weight:    *weight,
+		Volume:    *volume,
+		Packages:  *packages,
+		Commodity: *commodity,
+		HSCode:    *hsCode,
+		DangerousGoods: imdg.Goods{
+			Class:    imdg.Class(*imdgClass),
+			UNNumber: imdg.UNNumber(*unNumber),
+		},
+	}
+
+	quote := pricing.Acceptance{QuoteID: pricing.QuoteID(*quoteID), Option: *option}
+	id, err := e.booking.BookNewCargo(e.ctx, location.UNLocode(*origin), location.UNLocode(*destination), deadline.Time, attributes, quote)
+	if err != nil {
+		return err
+	}
+
+	return e.out.done(id, "booked")
+}
+
+// token issues a bearer token for the caller given by the global flags, to
+// be handed to the customer calling the gateway.
+func token(e env, args []string) error {
+	fs := flag.NewFlagSet("token", flag.ContinueOnError)
+	ttl := fs.Duration("ttl", 30*24*time.Hour, "how long the token is valid")
+
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	caller, _ := customer.FromContext(e.ctx)
+	if caller.CustomerID == "" && !caller.Staff {
+		return usageError("-customer or -staff is required")
+	}
+
+	expires := time.Now().Add(*ttl).Truncate(time.Second)
+	return e.out.token(e.tokens.Issue(caller, expires), expires)
+}
+
+func show(e env, args []string) error {
+	args, err := parse(flag.NewFlagSet("show", flag.ContinueOnError), args, 1)
+	if err != nil {
+		return err
+	}
+
+	c, err := e.booking.LoadCargo(e.ctx, cargo.TrackingID(args[0]))
+	if err != nil {
+		return err
+	}
+
+	return e.out.cargo(c)
+}
+
+func history(e env, args []string) error {
+	args, err := parse(flag.NewFlagSet("history", flag.ContinueOnError), args, 1)
+	if err != nil {
+		return err
+	}
+
+	events, err := e.booking.HandlingHistory(e.ctx, cargo.TrackingID(args[0]))
+	if err != nil {
+		return err
+	}
+
+	return e.out.history(events)
+}
+
+func list(e env, args []string) error {
+	var (
+		fs            = flag.NewFlagSet("list", flag.ContinueOnError)
+		origin        = fs.String("origin", "", "only cargos leaving this UN/LOCODE")
+		destination   = fs.String("destination", "", "only cargos bound for this UN/LOCODE")
+		customerID    = fs.String("customer-id", "", "only cargos of this customer, staff only")
+		excludeClosed = fs.Bool("exclude-closed", false, "leave out cargos whose booking is closed or cancelled")
+		misdirected   = fs.Bool("misdirected", false, "only misdirected cargos")
+		late          = fs.Bool("late", false, "only cargos expected after their arrival deadline")
+		deadlineFrom  timeFlag
+		deadlineTo    timeFlag
+	)
+	fs.Var(&deadlineFrom, "deadline-from", "only cargos due at or after this time")
+	fs.Var(&deadlineTo, "deadline-to", "only cargos due before this time")
+
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	cargos, err := e.booking.Cargos(e.ctx, cargo.Filter{
+		Origin:        location.UNLocode(*origin),
+		Destination:   location.UNLocode(*destination),
+		CustomerID:    customer.ID(*customerID),
+		DeadlineFrom:  deadlineFrom.Time,
+		DeadlineTo:    deadlineTo.Time,
+		ExcludeClosed: *excludeClosed,
+		Misdirected:   *misdirected,
+		Late:          *late,
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.out.cargos(cargos)
+}
+
+func assignRoute(e env, args []string) error {
+	var (
+		fs          = flag.NewFlagSet("assign-route", flag.ContinueOnError)
+		itineraryID = fs.Int64("itinerary-id", 0, "id of the itinerary being replaced, if any")
+		legs        legsFlag
+	)
+	fs.Var(&legs, "leg", "itinerary leg as VOYAGE,FROM,TO,LOAD,UNLOAD, repeat for every leg")
+
+	args, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	if len(legs) == 0 {
+		return usageError("at least one -leg is required")
+	}
+
+	id := cargo.TrackingID(args[0])
+	if err := e.booking.AssignCargoToRoute(e.ctx, id, cargo.Itinerary{ID: *itineraryID, Legs: legs}); err != nil {
+		return err
+	}
+
+	return e.out.done(id, "routed")
+}
+
+func changeDestination(e env, args []string) error {
+	args, err := parse(flag.NewFlagSet("change-destination", flag.ContinueOnError), args, 2)
+	if err != nil {
+		return err
+	}
+
+	id := cargo.TrackingID(args[0])
+	if err := e.booking.ChangeDestination(e.ctx, id, location.UNLocode(args[1])); err != nil {
+		return err
+	}
+
+	return e.out.done(id, "destination changed")
+}
+
+func updateRoute(e env, args []string) error {
+	var (
+		fs          = flag.NewFlagSet("update-route", flag.ContinueOnError)
+		origin      = fs.String("origin", "", "new origin UN/LOCODE, only before the cargo is received")
+		destination = fs.String("destination", "", "new destination UN/LOCODE")
+		deadline    timeFlag
+	)
+	fs.Var(&deadline, "deadline", "new arrival deadline")
+
+	args, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	id := cargo.TrackingID(args[0])
+	satisfied, err := e.booking.UpdateRouteSpecification(e.ctx, id, cargo.RouteSpecification{
+		Origin:          location.UNLocode(*origin),
+		Destination:     location.UNLocode(*destination),
+		ArrivalDeadline: deadline.Time,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !satisfied {
+		return e.out.done(id, "route specification updated, the cargo needs a new route")
+	}
+
+	return e.out.done(id, "route specification updated")
+}
+
+func cancel(e env, args []string) error {
+	args, err := parse(flag.NewFlagSet("cancel", flag.ContinueOnError), args, 1)
+	if err != nil {
+		return err
+	}
+
+	id := cargo.TrackingID(args[0])
+	if err := e.booking.CancelBooking(e.ctx, id); err != nil {
+		return err
+	}
+
+	return e.out.done(id, "booking cancelled")
+}
+
+func hold(e env, args []string) error {
+	var (
+		fs     = flag.NewFlagSet("hold", flag.ContinueOnError)
+		reason = fs.String("reason", "", "why customs hold the cargo")
+	)
+
+	args, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	id := cargo.TrackingID(args[0])
+	if err := e.booking.HoldCargo(e.ctx, id, *reason); err != nil {
+		return err
+	}
+
+	return e.out.done(id, "placed on customs hold")
+}
+
+func release(e env, args []string) error {
+	args, err := parse(flag.NewFlagSet("release", flag.ContinueOnError), args, 1)
+	if err != nil {
+		return err
+	}
+
+	id := cargo.TrackingID(args[0])
+	if err := e.booking.ReleaseCargo(e.ctx, id); err != nil {
+		return err
+	}
+
+	return e.out.done(id, "released from customs hold")
+}
+
+// handle registers a handling event for one cargo, or with -container for
+// every cargo in the container.
+func handle(e env, args []string) error {
+	var (
+		fs           = flag.NewFlagSet("handle", flag.ContinueOnError)
+		eventType    = fs.String("type", "", "event type: load, unload, receive, claim or customs")
+		loc          = fs.String("location", "", "UN/LOCODE where the cargo was handled")
+		voyageNumber = fs.String("voyage", "", "voyage number, required for load and unload")
+		container    = fs.String("container", "", "handle every cargo in this container instead of one cargo")
+		completed    timeFlag
+	)
+	fs.Var(&completed, "completed", "when the handling completed, now by default")
+
+	want := 1
+	if hasFlag(args, "container") {
+		want = 0
+	}
+
+	args, err := parse(fs, args, want)
+	if err != nil {
+		return err
+	}
+
+	t, err := cargo.ParseHandlingEventType(*eventType)
+	if err != nil {
+		return usageError(err.Error())
+	}
+
+	if *container != "" {
+		ids, err := e.handling.RegisterContainerHandlingEvent(e.ctx, completed.Time, shipment.ContainerID(*container), voyage.Number(*voyageNumber), location.UNLocode(*loc), t)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			if err := e.out.done(id, "handling event registered"); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	id := cargo.TrackingID(args[0])
+	err = e.handling.RegisterHandlingEvent(e.ctx, completed.Time, id, voyage.Number(*voyageNumber), location.UNLocode(*loc), t)
+	if err != nil {
+		return err
+	}
+
+	return e.out.done(id, "handling event registered")
+}
+
+// ingestEdifact sends every file as one EDIFACT interchange, - reading
+// standard input. It fails if any message was rejected, after reporting on
+// all files.
+func ingestEdifact(e env, args []string) error {
+	args, err := parse(flag.NewFlagSet("ingest-edifact", flag.ContinueOnError), args, -1)
+	if err != nil {
+		return err
+	}
+
+	var messages, rejected int
+	for _, name := range args {
+		var data []byte
+		if name == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(name)
+		}
+		if err != nil {
+			return err
+		}
+
+		report, err := e.handling.IngestEDIFACT(e.ctx, data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		if err := e.out.ingested(report); err != nil {
+			return err
+		}
+
+		messages += len(report.Results)
+		rejected += len(report.Rejected())
+	}
+
+	if rejected > 0 {
+		return fmt.Errorf("%d of %d messages rejected", rejected, messages)
+	}
+
+	return nil
+}
+
+// hasFlag reports whether the flag is among args, before parsing them.
+func hasFlag(args []string, name string) bool {
+	for _, arg := range args {
+		arg = strings.TrimLeft(arg, "-")
+		if arg == name || strings.HasPrefix(arg, name+"=") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// watch polls the given cargos and prints them every time they change,
+// until interrupted.
+func watch(e env, args []string) error {
+	var (
+		fs       = flag.NewFlagSet("watch", flag.ContinueOnError)
+		interval = fs.Duration("interval", 5*time.Second, "time between polls")
+	)
+
+	args, err := parse(fs, args, -1)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(e.ctx, os.Interrupt)
+	defer stop()
+
+	last := make(map[string]services.Cargo)
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+
+	for {
+		for _, id := range args {
+			c, err := poll(ctx, e, cargo.TrackingID(id))
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "shippingctl: %s: %v\n", id, err)
+				continue
+			}
+
+			if prev, seen := last[id]; seen && reflect.DeepEqual(prev, c) {
+				continue
+			}
+
+			last[id] = c
+			if err := e.out.watched(time.Now(), c); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func poll(ctx context.Context, e env, id cargo.TrackingID) (services.Cargo, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+
+	return e.booking.LoadCargo(ctx, id)
+}
+
+func invoices(e env, args []string) error {
+	var (
+		fs         = flag.NewFlagSet("invoices", flag.ContinueOnError)
+		customerID = fs.String("customer-id", "", "invoices of this customer, staff only")
+	)
+
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	invoices, err := e.billing.Invoices(e.ctx, customer.ID(*customerID))
+	if err != nil {
+		return err
+	}
+
+	return e.out.invoices(invoices)
+}
+
+func finalizeInvoice(e env, args []string) error {
+	args, err := parse(flag.NewFlagSet("finalize-invoice", flag.ContinueOnError), args, 1)
+	if err != nil {
+		return err
+	}
+
+	i, err := e.billing.FinalizeInvoice(e.ctx, billing.InvoiceID(args[0]))
+	if err != nil {
+		return err
+	}
+
+	return e.out.invoices([]billing.Invoice{i})
+}
+
+// renderInvoice writes the rendered invoice to stdout unless a file is
+// given, the output format does not apply.
+func renderInvoice(e env, args []string) error {
+	var (
+		fs     = flag.NewFlagSet("render-invoice", flag.ContinueOnError)
+		format = fs.String("format", billing.FormatPDF, "document format: "+strings.Join(billing.Formats, " or "))
+		file   = fs.String("file", "", "write the document to this file")
+	)
+
+	args, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	document, err := e.billing.RenderInvoice(e.ctx, billing.InvoiceID(args[0]), *format)
+	if err != nil {
+		return err
+	}
+
+	if *file != "" {
+		return os.WriteFile(*file, document, 0o644)
+	}
+
+	_, err = os.Stdout.Write(document)
+	return err
+}
+
+// eventsFlag collects repeated -event NAME flags.
+type eventsFlag []string
+
+func (f *eventsFlag) String() string { return strings.Join(*f, ",") }
+
+func (f *eventsFlag) Set(s string) error {
+	*f = append(*f, s)
+	return nil
+}
+
+// subscribe prints the new subscription along with its secret, which is
+// not shown afterwards.
+func subscribe(e env, args []string) error {
+	var (
+		fs         = flag.NewFlagSet("subscribe", flag.ContinueOnError)
+		customerID = fs.String("customer-id", "", "subscribe for this customer, staff only")
+		secret     = fs.String("secret", "", "secret to sign payloads with, generated when empty")
+		events     eventsFlag
+	)
+
+	fs.Var(&events, "event", "event to post, repeatable, every event when unset: "+strings.Join(webhook.Filter(webhook.Events).Names(), ", "))
+	args, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	filter, err := webhook.ParseFilter(events)
+	if err != nil {
+		return usageError(err.Error())
+	}
+
+	s, err := e.webhooks.Subscribe(e.ctx, customer.ID(*customerID), args[0], filter, *secret)
+	if err != nil {
+		return err
+	}
+
+	return e.out.subscriptions([]webhook.Subscription{s})
+}
+
+func subscriptions(e env, args []string) error {
+	var (
+		fs         = flag.NewFlagSet("subscriptions", flag.ContinueOnError)
+		customerID = fs.String("customer-id", "", "subscriptions of this customer, staff only")
+	)
+
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	subscriptions, err := e.webhooks.Subscriptions(e.ctx, customer.ID(*customerID))
+	if err != nil {
+		return err
+	}
+
+	return e.out.subscriptions(subscriptions)
+}
+
+// unsubscribe prints nothing on success.
+func unsubscribe(e env, args []string) error {
+	args, err := parse(flag.NewFlagSet("unsubscribe", flag.ContinueOnError), args, 1)
+	if err != nil {
+		return err
+	}
+
+	return e.webhooks.Unsubscribe(e.ctx, webhook.SubscriptionID(args[0]))
+}
+
+func failedDeliveries(e env, args []string) error {
+	var (
+		fs         = flag.NewFlagSet("failed-deliveries", flag.ContinueOnError)
+		customerID = fs.String("customer-id", "", "dead letters of this customer, staff only")
+	)
+
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	deliveries, err := e.webhooks.FailedDeliveries(e.ctx, customer.ID(*customerID))
+	if err != nil {
+		return err
+	}
+
+	return e.out.deliveries(deliveries)
+}
+
+func replayDelivery(e env, args []string) error {
+	args, err := parse(flag.NewFlagSet("replay-delivery", flag.ContinueOnError), args, 1)
+	if err != nil {
+		return err
+	}
+
+	d, err := e.webhooks.ReplayDelivery(e.ctx, webhook.DeliveryID(args[0]))
+	if err != nil {
+		return err
+	}
+
+	return e.out.deliveries([]webhook.Delivery{d})
+}
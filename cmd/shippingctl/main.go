@@ -0,0 +1,218 @@
+// Command shippingctl administers the booking service over gRPC.
+//
+//	shippingctl [global flags] <command> [flags] [args]
+//
+// The booking service is reached either directly with -addr or through the
+// first passing instance registered in Consul. Calls are made as the caller
+// given by -customer and -staff, asserted with tokens signed by -auth.key.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/mproyyan/grpc-shipping-microservice/booking/services"
+	"github.com/mproyyan/grpc-shipping-microservice/booking/transports"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"google.golang.org/grpc"
+)
+
+// env is what every command runs with.
+type env struct {
+	ctx      context.Context
+	booking  services.BookingServiceContract
+	handling services.HandlingServiceContract
+	pricing  services.PricingServiceContract
+	billing  services.BillingServiceContract
+	webhooks services.WebhookServiceContract
+	tokens   customer.Tokens
+	out      printer
+	timeout  time.Duration
+}
+
+type command struct {
+	usage string
+	run   func(e env, args []string) error
+}
+
+var commands = map[string]command{
+	"quote":              {"-origin LOCODE -destination LOCODE -deadline RFC3339 [-weight KG] [-volume M3] [-imdg-class CLASS -un-number NUMBER]", quote},
+	"book":               {"-origin LOCODE -destination LOCODE -deadline RFC3339 [-weight KG] [-volume M3] [-packages N] [-commodity TEXT] [-hs-code CODE] [-imdg-class CLASS -un-number NUMBER] [-quote ID [-option N]]", book},
+	"token":              {"[-ttl DURATION]", token},
+	"show":               {"TRACKING_ID", show},
+	"history":            {"TRACKING_ID", history},
+	"list":               {"[-origin LOCODE] [-destination LOCODE] [-customer-id ID] [-deadline-from RFC3339] [-deadline-to RFC3339] [-exclude-closed] [-misdirected] [-late]", list},
+	"assign-route":       {"[-itinerary-id ID] -leg VOYAGE,FROM,TO,LOAD,UNLOAD... TRACKING_ID", assignRoute},
+	"change-destination": {"TRACKING_ID LOCODE", changeDestination},
+	"update-route":       {"[-origin LOCODE] [-destination LOCODE] [-deadline RFC3339] TRACKING_ID", updateRoute},
+	"cancel":             {"TRACKING_ID", cancel},
+	"hold":               {"-reason TEXT TRACKING_ID", hold},
+	"release":            {"TRACKING_ID", release},
+	"handle":             {"-type TYPE -location LOCODE [-voyage NUMBER] [-completed RFC3339] (TRACKING_ID | -container ID)", handle},
+	"ingest-edifact":     {"FILE...", ingestEdifact},
+	"watch":              {"[-interval DURATION] TRACKING_ID...", watch},
+	"invoices":           {"[-customer-id ID]", invoices},
+	"finalize-invoice":   {"INVOICE_ID", finalizeInvoice},
+	"render-invoice":     {"[-format json|pdf] [-file PATH] INVOICE_ID", renderInvoice},
+	"subscribe":          {"[-customer-id ID] [-event NAME]... [-secret SECRET] URL", subscribe},
+	"subscriptions":      {"[-customer-id ID]", subscriptions},
+	"unsubscribe":        {"SUBSCRIPTION_ID", unsubscribe},
+	"failed-deliveries":  {"[-customer-id ID]", failedDeliveries},
+	"replay-delivery":    {"DELIVERY_ID", replayDelivery},
+}
+
+func main() {
+	var (
+		addr       = flag.String("addr", "", "booking service address, skips discovery when set")
+		consulAddr = flag.String("consul.addr", "", "Consul agent address used for discovery")
+		service    = flag.String("service", "bookingservice", "service name to discover in Consul")
+		customerID = flag.String("customer", "", "act on behalf of this customer")
+		staff      = flag.Bool("staff", false, "act as staff")
+		authKey    = flag.String("auth.key", os.Getenv("AUTH_KEY"), "key the caller tokens are signed with, AUTH_KEY when unset")
+		output     = flag.String("o", "table", "output format: table or json")
+		timeout    = flag.Duration("timeout", 10*time.Second, "timeout of every call, watch uses it per poll")
+	)
+
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[flag.Arg(0)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "shippingctl: unknown command %q\n", flag.Arg(0))
+		usage()
+		os.Exit(2)
+	}
+
+	out, err := newPrinter(*output, os.Stdout)
+	if err != nil {
+		fatal(err)
+	}
+
+	tokens, err := customer.NewTokens(*authKey)
+	if err != nil {
+		fatal(err)
+	}
+
+	e := env{
+		ctx: customer.NewContext(context.Background(), customer.Caller{
+			CustomerID: customer.ID(*customerID),
+			Staff:      *staff,
+		}),
+		tokens:  tokens,
+		out:     out,
+		timeout: *timeout,
+	}
+
+	// token is signed locally, there is no service to reach.
+	if flag.Arg(0) != "token" {
+		target, err := resolve(*addr, *consulAddr, *service)
+		if err != nil {
+			fatal(err)
+		}
+
+		conn, err := grpc.Dial(target, append([]grpc.DialOption{grpc.WithInsecure()}, transports.CallerDialOptions(tokens)...)...)
+		if err != nil {
+			fatal(err)
+		}
+		defer conn.Close()
+
+		e.booking = transports.NewGRPCClient(conn)
+		e.handling = transports.NewHandlingGRPCClient(conn)
+		e.pricing = transports.NewPricingGRPCClient(conn)
+		e.billing = transports.NewBillingGRPCClient(conn)
+		e.webhooks = transports.NewWebhookGRPCClient(conn)
+	}
+
+	if err := runCommand(e, flag.Arg(0), cmd, flag.Args()[1:]); err != nil {
+		fatal(err)
+	}
+}
+
+// runCommand bounds one-shot commands by timeout. watch runs until it is
+// interrupted and applies the timeout to every poll itself.
+func runCommand(e env, name string, cmd command, args []string) error {
+	if name == "watch" {
+		return cmd.run(e, args)
+	}
+
+	ctx, cancel := context.WithTimeout(e.ctx, e.timeout)
+	defer cancel()
+
+	e.ctx = ctx
+	return cmd.run(e, args)
+}
+
+// resolve returns addr when set, otherwise the address of a passing
+// instance of service found in Consul.
+func resolve(addr, consulAddr, service string) (string, error) {
+	if addr != "" {
+		return addr, nil
+	}
+
+	config := api.DefaultConfig()
+	if consulAddr != "" {
+		config.Address = consulAddr
+	}
+
+	client, err := api.NewClient(config)
+	if err != nil {
+		return "", err
+	}
+
+	entries, _, err := client.Health().Service(service, "", true, nil)
+	if err != nil {
+		return "", fmt.Errorf("discovering %s: %w", service, err)
+	}
+
+	if len(entries) == 0 {
+		return "", fmt.Errorf("no passing instance of %s registered in Consul", service)
+	}
+
+	s := entries[0].Service
+	host := s.Address
+	if host == "" {
+		host = entries[0].Node.Address
+	}
+
+	return fmt.Sprintf("%s:%d", host, s.Port), nil
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: shippingctl [global flags] <command> [flags] [args]")
+	fmt.Fprintln(os.Stderr, "\ncommands:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %s %s\n", name, commands[name].usage)
+	}
+
+	fmt.Fprintln(os.Stderr, "\nglobal flags:")
+	flag.PrintDefaults()
+}
+
+func fatal(err error) {
+	var usageErr usageError
+	if errors.As(err, &usageErr) {
+		fmt.Fprintf(os.Stderr, "shippingctl: %v\nusage: shippingctl %s %s\n", err, flag.Arg(0), commands[flag.Arg(0)].usage)
+		os.Exit(2)
+	}
+
+	fmt.Fprintf(os.Stderr, "shippingctl: %v\n", err)
+	os.Exit(1)
+}
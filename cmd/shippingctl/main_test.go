@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/booking/services"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLeg(t *testing.T) {
+	leg, err := parseLeg("V100,SESTO,AUMEL,2030-01-01T00:00:00Z,2030-01-08T00:00:00Z")
+	require.NoError(t, err)
+	require.EqualValues(t, "V100", leg.VoyageNumber)
+	require.EqualValues(t, "AUMEL", leg.UnloadLocation)
+	require.True(t, leg.UnloadTime.Equal(time.Date(2030, 1, 8, 0, 0, 0, 0, time.UTC)))
+
+	_, err = parseLeg("V100,SESTO,AUMEL")
+	require.Error(t, err)
+
+	_, err = parseLeg("V100,SESTO,AUMEL,tomorrow,2030-01-08T00:00:00Z")
+	require.Error(t, err)
+}
+
+func TestPrinters(t *testing.T) {
+	cargos := []services.Cargo{{TrackingID: "ABC123", Origin: "SESTO", Destination: "AUMEL", Status: "Booked", Routed: true}}
+
+	var buf bytes.Buffer
+	table, err := newPrinter("table", &buf)
+	require.NoError(t, err)
+	require.NoError(t, table.cargos(cargos))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	require.True(t, strings.HasPrefix(lines[0], "TRACKING ID"))
+	require.Equal(t, []string{"ABC123", "SESTO", "AUMEL", "-", "Booked", "true", "false"}, strings.Fields(lines[1]))
+
+	buf.Reset()
+	js, err := newPrinter("json", &buf)
+	require.NoError(t, err)
+	require.NoError(t, js.cargos(nil))
+	require.Equal(t, "[]\n", buf.String())
+
+	_, err = newPrinter("yaml", &buf)
+	require.Error(t, err)
+}
@@ -0,0 +1,386 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/billing"
+	"github.com/mproyyan/grpc-shipping-microservice/booking/services"
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/pricing"
+	"github.com/mproyyan/grpc-shipping-microservice/webhook"
+)
+
+// printer renders command results, either as aligned tables for people or
+// as JSON for scripts.
+type printer interface {
+	cargo(c services.Cargo) error
+	cargos(cs []services.Cargo) error
+	watched(at time.Time, c services.Cargo) error
+	history(events []services.HandlingEvent) error
+	quote(q pricing.Quote) error
+	invoices(is []billing.Invoice) error
+	subscriptions(ss []webhook.Subscription) error
+	deliveries(ds []webhook.Delivery) error
+	ingested(r services.IngestionReport) error
+	done(id cargo.TrackingID, what string) error
+	token(token string, expires time.Time) error
+}
+
+func newPrinter(format string, w io.Writer) (printer, error) {
+	switch format {
+	case "table":
+		return tablePrinter{w}, nil
+	case "json":
+		return jsonPrinter{json.NewEncoder(w)}, nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q, want table or json", format)
+	}
+}
+
+type tablePrinter struct {
+	w io.Writer
+}
+
+func (p tablePrinter) cargo(c services.Cargo) error {
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "Tracking ID:\t%s\n", c.TrackingID)
+	fmt.Fprintf(tw, "Customer:\t%s\n", c.CustomerID)
+	fmt.Fprintf(tw, "Origin:\t%s\n", c.Origin)
+	fmt.Fprintf(tw, "Destination:\t%s\n", c.Destination)
+	fmt.Fprintf(tw, "Arrival deadline:\t%s\n", formatTime(c.ArrivalDeadline))
+	fmt.Fprintf(tw, "Status:\t%s\n", c.Status)
+	if c.HoldReason != "" {
+		fmt.Fprintf(tw, "Customs:\t%s (%s)\n", c.Clearance, c.HoldReason)
+	} else if c.Clearance != "" {
+		fmt.Fprintf(tw, "Customs:\t%s\n", c.Clearance)
+	}
+	if c.Weight > 0 || c.Volume > 0 || c.Packages > 0 {
+		fmt.Fprintf(tw, "Weight:\t%g kg\n", c.Weight)
+		fmt.Fprintf(tw, "Volume:\t%g m3\n", c.Volume)
+		fmt.Fprintf(tw, "Packages:\t%d\n", c.Packages)
+	}
+	if c.Commodity != "" || c.HSCode != "" {
+		fmt.Fprintf(tw, "Commodity:\t%s\n", strings.TrimSpace(c.HSCode+" "+c.Commodity))
+	}
+	if c.IMDGClass != "" {
+		fmt.Fprintf(tw, "Dangerous goods:\tclass %s, UN %s\n", c.IMDGClass, c.UNNumber)
+	}
+	if c.Price != nil {
+		fmt.Fprintf(tw, "Price:\t%s (quote %s)\n", c.Price, c.QuoteID)
+	}
+	fmt.Fprintf(tw, "Routed:\t%t\n", c.Routed)
+	fmt.Fprintf(tw, "Misrouted:\t%t\n", c.Misrouted)
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(c.Legs) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(p.w)
+	tw = tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "VOYAGE\tFROM\tTO\tLOAD\tUNLOAD")
+	for _, l := range c.Legs {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", l.VoyageNumber, l.LoadLocation, l.UnloadLocation, formatTime(l.LoadTime), formatTime(l.UnloadTime))
+	}
+
+	return tw.Flush()
+}
+
+func (p tablePrinter) cargos(cs []services.Cargo) error {
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "TRACKING ID\tCUSTOMER\tORIGIN\tDESTINATION\tDEADLINE\tSTATUS\tCUSTOMS\tROUTED\tMISROUTED")
+	for _, c := range cs {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%t\t%t\n", c.TrackingID, c.CustomerID, c.Origin, c.Destination, formatTime(c.ArrivalDeadline), c.Status, c.Clearance, c.Routed, c.Misrouted)
+	}
+
+	return tw.Flush()
+}
+
+func (p tablePrinter) watched(at time.Time, c services.Cargo) error {
+	_, err := fmt.Fprintf(p.w, "%s  %s  status=%q customs=%q destination=%s routed=%t misrouted=%t legs=%d\n",
+		at.Format(time.RFC3339), c.TrackingID, c.Status, c.Clearance, c.Destination, c.Routed, c.Misrouted, len(c.Legs))
+	return err
+}
+
+func (p tablePrinter) quote(q pricing.Quote) error {
+	fmt.Fprintf(p.w, "Quote %s, valid until %s\n\n", q.ID, formatTime(q.Expires))
+
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "OPTION\tPRICE\tARRIVAL\tROUTE")
+	for i, o := range q.Options {
+		var route []string
+		for _, l := range o.Itinerary.Legs {
+			route = append(route, fmt.Sprintf("%s %s-%s", l.VoyageNumber, l.LoadLocation, l.UnloadLocation))
+		}
+
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", i, o.Price, formatTime(o.Itinerary.FinalArrivalTime()), strings.Join(route, ", "))
+	}
+
+	return tw.Flush()
+}
+
+func (p tablePrinter) history(events []services.HandlingEvent) error {
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "COMPLETED\tTYPE\tLOCATION\tNAME\tVOYAGE\tEXPECTED")
+	for _, e := range events {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%t\n", formatTime(e.Completed), e.Type, e.Location, orDash(e.LocationName), orDash(e.VoyageNumber), e.Expected)
+	}
+
+	return tw.Flush()
+}
+
+func (p tablePrinter) invoices(is []billing.Invoice) error {
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "INVOICE\tCUSTOMER\tSTATUS\tCREATED\tFINALIZED\tLINES\tTOTAL")
+	for _, i := range is {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%d\t%s\n", i.ID, i.CustomerID, i.Status, formatTime(i.Created), formatTime(i.Finalized), len(i.Lines), i.Total())
+	}
+
+	return tw.Flush()
+}
+
+// subscriptions shows the secret column only when a secret is known, i.e.
+// right after subscribing.
+func (p tablePrinter) subscriptions(ss []webhook.Subscription) error {
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "SUBSCRIPTION\tCUSTOMER\tURL\tEVENTS\tCREATED")
+	for _, s := range ss {
+		events := "all"
+		if len(s.Filter) > 0 {
+			events = strings.Join(s.Filter.Names(), ",")
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", s.ID, s.CustomerID, s.URL, events, formatTime(s.Created))
+	}
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	for _, s := range ss {
+		if s.Secret != "" {
+			fmt.Fprintf(p.w, "Secret of %s: %s\n", s.ID, s.Secret)
+		}
+	}
+
+	return nil
+}
+
+func (p tablePrinter) deliveries(ds []webhook.Delivery) error {
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "DELIVERY\tSUBSCRIPTION\tEVENT\tSTATUS\tATTEMPTS\tNEXT ATTEMPT\tCREATED\tLAST ERROR")
+	for _, d := range ds {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%s\t%s\t%s\n", d.ID, d.SubscriptionID, d.Event, d.Status, d.Attempts, formatTime(d.NextAttempt), formatTime(d.Created), d.LastError)
+	}
+
+	return tw.Flush()
+}
+
+func (p tablePrinter) ingested(r services.IngestionReport) error {
+	fmt.Fprintf(p.w, "Interchange %s from %s: %d messages, %d rejected\n", r.Interchange, r.Sender, len(r.Results), len(r.Rejected()))
+
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "MESSAGE\tTYPE\tEVENTS\tERROR")
+	for _, m := range r.Results {
+		errText := "-"
+		if m.Err != nil {
+			errText = m.Err.Error()
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\n", m.Reference, m.Type, m.Events, errText)
+	}
+
+	return tw.Flush()
+}
+
+func (p tablePrinter) done(id cargo.TrackingID, what string) error {
+	_, err := fmt.Fprintf(p.w, "%s: %s\n", id, what)
+	return err
+}
+
+func (p tablePrinter) token(token string, expires time.Time) error {
+	_, err := fmt.Fprintf(p.w, "%s\nExpires: %s\n", token, formatTime(expires))
+	return err
+}
+
+type jsonPrinter struct {
+	enc *json.Encoder
+}
+
+func (p jsonPrinter) cargo(c services.Cargo) error {
+	return p.enc.Encode(c)
+}
+
+func (p jsonPrinter) cargos(cs []services.Cargo) error {
+	if cs == nil {
+		cs = []services.Cargo{}
+	}
+
+	return p.enc.Encode(cs)
+}
+
+// watched writes one JSON document per line, so the output can be piped
+// into line oriented tools.
+func (p jsonPrinter) watched(at time.Time, c services.Cargo) error {
+	return p.enc.Encode(struct {
+		Time  time.Time      `json:"time"`
+		Cargo services.Cargo `json:"cargo"`
+	}{at, c})
+}
+
+func (p jsonPrinter) history(events []services.HandlingEvent) error {
+	if events == nil {
+		events = []services.HandlingEvent{}
+	}
+
+	return p.enc.Encode(events)
+}
+
+func (p jsonPrinter) quote(q pricing.Quote) error {
+	return p.enc.Encode(struct {
+		QuoteID         pricing.QuoteID  `json:"quote_id"`
+		Origin          string           `json:"origin"`
+		Destination     string           `json:"destination"`
+		ArrivalDeadline time.Time        `json:"arrival_deadline"`
+		Expires         time.Time        `json:"expires"`
+		Options         []pricing.Option `json:"options"`
+	}{q.ID, string(q.RouteSpecification.Origin), string(q.RouteSpecification.Destination), q.RouteSpecification.ArrivalDeadline, q.Expires, q.Options})
+}
+
+func (p jsonPrinter) invoices(is []billing.Invoice) error {
+	type line struct {
+		TrackingID  cargo.TrackingID `json:"tracking_id"`
+		Milestone   string           `json:"milestone"`
+		Description string           `json:"description"`
+		Amount      int64            `json:"amount"`
+		Occurred    time.Time        `json:"occurred"`
+	}
+
+	type invoice struct {
+		InvoiceID  billing.InvoiceID `json:"invoice_id"`
+		CustomerID string            `json:"customer_id"`
+		Status     string            `json:"status"`
+		Created    time.Time         `json:"created"`
+		Finalized  *time.Time        `json:"finalized,omitempty"`
+		Lines      []line            `json:"lines"`
+		Total      pricing.Money     `json:"total"`
+	}
+
+	views := []invoice{}
+	for _, i := range is {
+		v := invoice{InvoiceID: i.ID, CustomerID: string(i.CustomerID), Status: i.Status.String(), Created: i.Created, Lines: []line{}, Total: i.Total()}
+		if !i.Finalized.IsZero() {
+			finalized := i.Finalized
+			v.Finalized = &finalized
+		}
+
+		for _, l := range i.Lines {
+			v.Lines = append(v.Lines, line{l.TrackingID, l.Milestone.String(), l.Description, l.Amount, l.Occurred})
+		}
+
+		views = append(views, v)
+	}
+
+	return p.enc.Encode(views)
+}
+
+func (p jsonPrinter) subscriptions(ss []webhook.Subscription) error {
+	type subscription struct {
+		SubscriptionID webhook.SubscriptionID `json:"subscription_id"`
+		CustomerID     string                 `json:"customer_id"`
+		URL            string                 `json:"url"`
+		Events         []string               `json:"events"`
+		Secret         string                 `json:"secret,omitempty"`
+		Created        time.Time              `json:"created"`
+	}
+
+	views := []subscription{}
+	for _, s := range ss {
+		views = append(views, subscription{s.ID, string(s.CustomerID), s.URL, s.Filter.Names(), s.Secret, s.Created})
+	}
+
+	return p.enc.Encode(views)
+}
+
+// deliveries embeds the payload as is.
+func (p jsonPrinter) deliveries(ds []webhook.Delivery) error {
+	type delivery struct {
+		DeliveryID     webhook.DeliveryID     `json:"delivery_id"`
+		SubscriptionID webhook.SubscriptionID `json:"subscription_id"`
+		Event          string                 `json:"event"`
+		Status         string                 `json:"status"`
+		Attempts       int                    `json:"attempts"`
+		NextAttempt    time.Time              `json:"next_attempt"`
+		LastError      string                 `json:"last_error,omitempty"`
+		Created        time.Time              `json:"created"`
+		Payload        json.RawMessage        `json:"payload"`
+	}
+
+	views := []delivery{}
+	for _, d := range ds {
+		views = append(views, delivery{d.ID, d.SubscriptionID, d.Event.String(), d.Status.String(), d.Attempts, d.NextAttempt, d.LastError, d.Created, d.Payload})
+	}
+
+	return p.enc.Encode(views)
+}
+
+func (p jsonPrinter) ingested(r services.IngestionReport) error {
+	type message struct {
+		Reference string `json:"reference"`
+		Type      string `json:"type"`
+		Events    int    `json:"events"`
+		Error     string `json:"error,omitempty"`
+	}
+
+	messages := []message{}
+	for _, m := range r.Results {
+		v := message{Reference: m.Reference, Type: m.Type, Events: m.Events}
+		if m.Err != nil {
+			v.Error = m.Err.Error()
+		}
+
+		messages = append(messages, v)
+	}
+
+	return p.enc.Encode(struct {
+		Interchange string    `json:"interchange"`
+		Sender      string    `json:"sender"`
+		Messages    []message `json:"messages"`
+	}{r.Interchange, r.Sender, messages})
+}
+
+func (p jsonPrinter) done(id cargo.TrackingID, _ string) error {
+	return p.enc.Encode(struct {
+		TrackingID cargo.TrackingID `json:"tracking_id"`
+	}{id})
+}
+
+func (p jsonPrinter) token(token string, expires time.Time) error {
+	return p.enc.Encode(struct {
+		Token   string    `json:"token"`
+		Expires time.Time `json:"expires"`
+	}{token, expires})
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+
+	return s
+}
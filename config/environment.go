@@ -1,6 +1,10 @@
 package config
 
-import "github.com/spf13/viper"
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
 
 type Environment struct {
 	DBUsername string `mapstructure:"DB_USERNAME"`
@@ -8,6 +12,61 @@ type Environment struct {
 	DBHost     string `mapstructure:"DB_HOST"`
 	DBPort     string `mapstructure:"DB_PORT"`
 	DBName     string `mapstructure:"DB_NAME"`
+	// AuthKey signs the tokens asserting the caller of every call, it is
+	// shared with the gateway and the internal callers.
+	AuthKey string `mapstructure:"AUTH_KEY"`
+	// CustomsCountries lists the ISO 3166 codes of the countries where
+	// cargos have to clear customs, comma separated.
+	CustomsCountries []string `mapstructure:"CUSTOMS_COUNTRIES"`
+	// DangerousGoodsSurcharge and TightDeadlineSurcharge are the
+	// percentages added to the freight of quoted itineraries carrying
+	// dangerous goods or arriving less than TightDeadlineSlack, e.g. 48h,
+	// before the deadline.
+	DangerousGoodsSurcharge float64       `mapstructure:"DANGEROUS_GOODS_SURCHARGE"`
+	TightDeadlineSurcharge  float64       `mapstructure:"TIGHT_DEADLINE_SURCHARGE"`
+	TightDeadlineSlack      time.Duration `mapstructure:"TIGHT_DEADLINE_SLACK"`
+	// QuoteValidity is how long a quote can be accepted, 24h when unset.
+	QuoteValidity time.Duration `mapstructure:"QUOTE_VALIDITY"`
+	// BookedShare, LoadedShare and ClaimedShare are the percentages of the
+	// agreed price billed when a cargo is booked, first loaded and claimed.
+	// They add up to 100, 20/50/30 is used when none is set.
+	BookedShare  float64 `mapstructure:"BILLING_BOOKED_SHARE"`
+	LoadedShare  float64 `mapstructure:"BILLING_LOADED_SHARE"`
+	ClaimedShare float64 `mapstructure:"BILLING_CLAIMED_SHARE"`
+	// SMTPAddr, host:port, enables email notifications sent from SMTPFrom.
+	// SMTPUsername and SMTPPassword are only needed by servers requiring
+	// authentication.
+	SMTPAddr     string `mapstructure:"SMTP_ADDR"`
+	SMTPFrom     string `mapstructure:"SMTP_FROM"`
+	SMTPUsername string `mapstructure:"SMTP_USERNAME"`
+	SMTPPassword string `mapstructure:"SMTP_PASSWORD"`
+	// NotificationFile enables writing every notification to the file, as
+	// JSON lines. NotificationTemplates is a directory of *.tmpl files
+	// overriding the built-in message templates.
+	NotificationFile      string `mapstructure:"NOTIFICATION_FILE"`
+	NotificationTemplates string `mapstructure:"NOTIFICATION_TEMPLATES"`
+	// WebhookInitialBackoff, WebhookMaxBackoff and WebhookAttempts tune the
+	// retries of webhook deliveries, 30s, 1h and 10 when unset.
+	// WebhookPollInterval is how often due deliveries are looked for, 5s
+	// when unset.
+	WebhookInitialBackoff time.Duration `mapstructure:"WEBHOOK_INITIAL_BACKOFF"`
+	WebhookMaxBackoff     time.Duration `mapstructure:"WEBHOOK_MAX_BACKOFF"`
+	WebhookAttempts       int           `mapstructure:"WEBHOOK_ATTEMPTS"`
+	WebhookPollInterval   time.Duration `mapstructure:"WEBHOOK_POLL_INTERVAL"`
+	// HandlingImportDir enables importing the CSV handling reports dropped
+	// into the directory, which is looked at every HandlingImportInterval,
+	// 1m when unset.
+	HandlingImportDir      string        `mapstructure:"HANDLING_IMPORT_DIR"`
+	HandlingImportInterval time.Duration `mapstructure:"HANDLING_IMPORT_INTERVAL"`
+	// InspectionWorkers is the number of workers inspecting registered
+	// handling events, 4 when unset. InspectionAttempts and
+	// InspectionRetryDelay tune the retries of failed inspections, 5 and 5s
+	// when unset. InspectionPollInterval is how often an idle worker looks
+	// for events, 1s when unset.
+	InspectionWorkers      int           `mapstructure:"INSPECTION_WORKERS"`
+	InspectionAttempts     int           `mapstructure:"INSPECTION_ATTEMPTS"`
+	InspectionRetryDelay   time.Duration `mapstructure:"INSPECTION_RETRY_DELAY"`
+	InspectionPollInterval time.Duration `mapstructure:"INSPECTION_POLL_INTERVAL"`
 }
 
 func LoadEnv(path, envName string) (env Environment, err error) {
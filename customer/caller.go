@@ -0,0 +1,29 @@
+package customer
+
+import "context"
+
+// Caller identifies who is invoking the system. Staff callers are allowed to
+// act on behalf of every customer.
+type Caller struct {
+	CustomerID ID
+	Staff      bool
+}
+
+// CanAccess checks whether the caller is allowed to see data owned by the
+// given customer.
+func (c Caller) CanAccess(owner ID) bool {
+	return c.Staff || (c.CustomerID != "" && c.CustomerID == owner)
+}
+
+type callerKey struct{}
+
+// NewContext returns a copy of ctx carrying the given caller.
+func NewContext(ctx context.Context, c Caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, c)
+}
+
+// FromContext returns the caller stored in ctx, if any.
+func FromContext(ctx context.Context) (Caller, bool) {
+	c, ok := ctx.Value(callerKey{}).(Caller)
+	return c, ok
+}
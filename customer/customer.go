@@ -0,0 +1,205 @@
+// Package customer provides the Customer aggregate.
+package customer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+	"github.com/pborman/uuid"
+)
+
+// ErrUnknown is used when a customer could not be found.
+var ErrUnknown = errors.New("unknown customer")
+
+// ID uniquely identifies a particular customer.
+type ID string
+
+// NextID generates a new customer ID.
+func NextID() ID {
+	return ID(strings.ToUpper(uuid.New()))
+}
+
+// Contact holds the details used to reach a customer.
+type Contact struct {
+	Email   string
+	Phone   string
+	Address string
+}
+
+// NotificationPreferences describes how a customer wants to be notified
+// about their shipments, and about which delivery events: a cargo becoming
+// misdirected, being unloaded at its destination or running late.
+type NotificationPreferences struct {
+	Email       bool
+	SMS         bool
+	WebhookURL  string
+	Misdirected bool
+	Unloaded    bool
+	Late        bool
+}
+
+// Customer is the party that books and owns cargos.
+type Customer struct {
+	ID          ID
+	Name        string
+	Contact     Contact
+	Preferences NotificationPreferences
+}
+
+// New creates a new customer.
+func New(id ID, name string, contact Contact, preferences NotificationPreferences) *Customer {
+	return &Customer{
+		ID:          id,
+		Name:        name,
+		Contact:     contact,
+		Preferences: preferences,
+	}
+}
+
+type CustomerRepositoryContract interface {
+	Upsert(ctx context.Context, dbtx db.DBTX, customer *Customer) (*Customer, error)
+	Find(ctx context.Context, dbtx db.DBTX, id ID) (*Customer, error)
+	FindAll(ctx context.Context, dbtx db.DBTX) ([]*Customer, error)
+}
+
+type CustomerRepository struct {
+}
+
+func NewCustomerRepository() CustomerRepository {
+	return CustomerRepository{}
+}
+
+type customerResult struct {
+	id          string
+	name        string
+	email       string
+	phone       string
+	address     string
+	notifyEmail bool
+	notifySMS   bool
+	webhookURL  string
+	misdirected bool
+	unloaded    bool
+	late        bool
+}
+
+func (cr *customerResult) fields() []interface{} {
+	return []interface{}{
+		&cr.id,
+		&cr.name,
+		&cr.email,
+		&cr.phone,
+		&cr.address,
+		&cr.notifyEmail,
+		&cr.notifySMS,
+		&cr.webhookURL,
+		&cr.misdirected,
+		&cr.unloaded,
+		&cr.late,
+	}
+}
+
+func (cr customerResult) build() *Customer {
+	return &Customer{
+		ID:   ID(cr.id),
+		Name: cr.name,
+		Contact: Contact{
+			Email:   cr.email,
+			Phone:   cr.phone,
+			Address: cr.address,
+		},
+		Preferences: NotificationPreferences{
+			Email:       cr.notifyEmail,
+			SMS:         cr.notifySMS,
+			WebhookURL:  cr.webhookURL,
+			Misdirected: cr.misdirected,
+			Unloaded:    cr.unloaded,
+			Late:        cr.late,
+		},
+	}
+}
+
+func (cr CustomerRepository) Upsert(ctx context.Context, dbtx db.DBTX, customer *Customer) (*Customer, error) {
+	query := `
+		INSERT INTO customers (id, name, email, phone, address, notify_email, notify_sms, webhook_url, notify_misdirected, notify_unloaded, notify_late)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (id) DO UPDATE SET
+			name = EXCLUDED.name, email = EXCLUDED.email, phone = EXCLUDED.phone, address = EXCLUDED.address,
+			notify_email = EXCLUDED.notify_email, notify_sms = EXCLUDED.notify_sms, webhook_url = EXCLUDED.webhook_url,
+			notify_misdirected = EXCLUDED.notify_misdirected, notify_unloaded = EXCLUDED.notify_unloaded, notify_late = EXCLUDED.notify_late
+		RETURNING id, name, email, phone, address, notify_email, notify_sms, webhook_url, notify_misdirected, notify_unloaded, notify_late
+	`
+
+	row := dbtx.QueryRowContext(
+		ctx,
+		query,
+		customer.ID,
+		customer.Name,
+		customer.Contact.Email,
+		customer.Contact.Phone,
+		customer.Contact.Address,
+		customer.Preferences.Email,
+		customer.Preferences.SMS,
+		customer.Preferences.WebhookURL,
+		customer.Preferences.Misdirected,
+		customer.Preferences.Unloaded,
+		customer.Preferences.Late,
+	)
+
+	var result customerResult
+	err := row.Scan(result.fields()...)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.build(), nil
+}
+
+func (cr CustomerRepository) Find(ctx context.Context, dbtx db.DBTX, id ID) (*Customer, error) {
+	query := `
+		SELECT id, name, email, phone, address, notify_email, notify_sms, webhook_url, notify_misdirected, notify_unloaded, notify_late
+		FROM customers WHERE id = $1 LIMIT 1
+	`
+
+	var result customerResult
+	row := dbtx.QueryRowContext(ctx, query, id)
+	err := row.Scan(result.fields()...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrUnknown
+		}
+
+		return nil, err
+	}
+
+	return result.build(), nil
+}
+
+func (cr CustomerRepository) FindAll(ctx context.Context, dbtx db.DBTX) ([]*Customer, error) {
+	query := `
+		SELECT id, name, email, phone, address, notify_email, notify_sms, webhook_url, notify_misdirected, notify_unloaded, notify_late
+		FROM customers ORDER BY name
+	`
+
+	rows, err := dbtx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var customers []*Customer
+	for rows.Next() {
+		var result customerResult
+		err = rows.Scan(result.fields()...)
+		if err != nil {
+			return nil, err
+		}
+
+		customers = append(customers, result.build())
+	}
+
+	return customers, rows.Err()
+}
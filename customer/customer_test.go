@@ -0,0 +1,47 @@
+package customer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createNewCustomer(t *testing.T) *Customer {
+	c := New(NextID(), "PT Logistik", Contact{Email: "ops@logistik.id"}, NotificationPreferences{Email: true})
+	nc, err := customerTest.Upsert(context.Background(), dbTest, c)
+
+	require.NoError(t, err)
+	require.Equal(t, c.ID, nc.ID)
+	require.True(t, nc.Preferences.Email)
+
+	return nc
+}
+
+func TestInsertCustomer(t *testing.T) {
+	createNewCustomer(t)
+}
+
+func TestUpdateCustomer(t *testing.T) {
+	c := createNewCustomer(t)
+	c.Contact.Phone = "+62215551234"
+	c.Preferences.SMS = true
+
+	nc, err := customerTest.Upsert(context.Background(), dbTest, c)
+	require.NoError(t, err)
+	require.Equal(t, c.Contact.Phone, nc.Contact.Phone)
+	require.True(t, nc.Preferences.SMS)
+}
+
+func TestFindCustomerNotFound(t *testing.T) {
+	c, err := customerTest.Find(context.Background(), dbTest, "unknown")
+	require.ErrorIs(t, err, ErrUnknown)
+	require.Nil(t, c)
+}
+
+func TestCallerCanAccess(t *testing.T) {
+	require.True(t, Caller{CustomerID: "A"}.CanAccess("A"))
+	require.False(t, Caller{CustomerID: "A"}.CanAccess("B"))
+	require.False(t, Caller{}.CanAccess(""))
+	require.True(t, Caller{Staff: true}.CanAccess("B"))
+}
@@ -0,0 +1,44 @@
+package customer
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+)
+
+type instrumentingCustomerRepository struct {
+	latency metrics.Histogram
+	CustomerRepositoryContract
+}
+
+// NewInstrumentingCustomerRepository returns a customer repository that
+// records query latency, labeled by repository, method and outcome.
+func NewInstrumentingCustomerRepository(latency metrics.Histogram, r CustomerRepositoryContract) CustomerRepositoryContract {
+	return &instrumentingCustomerRepository{latency: latency, CustomerRepositoryContract: r}
+}
+
+func (r *instrumentingCustomerRepository) observe(method string, begin time.Time, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+
+	r.latency.With("repository", "customer", "method", method, "outcome", outcome).Observe(time.Since(begin).Seconds())
+}
+
+func (r *instrumentingCustomerRepository) Upsert(ctx context.Context, dbtx db.DBTX, customer *Customer) (c *Customer, err error) {
+	defer func(begin time.Time) { r.observe("upsert", begin, err) }(time.Now())
+	return r.CustomerRepositoryContract.Upsert(ctx, dbtx, customer)
+}
+
+func (r *instrumentingCustomerRepository) Find(ctx context.Context, dbtx db.DBTX, id ID) (c *Customer, err error) {
+	defer func(begin time.Time) { r.observe("find", begin, err) }(time.Now())
+	return r.CustomerRepositoryContract.Find(ctx, dbtx, id)
+}
+
+func (r *instrumentingCustomerRepository) FindAll(ctx context.Context, dbtx db.DBTX) (cs []*Customer, err error) {
+	defer func(begin time.Time) { r.observe("find_all", begin, err) }(time.Now())
+	return r.CustomerRepositoryContract.FindAll(ctx, dbtx)
+}
@@ -0,0 +1,43 @@
+package customer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testKey = "0123456789abcdef0123456789abcdef"
+
+func TestTokens(t *testing.T) {
+	_, err := NewTokens("short")
+	require.Error(t, err)
+
+	tokens, err := NewTokens(testKey)
+	require.NoError(t, err)
+
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	token := tokens.Issue(Caller{CustomerID: "acme"}, now.Add(time.Minute))
+
+	c, err := tokens.Verify(token, now)
+	require.NoError(t, err)
+	require.Equal(t, Caller{CustomerID: "acme"}, c)
+
+	_, err = tokens.Verify(token, now.Add(time.Minute))
+	require.ErrorIs(t, err, ErrInvalidToken)
+
+	other, err := NewTokens(strings.Repeat("x", MinKeyLength))
+	require.NoError(t, err)
+	_, err = other.Verify(token, now)
+	require.ErrorIs(t, err, ErrInvalidToken)
+
+	// A staff claim spliced onto a customer's signature is rejected.
+	staff := tokens.Issue(Caller{Staff: true}, now.Add(time.Minute))
+	forged := strings.SplitN(staff, ".", 2)[0] + "." + strings.SplitN(token, ".", 2)[1]
+	_, err = tokens.Verify(forged, now)
+	require.ErrorIs(t, err, ErrInvalidToken)
+
+	_, err = tokens.Verify("garbage", now)
+	require.ErrorIs(t, err, ErrInvalidToken)
+}
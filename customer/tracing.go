@@ -0,0 +1,47 @@
+package customer
+
+import (
+	"context"
+
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+	"github.com/mproyyan/grpc-shipping-microservice/tracing"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = tracing.Tracer("github.com/mproyyan/grpc-shipping-microservice/customer")
+
+type tracingCustomerRepository struct {
+	CustomerRepositoryContract
+}
+
+// NewTracingCustomerRepository returns a customer repository that wraps every
+// query in a client span.
+func NewTracingCustomerRepository(r CustomerRepositoryContract) CustomerRepositoryContract {
+	return &tracingCustomerRepository{CustomerRepositoryContract: r}
+}
+
+func (r *tracingCustomerRepository) start(ctx context.Context, name string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemPostgreSQL),
+	)
+}
+
+func (r *tracingCustomerRepository) Upsert(ctx context.Context, dbtx db.DBTX, customer *Customer) (c *Customer, err error) {
+	ctx, span := r.start(ctx, "CustomerRepository.Upsert")
+	defer func() { tracing.End(span, err) }()
+	return r.CustomerRepositoryContract.Upsert(ctx, dbtx, customer)
+}
+
+func (r *tracingCustomerRepository) Find(ctx context.Context, dbtx db.DBTX, id ID) (c *Customer, err error) {
+	ctx, span := r.start(ctx, "CustomerRepository.Find")
+	defer func() { tracing.End(span, err) }()
+	return r.CustomerRepositoryContract.Find(ctx, dbtx, id)
+}
+
+func (r *tracingCustomerRepository) FindAll(ctx context.Context, dbtx db.DBTX) (cs []*Customer, err error) {
+	ctx, span := r.start(ctx, "CustomerRepository.FindAll")
+	defer func() { tracing.End(span, err) }()
+	return r.CustomerRepositoryContract.FindAll(ctx, dbtx)
+}
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS customers (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    phone VARCHAR(32) NOT NULL DEFAULT '',
+    address TEXT NOT NULL DEFAULT '',
+    notify_email BOOLEAN NOT NULL DEFAULT FALSE,
+    notify_sms BOOLEAN NOT NULL DEFAULT FALSE,
+    webhook_url TEXT NOT NULL DEFAULT ''
+);
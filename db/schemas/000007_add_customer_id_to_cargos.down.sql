@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS cargos
+DROP COLUMN IF EXISTS customer_id;
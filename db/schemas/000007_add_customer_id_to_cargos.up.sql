@@ -0,0 +1,4 @@
+ALTER TABLE IF EXISTS cargos
+ADD COLUMN customer_id VARCHAR(36) REFERENCES customers (id);
+
+CREATE INDEX IF NOT EXISTS cargos_customer_id_idx ON cargos (customer_id);
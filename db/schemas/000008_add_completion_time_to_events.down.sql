@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS events_tracking_id_completed_at_idx;
+
+ALTER TABLE IF EXISTS events
+DROP COLUMN IF EXISTS completed_at;
@@ -0,0 +1,4 @@
+ALTER TABLE IF EXISTS events
+ADD COLUMN completed_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+CREATE INDEX IF NOT EXISTS events_tracking_id_completed_at_idx ON events (tracking_id, completed_at);
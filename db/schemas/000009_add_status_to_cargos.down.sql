@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS cargos_status_idx;
+
+ALTER TABLE IF EXISTS cargos
+DROP COLUMN IF EXISTS status;
@@ -0,0 +1,14 @@
+ALTER TABLE IF EXISTS cargos
+ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'Booked'
+CHECK (status IN ('Booked', 'In transit', 'Delivered', 'Cancelled', 'Closed'));
+
+-- derive the status of existing cargos from their handling history, event
+-- types are 1 for load, 2 for unload and 4 for claim
+UPDATE cargos c SET status = CASE
+    WHEN EXISTS (SELECT 1 FROM events e WHERE e.tracking_id = c.tracking_id AND e.event_type = 4) THEN 'Closed'
+    WHEN EXISTS (SELECT 1 FROM events e WHERE e.tracking_id = c.tracking_id AND e.event_type = 2 AND e.location = c.destination) THEN 'Delivered'
+    WHEN EXISTS (SELECT 1 FROM events e WHERE e.tracking_id = c.tracking_id AND e.event_type = 1) THEN 'In transit'
+    ELSE 'Booked'
+END;
+
+CREATE INDEX IF NOT EXISTS cargos_status_idx ON cargos (status);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS container_cargos;
+DROP TABLE IF EXISTS containers;
+DROP TABLE IF EXISTS shipments;
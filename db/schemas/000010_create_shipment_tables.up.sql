@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS shipments (
+    id VARCHAR(10) PRIMARY KEY,
+    origin VARCHAR(5) NOT NULL,
+    destination VARCHAR(5) NOT NULL,
+    legs JSON
+);
+
+CREATE TABLE IF NOT EXISTS containers (
+    id VARCHAR(11) PRIMARY KEY,
+    shipment_id VARCHAR(10) NOT NULL REFERENCES shipments (id),
+    max_weight DOUBLE PRECISION NOT NULL CHECK (max_weight > 0),
+    max_volume DOUBLE PRECISION NOT NULL CHECK (max_volume > 0)
+);
+
+CREATE INDEX IF NOT EXISTS containers_shipment_id_idx ON containers (shipment_id);
+
+-- a cargo is in at most one container
+CREATE TABLE IF NOT EXISTS container_cargos (
+    container_id VARCHAR(11) NOT NULL REFERENCES containers (id),
+    tracking_id VARCHAR(10) NOT NULL UNIQUE REFERENCES cargos (tracking_id),
+    weight DOUBLE PRECISION NOT NULL CHECK (weight >= 0),
+    volume DOUBLE PRECISION NOT NULL CHECK (volume >= 0),
+    PRIMARY KEY (container_id, tracking_id)
+);
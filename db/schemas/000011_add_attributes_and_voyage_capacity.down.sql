@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS carrier_movements;
+
+DROP TABLE IF EXISTS voyages;
+
+ALTER TABLE IF EXISTS cargos
+DROP COLUMN IF EXISTS hs_code,
+DROP COLUMN IF EXISTS commodity,
+DROP COLUMN IF EXISTS packages,
+DROP COLUMN IF EXISTS volume,
+DROP COLUMN IF EXISTS weight;
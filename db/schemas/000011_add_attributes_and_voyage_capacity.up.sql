@@ -0,0 +1,23 @@
+ALTER TABLE IF EXISTS cargos
+ADD COLUMN weight DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (weight >= 0),
+ADD COLUMN volume DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (volume >= 0),
+ADD COLUMN packages INTEGER NOT NULL DEFAULT 0 CHECK (packages >= 0),
+ADD COLUMN commodity VARCHAR(255) NOT NULL DEFAULT '',
+ADD COLUMN hs_code VARCHAR(10) NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS voyages (
+    voyage_number VARCHAR(10) PRIMARY KEY
+);
+
+-- a zero max_weight or max_volume is unlimited
+CREATE TABLE IF NOT EXISTS carrier_movements (
+    voyage_number VARCHAR(10) NOT NULL REFERENCES voyages (voyage_number) ON DELETE CASCADE,
+    seq INTEGER NOT NULL,
+    departure_location VARCHAR(5) NOT NULL,
+    arrival_location VARCHAR(5) NOT NULL,
+    departure_time TIMESTAMPTZ NOT NULL,
+    arrival_time TIMESTAMPTZ NOT NULL,
+    max_weight DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (max_weight >= 0),
+    max_volume DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (max_volume >= 0),
+    PRIMARY KEY (voyage_number, seq)
+);
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS locations;
+
+ALTER TABLE IF EXISTS voyages
+DROP COLUMN IF EXISTS dangerous_goods_classes;
+
+ALTER TABLE IF EXISTS cargos
+DROP COLUMN IF EXISTS un_number,
+DROP COLUMN IF EXISTS imdg_class;
@@ -0,0 +1,13 @@
+ALTER TABLE IF EXISTS cargos
+ADD COLUMN imdg_class VARCHAR(3) NOT NULL DEFAULT '',
+ADD COLUMN un_number VARCHAR(4) NOT NULL DEFAULT '';
+
+-- a NULL dangerous_goods_classes accepts every class, an empty array none
+ALTER TABLE IF EXISTS voyages
+ADD COLUMN dangerous_goods_classes TEXT[];
+
+CREATE TABLE IF NOT EXISTS locations (
+    unlocode VARCHAR(5) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    dangerous_goods_classes TEXT[]
+);
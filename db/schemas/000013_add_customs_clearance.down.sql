@@ -0,0 +1,5 @@
+ALTER TABLE IF EXISTS cargos
+DROP COLUMN IF EXISTS customs_hold_reason,
+DROP COLUMN IF EXISTS customs_hold,
+DROP COLUMN IF EXISTS customs_cleared,
+DROP COLUMN IF EXISTS customs_required;
@@ -0,0 +1,5 @@
+ALTER TABLE IF EXISTS cargos
+ADD COLUMN customs_required BOOLEAN NOT NULL DEFAULT false,
+ADD COLUMN customs_cleared BOOLEAN NOT NULL DEFAULT false,
+ADD COLUMN customs_hold BOOLEAN NOT NULL DEFAULT false,
+ADD COLUMN customs_hold_reason VARCHAR(255) NOT NULL DEFAULT '';
@@ -0,0 +1,30 @@
+-- an empty voyage_number, origin or destination matches every leg, amounts
+-- are in the minor unit of the currency
+CREATE TABLE IF NOT EXISTS tariffs (
+    voyage_number VARCHAR(10) NOT NULL DEFAULT '',
+    origin VARCHAR(5) NOT NULL DEFAULT '',
+    destination VARCHAR(5) NOT NULL DEFAULT '',
+    currency VARCHAR(3) NOT NULL,
+    base_amount BIGINT NOT NULL DEFAULT 0,
+    per_tonne BIGINT NOT NULL DEFAULT 0,
+    per_cubic_metre BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (voyage_number, origin, destination)
+);
+
+CREATE TABLE IF NOT EXISTS quotes (
+    id VARCHAR(10) PRIMARY KEY,
+    customer_id VARCHAR(36) NOT NULL REFERENCES customers (id),
+    origin VARCHAR(5) NOT NULL,
+    destination VARCHAR(5) NOT NULL,
+    arrival_deadline TIMESTAMPTZ NOT NULL,
+    weight DOUBLE PRECISION NOT NULL DEFAULT 0,
+    volume DOUBLE PRECISION NOT NULL DEFAULT 0,
+    imdg_class VARCHAR(3) NOT NULL DEFAULT '',
+    un_number VARCHAR(4) NOT NULL DEFAULT '',
+    options JSON NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    -- the cargo booked from the quote and the option it was booked at
+    tracking_id VARCHAR(10) UNIQUE REFERENCES cargos (tracking_id),
+    accepted_option INT NOT NULL DEFAULT 0
+);
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS invoices (
+    id VARCHAR(10) PRIMARY KEY,
+    customer_id VARCHAR(36) NOT NULL REFERENCES customers (id),
+    currency VARCHAR(3) NOT NULL,
+    status INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL,
+    finalized_at TIMESTAMPTZ
+);
+
+-- lines are added to the one draft invoice of a customer per currency
+CREATE UNIQUE INDEX IF NOT EXISTS invoices_draft_idx ON invoices (customer_id, currency) WHERE status = 0;
+
+-- amounts are in the minor unit of the currency of the invoice, a cargo is
+-- billed once per milestone
+CREATE TABLE IF NOT EXISTS invoice_lines (
+    invoice_id VARCHAR(10) NOT NULL REFERENCES invoices (id),
+    tracking_id VARCHAR(10) NOT NULL REFERENCES cargos (tracking_id),
+    milestone INT NOT NULL,
+    description TEXT NOT NULL,
+    amount BIGINT NOT NULL,
+    occurred_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (tracking_id, milestone)
+);
+
+CREATE INDEX IF NOT EXISTS invoice_lines_invoice_id_idx ON invoice_lines (invoice_id);
@@ -0,0 +1,4 @@
+ALTER TABLE IF EXISTS customers
+DROP COLUMN IF EXISTS notify_late,
+DROP COLUMN IF EXISTS notify_unloaded,
+DROP COLUMN IF EXISTS notify_misdirected;
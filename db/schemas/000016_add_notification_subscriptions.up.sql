@@ -0,0 +1,4 @@
+ALTER TABLE IF EXISTS customers
+ADD COLUMN notify_misdirected BOOLEAN NOT NULL DEFAULT false,
+ADD COLUMN notify_unloaded BOOLEAN NOT NULL DEFAULT false,
+ADD COLUMN notify_late BOOLEAN NOT NULL DEFAULT false;
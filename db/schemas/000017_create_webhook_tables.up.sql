@@ -0,0 +1,28 @@
+-- events holds the names of the events posted, every event when empty
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id VARCHAR(10) PRIMARY KEY,
+    customer_id VARCHAR(36) NOT NULL REFERENCES customers (id),
+    url TEXT NOT NULL,
+    events TEXT[] NOT NULL DEFAULT '{}',
+    secret TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS webhook_subscriptions_customer_id_idx ON webhook_subscriptions (customer_id);
+
+-- failed deliveries (status 2) make up the dead-letter list
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id VARCHAR(10) PRIMARY KEY,
+    subscription_id VARCHAR(10) NOT NULL REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+    customer_id VARCHAR(36) NOT NULL REFERENCES customers (id),
+    event INT NOT NULL,
+    payload BYTEA NOT NULL,
+    status INT NOT NULL DEFAULT 0,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_due_idx ON webhook_deliveries (next_attempt_at) WHERE status = 0;
+CREATE INDEX IF NOT EXISTS webhook_deliveries_failed_idx ON webhook_deliveries (customer_id, created_at) WHERE status = 2;
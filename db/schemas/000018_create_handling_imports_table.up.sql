@@ -0,0 +1,7 @@
+-- key identifies an imported handling report line by its content, so the
+-- same event dropped again is skipped
+CREATE TABLE IF NOT EXISTS handling_imports (
+    key CHAR(64) PRIMARY KEY,
+    source TEXT NOT NULL,
+    imported_at TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS handling_dead_letters;
+DROP TABLE IF EXISTS handling_queue;
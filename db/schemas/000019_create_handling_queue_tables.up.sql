@@ -0,0 +1,25 @@
+-- jobs of a cargo are inspected one at a time in id order, a job is only
+-- picked up once available_at has passed
+CREATE TABLE IF NOT EXISTS handling_queue (
+    id BIGSERIAL PRIMARY KEY,
+    tracking_id VARCHAR(10) NOT NULL REFERENCES cargos (tracking_id),
+    event_id BIGINT NOT NULL REFERENCES events (id),
+    attempts INT NOT NULL DEFAULT 0,
+    available_at TIMESTAMPTZ NOT NULL,
+    last_error TEXT NOT NULL DEFAULT '',
+    enqueued_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS handling_queue_tracking_id_idx ON handling_queue (tracking_id, id);
+CREATE INDEX IF NOT EXISTS handling_queue_available_at_idx ON handling_queue (available_at);
+
+-- jobs that kept failing, kept with the id they had in the queue
+CREATE TABLE IF NOT EXISTS handling_dead_letters (
+    id BIGINT PRIMARY KEY,
+    tracking_id VARCHAR(10) NOT NULL REFERENCES cargos (tracking_id),
+    event_id BIGINT NOT NULL REFERENCES events (id),
+    attempts INT NOT NULL,
+    last_error TEXT NOT NULL,
+    enqueued_at TIMESTAMPTZ NOT NULL,
+    buried_at TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,17 @@
+DROP INDEX IF EXISTS deliveries_underived_idx;
+DROP INDEX IF EXISTS deliveries_transport_status_idx;
+DROP INDEX IF EXISTS deliveries_late_idx;
+DROP INDEX IF EXISTS deliveries_misdirected_idx;
+
+ALTER TABLE IF EXISTS deliveries
+DROP COLUMN IF EXISTS derived,
+DROP COLUMN IF EXISTS next_activity_voyage,
+DROP COLUMN IF EXISTS next_activity_location,
+DROP COLUMN IF EXISTS next_activity_type,
+DROP COLUMN IF EXISTS is_unloaded_at_destination,
+DROP COLUMN IF EXISTS is_misdirected,
+DROP COLUMN IF EXISTS eta,
+DROP COLUMN IF EXISTS current_voyage,
+DROP COLUMN IF EXISTS last_known_location,
+DROP COLUMN IF EXISTS transport_status,
+DROP COLUMN IF EXISTS routing_status;
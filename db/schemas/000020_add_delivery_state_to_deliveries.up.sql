@@ -0,0 +1,19 @@
+-- the delivery snapshot is stored as derived, rows stored before have
+-- derived unset until backfilled and are recomputed when read
+ALTER TABLE IF EXISTS deliveries
+ADD COLUMN routing_status INT NOT NULL DEFAULT 0,
+ADD COLUMN transport_status INT NOT NULL DEFAULT 0,
+ADD COLUMN last_known_location VARCHAR(5) NOT NULL DEFAULT '',
+ADD COLUMN current_voyage VARCHAR(10) NOT NULL DEFAULT '',
+ADD COLUMN eta TIMESTAMPTZ,
+ADD COLUMN is_misdirected BOOLEAN NOT NULL DEFAULT false,
+ADD COLUMN is_unloaded_at_destination BOOLEAN NOT NULL DEFAULT false,
+ADD COLUMN next_activity_type INT NOT NULL DEFAULT 0,
+ADD COLUMN next_activity_location VARCHAR(5) NOT NULL DEFAULT '',
+ADD COLUMN next_activity_voyage VARCHAR(10) NOT NULL DEFAULT '',
+ADD COLUMN derived BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX IF NOT EXISTS deliveries_misdirected_idx ON deliveries (id) WHERE is_misdirected;
+CREATE INDEX IF NOT EXISTS deliveries_late_idx ON deliveries (eta) WHERE eta > arrival_deadline;
+CREATE INDEX IF NOT EXISTS deliveries_transport_status_idx ON deliveries (transport_status);
+CREATE INDEX IF NOT EXISTS deliveries_underived_idx ON deliveries (id) WHERE NOT derived;
@@ -0,0 +1,22 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+// WithTx runs fn inside a transaction. The transaction is committed when fn
+// returns nil and rolled back otherwise.
+func WithTx(ctx context.Context, conn *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,217 @@
+// Package edifact reads UN/EDIFACT interchanges and maps the status and
+// gate messages terminals and carriers send, IFTSTA and CODECO, to handling
+// events.
+package edifact
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrSyntax is returned for interchanges that cannot be read at all, every
+// message they contain is lost.
+var ErrSyntax = errors.New("malformed EDIFACT interchange")
+
+// ErrMalformedMessage is returned for a message whose trailer does not match
+// its header or segment count.
+var ErrMalformedMessage = errors.New("malformed EDIFACT message")
+
+// delimiters are the service characters of an interchange, announced by its
+// UNA segment.
+type delimiters struct {
+	component  byte
+	element    byte
+	release    byte
+	terminator byte
+}
+
+// defaultDelimiters apply to interchanges without a UNA segment.
+var defaultDelimiters = delimiters{component: ':', element: '+', release: '?', terminator: '\''}
+
+// Segment is a tag followed by data elements, each split into its
+// components.
+type Segment struct {
+	Tag      string
+	Elements [][]string
+}
+
+// Value returns the component of the data element, both counted from zero
+// after the tag. Missing elements and components are empty.
+func (s Segment) Value(element, component int) string {
+	if element >= len(s.Elements) || component >= len(s.Elements[element]) {
+		return ""
+	}
+
+	return s.Elements[element][component]
+}
+
+// Message is a message of an interchange, without its UNH header and UNT
+// trailer. Err is set when the trailer does not match, the segments cannot
+// be trusted then.
+type Message struct {
+	Reference string
+	Type      string
+	Segments  []Segment
+	Err       error
+}
+
+// Interchange is a parsed interchange. Reference is the control reference
+// the sender numbers its interchanges with.
+type Interchange struct {
+	Sender    string
+	Recipient string
+	Reference string
+	Messages  []Message
+}
+
+// Parse reads an interchange from its UNB header up to its UNZ trailer.
+// Functional groups are accepted and ignored. Line breaks between segments
+// are skipped, senders often add them for readability.
+func Parse(data []byte) (Interchange, error) {
+	data = bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+
+	d := defaultDelimiters
+	if bytes.HasPrefix(data, []byte("UNA")) {
+		if len(data) < 9 {
+			return Interchange{}, fmt.Errorf("%w: truncated UNA segment", ErrSyntax)
+		}
+
+		d = delimiters{component: data[3], element: data[4], release: data[6], terminator: data[8]}
+		data = data[9:]
+	}
+
+	segments, err := split(data, d)
+	if err != nil {
+		return Interchange{}, err
+	}
+
+	if len(segments) == 0 || segments[0].Tag != "UNB" {
+		return Interchange{}, fmt.Errorf("%w: missing UNB segment", ErrSyntax)
+	}
+
+	header, trailer := segments[0], segments[len(segments)-1]
+	if trailer.Tag != "UNZ" {
+		return Interchange{}, fmt.Errorf("%w: missing UNZ segment", ErrSyntax)
+	}
+
+	ic := Interchange{
+		Sender:    header.Value(1, 0),
+		Recipient: header.Value(2, 0),
+		Reference: header.Value(4, 0),
+	}
+
+	if trailer.Value(1, 0) != ic.Reference {
+		return Interchange{}, fmt.Errorf("%w: UNZ reference %q does not match UNB reference %q", ErrSyntax, trailer.Value(1, 0), ic.Reference)
+	}
+
+	var (
+		m      *Message
+		groups int
+	)
+	for i, s := range segments[1 : len(segments)-1] {
+		switch {
+		case s.Tag == "UNH":
+			if m != nil {
+				return Interchange{}, fmt.Errorf("%w: segment %d: message %s is not terminated", ErrSyntax, i+2, m.Reference)
+			}
+
+			m = &Message{Reference: s.Value(0, 0), Type: s.Value(1, 0)}
+		case s.Tag == "UNT":
+			if m == nil {
+				return Interchange{}, fmt.Errorf("%w: segment %d: UNT outside of a message", ErrSyntax, i+2)
+			}
+
+			m.Err = checkTrailer(*m, s)
+			ic.Messages = append(ic.Messages, *m)
+			m = nil
+		case m != nil:
+			m.Segments = append(m.Segments, s)
+		case s.Tag == "UNG":
+			groups++
+		case s.Tag == "UNE":
+		default:
+			return Interchange{}, fmt.Errorf("%w: segment %d: %s outside of a message", ErrSyntax, i+2, s.Tag)
+		}
+	}
+
+	if m != nil {
+		return Interchange{}, fmt.Errorf("%w: message %s is not terminated", ErrSyntax, m.Reference)
+	}
+
+	// UNZ counts the functional groups if there are any, else the messages
+	count := len(ic.Messages)
+	if groups > 0 {
+		count = groups
+	}
+
+	if n, err := strconv.Atoi(trailer.Value(0, 0)); err != nil || n != count {
+		return Interchange{}, fmt.Errorf("%w: UNZ counts %q, the interchange holds %d", ErrSyntax, trailer.Value(0, 0), count)
+	}
+
+	return ic, nil
+}
+
+// checkTrailer checks that the UNT segment closes the message, counting the
+// header and the trailer themselves.
+func checkTrailer(m Message, trailer Segment) error {
+	if trailer.Value(1, 0) != m.Reference {
+		return fmt.Errorf("%w: UNT reference %q does not match UNH reference %q", ErrMalformedMessage, trailer.Value(1, 0), m.Reference)
+	}
+
+	if n, err := strconv.Atoi(trailer.Value(0, 0)); err != nil || n != len(m.Segments)+2 {
+		return fmt.Errorf("%w: UNT counts %q segments, the message holds %d", ErrMalformedMessage, trailer.Value(0, 0), len(m.Segments)+2)
+	}
+
+	return nil
+}
+
+// split cuts data into segments, elements and components. A character
+// following the release character is taken literally.
+func split(data []byte, d delimiters) ([]Segment, error) {
+	var (
+		segments   []Segment
+		elements   [][]string
+		components []string
+		value      []byte
+		pending    bool
+	)
+
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case c == d.release:
+			i++
+			if i == len(data) {
+				return nil, fmt.Errorf("%w: release character at the end of the interchange", ErrSyntax)
+			}
+
+			value = append(value, data[i])
+			pending = true
+		case c == d.component:
+			components = append(components, string(value))
+			value = value[:0]
+			pending = true
+		case c == d.element:
+			elements = append(elements, append(components, string(value)))
+			components, value = nil, value[:0]
+			pending = true
+		case c == d.terminator:
+			elements = append(elements, append(components, string(value)))
+			segments = append(segments, Segment{Tag: elements[0][0], Elements: elements[1:]})
+			elements, components, value = nil, nil, value[:0]
+			pending = false
+		case !pending && (c == '\r' || c == '\n' || c == ' ' || c == '\t'):
+		default:
+			value = append(value, c)
+			pending = true
+		}
+	}
+
+	if pending {
+		return nil, fmt.Errorf("%w: last segment is not terminated", ErrSyntax)
+	}
+
+	return segments, nil
+}
@@ -0,0 +1,60 @@
+package edifact
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	data := "UNA:+.? '\r\n" +
+		"UNB+UNOC:3+TERMINAL:ZZ+SHIPPING:ZZ+191018:1200+IC42'\r\n" +
+		"UNH+1+IFTSTA:D:99B:UN'\r\n" +
+		"BGM+23+ST?+1+9'\r\n" +
+		"UNT+3+1'\r\n" +
+		"UNH+2+CODECO:D:95B:UN'\r\n" +
+		"BGM+34+GI1+9'\r\n" +
+		"UNT+4+2'\r\n" +
+		"UNZ+2+IC42'\r\n"
+
+	ic, err := Parse([]byte(data))
+	require.NoError(t, err)
+	require.Equal(t, "TERMINAL", ic.Sender)
+	require.Equal(t, "SHIPPING", ic.Recipient)
+	require.Equal(t, "IC42", ic.Reference)
+	require.Len(t, ic.Messages, 2)
+
+	require.Equal(t, "1", ic.Messages[0].Reference)
+	require.Equal(t, IFTSTA, ic.Messages[0].Type)
+	require.NoError(t, ic.Messages[0].Err)
+	require.Equal(t, "ST+1", ic.Messages[0].Segments[0].Value(1, 0))
+	require.Equal(t, "", ic.Messages[0].Segments[0].Value(5, 0))
+
+	require.Equal(t, CODECO, ic.Messages[1].Type)
+	require.ErrorIs(t, ic.Messages[1].Err, ErrMalformedMessage)
+}
+
+func TestParseDelimiters(t *testing.T) {
+	data := "UNA*|.! ~UNB|UNOC*3|A|B|191018*1200|7~UNH|9|IFTSTA*D*99B*UN~BGM|23|A!|B~UNT|3|9~UNZ|1|7~"
+
+	ic, err := Parse([]byte(data))
+	require.NoError(t, err)
+	require.Len(t, ic.Messages, 1)
+	require.Equal(t, IFTSTA, ic.Messages[0].Type)
+	require.Equal(t, "A|B", ic.Messages[0].Segments[0].Value(1, 0))
+}
+
+func TestParseRejectsBrokenInterchanges(t *testing.T) {
+	for _, data := range []string{
+		"",
+		"UNH+1+IFTSTA'UNT+2+1'",
+		"UNB+UNOC:3+A+B+191018:1200+1'UNH+1+IFTSTA'UNZ+1+1'",
+		"UNB+UNOC:3+A+B+191018:1200+1'UNH+1+IFTSTA'UNT+2+1'UNZ+2+1'",
+		"UNB+UNOC:3+A+B+191018:1200+1'UNH+1+IFTSTA'UNT+2+1'UNZ+1+2'",
+		"UNB+UNOC:3+A+B+191018:1200+1'BGM+23'UNZ+0+1'",
+		"UNB+UNOC:3+A+B+191018:1200+1'UNZ+0+1",
+	} {
+		_, err := Parse([]byte(data))
+		require.ErrorIs(t, err, ErrSyntax, data)
+	}
+}
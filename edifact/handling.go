@@ -0,0 +1,248 @@
+package edifact
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/location"
+	"github.com/mproyyan/grpc-shipping-microservice/shipment"
+	"github.com/mproyyan/grpc-shipping-microservice/voyage"
+)
+
+// Message types that can be mapped to handling events.
+const (
+	// IFTSTA reports the status of consignments, e.g. that they were loaded.
+	IFTSTA = "IFTSTA"
+
+	// CODECO reports containers passing the gate of a terminal.
+	CODECO = "CODECO"
+)
+
+// Errors returned while mapping messages.
+var (
+	ErrUnsupportedMessage = errors.New("unsupported EDIFACT message type")
+	ErrUnknownStatus      = errors.New("unknown EDIFACT status code")
+	ErrInvalidHandling    = errors.New("invalid handling event")
+)
+
+// statusEvents maps the status codes of STS segments to event types. The
+// codes are those of the X12 315 container status message, which carriers
+// also send in IFTSTA.
+var statusEvents = map[string]cargo.HandlingEventType{
+	"I":  cargo.Receive,
+	"AE": cargo.Load,
+	"UV": cargo.Unload,
+	"CT": cargo.Customs,
+	"D":  cargo.Claim,
+}
+
+// gateEvents maps the document name codes of CODECO messages to event
+// types: containers coming in are received, those going out are claimed.
+var gateEvents = map[string]cargo.HandlingEventType{
+	"34": cargo.Receive,
+	"36": cargo.Claim,
+}
+
+// Qualifiers of the segments the events are read from.
+const (
+	bookingReference = "BN"  // RFF: the tracking ID of the cargo
+	container        = "CN"  // EQD: a container
+	activityLocation = "175" // LOC: where a status was reached
+	terminalLocation = "165" // LOC: the terminal a gate message is about
+	eventTime        = "334" // DTM: when a status was reached
+	gateTime         = "7"   // DTM: when a container passed the gate
+)
+
+// Handling is a handling event read from a message. It names either the
+// cargo, by its tracking ID, or the container the cargo travels in; when a
+// message gives both, the tracking ID wins.
+type Handling struct {
+	cargo.HandlingEvent
+	ContainerID shipment.ContainerID
+}
+
+// Handlings maps a message to the handling events it reports, in message
+// order. The message is rejected as a whole if any of them is invalid.
+func Handlings(m Message) ([]Handling, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+
+	switch m.Type {
+	case IFTSTA:
+		return statusHandlings(m.Segments)
+	case CODECO:
+		return gateHandlings(m.Segments)
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrUnsupportedMessage, m.Type)
+}
+
+// statusHandlings reads an event from every STS segment. The segments
+// following it describe the event, those of the CNI consignment group
+// before it apply to all of its events.
+func statusHandlings(segments []Segment) ([]Handling, error) {
+	var (
+		results     []Handling
+		consignment Handling
+		current     *Handling
+	)
+
+	for _, s := range segments {
+		h := &consignment
+		if current != nil {
+			h = current
+		}
+
+		switch s.Tag {
+		case "CNI":
+			consignment = Handling{}
+			consignment.TrackingID = cargo.TrackingID(s.Value(1, 0))
+			current = nil
+		case "STS":
+			t, ok := statusEvents[s.Value(1, 0)]
+			if !ok {
+				return nil, fmt.Errorf("%w: %q", ErrUnknownStatus, s.Value(1, 0))
+			}
+
+			results = append(results, consignment)
+			current = &results[len(results)-1]
+			current.Activity.Type = t
+		case "RFF", "LOC", "DTM", "TDT", "EQD":
+			if err := read(h, s, activityLocation, eventTime); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return checked(results)
+}
+
+// gateHandlings reads an event from every container of a CODECO message.
+// The segments before the first EQD apply to all containers.
+func gateHandlings(segments []Segment) ([]Handling, error) {
+	var (
+		results []Handling
+		message Handling
+		t       cargo.HandlingEventType
+	)
+
+	for _, s := range segments {
+		h := &message
+		if len(results) > 0 {
+			h = &results[len(results)-1]
+		}
+
+		switch s.Tag {
+		case "BGM":
+			var ok bool
+			if t, ok = gateEvents[s.Value(0, 0)]; !ok {
+				return nil, fmt.Errorf("%w: document name %q", ErrUnknownStatus, s.Value(0, 0))
+			}
+		case "EQD":
+			if s.Value(0, 0) == container {
+				results = append(results, message)
+				h = &results[len(results)-1]
+			}
+
+			fallthrough
+		case "RFF", "LOC", "DTM", "TDT":
+			if err := read(h, s, terminalLocation, gateTime); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for i := range results {
+		results[i].Activity.Type = t
+	}
+
+	return checked(results)
+}
+
+// read copies what the segment says about the event into h. Only the
+// location and time with the given qualifiers are taken.
+func read(h *Handling, s Segment, loc, when string) error {
+	switch s.Tag {
+	case "RFF":
+		if s.Value(0, 0) == bookingReference {
+			h.TrackingID = cargo.TrackingID(s.Value(0, 1))
+		}
+	case "LOC":
+		if s.Value(0, 0) == loc {
+			h.Activity.Location = location.UNLocode(s.Value(1, 0))
+		}
+	case "DTM":
+		if s.Value(0, 0) == when {
+			t, err := parseTime(s.Value(0, 1), s.Value(0, 2))
+			if err != nil {
+				return err
+			}
+
+			h.Completed = t
+		}
+	case "TDT":
+		h.Activity.VoyageNumber = voyage.Number(s.Value(1, 0))
+	case "EQD":
+		if s.Value(0, 0) == container {
+			h.ContainerID = shipment.ContainerID(s.Value(1, 0))
+		}
+	}
+
+	return nil
+}
+
+// parseTime reads a DTM value in one of the formats partners use, all of
+// them in UTC.
+func parseTime(value, format string) (time.Time, error) {
+	layouts := map[string]string{
+		"102": "20060102",
+		"203": "200601021504",
+		"204": "20060102150405",
+	}
+
+	layout, ok := layouts[format]
+	if !ok {
+		return time.Time{}, fmt.Errorf("%w: unsupported date format %q", ErrInvalidHandling, format)
+	}
+
+	t, err := time.Parse(layout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: date %q does not match format %s", ErrInvalidHandling, value, format)
+	}
+
+	return t, nil
+}
+
+// checked applies the rules events registered through the API follow.
+func checked(handlings []Handling) ([]Handling, error) {
+	if len(handlings) == 0 {
+		return nil, fmt.Errorf("%w: the message reports no events", ErrInvalidHandling)
+	}
+
+	for i, h := range handlings {
+		var problem string
+		switch {
+		case h.Activity.Type == cargo.NotHandled:
+			problem = "has no event type"
+		case h.TrackingID == "" && h.ContainerID == "":
+			problem = "names neither a cargo nor a container"
+		case h.TrackingID == "" && !h.ContainerID.IsValid():
+			problem = fmt.Sprintf("container %q is not an ISO 6346 container number", h.ContainerID)
+		case !h.Activity.Location.IsValid():
+			problem = fmt.Sprintf("location %q is not a UN/LOCODE", h.Activity.Location)
+		case (h.Activity.Type == cargo.Load || h.Activity.Type == cargo.Unload) && h.Activity.VoyageNumber == "":
+			problem = "has no voyage number"
+		case h.Completed.IsZero():
+			problem = "has no completion time"
+		}
+
+		if problem != "" {
+			return nil, fmt.Errorf("%w: event %d %s", ErrInvalidHandling, i+1, problem)
+		}
+	}
+
+	return handlings, nil
+}
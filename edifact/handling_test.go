@@ -0,0 +1,124 @@
+package edifact
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/shipment"
+	"github.com/stretchr/testify/require"
+)
+
+func message(t *testing.T, data string) Message {
+	t.Helper()
+	ic, err := Parse([]byte(data))
+	require.NoError(t, err)
+	require.Len(t, ic.Messages, 1)
+	return ic.Messages[0]
+}
+
+func TestStatusHandlings(t *testing.T) {
+	m := message(t, "UNB+UNOC:3+CARRIER+SHIPPING+191018:1200+1'"+
+		"UNH+1+IFTSTA:D:99B:UN'"+
+		"BGM+23+ST1+9'"+
+		"CNI+1+ABC123'"+
+		"STS+1+AE'"+
+		"LOC+175+SESTO:139:6'"+
+		"DTM+334:201910181130:203'"+
+		"TDT+20+V100+1'"+
+		"STS+1+UV'"+
+		"LOC+175+CNHKG:139:6'"+
+		"DTM+334:20191020:102'"+
+		"TDT+20+V100+1'"+
+		"CNI+2+DEF456'"+
+		"STS+1+I'"+
+		"LOC+175+SESTO'"+
+		"DTM+334:20191018093000:204'"+
+		"EQD+CN+MSCU1234565'"+
+		"UNT+17+1'"+
+		"UNZ+1+1'")
+
+	handlings, err := Handlings(m)
+	require.NoError(t, err)
+	require.Len(t, handlings, 3)
+
+	require.Equal(t, cargo.TrackingID("ABC123"), handlings[0].TrackingID)
+	require.Equal(t, cargo.HandlingActivity{Type: cargo.Load, Location: "SESTO", VoyageNumber: "V100"}, handlings[0].Activity)
+	require.Equal(t, time.Date(2019, 10, 18, 11, 30, 0, 0, time.UTC), handlings[0].Completed)
+
+	require.Equal(t, cargo.HandlingActivity{Type: cargo.Unload, Location: "CNHKG", VoyageNumber: "V100"}, handlings[1].Activity)
+	require.Equal(t, time.Date(2019, 10, 20, 0, 0, 0, 0, time.UTC), handlings[1].Completed)
+
+	require.Equal(t, cargo.TrackingID("DEF456"), handlings[2].TrackingID)
+	require.Equal(t, shipment.ContainerID("MSCU1234565"), handlings[2].ContainerID)
+	require.Equal(t, cargo.Receive, handlings[2].Activity.Type)
+	require.Equal(t, time.Date(2019, 10, 18, 9, 30, 0, 0, time.UTC), handlings[2].Completed)
+}
+
+func TestGateHandlings(t *testing.T) {
+	m := message(t, "UNB+UNOC:3+TERMINAL+SHIPPING+191018:1200+1'"+
+		"UNH+1+CODECO:D:95B:UN'"+
+		"BGM+36+GO1+9'"+
+		"LOC+165+AUMEL:139:6'"+
+		"DTM+7:201910251400:203'"+
+		"EQD+CN+MSCU1234565+22G1:102:5'"+
+		"EQD+CN+TGHU7654321+45G1:102:5'"+
+		"DTM+7:201910251530:203'"+
+		"RFF+BN:ABC123'"+
+		"UNT+9+1'"+
+		"UNZ+1+1'")
+
+	handlings, err := Handlings(m)
+	require.NoError(t, err)
+	require.Len(t, handlings, 2)
+
+	require.Equal(t, shipment.ContainerID("MSCU1234565"), handlings[0].ContainerID)
+	require.Empty(t, handlings[0].TrackingID)
+	require.Equal(t, cargo.HandlingActivity{Type: cargo.Claim, Location: "AUMEL"}, handlings[0].Activity)
+	require.Equal(t, time.Date(2019, 10, 25, 14, 0, 0, 0, time.UTC), handlings[0].Completed)
+
+	require.Equal(t, shipment.ContainerID("TGHU7654321"), handlings[1].ContainerID)
+	require.Equal(t, cargo.TrackingID("ABC123"), handlings[1].TrackingID)
+	require.Equal(t, time.Date(2019, 10, 25, 15, 30, 0, 0, time.UTC), handlings[1].Completed)
+}
+
+func TestHandlingsRejectsMessages(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Message
+		err  error
+	}{
+		{"malformed", Message{Type: IFTSTA, Err: ErrMalformedMessage}, ErrMalformedMessage},
+		{"unsupported", Message{Type: "IFTMIN"}, ErrUnsupportedMessage},
+		{"no events", Message{Type: IFTSTA}, ErrInvalidHandling},
+		{"unknown status", Message{Type: IFTSTA, Segments: []Segment{
+			{Tag: "STS", Elements: [][]string{{"1"}, {"XX"}}},
+		}}, ErrUnknownStatus},
+		{"load without voyage", Message{Type: IFTSTA, Segments: []Segment{
+			{Tag: "CNI", Elements: [][]string{{"1"}, {"ABC123"}}},
+			{Tag: "STS", Elements: [][]string{{"1"}, {"AE"}}},
+			{Tag: "LOC", Elements: [][]string{{"175"}, {"SESTO"}}},
+			{Tag: "DTM", Elements: [][]string{{"334", "201910181130", "203"}}},
+		}}, ErrInvalidHandling},
+		{"bad date", Message{Type: IFTSTA, Segments: []Segment{
+			{Tag: "STS", Elements: [][]string{{"1"}, {"I"}}},
+			{Tag: "DTM", Elements: [][]string{{"334", "18.10.2019", "203"}}},
+		}}, ErrInvalidHandling},
+		{"bad container", Message{Type: CODECO, Segments: []Segment{
+			{Tag: "BGM", Elements: [][]string{{"34"}}},
+			{Tag: "LOC", Elements: [][]string{{"165"}, {"SESTO"}}},
+			{Tag: "DTM", Elements: [][]string{{"7", "201910181130", "203"}}},
+			{Tag: "EQD", Elements: [][]string{{"CN"}, {"1234"}}},
+		}}, ErrInvalidHandling},
+		{"unknown gate", Message{Type: CODECO, Segments: []Segment{
+			{Tag: "BGM", Elements: [][]string{{"99"}}},
+		}}, ErrUnknownStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Handlings(tt.m)
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
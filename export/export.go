@@ -0,0 +1,272 @@
+// Package export writes cargo records in the formats offered for data dumps:
+// CSV, JSON Lines and Parquet.
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// Supported formats.
+const (
+	FormatCSV     = "csv"
+	FormatJSONL   = "jsonl"
+	FormatParquet = "parquet"
+)
+
+// Formats lists the supported formats.
+var Formats = []string{FormatCSV, FormatJSONL, FormatParquet}
+
+// Event is a handling event of an exported cargo. Completed is the zero
+// time for an activity that has not happened yet, such as the next expected
+// one.
+type Event struct {
+	Type         string    `json:"type"`
+	Location     string    `json:"location"`
+	VoyageNumber string    `json:"voyage_number,omitempty"`
+	Completed    time.Time `json:"completed"`
+}
+
+func (e Event) String() string {
+	s := e.Type + "@" + e.Location
+	if e.VoyageNumber != "" {
+		s += "/" + e.VoyageNumber
+	}
+
+	if !e.Completed.IsZero() {
+		s += " " + formatTime(e.Completed)
+	}
+
+	return s
+}
+
+// Record is a cargo together with its current delivery state. ETA is nil
+// when there is no estimate, History is only filled in when the handling
+// history was requested.
+type Record struct {
+	TrackingID            string     `json:"tracking_id"`
+	CustomerID            string     `json:"customer_id,omitempty"`
+	Origin                string     `json:"origin"`
+	Destination           string     `json:"destination"`
+	ArrivalDeadline       time.Time  `json:"arrival_deadline"`
+	RoutingStatus         string     `json:"routing_status"`
+	TransportStatus       string     `json:"transport_status"`
+	LastKnownLocation     string     `json:"last_known_location,omitempty"`
+	CurrentVoyage         string     `json:"current_voyage,omitempty"`
+	ETA                   *time.Time `json:"eta,omitempty"`
+	Misdirected           bool       `json:"misdirected"`
+	UnloadedAtDestination bool       `json:"unloaded_at_destination"`
+	NextExpectedActivity  string     `json:"next_expected_activity,omitempty"`
+	History               []Event    `json:"handling_history,omitempty"`
+}
+
+// Writer writes records in one of the supported formats. Close must be
+// called once all records are written, it flushes buffered output but does
+// not close the underlying io.Writer.
+type Writer interface {
+	Write(r Record) error
+	Close() error
+}
+
+// Supported reports whether format is one of Formats.
+func Supported(format string) bool {
+	for _, f := range Formats {
+		if f == format {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewWriter returns a writer for format.
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w), nil
+	case FormatJSONL:
+		return jsonlWriter{enc: json.NewEncoder(w)}, nil
+	case FormatParquet:
+		return newParquetWriter(w)
+	}
+
+	return nil, fmt.Errorf("unsupported export format %q, use one of %s", format, strings.Join(Formats, ", "))
+}
+
+// ContentType returns the media type of format.
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv"
+	case FormatJSONL:
+		return "application/x-ndjson"
+	case FormatParquet:
+		return "application/vnd.apache.parquet"
+	}
+
+	return "application/octet-stream"
+}
+
+var csvHeader = []string{
+	"tracking_id", "customer_id", "origin", "destination", "arrival_deadline",
+	"routing_status", "transport_status", "last_known_location", "current_voyage",
+	"eta", "misdirected", "unloaded_at_destination", "next_expected_activity",
+	"handling_history",
+}
+
+type csvWriter struct {
+	w           *csv.Writer
+	wroteHeader bool
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (cw *csvWriter) Write(r Record) error {
+	if !cw.wroteHeader {
+		if err := cw.w.Write(csvHeader); err != nil {
+			return err
+		}
+		cw.wroteHeader = true
+	}
+
+	history := make([]string, 0, len(r.History))
+	for _, e := range r.History {
+		history = append(history, e.String())
+	}
+
+	return cw.w.Write([]string{
+		r.TrackingID,
+		r.CustomerID,
+		r.Origin,
+		r.Destination,
+		formatTime(r.ArrivalDeadline),
+		r.RoutingStatus,
+		r.TransportStatus,
+		r.LastKnownLocation,
+		r.CurrentVoyage,
+		formatTime(derefTime(r.ETA)),
+		strconv.FormatBool(r.Misdirected),
+		strconv.FormatBool(r.UnloadedAtDestination),
+		r.NextExpectedActivity,
+		strings.Join(history, ";"),
+	})
+}
+
+func (cw *csvWriter) Close() error {
+	if !cw.wroteHeader {
+		if err := cw.w.Write(csvHeader); err != nil {
+			return err
+		}
+	}
+
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+type jsonlWriter struct {
+	enc *json.Encoder
+}
+
+func (jw jsonlWriter) Write(r Record) error { return jw.enc.Encode(r) }
+
+func (jw jsonlWriter) Close() error { return nil }
+
+// parquetRecord is the Parquet layout of a Record. Timestamps are stored as
+// milliseconds since the epoch, the handling history as JSON.
+type parquetRecord struct {
+	TrackingID            string `parquet:"name=tracking_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	CustomerID            string `parquet:"name=customer_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Origin                string `parquet:"name=origin, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Destination           string `parquet:"name=destination, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ArrivalDeadline       *int64 `parquet:"name=arrival_deadline, type=INT64, convertedtype=TIMESTAMP_MILLIS, repetitiontype=OPTIONAL"`
+	RoutingStatus         string `parquet:"name=routing_status, type=BYTE_ARRAY, convertedtype=UTF8"`
+	TransportStatus       string `parquet:"name=transport_status, type=BYTE_ARRAY, convertedtype=UTF8"`
+	LastKnownLocation     string `parquet:"name=last_known_location, type=BYTE_ARRAY, convertedtype=UTF8"`
+	CurrentVoyage         string `parquet:"name=current_voyage, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ETA                   *int64 `parquet:"name=eta, type=INT64, convertedtype=TIMESTAMP_MILLIS, repetitiontype=OPTIONAL"`
+	Misdirected           bool   `parquet:"name=misdirected, type=BOOLEAN"`
+	UnloadedAtDestination bool   `parquet:"name=unloaded_at_destination, type=BOOLEAN"`
+	NextExpectedActivity  string `parquet:"name=next_expected_activity, type=BYTE_ARRAY, convertedtype=UTF8"`
+	HandlingHistory       string `parquet:"name=handling_history, type=BYTE_ARRAY, convertedtype=UTF8"`
+}
+
+type parquetWriter struct {
+	pw *writer.ParquetWriter
+}
+
+func newParquetWriter(w io.Writer) (*parquetWriter, error) {
+	pw, err := writer.NewParquetWriterFromWriter(w, new(parquetRecord), 1)
+	if err != nil {
+		return nil, err
+	}
+
+	pw.CompressionType = parquet.CompressionCodec_SNAPPY
+	return &parquetWriter{pw: pw}, nil
+}
+
+func (pw *parquetWriter) Write(r Record) error {
+	var history string
+	if len(r.History) > 0 {
+		b, err := json.Marshal(r.History)
+		if err != nil {
+			return err
+		}
+		history = string(b)
+	}
+
+	return pw.pw.Write(parquetRecord{
+		TrackingID:            r.TrackingID,
+		CustomerID:            r.CustomerID,
+		Origin:                r.Origin,
+		Destination:           r.Destination,
+		ArrivalDeadline:       millis(r.ArrivalDeadline),
+		RoutingStatus:         r.RoutingStatus,
+		TransportStatus:       r.TransportStatus,
+		LastKnownLocation:     r.LastKnownLocation,
+		CurrentVoyage:         r.CurrentVoyage,
+		ETA:                   millis(derefTime(r.ETA)),
+		Misdirected:           r.Misdirected,
+		UnloadedAtDestination: r.UnloadedAtDestination,
+		NextExpectedActivity:  r.NextExpectedActivity,
+		HandlingHistory:       history,
+	})
+}
+
+func (pw *parquetWriter) Close() error {
+	return pw.pw.WriteStop()
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
+
+func derefTime(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+
+	return *t
+}
+
+func millis(t time.Time) *int64 {
+	if t.IsZero() {
+		return nil
+	}
+
+	ms := t.UnixMilli()
+	return &ms
+}
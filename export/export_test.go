@@ -0,0 +1,98 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+func sampleRecords() []Record {
+	deadline := time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC)
+	return []Record{
+		{
+			TrackingID:      "ABC123",
+			CustomerID:      "acme",
+			Origin:          "SESTO",
+			Destination:     "AUMEL",
+			ArrivalDeadline: deadline,
+			RoutingStatus:   "Routed",
+			TransportStatus: "Onboard carrier",
+			CurrentVoyage:   "V100",
+			History: []Event{
+				{Type: "Receive", Location: "SESTO", Completed: time.Date(2029, 12, 1, 8, 0, 0, 0, time.UTC)},
+				{Type: "Load", Location: "SESTO", VoyageNumber: "V100", Completed: time.Date(2029, 12, 2, 9, 30, 0, 0, time.UTC)},
+			},
+		},
+		{
+			TrackingID:      "DEF456",
+			Origin:          "CNHKG",
+			Destination:     "USNYC",
+			ArrivalDeadline: deadline,
+			RoutingStatus:   "Not routed",
+			TransportStatus: "Not received",
+		},
+	}
+}
+
+func writeAll(t *testing.T, format string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewWriter(format, &buf)
+	require.NoError(t, err)
+
+	for _, r := range sampleRecords() {
+		require.NoError(t, w.Write(r))
+	}
+	require.NoError(t, w.Close())
+
+	return buf.Bytes()
+}
+
+func TestCSV(t *testing.T) {
+	rows, err := csv.NewReader(bytes.NewReader(writeAll(t, FormatCSV))).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	require.Equal(t, csvHeader, rows[0])
+	require.Equal(t, "ABC123", rows[1][0])
+	require.Equal(t, "2030-01-02T00:00:00Z", rows[1][4])
+	require.Equal(t, "Receive@SESTO 2029-12-01T08:00:00Z;Load@SESTO/V100 2029-12-02T09:30:00Z", rows[1][13])
+	require.Equal(t, "", rows[2][9])
+}
+
+func TestJSONL(t *testing.T) {
+	lines := bytes.Split(bytes.TrimSpace(writeAll(t, FormatJSONL)), []byte("\n"))
+	require.Len(t, lines, 2)
+
+	var r Record
+	require.NoError(t, json.Unmarshal(lines[0], &r))
+	require.Equal(t, sampleRecords()[0], r)
+}
+
+func TestParquet(t *testing.T) {
+	file, err := buffer.NewBufferFile(writeAll(t, FormatParquet))
+	require.NoError(t, err)
+
+	pr, err := reader.NewParquetReader(file, new(parquetRecord), 1)
+	require.NoError(t, err)
+	defer pr.ReadStop()
+	require.EqualValues(t, 2, pr.GetNumRows())
+
+	got := make([]parquetRecord, 2)
+	require.NoError(t, pr.Read(&got))
+	require.Equal(t, "ABC123", got[0].TrackingID)
+	require.Equal(t, time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC).UnixMilli(), *got[0].ArrivalDeadline)
+	require.Nil(t, got[1].ETA)
+	require.Contains(t, got[0].HandlingHistory, `"voyage_number":"V100"`)
+	require.Contains(t, got[0].HandlingHistory, `"completed":"2029-12-02T09:30:00Z"`)
+}
+
+func TestUnsupportedFormat(t *testing.T) {
+	_, err := NewWriter("xlsx", &bytes.Buffer{})
+	require.Error(t, err)
+}
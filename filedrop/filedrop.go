@@ -0,0 +1,194 @@
+// Package filedrop imports the handling reports ports drop into a directory
+// as CSV files at the end of a shift.
+package filedrop
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+	"github.com/mproyyan/grpc-shipping-microservice/location"
+	"github.com/mproyyan/grpc-shipping-microservice/shipment"
+	"github.com/mproyyan/grpc-shipping-microservice/voyage"
+)
+
+// ErrInvalidLine is returned for lines that do not describe a handling
+// event that can be registered.
+var ErrInvalidLine = errors.New("invalid handling report line")
+
+// Line is a handling event read from a line of a report. It names either
+// the cargo or the container the cargo travels in. Err is set for lines
+// that could not be read, they are not registered.
+type Line struct {
+	Number      int
+	Key         string
+	TrackingID  cargo.TrackingID
+	ContainerID shipment.ContainerID
+	Activity    cargo.HandlingActivity
+	Completed   time.Time
+	Err         error
+}
+
+// Outcome is the result of importing a line. Duplicate lines were imported
+// before, from this or another file, and were skipped.
+type Outcome struct {
+	TrackingIDs []cargo.TrackingID
+	Duplicate   bool
+	Err         error
+}
+
+// Importer registers the events of a report, source naming the file they
+// come from.
+type Importer interface {
+	ImportHandlingEvents(ctx context.Context, source string, lines []Line) ([]Outcome, error)
+}
+
+// ParseReport reads a report with a header row naming the type, location
+// and completed columns and either tracking_id or container_id, or both.
+// voyage_number is optional. Lines failing the checks carry their error,
+// only a report that cannot be read at all is an error.
+func ParseReport(r io.Reader) ([]Line, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	for _, name := range []string{"type", "location", "completed"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("header is missing the %s column", name)
+		}
+	}
+
+	_, byCargo := columns["tracking_id"]
+	_, byContainer := columns["container_id"]
+	if !byCargo && !byContainer {
+		return nil, errors.New("header is missing the tracking_id or container_id column")
+	}
+
+	var lines []Line
+	for number := 2; ; number++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return lines, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		cell := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+
+			return ""
+		}
+
+		lines = append(lines, parseLine(number, cell))
+	}
+}
+
+func parseLine(number int, cell func(string) string) Line {
+	l := Line{
+		Number:      number,
+		TrackingID:  cargo.TrackingID(cell("tracking_id")),
+		ContainerID: shipment.ContainerID(cell("container_id")),
+		Activity: cargo.HandlingActivity{
+			Location:     location.UNLocode(cell("location")),
+			VoyageNumber: voyage.Number(cell("voyage_number")),
+		},
+	}
+
+	var err error
+	if l.Activity.Type, err = cargo.ParseHandlingEventType(cell("type")); err != nil {
+		l.Err = fmt.Errorf("%w: type %q is not load, unload, receive, claim or customs", ErrInvalidLine, cell("type"))
+		return l
+	}
+
+	if l.Completed, err = time.Parse(time.RFC3339, cell("completed")); err != nil {
+		l.Err = fmt.Errorf("%w: completed %q is not an RFC 3339 timestamp", ErrInvalidLine, cell("completed"))
+		return l
+	}
+
+	switch {
+	case l.TrackingID == "" && l.ContainerID == "":
+		l.Err = fmt.Errorf("%w: names neither a cargo nor a container", ErrInvalidLine)
+	case l.TrackingID == "" && !l.ContainerID.IsValid():
+		l.Err = fmt.Errorf("%w: container %q is not an ISO 6346 container number", ErrInvalidLine, l.ContainerID)
+	case !l.Activity.Location.IsValid():
+		l.Err = fmt.Errorf("%w: location %q is not a UN/LOCODE", ErrInvalidLine, l.Activity.Location)
+	case (l.Activity.Type == cargo.Load || l.Activity.Type == cargo.Unload) && l.Activity.VoyageNumber == "":
+		l.Err = fmt.Errorf("%w: %s needs a voyage number", ErrInvalidLine, strings.ToLower(l.Activity.Type.String()))
+	case l.Completed.After(time.Now()):
+		l.Err = fmt.Errorf("%w: completed lies in the future", ErrInvalidLine)
+	}
+
+	l.Key = l.key()
+	return l
+}
+
+// key identifies the event a line reports regardless of the file it comes
+// in or its position, so a report uploaded twice is imported once.
+func (l Line) key() string {
+	subject := string(l.TrackingID)
+	if subject == "" {
+		subject = "container:" + string(l.ContainerID)
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		subject,
+		l.Activity.Type.String(),
+		string(l.Activity.Location),
+		string(l.Activity.VoyageNumber),
+		l.Completed.UTC().Format(time.RFC3339),
+	}, "|")))
+
+	return hex.EncodeToString(sum[:])
+}
+
+type ImportRepositoryContract interface {
+	Claim(ctx context.Context, dbtx db.DBTX, key, source string, at time.Time) (bool, error)
+}
+
+type ImportRepository struct {
+}
+
+func NewImportRepository() ImportRepository {
+	return ImportRepository{}
+}
+
+// Claim records that the line with the key was imported from source and
+// reports whether it was new. Claimed in the transaction registering the
+// event, the key is released again when registering fails.
+func (ir ImportRepository) Claim(ctx context.Context, dbtx db.DBTX, key, source string, at time.Time) (bool, error) {
+	query := `INSERT INTO handling_imports (key, source, imported_at) VALUES ($1, $2, $3)
+		ON CONFLICT (key) DO NOTHING`
+
+	res, err := dbtx.ExecContext(ctx, query, key, source, at)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return n == 1, nil
+}
@@ -0,0 +1,76 @@
+package filedrop
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/shipment"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReport(t *testing.T) {
+	report := "Tracking_ID,container_id,type,location,voyage_number,completed\n" +
+		"ABC123,,load,SESTO,V100,2019-10-18T11:30:00Z\n" +
+		",MSCU1234565,Receive,SESTO,,2019-10-18T09:00:00+02:00\n" +
+		"ABC123,,unload,CNHKG,,2019-10-20T00:00:00Z\n" +
+		"ABC123,,dropped,CNHKG,,2019-10-20T00:00:00Z\n" +
+		",,claim,AUMEL,,2019-10-25T00:00:00Z\n" +
+		"ABC123,,claim,AUMEL,,2099-10-25T00:00:00Z\n"
+
+	lines, err := ParseReport(strings.NewReader(report))
+	require.NoError(t, err)
+	require.Len(t, lines, 6)
+
+	require.NoError(t, lines[0].Err)
+	require.Equal(t, 2, lines[0].Number)
+	require.Equal(t, cargo.TrackingID("ABC123"), lines[0].TrackingID)
+	require.Equal(t, cargo.HandlingActivity{Type: cargo.Load, Location: "SESTO", VoyageNumber: "V100"}, lines[0].Activity)
+	require.Equal(t, time.Date(2019, 10, 18, 11, 30, 0, 0, time.UTC), lines[0].Completed.UTC())
+
+	require.NoError(t, lines[1].Err)
+	require.Equal(t, shipment.ContainerID("MSCU1234565"), lines[1].ContainerID)
+	require.Equal(t, cargo.Receive, lines[1].Activity.Type)
+
+	for _, l := range lines[2:] {
+		require.ErrorIs(t, l.Err, ErrInvalidLine, l.Number)
+	}
+	require.Contains(t, lines[2].Err.Error(), "voyage")
+	require.Contains(t, lines[3].Err.Error(), "type")
+	require.Contains(t, lines[4].Err.Error(), "neither")
+	require.Contains(t, lines[5].Err.Error(), "future")
+
+	for _, header := range []string{"tracking_id,type,location\n", "type,location,completed\n", ""} {
+		_, err := ParseReport(strings.NewReader(header))
+		require.Error(t, err, header)
+	}
+}
+
+func TestLineKey(t *testing.T) {
+	first, err := ParseReport(strings.NewReader("tracking_id,type,location,voyage_number,completed\nABC123,load,SESTO,V100,2019-10-18T11:30:00Z\n"))
+	require.NoError(t, err)
+
+	// the same event in another layout and time zone
+	again, err := ParseReport(strings.NewReader("completed,type,location,voyage_number,tracking_id\n2019-10-18T13:30:00+02:00,LOAD,SESTO,V100,ABC123\n"))
+	require.NoError(t, err)
+	require.Equal(t, first[0].Key, again[0].Key)
+
+	other, err := ParseReport(strings.NewReader("tracking_id,type,location,voyage_number,completed\nABC123,load,SESTO,V200,2019-10-18T11:30:00Z\n"))
+	require.NoError(t, err)
+	require.NotEqual(t, first[0].Key, other[0].Key)
+}
+
+func TestClaim(t *testing.T) {
+	ctx := context.Background()
+	key := Line{TrackingID: cargo.NextTrackingID(), Activity: cargo.HandlingActivity{Type: cargo.Receive, Location: "SESTO"}}.key()
+
+	fresh, err := importTest.Claim(ctx, dbTest, key, "shift.csv", time.Now())
+	require.NoError(t, err)
+	require.True(t, fresh)
+
+	fresh, err = importTest.Claim(ctx, dbTest, key, "shift-again.csv", time.Now())
+	require.NoError(t, err)
+	require.False(t, fresh)
+}
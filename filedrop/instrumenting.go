@@ -0,0 +1,34 @@
+package filedrop
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+)
+
+type instrumentingImportRepository struct {
+	latency metrics.Histogram
+	ImportRepositoryContract
+}
+
+// NewInstrumentingImportRepository returns an import repository that records
+// query latency, labeled by repository, method and outcome.
+func NewInstrumentingImportRepository(latency metrics.Histogram, r ImportRepositoryContract) ImportRepositoryContract {
+	return &instrumentingImportRepository{latency: latency, ImportRepositoryContract: r}
+}
+
+func (r *instrumentingImportRepository) observe(method string, begin time.Time, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+
+	r.latency.With("repository", "handling_import", "method", method, "outcome", outcome).Observe(time.Since(begin).Seconds())
+}
+
+func (r *instrumentingImportRepository) Claim(ctx context.Context, dbtx db.DBTX, key, source string, at time.Time) (fresh bool, err error) {
+	defer func(begin time.Time) { r.observe("claim", begin, err) }(time.Now())
+	return r.ImportRepositoryContract.Claim(ctx, dbtx, key, source, at)
+}
@@ -0,0 +1,36 @@
+package filedrop
+
+import (
+	"context"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+	"github.com/mproyyan/grpc-shipping-microservice/tracing"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = tracing.Tracer("github.com/mproyyan/grpc-shipping-microservice/filedrop")
+
+type tracingImportRepository struct {
+	ImportRepositoryContract
+}
+
+// NewTracingImportRepository returns an import repository that wraps every
+// query in a client span.
+func NewTracingImportRepository(r ImportRepositoryContract) ImportRepositoryContract {
+	return &tracingImportRepository{ImportRepositoryContract: r}
+}
+
+func (r *tracingImportRepository) start(ctx context.Context, name string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemPostgreSQL),
+	)
+}
+
+func (r *tracingImportRepository) Claim(ctx context.Context, dbtx db.DBTX, key, source string, at time.Time) (fresh bool, err error) {
+	ctx, span := r.start(ctx, "ImportRepository.Claim")
+	defer func() { tracing.End(span, err) }()
+	return r.ImportRepositoryContract.Claim(ctx, dbtx, key, source, at)
+}
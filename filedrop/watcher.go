@@ -0,0 +1,170 @@
+package filedrop
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+)
+
+// Folders inside the watched directory processed reports are moved to.
+const (
+	DoneFolder   = "done"
+	FailedFolder = "failed"
+)
+
+// Watcher imports the reports dropped into a directory. A report is moved
+// to the done folder once every line was imported or skipped as a
+// duplicate, and to the failed folder otherwise; fixed reports can be
+// dropped again as a whole, lines imported before are skipped.
+//
+// Only *.csv files are picked up, uploads should be written under another
+// name and renamed when complete.
+type Watcher struct {
+	dir      string
+	importer Importer
+	logger   log.Logger
+}
+
+func NewWatcher(dir string, importer Importer, logger log.Logger) Watcher {
+	return Watcher{
+		dir:      dir,
+		importer: importer,
+		logger:   logger,
+	}
+}
+
+// Run imports the reports in the directory every interval until the
+// context is done.
+func (w Watcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.Scan(ctx); err != nil {
+			level.Error(w.logger).Log("msg", "cannot scan handling report directory", "dir", w.dir, "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Scan imports the reports currently in the directory, oldest first.
+func (w Watcher) Scan(ctx context.Context) error {
+	for _, folder := range []string{DoneFolder, FailedFolder} {
+		if err := os.MkdirAll(filepath.Join(w.dir, folder), 0o755); err != nil {
+			return err
+		}
+	}
+
+	entries, err := os.ReadDir(w.dir)
+	if err != nil {
+		return err
+	}
+
+	type report struct {
+		name     string
+		modified time.Time
+	}
+
+	var reports []report
+	for _, e := range entries {
+		if !e.Type().IsRegular() || !strings.EqualFold(filepath.Ext(e.Name()), ".csv") {
+			continue
+		}
+
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+
+		reports = append(reports, report{e.Name(), info.ModTime()})
+	}
+
+	// ReadDir sorts by name, which breaks ties between equal times
+	sort.SliceStable(reports, func(i, j int) bool { return reports[i].modified.Before(reports[j].modified) })
+
+	for _, r := range reports {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		folder := DoneFolder
+		if !w.importFile(ctx, r.name) {
+			folder = FailedFolder
+		}
+
+		if err := move(filepath.Join(w.dir, r.name), filepath.Join(w.dir, folder)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// importFile imports a report, logging the outcome of every line, and
+// reports whether all of them succeeded.
+func (w Watcher) importFile(ctx context.Context, name string) bool {
+	logger := log.With(w.logger, "file", name)
+
+	f, err := os.Open(filepath.Join(w.dir, name))
+	if err != nil {
+		level.Error(logger).Log("msg", "cannot open handling report", "err", err)
+		return false
+	}
+	defer f.Close()
+
+	lines, err := ParseReport(f)
+	if err != nil {
+		level.Error(logger).Log("msg", "cannot read handling report", "err", err)
+		return false
+	}
+
+	outcomes, err := w.importer.ImportHandlingEvents(ctx, name, lines)
+	if err != nil {
+		level.Error(logger).Log("msg", "cannot import handling report", "err", err)
+		return false
+	}
+
+	var imported, duplicates, failed int
+	for i, o := range outcomes {
+		l := lines[i]
+		switch {
+		case o.Err != nil:
+			failed++
+			level.Warn(logger).Log("msg", "handling report line", "line", l.Number, "outcome", "failed", "err", o.Err)
+		case o.Duplicate:
+			duplicates++
+			level.Info(logger).Log("msg", "handling report line", "line", l.Number, "outcome", "duplicate")
+		default:
+			imported++
+			level.Info(logger).Log("msg", "handling report line", "line", l.Number, "outcome", "imported", "tracking_ids", fmt.Sprint(o.TrackingIDs))
+		}
+	}
+
+	level.Info(logger).Log("msg", "handling report processed", "imported", imported, "duplicates", duplicates, "failed", failed)
+	return failed == 0
+}
+
+// move moves the file into the folder, adding the time to its name if the
+// folder holds a file of that name already.
+func move(path, folder string) error {
+	name := filepath.Base(path)
+	target := filepath.Join(folder, name)
+	if _, err := os.Stat(target); err == nil {
+		ext := filepath.Ext(name)
+		target = filepath.Join(folder, fmt.Sprintf("%s-%s%s", strings.TrimSuffix(name, ext), time.Now().UTC().Format("20060102T150405.000"), ext))
+	}
+
+	return os.Rename(path, target)
+}
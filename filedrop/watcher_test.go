@@ -0,0 +1,77 @@
+package filedrop
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryImporter imports every valid line once, remembering the keys, and
+// fails the lines of cargo XXX.
+type memoryImporter struct {
+	keys    map[string]bool
+	sources []string
+}
+
+func (i *memoryImporter) ImportHandlingEvents(_ context.Context, source string, lines []Line) ([]Outcome, error) {
+	i.sources = append(i.sources, source)
+
+	outcomes := make([]Outcome, len(lines))
+	for n, l := range lines {
+		switch {
+		case l.Err != nil:
+			outcomes[n].Err = l.Err
+		case l.TrackingID == "XXX":
+			outcomes[n].Err = errors.New("unknown cargo")
+		case i.keys[l.Key]:
+			outcomes[n].Duplicate = true
+		default:
+			i.keys[l.Key] = true
+			outcomes[n].TrackingIDs = []cargo.TrackingID{l.TrackingID}
+		}
+	}
+
+	return outcomes, nil
+}
+
+func TestWatcherScan(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+
+	const header = "tracking_id,type,location,voyage_number,completed\n"
+	write("morning.csv", header+"ABC123,load,SESTO,V100,2019-10-18T11:30:00Z\n")
+	write("evening.csv", header+"ABC123,load,SESTO,V100,2019-10-18T11:30:00Z\nXXX,receive,SESTO,,2019-10-18T12:00:00Z\n")
+	write("broken.csv", "nothing,useful\n")
+	write("upload.csv.part", header)
+
+	importer := &memoryImporter{keys: map[string]bool{}}
+	var logs bytes.Buffer
+	w := NewWatcher(dir, importer, log.NewLogfmtLogger(&logs))
+	require.NoError(t, w.Scan(context.Background()))
+
+	require.ElementsMatch(t, []string{"morning.csv", "evening.csv"}, importer.sources)
+	require.FileExists(t, filepath.Join(dir, DoneFolder, "morning.csv"))
+	require.FileExists(t, filepath.Join(dir, FailedFolder, "evening.csv"))
+	require.FileExists(t, filepath.Join(dir, FailedFolder, "broken.csv"))
+	require.FileExists(t, filepath.Join(dir, "upload.csv.part"))
+	require.Contains(t, logs.String(), "outcome=duplicate")
+	require.Contains(t, logs.String(), "outcome=failed")
+
+	// the morning report dropped again is skipped line by line
+	write("morning.csv", header+"ABC123,load,SESTO,V100,2019-10-18T11:30:00Z\n")
+	require.NoError(t, w.Scan(context.Background()))
+	require.Len(t, importer.keys, 1)
+
+	done, err := os.ReadDir(filepath.Join(dir, DoneFolder))
+	require.NoError(t, err)
+	require.Len(t, done, 2)
+}
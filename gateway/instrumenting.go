@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/metrics"
+	"github.com/gorilla/mux"
+)
+
+// routeMetrics records request count and latency for every HTTP route served
+// by the gateway, labeled by route template, HTTP method and status code.
+type routeMetrics struct {
+	requestCount   metrics.Counter
+	requestLatency metrics.Histogram
+}
+
+func (m routeMetrics) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		begin := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		route := "unknown"
+		if current := mux.CurrentRoute(r); current != nil {
+			if tpl, err := current.GetPathTemplate(); err == nil {
+				route = tpl
+			}
+		}
+
+		lvs := []string{"route", route, "method", r.Method, "code", strconv.Itoa(rec.status)}
+		m.requestCount.With(lvs...).Add(1)
+		m.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	})
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// upstreamMetrics records calls made to each discovered booking service
+// instance, so a single slow or failing instance stands out.
+type upstreamMetrics struct {
+	requestCount   metrics.Counter
+	requestLatency metrics.Histogram
+}
+
+func (m upstreamMetrics) middleware(method, instance string) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+			defer func(begin time.Time) {
+				outcome := "success"
+				if err != nil {
+					outcome = "error"
+				}
+
+				lvs := []string{"method", method, "instance", instance, "outcome", outcome}
+				m.requestCount.With(lvs...).Add(1)
+				m.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+			}(time.Now())
+
+			return next(ctx, request)
+		}
+	}
+}
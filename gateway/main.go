@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"io"
@@ -12,34 +13,62 @@ import (
 	"time"
 
 	"github.com/go-kit/kit/endpoint"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	"github.com/go-kit/kit/sd"
 	consulsd "github.com/go-kit/kit/sd/consul"
 	"github.com/go-kit/kit/sd/lb"
 	kitlog "github.com/go-kit/log"
+	"github.com/go-kit/log/level"
 	"github.com/gorilla/mux"
 	"github.com/hashicorp/consul/api"
 	be "github.com/mproyyan/grpc-shipping-microservice/booking/endpoints"
 	bs "github.com/mproyyan/grpc-shipping-microservice/booking/services"
 	bt "github.com/mproyyan/grpc-shipping-microservice/booking/transports"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/logging"
+	"github.com/mproyyan/grpc-shipping-microservice/tracing"
+	"github.com/mproyyan/grpc-shipping-microservice/validation"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 )
 
 func main() {
 	var (
-		httpAddr     = flag.String("http.addr", ":8000", "Address for HTTP (JSON) server")
-		consulAddr   = flag.String("consul.addr", "", "Consul agent address")
-		retryMax     = flag.Int("retry.max", 3, "per-request retries to different instances")
-		retryTimeout = flag.Duration("retry.timeout", 500*time.Millisecond, "per-request timeout, including retries")
+		httpAddr      = flag.String("http.addr", ":8000", "Address for HTTP (JSON) server")
+		consulAddr    = flag.String("consul.addr", "", "Consul agent address")
+		retryMax      = flag.Int("retry.max", 3, "per-request retries to different instances")
+		retryTimeout  = flag.Duration("retry.timeout", 500*time.Millisecond, "per-request timeout, including retries")
+		bulkTimeout   = flag.Duration("bulk.timeout", 2*time.Minute, "timeout of a bulk booking upload")
+		exportTimeout = flag.Duration("export.timeout", 10*time.Minute, "timeout of a cargo export download")
+		logLevel      = flag.String("log.level", "info", "minimum log level: debug, info, warn or error")
+		authKey       = flag.String("auth.key", os.Getenv("AUTH_KEY"), "key the caller tokens are signed with, AUTH_KEY when unset")
+		traceCfg      tracing.Config
 	)
 
+	flag.StringVar(&traceCfg.Exporter, "tracing.exporter", tracing.ExporterNone, "span exporter: none, stdout, file or otlp")
+	flag.StringVar(&traceCfg.File, "tracing.file", "traces.json", "file spans are written to when using the file exporter")
+	flag.StringVar(&traceCfg.OTLPEndpoint, "tracing.otlp.endpoint", "localhost:4317", "OTLP collector address")
+	flag.Float64Var(&traceCfg.SampleRatio, "tracing.sample", 1, "fraction of traces to record")
 	flag.Parse()
 
-	var logger kitlog.Logger
-	{
-		logger = kitlog.NewLogfmtLogger(os.Stderr)
-		logger = kitlog.With(logger, "ts", kitlog.DefaultTimestampUTC)
-		logger = kitlog.With(logger, "caller", kitlog.DefaultCaller)
+	logger, err := logging.NewLogger(os.Stderr, *logLevel)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	tokens, err := customer.NewTokens(*authKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	shutdownTracing, err := tracing.Init(context.Background(), "gateway", traceCfg)
+	if err != nil {
+		log.Fatal(err)
 	}
+	defer shutdownTracing(context.Background())
 
 	// Service discovery domain. In this example we use Consul.
 	var client consulsd.Client
@@ -56,6 +85,45 @@ func main() {
 		client = consulsd.NewClient(consulClient)
 	}
 
+	routes := routeMetrics{
+		requestCount: kitprometheus.NewCounterFrom(prometheus.CounterOpts{
+			Namespace: "api",
+			Subsystem: "gateway",
+			Name:      "request_count",
+			Help:      "Number of HTTP requests received per route.",
+		}, []string{"route", "method", "code"}),
+		requestLatency: kitprometheus.NewHistogramFrom(prometheus.HistogramOpts{
+			Namespace: "api",
+			Subsystem: "gateway",
+			Name:      "request_latency_seconds",
+			Help:      "Total duration of HTTP requests in seconds per route.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method", "code"}),
+	}
+
+	upstreams := upstreamMetrics{
+		requestCount: kitprometheus.NewCounterFrom(prometheus.CounterOpts{
+			Namespace: "api",
+			Subsystem: "gateway",
+			Name:      "upstream_request_count",
+			Help:      "Number of requests sent to each booking service instance.",
+		}, []string{"method", "instance", "outcome"}),
+		requestLatency: kitprometheus.NewHistogramFrom(prometheus.HistogramOpts{
+			Namespace: "api",
+			Subsystem: "gateway",
+			Name:      "upstream_request_latency_seconds",
+			Help:      "Total duration of requests sent to each booking service instance.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "instance", "outcome"}),
+	}
+
+	gatewayMiddleware := func(method string) endpoint.Middleware {
+		return endpoint.Chain(
+			tracing.EndpointMiddleware("gateway."+method, trace.SpanKindInternal),
+			be.LoggingMiddleware(kitlog.With(logger, "component", "gateway"), method),
+		)
+	}
+
 	var (
 		tags        = []string{}
 		passingOnly = true
@@ -65,52 +133,125 @@ func main() {
 
 	{
 		// book new cargo
-		factory := bookingServiceFactory(be.MakeBookNewCargoEndpoint)
+		factory := bookingServiceFactory("BookNewCargo", be.MakeBookNewCargoEndpoint, upstreams, tokens)
 		endpointer := sd.NewEndpointer(instancer, factory, logger)
 		balancer := lb.NewRoundRobin(endpointer)
-		retry := lb.Retry(*retryMax, *retryTimeout, balancer)
-		endpoints.BookNewCargoEndpoint = retry
+		retry := retryEndpoint(*retryMax, *retryTimeout, balancer)
+		endpoints.BookNewCargoEndpoint = gatewayMiddleware("BookNewCargo")(retry)
 	}
 	{
 		// load cargo
-		factory := bookingServiceFactory(be.MakeLoadCargoEndpoint)
+		factory := bookingServiceFactory("LoadCargo", be.MakeLoadCargoEndpoint, upstreams, tokens)
 		endpointer := sd.NewEndpointer(instancer, factory, logger)
 		balancer := lb.NewRoundRobin(endpointer)
-		retry := lb.Retry(*retryMax, *retryTimeout, balancer)
-		endpoints.LoadCargoEndpoint = retry
+		retry := retryEndpoint(*retryMax, *retryTimeout, balancer)
+		endpoints.LoadCargoEndpoint = gatewayMiddleware("LoadCargo")(retry)
 	}
 	{
 		// assign cargo to route
-		factory := bookingServiceFactory(be.MakeAssignCargoToRouteEndpoint)
+		factory := bookingServiceFactory("AssignCargoToRoute", be.MakeAssignCargoToRouteEndpoint, upstreams, tokens)
 		endpointer := sd.NewEndpointer(instancer, factory, logger)
 		balancer := lb.NewRoundRobin(endpointer)
-		retry := lb.Retry(*retryMax, *retryTimeout, balancer)
-		endpoints.AssignCargoToRouteEndpoint = retry
+		retry := retryEndpoint(*retryMax, *retryTimeout, balancer)
+		endpoints.AssignCargoToRouteEndpoint = gatewayMiddleware("AssignCargoToRoute")(retry)
 	}
 	{
 		// change destination
-		factory := bookingServiceFactory(be.MakeChangeDestinationEndpoint)
+		factory := bookingServiceFactory("ChangeDestination", be.MakeChangeDestinationEndpoint, upstreams, tokens)
+		endpointer := sd.NewEndpointer(instancer, factory, logger)
+		balancer := lb.NewRoundRobin(endpointer)
+		retry := retryEndpoint(*retryMax, *retryTimeout, balancer)
+		endpoints.ChangeDestinationEndpoint = gatewayMiddleware("ChangeDestination")(retry)
+	}
+	{
+		// update route specification
+		factory := bookingServiceFactory("UpdateRouteSpecification", be.MakeUpdateRouteSpecificationEndpoint, upstreams, tokens)
+		endpointer := sd.NewEndpointer(instancer, factory, logger)
+		balancer := lb.NewRoundRobin(endpointer)
+		retry := retryEndpoint(*retryMax, *retryTimeout, balancer)
+		endpoints.UpdateRouteSpecificationEndpoint = gatewayMiddleware("UpdateRouteSpecification")(retry)
+	}
+	{
+		// cancel booking
+		factory := bookingServiceFactory("CancelBooking", be.MakeCancelBookingEndpoint, upstreams, tokens)
+		endpointer := sd.NewEndpointer(instancer, factory, logger)
+		balancer := lb.NewRoundRobin(endpointer)
+		retry := retryEndpoint(*retryMax, *retryTimeout, balancer)
+		endpoints.CancelBookingEndpoint = gatewayMiddleware("CancelBooking")(retry)
+	}
+	{
+		// place a cargo on customs hold
+		factory := bookingServiceFactory("HoldCargo", be.MakeHoldCargoEndpoint, upstreams, tokens)
+		endpointer := sd.NewEndpointer(instancer, factory, logger)
+		balancer := lb.NewRoundRobin(endpointer)
+		retry := retryEndpoint(*retryMax, *retryTimeout, balancer)
+		endpoints.HoldCargoEndpoint = gatewayMiddleware("HoldCargo")(retry)
+	}
+	{
+		// release a cargo from customs hold
+		factory := bookingServiceFactory("ReleaseCargo", be.MakeReleaseCargoEndpoint, upstreams, tokens)
+		endpointer := sd.NewEndpointer(instancer, factory, logger)
+		balancer := lb.NewRoundRobin(endpointer)
+		retry := retryEndpoint(*retryMax, *retryTimeout, balancer)
+		endpoints.ReleaseCargoEndpoint = gatewayMiddleware("ReleaseCargo")(retry)
+	}
+	{
+		// handling history of a cargo
+		factory := bookingServiceFactory("HandlingHistory", be.MakeHandlingHistoryEndpoint, upstreams, tokens)
 		endpointer := sd.NewEndpointer(instancer, factory, logger)
 		balancer := lb.NewRoundRobin(endpointer)
-		retry := lb.Retry(*retryMax, *retryTimeout, balancer)
-		endpoints.ChangeDestinationEndpoint = retry
+		retry := retryEndpoint(*retryMax, *retryTimeout, balancer)
+		endpoints.HandlingHistoryEndpoint = gatewayMiddleware("HandlingHistory")(retry)
 	}
 	{
 		// list all cargos
-		factory := bookingServiceFactory(be.MakeListCargosEndpoint)
+		factory := bookingServiceFactory("Cargos", be.MakeListCargosEndpoint, upstreams, tokens)
 		endpointer := sd.NewEndpointer(instancer, factory, logger)
 		balancer := lb.NewRoundRobin(endpointer)
-		retry := lb.Retry(*retryMax, *retryTimeout, balancer)
-		endpoints.CargosEndpoint = retry
+		retry := retryEndpoint(*retryMax, *retryTimeout, balancer)
+		endpoints.CargosEndpoint = gatewayMiddleware("Cargos")(retry)
+	}
+	{
+		// bulk booking, not retried since a failed upload may have been
+		// partially booked
+		factory := bookingServiceFactory("BookCargos", be.MakeBookCargosEndpoint, upstreams, tokens)
+		endpointer := sd.NewEndpointer(instancer, factory, logger)
+		balancer := lb.NewRoundRobin(endpointer)
+		retry := retryEndpoint(1, *bulkTimeout, balancer)
+		endpoints.BookCargosEndpoint = gatewayMiddleware("BookCargos")(retry)
+	}
+	{
+		// cargo export, not retried since part of the download may already
+		// have been sent to the client
+		factory := bookingServiceFactory("ExportCargos", be.MakeExportCargosEndpoint, upstreams, tokens)
+		endpointer := sd.NewEndpointer(instancer, factory, logger)
+		balancer := lb.NewRoundRobin(endpointer)
+		retry := retryEndpoint(1, *exportTimeout, balancer)
+		endpoints.ExportCargosEndpoint = gatewayMiddleware("ExportCargos")(retry)
 	}
 
+	spec, err := bt.LoadOpenAPI(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	validateRequests, err := bt.NewOpenAPIValidator(spec)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	booking := bt.NewHttpHandler(endpoints)
+	booking.Use(routes.middleware, tracing.HTTPMiddleware, bt.NewAuthenticator(tokens), validateRequests)
+
 	r := mux.NewRouter()
-	r.PathPrefix("/booking").Handler(bt.NewHttpHandler(endpoints))
+	r.Handle("/metrics", promhttp.Handler()).Methods("GET")
+	r.Handle("/openapi.json", bt.NewOpenAPIHandler()).Methods("GET")
+	r.PathPrefix("/booking").Handler(booking)
 
-	err := r.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+	err = r.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
 		pathTemplate, err := route.GetPathTemplate()
 		if err == nil {
-			fmt.Println("Route:", pathTemplate)
+			level.Debug(logger).Log("route", pathTemplate)
 		}
 
 		return nil
@@ -123,7 +264,7 @@ func main() {
 	// Interrupt handler.
 	errc := make(chan error)
 	go func() {
-		c := make(chan os.Signal)
+		c := make(chan os.Signal, 1)
 		signal.Notify(c, syscall.SIGINT, syscall.SIGTERM)
 		errc <- fmt.Errorf("%s", <-c)
 	}()
@@ -138,16 +279,42 @@ func main() {
 	logger.Log("exit", <-errc)
 }
 
-func bookingServiceFactory(makeEndpoint func(bookingService bs.BookingServiceContract) endpoint.Endpoint) sd.Factory {
+func bookingServiceFactory(method string, makeEndpoint func(bookingService bs.BookingServiceContract) endpoint.Endpoint, upstreams upstreamMetrics, tokens customer.Tokens) sd.Factory {
 	return func(instance string) (endpoint.Endpoint, io.Closer, error) {
-		conn, err := grpc.Dial(instance, grpc.WithInsecure())
+		conn, err := grpc.Dial(instance, append([]grpc.DialOption{grpc.WithInsecure()}, bt.CallerDialOptions(tokens)...)...)
 		if err != nil {
 			return nil, nil, err
 		}
 
 		service := bt.NewGRPCClient(conn)
 		endpoint := makeEndpoint(service)
+		endpoint = tracing.EndpointMiddleware("upstream."+method, trace.SpanKindClient, attribute.String("upstream.instance", instance))(endpoint)
+		endpoint = upstreams.middleware(method, instance)(endpoint)
 
 		return endpoint, conn, nil
 	}
 }
+
+// retryEndpoint retries failed requests on other instances, except for
+// validation failures which no instance would accept. Those are returned
+// as-is so the HTTP transport can report the individual violations.
+func retryEndpoint(max int, timeout time.Duration, balancer lb.Balancer) endpoint.Endpoint {
+	retry := lb.RetryWithCallback(timeout, balancer, func(n int, err error) (bool, error) {
+		if _, ok := validation.FromError(err); ok {
+			return false, nil
+		}
+
+		return n < max, nil
+	})
+
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		response, err := retry(ctx, request)
+		if retryErr, ok := err.(lb.RetryError); ok {
+			if violations, ok := validation.FromError(retryErr.Final); ok {
+				return nil, violations
+			}
+		}
+
+		return response, err
+	}
+}
@@ -0,0 +1,133 @@
+// Package imdg provides the dangerous goods classification of the
+// International Maritime Dangerous Goods code.
+package imdg
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Class is an IMDG hazard class, or a division of one such as 2.1.
+type Class string
+
+var classes = map[Class]bool{
+	"1": true, "1.1": true, "1.2": true, "1.3": true, "1.4": true, "1.5": true, "1.6": true,
+	"2": true, "2.1": true, "2.2": true, "2.3": true,
+	"3": true,
+	"4": true, "4.1": true, "4.2": true, "4.3": true,
+	"5": true, "5.1": true, "5.2": true,
+	"6": true, "6.1": true, "6.2": true,
+	"7": true,
+	"8": true,
+	"9": true,
+}
+
+// IsValid checks that c is one of the IMDG classes or divisions.
+func (c Class) IsValid() bool {
+	return classes[c]
+}
+
+// Covers reports whether c is the class itself or the class the division
+// other belongs to, e.g. 2 covers 2.1.
+func (c Class) Covers(other Class) bool {
+	return c == other || strings.HasPrefix(string(other), string(c)+".")
+}
+
+// UNNumber is the four digit number the United Nations assign to a
+// dangerous substance, e.g. 1203 for petrol.
+type UNNumber string
+
+var unNumberPattern = regexp.MustCompile(`^[0-9]{4}$`)
+
+// IsValid checks that n is four digits, without the UN prefix.
+func (n UNNumber) IsValid() bool {
+	return unNumberPattern.MatchString(string(n))
+}
+
+// Goods classifies dangerous goods. The zero value means the goods are not
+// dangerous.
+type Goods struct {
+	Class    Class
+	UNNumber UNNumber
+}
+
+// IsDangerous reports whether the goods are classified as dangerous.
+func (g Goods) IsDangerous() bool {
+	return g.Class != ""
+}
+
+// Policy declares the dangerous goods a port or a vessel accepts. The zero
+// value accepts every class, a restricted policy only the listed classes.
+type Policy struct {
+	Restricted bool
+	Allowed    []Class
+}
+
+// Only returns a policy accepting the given classes only, none when no
+// class is given.
+func Only(classes ...Class) Policy {
+	return Policy{Restricted: true, Allowed: classes}
+}
+
+// Permits reports whether the policy accepts the goods.
+func (p Policy) Permits(g Goods) bool {
+	if !g.IsDangerous() || !p.Restricted {
+		return true
+	}
+
+	for _, c := range p.Allowed {
+		if c.Covers(g.Class) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Scan reads the policy from a nullable text array column, NULL being
+// unrestricted.
+func (p *Policy) Scan(src interface{}) error {
+	var s string
+	switch src := src.(type) {
+	case nil:
+		*p = Policy{}
+		return nil
+	case []byte:
+		s = string(src)
+	case string:
+		s = src
+	default:
+		return fmt.Errorf("imdg: cannot scan %T into a policy", src)
+	}
+
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return fmt.Errorf("imdg: malformed policy %q", s)
+	}
+
+	*p = Only()
+	if s = s[1 : len(s)-1]; s == "" {
+		return nil
+	}
+
+	for _, c := range strings.Split(s, ",") {
+		p.Allowed = append(p.Allowed, Class(strings.Trim(c, `"`)))
+	}
+
+	return nil
+}
+
+// Value writes the policy as a text array, NULL when unrestricted.
+func (p Policy) Value() (driver.Value, error) {
+	if !p.Restricted {
+		return nil, nil
+	}
+
+	classes := make([]string, len(p.Allowed))
+	for i, c := range p.Allowed {
+		classes[i] = string(c)
+	}
+
+	return "{" + strings.Join(classes, ",") + "}", nil
+}
@@ -0,0 +1,49 @@
+package imdg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassIsValid(t *testing.T) {
+	for _, c := range []Class{"1.4", "2.1", "3", "6.1", "9"} {
+		require.True(t, c.IsValid(), c)
+	}
+
+	for _, c := range []Class{"", "10", "2.4", "3.1", "class 3"} {
+		require.False(t, c.IsValid(), c)
+	}
+}
+
+func TestUNNumberIsValid(t *testing.T) {
+	require.True(t, UNNumber("1203").IsValid())
+	require.False(t, UNNumber("UN1203").IsValid())
+	require.False(t, UNNumber("123").IsValid())
+}
+
+func TestPolicyPermits(t *testing.T) {
+	petrol := Goods{Class: "3", UNNumber: "1203"}
+	propane := Goods{Class: "2.1", UNNumber: "1978"}
+
+	require.True(t, Policy{}.Permits(petrol))
+	require.True(t, Only().Permits(Goods{}))
+	require.False(t, Only().Permits(petrol))
+	require.True(t, Only("2", "3").Permits(propane))
+	require.False(t, Only("2.2", "3").Permits(propane))
+}
+
+func TestPolicyScanValue(t *testing.T) {
+	for _, p := range []Policy{{}, Only(), Only("2.1", "3")} {
+		v, err := p.Value()
+		require.NoError(t, err)
+
+		var scanned Policy
+		require.NoError(t, scanned.Scan(v))
+		require.Equal(t, p.Restricted, scanned.Restricted)
+		require.ElementsMatch(t, p.Allowed, scanned.Allowed)
+	}
+
+	var p Policy
+	require.Error(t, p.Scan("3,2.1"))
+}
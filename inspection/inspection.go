@@ -0,0 +1,100 @@
+// Package inspection queues the registered handling events of cargos for
+// inspection: re-deriving the delivery of the cargo from its handling
+// history and following up on what changed. Events are accepted as soon as
+// they are stored and queued, a pool of workers inspects them.
+package inspection
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+)
+
+// Job asks for the cargo to be inspected after the event was registered.
+// Jobs of a cargo are handed out one at a time, in the order they were
+// queued.
+type Job struct {
+	ID         int64
+	TrackingID cargo.TrackingID
+	EventID    int64
+	Attempts   int
+	Available  time.Time
+	LastError  string
+	Enqueued   time.Time
+}
+
+// NewJob returns a job that is available right away.
+func NewJob(id cargo.TrackingID, eventID int64, now time.Time) Job {
+	return Job{
+		TrackingID: id,
+		EventID:    eventID,
+		Available:  now,
+		Enqueued:   now,
+	}
+}
+
+// Retry spaces the attempts of a job linearly: the nth retry waits n times
+// Delay. A job failing Attempts times is moved to the dead-letter table.
+type Retry struct {
+	Delay    time.Duration
+	Attempts int
+}
+
+// DefaultRetry is used unless retries are configured, it gives up after
+// about a minute.
+var DefaultRetry = Retry{Delay: 5 * time.Second, Attempts: 5}
+
+// Fail records a failed attempt and reports whether the job is dead, else
+// it becomes available again after the delay.
+func (j *Job) Fail(err error, r Retry, now time.Time) bool {
+	j.Attempts++
+	j.LastError = err.Error()
+	if j.Attempts >= r.Attempts {
+		return true
+	}
+
+	j.Available = now.Add(time.Duration(j.Attempts) * r.Delay)
+	return false
+}
+
+// Handler inspects the cargo of a job inside the transaction of the queue,
+// if there is one. The returned function, if any, is run once the
+// transaction committed, e.g. to send notifications.
+type Handler func(ctx context.Context, dbtx db.DBTX, job Job) (func(), error)
+
+// Queue holds the jobs waiting for inspection.
+type Queue interface {
+	// Enqueue adds the jobs. A queue backed by the database adds them in
+	// the transaction of dbtx, so a job exists exactly when its event does.
+	Enqueue(ctx context.Context, dbtx db.DBTX, jobs ...Job) error
+
+	// Process hands the next available job to handle and reports whether
+	// there was one. The job is removed when handle succeeds, else it is
+	// retried later or buried in the dead-letter table and a *FailedError
+	// is returned.
+	Process(ctx context.Context, handle Handler) (bool, error)
+}
+
+// FailedError is returned by Process when handling a job failed. Dead is
+// set when the job was moved to the dead-letter table.
+type FailedError struct {
+	Job  Job
+	Dead bool
+	Err  error
+}
+
+func (e *FailedError) Error() string {
+	return fmt.Sprintf("inspecting cargo %s for event %d: %v", e.Job.TrackingID, e.Job.EventID, e.Err)
+}
+
+func (e *FailedError) Unwrap() error { return e.Err }
+
+// fail records the failed attempt of the job and returns the error telling
+// about it.
+func fail(job *Job, err error, r Retry, now time.Time) *FailedError {
+	dead := job.Fail(err, r, now)
+	return &FailedError{Job: *job, Dead: dead, Err: err}
+}
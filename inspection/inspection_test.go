@@ -0,0 +1,25 @@
+package inspection
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobFail(t *testing.T) {
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := Retry{Delay: time.Minute, Attempts: 3}
+	j := NewJob("ABC123", 1, now)
+
+	require.False(t, j.Fail(errors.New("timeout"), r, now))
+	require.Equal(t, now.Add(time.Minute), j.Available)
+
+	require.False(t, j.Fail(errors.New("timeout"), r, now))
+	require.Equal(t, now.Add(2*time.Minute), j.Available)
+
+	require.True(t, j.Fail(errors.New("deadlock detected"), r, now))
+	require.Equal(t, 3, j.Attempts)
+	require.Equal(t, "deadlock detected", j.LastError)
+}
@@ -0,0 +1,129 @@
+package inspection
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+)
+
+// MemoryQueue keeps the jobs in memory, for tests and single process
+// setups. It ignores transactions, handlers are given a nil dbtx.
+type MemoryQueue struct {
+	retry Retry
+
+	mu       sync.Mutex
+	lastID   int64
+	jobs     []Job
+	inFlight map[cargo.TrackingID]bool
+	dead     []Job
+}
+
+func NewMemoryQueue(retry Retry) *MemoryQueue {
+	return &MemoryQueue{retry: retry, inFlight: map[cargo.TrackingID]bool{}}
+}
+
+func (q *MemoryQueue) Enqueue(_ context.Context, _ db.DBTX, jobs ...Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, j := range jobs {
+		q.lastID++
+		j.ID = q.lastID
+		q.jobs = append(q.jobs, j)
+	}
+
+	return nil
+}
+
+func (q *MemoryQueue) Process(ctx context.Context, handle Handler) (bool, error) {
+	job, ok := q.next(time.Now())
+	if !ok {
+		return false, nil
+	}
+
+	after, err := handle(ctx, nil, job)
+	if err != nil {
+		return true, q.failed(job, err)
+	}
+
+	q.mu.Lock()
+	delete(q.inFlight, job.TrackingID)
+	q.remove(job.ID)
+	q.mu.Unlock()
+
+	if after != nil {
+		after()
+	}
+
+	return true, nil
+}
+
+func (q *MemoryQueue) failed(job Job, err error) *FailedError {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.inFlight, job.TrackingID)
+
+	failed := fail(&job, err, q.retry, time.Now())
+	if failed.Dead {
+		q.remove(job.ID)
+		q.dead = append(q.dead, job)
+	} else {
+		q.replace(job)
+	}
+
+	return failed
+}
+
+// Len returns the number of jobs waiting, including those being handled.
+func (q *MemoryQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.jobs)
+}
+
+// Dead returns the buried jobs, oldest first.
+func (q *MemoryQueue) Dead() []Job {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return append([]Job(nil), q.dead...)
+}
+
+// next takes the first available job whose cargo has no earlier job
+// waiting or being handled.
+func (q *MemoryQueue) next(now time.Time) (Job, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	blocked := map[cargo.TrackingID]bool{}
+	for _, j := range q.jobs {
+		if !blocked[j.TrackingID] && !q.inFlight[j.TrackingID] && !j.Available.After(now) {
+			q.inFlight[j.TrackingID] = true
+			return j, true
+		}
+
+		blocked[j.TrackingID] = true
+	}
+
+	return Job{}, false
+}
+
+func (q *MemoryQueue) remove(id int64) {
+	for i, j := range q.jobs {
+		if j.ID == id {
+			q.jobs = append(q.jobs[:i], q.jobs[i+1:]...)
+			return
+		}
+	}
+}
+
+func (q *MemoryQueue) replace(job Job) {
+	for i, j := range q.jobs {
+		if j.ID == job.ID {
+			q.jobs[i] = job
+			return
+		}
+	}
+}
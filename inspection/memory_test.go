@@ -0,0 +1,86 @@
+package inspection
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryQueueOrder(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	q := NewMemoryQueue(DefaultRetry)
+
+	require.NoError(t, q.Enqueue(ctx, nil, NewJob("AAA", 1, now), NewJob("BBB", 2, now), NewJob("AAA", 3, now)))
+
+	// the second job of AAA waits while the first is being handled
+	var handled []int64
+	_, err := q.Process(ctx, func(ctx context.Context, _ db.DBTX, job Job) (func(), error) {
+		handled = append(handled, job.EventID)
+
+		found, err := q.Process(ctx, func(_ context.Context, _ db.DBTX, job Job) (func(), error) {
+			handled = append(handled, job.EventID)
+			return nil, nil
+		})
+		require.True(t, found)
+		return nil, err
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 2}, handled)
+
+	found, err := q.Process(ctx, func(_ context.Context, _ db.DBTX, job Job) (func(), error) {
+		handled = append(handled, job.EventID)
+		return nil, nil
+	})
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []int64{1, 2, 3}, handled)
+	require.Zero(t, q.Len())
+}
+
+func TestMemoryQueueRetries(t *testing.T) {
+	ctx := context.Background()
+	q := NewMemoryQueue(Retry{Attempts: 2})
+
+	require.NoError(t, q.Enqueue(ctx, nil, NewJob("AAA", 1, time.Now()), NewJob("AAA", 2, time.Now())))
+
+	var after int
+	failing := func(_ context.Context, _ db.DBTX, job Job) (func(), error) {
+		if job.EventID == 1 {
+			return func() { after++ }, errors.New("cargo is locked")
+		}
+
+		return func() { after++ }, nil
+	}
+
+	_, err := q.Process(ctx, failing)
+	var failed *FailedError
+	require.ErrorAs(t, err, &failed)
+	require.False(t, failed.Dead)
+	require.Equal(t, 2, q.Len())
+
+	_, err = q.Process(ctx, failing)
+	require.ErrorAs(t, err, &failed)
+	require.True(t, failed.Dead)
+	require.Equal(t, cargo.TrackingID("AAA"), failed.Job.TrackingID)
+	require.Zero(t, after)
+
+	dead := q.Dead()
+	require.Len(t, dead, 1)
+	require.Equal(t, "cargo is locked", dead[0].LastError)
+
+	// burying the job unblocks the next one of the cargo
+	found, err := q.Process(ctx, failing)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, 1, after)
+
+	found, err = q.Process(ctx, failing)
+	require.NoError(t, err)
+	require.False(t, found)
+}
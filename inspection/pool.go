@@ -0,0 +1,90 @@
+package inspection
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+)
+
+// DefaultWorkers is the number of workers of a pool unless configured.
+const DefaultWorkers = 4
+
+// Pool runs workers handling the jobs of a queue.
+type Pool struct {
+	queue   Queue
+	handle  Handler
+	workers int
+	logger  log.Logger
+}
+
+func NewPool(queue Queue, handle Handler, workers int, logger log.Logger) Pool {
+	return Pool{
+		queue:   queue,
+		handle:  handle,
+		workers: workers,
+		logger:  logger,
+	}
+}
+
+// Run handles jobs until the context is done and the workers finished the
+// jobs at hand. A worker finding the queue empty waits for the interval
+// before looking again.
+func (p Pool) Run(ctx context.Context, interval time.Duration) {
+	var wg sync.WaitGroup
+	for i := 0; i < p.workers; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			p.work(ctx, log.With(p.logger, "worker", worker), interval)
+		}(i)
+	}
+
+	wg.Wait()
+}
+
+func (p Pool) work(ctx context.Context, logger log.Logger, interval time.Duration) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		// keep going while there are jobs, wait when the queue is empty or
+		// unreachable
+		for ctx.Err() == nil {
+			found, err := p.queue.Process(ctx, p.handle)
+			p.log(logger, err)
+			if !found || (err != nil && !isFailed(err)) {
+				break
+			}
+		}
+
+		timer.Reset(interval)
+	}
+}
+
+func (p Pool) log(logger log.Logger, err error) {
+	var failed *FailedError
+	switch {
+	case err == nil:
+	case errors.As(err, &failed) && failed.Dead:
+		level.Error(logger).Log("msg", "handling event buried after failed inspections", "tracking_id", failed.Job.TrackingID, "event_id", failed.Job.EventID, "attempts", failed.Job.Attempts, "err", failed.Err)
+	case errors.As(err, &failed):
+		level.Warn(logger).Log("msg", "handling event inspection failed", "tracking_id", failed.Job.TrackingID, "event_id", failed.Job.EventID, "attempts", failed.Job.Attempts, "retry_at", failed.Job.Available, "err", failed.Err)
+	default:
+		level.Error(logger).Log("msg", "cannot process inspection queue", "err", err)
+	}
+}
+
+func isFailed(err error) bool {
+	var failed *FailedError
+	return errors.As(err, &failed)
+}
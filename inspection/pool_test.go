@@ -0,0 +1,69 @@
+package inspection
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoolRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	q := NewMemoryQueue(DefaultRetry)
+	for i := int64(1); i <= 20; i++ {
+		id := cargo.TrackingID("AAA")
+		if i%2 == 0 {
+			id = "BBB"
+		}
+
+		require.NoError(t, q.Enqueue(ctx, nil, NewJob(id, i, time.Now())))
+	}
+
+	// jobs are recorded by the handler, when the next job of the cargo
+	// cannot have been handed out yet
+	var (
+		mu      sync.Mutex
+		handled = map[cargo.TrackingID][]int64{}
+		after   int
+		done    = make(chan struct{})
+	)
+	handle := func(_ context.Context, _ db.DBTX, job Job) (func(), error) {
+		mu.Lock()
+		handled[job.TrackingID] = append(handled[job.TrackingID], job.EventID)
+		mu.Unlock()
+
+		return func() {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if after++; after == 20 {
+				close(done)
+			}
+		}, nil
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		NewPool(q, handle, 4, log.NewNopLogger()).Run(ctx, 10*time.Millisecond)
+		close(stopped)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("jobs not handled")
+	}
+
+	cancel()
+	<-stopped
+
+	require.Equal(t, []int64{1, 3, 5, 7, 9, 11, 13, 15, 17, 19}, handled["AAA"])
+	require.Equal(t, []int64{2, 4, 6, 8, 10, 12, 14, 16, 18, 20}, handled["BBB"])
+}
@@ -0,0 +1,143 @@
+package inspection
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+)
+
+// PostgresQueue keeps the jobs in the handling_queue table and buries dead
+// ones in handling_dead_letters. Several processes can share the queue, a
+// job is only handed to one worker at a time.
+type PostgresQueue struct {
+	db    *sql.DB
+	retry Retry
+}
+
+func NewPostgresQueue(db *sql.DB, retry Retry) PostgresQueue {
+	return PostgresQueue{db: db, retry: retry}
+}
+
+func (q PostgresQueue) Enqueue(ctx context.Context, dbtx db.DBTX, jobs ...Job) error {
+	query := `INSERT INTO handling_queue (tracking_id, event_id, attempts, available_at, last_error, enqueued_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	for _, j := range jobs {
+		if _, err := dbtx.ExecContext(ctx, query, j.TrackingID, j.EventID, j.Attempts, j.Available, j.LastError, j.Enqueued); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Process handles the job in the transaction that locks it. The work of a
+// failed handler is rolled back to a savepoint, so the failed attempt can
+// be recorded while the job stays locked.
+func (q PostgresQueue) Process(ctx context.Context, handle Handler) (bool, error) {
+	var (
+		found  bool
+		after  func()
+		failed *FailedError
+	)
+
+	err := db.WithTx(ctx, q.db, func(tx *sql.Tx) error {
+		job, err := q.next(ctx, tx, time.Now())
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		found = true
+		if _, err := tx.ExecContext(ctx, "SAVEPOINT inspection"); err != nil {
+			return err
+		}
+
+		after, err = handle(ctx, tx, job)
+		if err == nil {
+			_, err = tx.ExecContext(ctx, "DELETE FROM handling_queue WHERE id = $1", job.ID)
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT inspection"); err != nil {
+			return err
+		}
+
+		after = nil
+		failed = fail(&job, err, q.retry, time.Now())
+		if failed.Dead {
+			return q.bury(ctx, tx, job)
+		}
+
+		_, err = tx.ExecContext(ctx, "UPDATE handling_queue SET attempts = $2, available_at = $3, last_error = $4 WHERE id = $1",
+			job.ID, job.Attempts, job.Available, job.LastError)
+		return err
+	})
+	if err != nil {
+		return found, err
+	}
+
+	if failed != nil {
+		return true, failed
+	}
+
+	if after != nil {
+		after()
+	}
+
+	return found, nil
+}
+
+// next locks the first available job whose cargo has no earlier job
+// waiting, skipping jobs locked by other workers.
+func (q PostgresQueue) next(ctx context.Context, tx *sql.Tx, now time.Time) (Job, error) {
+	query := `SELECT id, tracking_id, event_id, attempts, available_at, last_error, enqueued_at FROM handling_queue q
+		WHERE available_at <= $1
+		AND NOT EXISTS (SELECT 1 FROM handling_queue e WHERE e.tracking_id = q.tracking_id AND e.id < q.id)
+		ORDER BY id
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED`
+
+	var j Job
+	err := tx.QueryRowContext(ctx, query, now).Scan(&j.ID, &j.TrackingID, &j.EventID, &j.Attempts, &j.Available, &j.LastError, &j.Enqueued)
+	return j, err
+}
+
+func (q PostgresQueue) bury(ctx context.Context, tx *sql.Tx, job Job) error {
+	query := `INSERT INTO handling_dead_letters (id, tracking_id, event_id, attempts, last_error, enqueued_at, buried_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	if _, err := tx.ExecContext(ctx, query, job.ID, job.TrackingID, job.EventID, job.Attempts, job.LastError, job.Enqueued, time.Now()); err != nil {
+		return err
+	}
+
+	_, err := tx.ExecContext(ctx, "DELETE FROM handling_queue WHERE id = $1", job.ID)
+	return err
+}
+
+// Dead returns the buried jobs, oldest first.
+func (q PostgresQueue) Dead(ctx context.Context, dbtx db.DBTX) ([]Job, error) {
+	query := `SELECT id, tracking_id, event_id, attempts, last_error, enqueued_at FROM handling_dead_letters ORDER BY id`
+
+	rows, err := dbtx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []Job
+	for rows.Next() {
+		var j Job
+		if err := rows.Scan(&j.ID, &j.TrackingID, &j.EventID, &j.Attempts, &j.LastError, &j.Enqueued); err != nil {
+			return nil, err
+		}
+
+		jobs = append(jobs, j)
+	}
+
+	return jobs, rows.Err()
+}
@@ -0,0 +1,68 @@
+package inspection
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresQueue(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	q := NewPostgresQueue(dbTest, Retry{Attempts: 1})
+
+	c, err := cargoTest.Upsert(ctx, dbTest, cargo.New(cargo.NextTrackingID(), cargo.RouteSpecification{Origin: "SESTO", Destination: "AUMEL", ArrivalDeadline: now}))
+	require.NoError(t, err)
+
+	var jobs []Job
+	for i := 0; i < 2; i++ {
+		e, err := eventTest.Store(ctx, dbTest, cargo.HandlingEvent{
+			TrackingID: c.TrackingID,
+			Activity:   cargo.HandlingActivity{Type: cargo.Receive, Location: "SESTO"},
+			Completed:  now,
+		})
+		require.NoError(t, err)
+
+		jobs = append(jobs, NewJob(c.TrackingID, e.ID, now))
+	}
+	require.NoError(t, q.Enqueue(ctx, dbTest, jobs...))
+
+	// jobs of other cargos may be waiting in the shared table
+	handle := func(events map[int64]bool, err error) Handler {
+		return func(_ context.Context, _ db.DBTX, job Job) (func(), error) {
+			if job.TrackingID != c.TrackingID {
+				return nil, nil
+			}
+
+			events[job.EventID] = true
+			return nil, err
+		}
+	}
+
+	failing := map[int64]bool{}
+	for found := true; found && len(failing) == 0; {
+		found, err = q.Process(ctx, handle(failing, errors.New("cargo is locked")))
+	}
+
+	var failed *FailedError
+	require.ErrorAs(t, err, &failed)
+	require.True(t, failed.Dead)
+	require.Equal(t, map[int64]bool{jobs[0].EventID: true}, failing)
+
+	dead, err := q.Dead(ctx, dbTest)
+	require.NoError(t, err)
+	require.Equal(t, jobs[0].EventID, dead[len(dead)-1].EventID)
+	require.Equal(t, "cargo is locked", dead[len(dead)-1].LastError)
+
+	handled := map[int64]bool{}
+	for found := true; found; {
+		found, err = q.Process(ctx, handle(handled, nil))
+		require.NoError(t, err)
+	}
+	require.Equal(t, map[int64]bool{jobs[1].EventID: true}, handled)
+}
@@ -0,0 +1,39 @@
+package location
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+)
+
+type instrumentingLocationRepository struct {
+	latency metrics.Histogram
+	LocationRepositoryContract
+}
+
+// NewInstrumentingLocationRepository returns a location repository that records
+// query latency, labeled by repository, method and outcome.
+func NewInstrumentingLocationRepository(latency metrics.Histogram, r LocationRepositoryContract) LocationRepositoryContract {
+	return &instrumentingLocationRepository{latency: latency, LocationRepositoryContract: r}
+}
+
+func (r *instrumentingLocationRepository) observe(method string, begin time.Time, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+
+	r.latency.With("repository", "location", "method", method, "outcome", outcome).Observe(time.Since(begin).Seconds())
+}
+
+func (r *instrumentingLocationRepository) Store(ctx context.Context, dbtx db.DBTX, l *Location) (err error) {
+	defer func(begin time.Time) { r.observe("store", begin, err) }(time.Now())
+	return r.LocationRepositoryContract.Store(ctx, dbtx, l)
+}
+
+func (r *instrumentingLocationRepository) Find(ctx context.Context, dbtx db.DBTX, locode UNLocode) (l *Location, err error) {
+	defer func(begin time.Time) { r.observe("find", begin, err) }(time.Now())
+	return r.LocationRepositoryContract.Find(ctx, dbtx, locode)
+}
@@ -2,7 +2,12 @@
 package location
 
 import (
+	"context"
+	"database/sql"
 	"errors"
+
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+	"github.com/mproyyan/grpc-shipping-microservice/imdg"
 )
 
 // UNLocode is the United Nations location code that uniquely identifies a
@@ -17,13 +22,75 @@ type UNLocode string
 type Location struct {
 	UNLocode UNLocode
 	Name     string
+	// DangerousGoods are the dangerous goods the port lets through.
+	DangerousGoods imdg.Policy
 }
 
 // ErrUnknown is used when a location could not be found.
 var ErrUnknown = errors.New("unknown location")
 
-// Repository provides access a location store.
-type Repository interface {
-	Find(locode UNLocode) (*Location, error)
-	FindAll() []*Location
+type LocationRepositoryContract interface {
+	Store(ctx context.Context, dbtx db.DBTX, l *Location) error
+	Find(ctx context.Context, dbtx db.DBTX, locode UNLocode) (*Location, error)
+}
+
+type LocationRepository struct {
+}
+
+func NewLocationRepository() LocationRepository {
+	return LocationRepository{}
+}
+
+// Store creates the location or replaces its name and policy.
+func (lr LocationRepository) Store(ctx context.Context, dbtx db.DBTX, l *Location) error {
+	query := `
+		INSERT INTO locations (unlocode, name, dangerous_goods_classes) VALUES ($1, $2, $3)
+		ON CONFLICT (unlocode) DO UPDATE SET name = EXCLUDED.name, dangerous_goods_classes = EXCLUDED.dangerous_goods_classes
+	`
+
+	_, err := dbtx.ExecContext(ctx, query, l.UNLocode, l.Name, l.DangerousGoods)
+	return err
+}
+
+func (lr LocationRepository) Find(ctx context.Context, dbtx db.DBTX, locode UNLocode) (*Location, error) {
+	var l Location
+	row := dbtx.QueryRowContext(ctx, `SELECT unlocode, name, dangerous_goods_classes FROM locations WHERE unlocode = $1`, locode)
+	if err := row.Scan(&l.UNLocode, &l.Name, &l.DangerousGoods); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrUnknown
+		}
+
+		return nil, err
+	}
+
+	return &l, nil
+}
+
+// Country returns the ISO 3166 country code the location is in, the first
+// two letters of the code.
+func (c UNLocode) Country() string {
+	if len(c) < 2 {
+		return ""
+	}
+
+	return string(c[:2])
+}
+
+// IsValid checks whether the code is shaped like a UN/LOCODE: a two letter
+// ISO 3166 country code followed by three letters or digits 2-9.
+func (c UNLocode) IsValid() bool {
+	if len(c) != 5 {
+		return false
+	}
+
+	for i, r := range c {
+		switch {
+		case r >= 'A' && r <= 'Z':
+		case i >= 2 && r >= '2' && r <= '9':
+		default:
+			return false
+		}
+	}
+
+	return true
 }
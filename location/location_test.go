@@ -0,0 +1,28 @@
+package location
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mproyyan/grpc-shipping-microservice/imdg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreLocation(t *testing.T) {
+	l := &Location{UNLocode: "SEGOT", Name: "Göteborg", DangerousGoods: imdg.Only("3", "9")}
+	require.NoError(t, locationTest.Store(context.Background(), dbTest, l))
+
+	found, err := locationTest.Find(context.Background(), dbTest, l.UNLocode)
+	require.NoError(t, err)
+	require.Equal(t, l, found)
+
+	l.DangerousGoods = imdg.Policy{}
+	require.NoError(t, locationTest.Store(context.Background(), dbTest, l))
+
+	found, err = locationTest.Find(context.Background(), dbTest, l.UNLocode)
+	require.NoError(t, err)
+	require.False(t, found.DangerousGoods.Restricted)
+
+	_, err = locationTest.Find(context.Background(), dbTest, "XXNOP")
+	require.Equal(t, ErrUnknown, err)
+}
@@ -0,0 +1,41 @@
+package location
+
+import (
+	"context"
+
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+	"github.com/mproyyan/grpc-shipping-microservice/tracing"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = tracing.Tracer("github.com/mproyyan/grpc-shipping-microservice/location")
+
+type tracingLocationRepository struct {
+	LocationRepositoryContract
+}
+
+// NewTracingLocationRepository returns a location repository that wraps every
+// query in a client span.
+func NewTracingLocationRepository(r LocationRepositoryContract) LocationRepositoryContract {
+	return &tracingLocationRepository{LocationRepositoryContract: r}
+}
+
+func (r *tracingLocationRepository) start(ctx context.Context, name string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemPostgreSQL),
+	)
+}
+
+func (r *tracingLocationRepository) Store(ctx context.Context, dbtx db.DBTX, l *Location) (err error) {
+	ctx, span := r.start(ctx, "LocationRepository.Store")
+	defer func() { tracing.End(span, err) }()
+	return r.LocationRepositoryContract.Store(ctx, dbtx, l)
+}
+
+func (r *tracingLocationRepository) Find(ctx context.Context, dbtx db.DBTX, locode UNLocode) (l *Location, err error) {
+	ctx, span := r.start(ctx, "LocationRepository.Find")
+	defer func() { tracing.End(span, err) }()
+	return r.LocationRepositoryContract.Find(ctx, dbtx, locode)
+}
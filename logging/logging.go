@@ -0,0 +1,104 @@
+// Package logging builds the structured loggers used by both processes and
+// carries request IDs across the HTTP and gRPC transports.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/pborman/uuid"
+	"google.golang.org/grpc/metadata"
+)
+
+// NewLogger returns a logfmt logger writing to w that drops every entry below
+// the given level (debug, info, warn or error).
+func NewLogger(w io.Writer, lvl string) (log.Logger, error) {
+	var option level.Option
+	switch strings.ToLower(lvl) {
+	case "debug":
+		option = level.AllowDebug()
+	case "info", "":
+		option = level.AllowInfo()
+	case "warn":
+		option = level.AllowWarn()
+	case "error":
+		option = level.AllowError()
+	default:
+		return nil, fmt.Errorf("logging: unknown level %q", lvl)
+	}
+
+	logger := log.NewLogfmtLogger(log.NewSyncWriter(w))
+	logger = level.NewFilter(logger, option)
+	logger = log.With(logger, "ts", log.DefaultTimestampUTC)
+	logger = log.With(logger, "caller", log.DefaultCaller)
+
+	return logger, nil
+}
+
+const (
+	requestIDHeader   = "X-Request-ID"
+	requestIDMetadata = "x-request-id"
+)
+
+type requestIDKey struct{}
+
+// NewRequestID generates a new request ID.
+func NewRequestID() string {
+	return uuid.New()
+}
+
+// WithRequestID returns a copy of ctx carrying the given request ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID stored in ctx, or an empty string.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// HTTPToContext reuses the request ID sent by the client or generates a new
+// one. It is meant to be used with go-kit's http.ServerBefore.
+func HTTPToContext(ctx context.Context, r *http.Request) context.Context {
+	id := r.Header.Get(requestIDHeader)
+	if id == "" {
+		id = NewRequestID()
+	}
+
+	return WithRequestID(ctx, id)
+}
+
+// ContextToHTTPResponse echoes the request ID back to the client. It is meant
+// to be used with go-kit's http.ServerAfter.
+func ContextToHTTPResponse(ctx context.Context, w http.ResponseWriter) context.Context {
+	if id := RequestID(ctx); id != "" {
+		w.Header().Set(requestIDHeader, id)
+	}
+
+	return ctx
+}
+
+// GRPCClientBefore forwards the request ID to the upstream gRPC server.
+func GRPCClientBefore(ctx context.Context, md *metadata.MD) context.Context {
+	if id := RequestID(ctx); id != "" {
+		md.Set(requestIDMetadata, id)
+	}
+
+	return ctx
+}
+
+// GRPCServerBefore reads the request ID from incoming gRPC metadata, or
+// generates one when the caller did not send any.
+func GRPCServerBefore(ctx context.Context, md metadata.MD) context.Context {
+	if values := md.Get(requestIDMetadata); len(values) > 0 && values[0] != "" {
+		return WithRequestID(ctx, values[0])
+	}
+
+	return WithRequestID(ctx, NewRequestID())
+}
@@ -0,0 +1,38 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/go-kit/log/level"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestNewLoggerFiltersLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := NewLogger(&buf, "warn")
+	require.NoError(t, err)
+
+	level.Info(logger).Log("msg", "hidden")
+	require.Empty(t, buf.String())
+
+	level.Error(logger).Log("msg", "shown")
+	require.Contains(t, buf.String(), "msg=shown")
+}
+
+func TestNewLoggerUnknownLevel(t *testing.T) {
+	_, err := NewLogger(&bytes.Buffer{}, "verbose")
+	require.Error(t, err)
+}
+
+func TestRequestIDPropagation(t *testing.T) {
+	ctx := WithRequestID(context.Background(), "abc")
+
+	md := metadata.MD{}
+	GRPCClientBefore(ctx, &md)
+	require.Equal(t, "abc", RequestID(GRPCServerBefore(context.Background(), md)))
+
+	require.NotEmpty(t, RequestID(GRPCServerBefore(context.Background(), metadata.MD{})))
+}
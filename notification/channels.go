@@ -0,0 +1,167 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/webhook"
+)
+
+// payload is the JSON document posted to webhooks and written to files.
+type payload struct {
+	Event      string    `json:"event"`
+	CustomerID string    `json:"customer_id"`
+	TrackingID string    `json:"tracking_id"`
+	Subject    string    `json:"subject"`
+	Body       string    `json:"body"`
+	Occurred   time.Time `json:"occurred"`
+}
+
+func newPayload(m Message) payload {
+	return payload{
+		Event:      strings.ToLower(m.Event.String()),
+		CustomerID: string(m.Customer.ID),
+		TrackingID: string(m.TrackingID),
+		Subject:    m.Subject,
+		Body:       m.Body,
+		Occurred:   m.Occurred,
+	}
+}
+
+// SMTPChannel emails customers who opted in to email notifications.
+type SMTPChannel struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewSMTPChannel sends emails from the given address through the SMTP
+// server at addr, host:port. Credentials are optional.
+func NewSMTPChannel(addr, from, username, password string) SMTPChannel {
+	var auth smtp.Auth
+	if username != "" {
+		host, _, _ := net.SplitHostPort(addr)
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return SMTPChannel{addr: addr, from: from, auth: auth}
+}
+
+func (ch SMTPChannel) Name() string { return "smtp" }
+
+func (ch SMTPChannel) Reaches(c customer.Customer) bool {
+	return c.Preferences.Email && c.Contact.Email != ""
+}
+
+// Send emails the message. The context only bounds the time spent waiting
+// for the server, net/smtp does not support cancellation.
+func (ch SMTPChannel) Send(ctx context.Context, m Message) error {
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n%s",
+		ch.from, m.Customer.Contact.Email, m.Subject, strings.ReplaceAll(m.Body, "\n", "\r\n"))
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- smtp.SendMail(ch.addr, ch.auth, ch.from, []string{m.Customer.Contact.Email}, []byte(msg))
+	}()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// WebhookChannel posts messages as JSON to the webhook URL of customers.
+type WebhookChannel struct {
+	client *http.Client
+}
+
+// NewWebhookChannel posts with the given client, one that only connects to
+// public addresses when nil.
+func NewWebhookChannel(client *http.Client) WebhookChannel {
+	if client == nil {
+		client = webhook.NewClient(webhook.DefaultTimeout)
+	}
+
+	return WebhookChannel{client: client}
+}
+
+func (ch WebhookChannel) Name() string { return "webhook" }
+
+func (ch WebhookChannel) Reaches(c customer.Customer) bool {
+	return c.Preferences.WebhookURL != ""
+}
+
+// Send fails unless the webhook answers with a 2xx status.
+func (ch WebhookChannel) Send(ctx context.Context, m Message) error {
+	body, err := json.Marshal(newPayload(m))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.Customer.Preferences.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := ch.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook answered %s", resp.Status)
+	}
+
+	return nil
+}
+
+// FileChannel appends every message as a line of JSON to a file. It is
+// meant for development and auditing and reaches every customer.
+type FileChannel struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileChannel(path string) *FileChannel {
+	return &FileChannel{path: path}
+}
+
+func (ch *FileChannel) Name() string { return "file" }
+
+func (ch *FileChannel) Reaches(c customer.Customer) bool { return true }
+
+func (ch *FileChannel) Send(ctx context.Context, m Message) error {
+	line, err := json.Marshal(newPayload(m))
+	if err != nil {
+		return err
+	}
+
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+
+	f, err := os.OpenFile(ch.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
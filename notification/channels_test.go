@@ -0,0 +1,50 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookChannel(t *testing.T) {
+	var received payload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+
+		if received.TrackingID == "FAIL" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	ch := NewWebhookChannel(srv.Client())
+	owner := testCustomer(customer.NotificationPreferences{WebhookURL: srv.URL})
+	require.True(t, ch.Reaches(owner))
+	require.False(t, ch.Reaches(testCustomer(customer.NotificationPreferences{})))
+
+	m := Message{Alert: Alert{Event: Misdirected, Customer: owner, TrackingID: "ABC123"}, Subject: "subject", Body: "body"}
+	require.NoError(t, ch.Send(context.Background(), m))
+	require.Equal(t, "misdirected", received.Event)
+	require.Equal(t, "ACME", received.CustomerID)
+	require.Equal(t, "subject", received.Subject)
+
+	m.TrackingID = "FAIL"
+	require.Error(t, ch.Send(context.Background(), m))
+}
+
+func TestSMTPChannelReaches(t *testing.T) {
+	ch := NewSMTPChannel("localhost:25", "noreply@shipping.example", "", "")
+	require.True(t, ch.Reaches(testCustomer(customer.NotificationPreferences{Email: true})))
+	require.False(t, ch.Reaches(testCustomer(customer.NotificationPreferences{})))
+
+	noAddress := testCustomer(customer.NotificationPreferences{Email: true})
+	noAddress.Contact.Email = ""
+	require.False(t, ch.Reaches(noAddress))
+}
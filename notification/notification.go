@@ -0,0 +1,195 @@
+// Package notification alerts customers when the delivery of their cargo
+// changes in a way they subscribed to. Alerts are rendered from templates
+// and sent through every channel that reaches the customer.
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+)
+
+// Event is a change in the delivery of a cargo customers can be alerted
+// of.
+type Event int
+
+// Events customers can subscribe to.
+const (
+	Misdirected Event = iota
+	Unloaded
+	Late
+)
+
+func (e Event) String() string {
+	switch e {
+	case Misdirected:
+		return "Misdirected"
+	case Unloaded:
+		return "Unloaded"
+	case Late:
+		return "Late"
+	}
+
+	return ""
+}
+
+// Changes returns the events raised by the delivery changing from before
+// to after: the cargo became misdirected, was unloaded at its destination
+// or started running late.
+func Changes(before, after cargo.Delivery) []Event {
+	var events []Event
+	if after.IsMisdirected && !before.IsMisdirected {
+		events = append(events, Misdirected)
+	}
+
+	if after.IsUnloadedAtDestination && !before.IsUnloadedAtDestination {
+		events = append(events, Unloaded)
+	}
+
+	if after.IsLate() && !before.IsLate() {
+		events = append(events, Late)
+	}
+
+	return events
+}
+
+// Subscribed reports whether the customer wants to be alerted of the event.
+func Subscribed(p customer.NotificationPreferences, e Event) bool {
+	switch e {
+	case Misdirected:
+		return p.Misdirected
+	case Unloaded:
+		return p.Unloaded
+	case Late:
+		return p.Late
+	}
+
+	return false
+}
+
+// Alert tells a customer about an event in the delivery of their cargo.
+type Alert struct {
+	Event      Event
+	Customer   customer.Customer
+	TrackingID cargo.TrackingID
+	Delivery   cargo.Delivery
+	Occurred   time.Time
+}
+
+// Alerts returns an alert for each event the owner of the cargo subscribed
+// to.
+func Alerts(owner customer.Customer, c *cargo.Cargo, events []Event, now time.Time) []Alert {
+	var alerts []Alert
+	for _, e := range events {
+		if Subscribed(owner.Preferences, e) {
+			alerts = append(alerts, Alert{Event: e, Customer: owner, TrackingID: c.TrackingID, Delivery: c.Delivery, Occurred: now})
+		}
+	}
+
+	return alerts
+}
+
+// Notifier sends alerts. It must not block the caller on the delivery of
+// the alerts.
+type Notifier interface {
+	Notify(alerts ...Alert)
+}
+
+// Message is an alert rendered for people.
+type Message struct {
+	Alert
+	Subject string
+	Body    string
+}
+
+// Channel delivers messages to customers.
+type Channel interface {
+	// Name identifies the channel in logs.
+	Name() string
+	// Reaches reports whether the customer can be sent messages through
+	// the channel.
+	Reaches(c customer.Customer) bool
+	Send(ctx context.Context, m Message) error
+}
+
+// DefaultQueueSize is the number of alerts a Dispatcher holds before it
+// starts dropping them.
+const DefaultQueueSize = 1024
+
+// Dispatcher sends alerts in the background through its channels, one at a
+// time. Failures are logged, an alert is not sent again.
+type Dispatcher struct {
+	templates *Templates
+	channels  []Channel
+	timeout   time.Duration
+	logger    log.Logger
+	queue     chan Alert
+	done      chan struct{}
+}
+
+// NewDispatcher starts a dispatcher holding up to size alerts. Every send
+// is bounded by timeout.
+func NewDispatcher(templates *Templates, channels []Channel, size int, timeout time.Duration, logger log.Logger) *Dispatcher {
+	d := &Dispatcher{
+		templates: templates,
+		channels:  channels,
+		timeout:   timeout,
+		logger:    logger,
+		queue:     make(chan Alert, size),
+		done:      make(chan struct{}),
+	}
+
+	go d.run()
+	return d
+}
+
+// Notify queues the alerts, dropping those that do not fit in the queue.
+func (d *Dispatcher) Notify(alerts ...Alert) {
+	for _, a := range alerts {
+		select {
+		case d.queue <- a:
+		default:
+			level.Warn(d.logger).Log("msg", "notification queue full, alert dropped", "event", a.Event, "tracking_id", a.TrackingID, "customer_id", a.Customer.ID)
+		}
+	}
+}
+
+// Close sends the queued alerts and stops the dispatcher. Notify must not
+// be called afterwards.
+func (d *Dispatcher) Close() {
+	close(d.queue)
+	<-d.done
+}
+
+func (d *Dispatcher) run() {
+	defer close(d.done)
+	for a := range d.queue {
+		d.dispatch(a)
+	}
+}
+
+func (d *Dispatcher) dispatch(a Alert) {
+	m, err := d.templates.Render(a)
+	if err != nil {
+		level.Error(d.logger).Log("msg", "cannot render notification", "event", a.Event, "tracking_id", a.TrackingID, "err", err)
+		return
+	}
+
+	for _, ch := range d.channels {
+		if !ch.Reaches(a.Customer) {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), d.timeout)
+		err := ch.Send(ctx, m)
+		cancel()
+
+		if err != nil {
+			level.Error(d.logger).Log("msg", "cannot send notification", "channel", ch.Name(), "event", a.Event, "tracking_id", a.TrackingID, "customer_id", a.Customer.ID, "err", err)
+		}
+	}
+}
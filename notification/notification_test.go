@@ -0,0 +1,93 @@
+package notification
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/stretchr/testify/require"
+)
+
+var deadline = time.Date(2030, 1, 10, 0, 0, 0, 0, time.UTC)
+
+func testDelivery(arrival time.Time) cargo.Delivery {
+	return cargo.Delivery{
+		RouteSpecification: cargo.RouteSpecification{Origin: "SESTO", Destination: "AUMEL", ArrivalDeadline: deadline},
+		Itinerary: cargo.Itinerary{Legs: []cargo.Leg{
+			cargo.NewLeg("V100", "SESTO", "AUMEL", deadline.AddDate(0, 0, -5), arrival),
+		}},
+		LastKnownLocation: "SESTO",
+	}
+}
+
+func testCustomer(p customer.NotificationPreferences) customer.Customer {
+	return customer.Customer{ID: "ACME", Name: "Acme", Contact: customer.Contact{Email: "ops@acme.example"}, Preferences: p}
+}
+
+func TestChanges(t *testing.T) {
+	onTime := testDelivery(deadline.Add(-time.Hour))
+	late := testDelivery(deadline.Add(time.Hour))
+
+	misdirected := onTime
+	misdirected.IsMisdirected = true
+
+	unloaded := late
+	unloaded.IsUnloadedAtDestination = true
+
+	require.Empty(t, Changes(onTime, onTime))
+	require.Equal(t, []Event{Late}, Changes(onTime, late))
+	require.Empty(t, Changes(late, late))
+	require.Equal(t, []Event{Misdirected}, Changes(onTime, misdirected))
+	require.Empty(t, Changes(misdirected, misdirected))
+	require.Equal(t, []Event{Unloaded}, Changes(late, unloaded))
+}
+
+func TestAlerts(t *testing.T) {
+	c := &cargo.Cargo{TrackingID: "ABC123", Delivery: testDelivery(deadline.Add(time.Hour))}
+	events := []Event{Misdirected, Late}
+
+	require.Empty(t, Alerts(testCustomer(customer.NotificationPreferences{Unloaded: true}), c, events, deadline))
+
+	alerts := Alerts(testCustomer(customer.NotificationPreferences{Late: true}), c, events, deadline)
+	require.Len(t, alerts, 1)
+	require.Equal(t, Late, alerts[0].Event)
+	require.Equal(t, cargo.TrackingID("ABC123"), alerts[0].TrackingID)
+	require.Equal(t, customer.ID("ACME"), alerts[0].Customer.ID)
+}
+
+func TestDispatcher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notifications.jsonl")
+	d := NewDispatcher(DefaultTemplates(), []Channel{NewFileChannel(path)}, DefaultQueueSize, time.Second, log.NewNopLogger())
+
+	owner := testCustomer(customer.NotificationPreferences{Late: true, Unloaded: true})
+	d.Notify(
+		Alert{Event: Late, Customer: owner, TrackingID: "ABC123", Delivery: testDelivery(deadline.Add(time.Hour)), Occurred: deadline},
+		Alert{Event: Unloaded, Customer: owner, TrackingID: "DEF456", Delivery: testDelivery(deadline), Occurred: deadline},
+	)
+	d.Close()
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var lines []payload
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var p payload
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &p))
+		lines = append(lines, p)
+	}
+
+	require.NoError(t, scanner.Err())
+	require.Len(t, lines, 2)
+	require.Equal(t, "late", lines[0].Event)
+	require.Equal(t, "Cargo ABC123 will arrive late", lines[0].Subject)
+	require.Equal(t, "unloaded", lines[1].Event)
+	require.Equal(t, "DEF456", lines[1].TrackingID)
+}
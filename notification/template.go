@@ -0,0 +1,130 @@
+package notification
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// defaultTemplates define a subject and a body per event, named after the
+// event in lower case.
+const defaultTemplates = `
+{{define "misdirected.subject"}}Cargo {{.TrackingID}} is misdirected{{end}}
+{{define "misdirected.body"}}Dear {{.Customer}},
+
+your cargo {{.TrackingID}} bound for {{.Destination}} was last handled in
+{{.LastKnownLocation}}, which is not on its itinerary. We are rerouting it
+and will let you know its new arrival time.
+{{end}}
+
+{{define "unloaded.subject"}}Cargo {{.TrackingID}} has arrived in {{.Destination}}{{end}}
+{{define "unloaded.body"}}Dear {{.Customer}},
+
+your cargo {{.TrackingID}} was unloaded at its destination {{.Destination}}
+on {{date .Occurred}} and is ready to be claimed.
+{{end}}
+
+{{define "late.subject"}}Cargo {{.TrackingID}} will arrive late{{end}}
+{{define "late.body"}}Dear {{.Customer}},
+
+your cargo {{.TrackingID}} is expected in {{.Destination}} on {{date .Arrival}},
+after its arrival deadline of {{date .Deadline}}.
+{{end}}
+`
+
+var funcs = template.FuncMap{
+	"date": func(t time.Time) string {
+		if t.IsZero() {
+			return "an unknown date"
+		}
+
+		return t.UTC().Format("2 January 2006 15:04 MST")
+	},
+}
+
+// Templates render alerts into messages.
+type Templates struct {
+	t *template.Template
+}
+
+// DefaultTemplates returns the built-in templates.
+func DefaultTemplates() *Templates {
+	return &Templates{template.Must(template.New("notifications").Funcs(funcs).Parse(defaultTemplates))}
+}
+
+// LoadTemplates returns the built-in templates overridden by the *.tmpl
+// files of dir. Files define templates named like the built-in ones, e.g.
+// {{define "late.body"}}...{{end}}.
+func LoadTemplates(dir string) (*Templates, error) {
+	t := DefaultTemplates().t
+	matches, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no *.tmpl files in %s", dir)
+	}
+
+	t, err = t.ParseFiles(matches...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Templates{t}, nil
+}
+
+// templateData is what templates are executed with.
+type templateData struct {
+	Customer          string
+	TrackingID        string
+	Origin            string
+	Destination       string
+	Deadline          time.Time
+	Arrival           time.Time
+	LastKnownLocation string
+	Occurred          time.Time
+}
+
+// Render renders the subject and the body of the alert.
+func (ts *Templates) Render(a Alert) (Message, error) {
+	d := a.Delivery
+	data := templateData{
+		Customer:          a.Customer.Name,
+		TrackingID:        string(a.TrackingID),
+		Origin:            string(d.RouteSpecification.Origin),
+		Destination:       string(d.RouteSpecification.Destination),
+		Deadline:          d.RouteSpecification.ArrivalDeadline,
+		LastKnownLocation: string(d.LastKnownLocation),
+		Occurred:          a.Occurred,
+	}
+
+	if !d.Itinerary.IsEmpty() {
+		data.Arrival = d.Itinerary.FinalArrivalTime()
+	}
+
+	name := strings.ToLower(a.Event.String())
+	subject, err := ts.execute(name+".subject", data)
+	if err != nil {
+		return Message{}, err
+	}
+
+	body, err := ts.execute(name+".body", data)
+	if err != nil {
+		return Message{}, err
+	}
+
+	return Message{Alert: a, Subject: strings.TrimSpace(subject), Body: body}, nil
+}
+
+func (ts *Templates) execute(name string, data templateData) (string, error) {
+	var buf bytes.Buffer
+	if err := ts.t.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,45 @@
+package notification
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderTemplates(t *testing.T) {
+	owner := testCustomer(customer.NotificationPreferences{})
+	delivery := testDelivery(deadline.Add(24 * time.Hour))
+
+	for _, e := range []Event{Misdirected, Unloaded, Late} {
+		m, err := DefaultTemplates().Render(Alert{Event: e, Customer: owner, TrackingID: "ABC123", Delivery: delivery, Occurred: deadline})
+		require.NoError(t, err, e)
+		require.Contains(t, m.Subject, "ABC123", e)
+		require.Contains(t, m.Body, "Dear Acme", e)
+	}
+
+	m, err := DefaultTemplates().Render(Alert{Event: Late, Customer: owner, TrackingID: "ABC123", Delivery: delivery})
+	require.NoError(t, err)
+	require.Contains(t, m.Body, "expected in AUMEL on 11 January 2030 00:00 UTC")
+	require.Contains(t, m.Body, "deadline of 10 January 2030 00:00 UTC")
+}
+
+func TestLoadTemplates(t *testing.T) {
+	dir := t.TempDir()
+	override := `{{define "late.subject"}}Delay: {{.TrackingID}}{{end}}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "late.tmpl"), []byte(override), 0o644))
+
+	ts, err := LoadTemplates(dir)
+	require.NoError(t, err)
+
+	m, err := ts.Render(Alert{Event: Late, Customer: testCustomer(customer.NotificationPreferences{}), TrackingID: "ABC123", Delivery: testDelivery(deadline)})
+	require.NoError(t, err)
+	require.Equal(t, "Delay: ABC123", m.Subject)
+	require.Contains(t, m.Body, "arrival deadline")
+
+	_, err = LoadTemplates(t.TempDir())
+	require.Error(t, err)
+}
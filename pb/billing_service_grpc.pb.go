@@ -0,0 +1,193 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: billing_service.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Billing_ListInvoices_FullMethodName    = "/pb.Billing/ListInvoices"
+	Billing_FinalizeInvoice_FullMethodName = "/pb.Billing/FinalizeInvoice"
+	Billing_RenderInvoice_FullMethodName   = "/pb.Billing/RenderInvoice"
+)
+
+// BillingClient is the client API for Billing service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BillingClient interface {
+	// ListInvoices returns the invoices of a customer, newest first. Staff
+	// name the customer, customers get their own invoices.
+	ListInvoices(ctx context.Context, in *ListInvoicesRequest, opts ...grpc.CallOption) (*ListInvoicesResponse, error)
+	// FinalizeInvoice closes a draft invoice, later lines start a new
+	// draft. Staff only.
+	FinalizeInvoice(ctx context.Context, in *FinalizeInvoiceRequest, opts ...grpc.CallOption) (*FinalizeInvoiceResponse, error)
+	// RenderInvoice renders an invoice as a json or pdf document.
+	RenderInvoice(ctx context.Context, in *RenderInvoiceRequest, opts ...grpc.CallOption) (*RenderInvoiceResponse, error)
+}
+
+type billingClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBillingClient(cc grpc.ClientConnInterface) BillingClient {
+	return &billingClient{cc}
+}
+
+func (c *billingClient) ListInvoices(ctx context.Context, in *ListInvoicesRequest, opts ...grpc.CallOption) (*ListInvoicesResponse, error) {
+	out := new(ListInvoicesResponse)
+	err := c.cc.Invoke(ctx, Billing_ListInvoices_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *billingClient) FinalizeInvoice(ctx context.Context, in *FinalizeInvoiceRequest, opts ...grpc.CallOption) (*FinalizeInvoiceResponse, error) {
+	out := new(FinalizeInvoiceResponse)
+	err := c.cc.Invoke(ctx, Billing_FinalizeInvoice_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *billingClient) RenderInvoice(ctx context.Context, in *RenderInvoiceRequest, opts ...grpc.CallOption) (*RenderInvoiceResponse, error) {
+	out := new(RenderInvoiceResponse)
+	err := c.cc.Invoke(ctx, Billing_RenderInvoice_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BillingServer is the server API for Billing service.
+// All implementations must embed UnimplementedBillingServer
+// for forward compatibility
+type BillingServer interface {
+	// ListInvoices returns the invoices of a customer, newest first. Staff
+	// name the customer, customers get their own invoices.
+	ListInvoices(context.Context, *ListInvoicesRequest) (*ListInvoicesResponse, error)
+	// FinalizeInvoice closes a draft invoice, later lines start a new
+	// draft. Staff only.
+	FinalizeInvoice(context.Context, *FinalizeInvoiceRequest) (*FinalizeInvoiceResponse, error)
+	// RenderInvoice renders an invoice as a json or pdf document.
+	RenderInvoice(context.Context, *RenderInvoiceRequest) (*RenderInvoiceResponse, error)
+	mustEmbedUnimplementedBillingServer()
+}
+
+// UnimplementedBillingServer must be embedded to have forward compatible implementations.
+type UnimplementedBillingServer struct {
+}
+
+func (UnimplementedBillingServer) ListInvoices(context.Context, *ListInvoicesRequest) (*ListInvoicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInvoices not implemented")
+}
+func (UnimplementedBillingServer) FinalizeInvoice(context.Context, *FinalizeInvoiceRequest) (*FinalizeInvoiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinalizeInvoice not implemented")
+}
+func (UnimplementedBillingServer) RenderInvoice(context.Context, *RenderInvoiceRequest) (*RenderInvoiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenderInvoice not implemented")
+}
+func (UnimplementedBillingServer) mustEmbedUnimplementedBillingServer() {}
+
+// UnsafeBillingServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BillingServer will
+// result in compilation errors.
+type UnsafeBillingServer interface {
+	mustEmbedUnimplementedBillingServer()
+}
+
+func RegisterBillingServer(s grpc.ServiceRegistrar, srv BillingServer) {
+	s.RegisterService(&Billing_ServiceDesc, srv)
+}
+
+func _Billing_ListInvoices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInvoicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillingServer).ListInvoices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Billing_ListInvoices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillingServer).ListInvoices(ctx, req.(*ListInvoicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Billing_FinalizeInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinalizeInvoiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillingServer).FinalizeInvoice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Billing_FinalizeInvoice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillingServer).FinalizeInvoice(ctx, req.(*FinalizeInvoiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Billing_RenderInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenderInvoiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillingServer).RenderInvoice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Billing_RenderInvoice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillingServer).RenderInvoice(ctx, req.(*RenderInvoiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Billing_ServiceDesc is the grpc.ServiceDesc for Billing service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Billing_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.Billing",
+	HandlerType: (*BillingServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListInvoices",
+			Handler:    _Billing_ListInvoices_Handler,
+		},
+		{
+			MethodName: "FinalizeInvoice",
+			Handler:    _Billing_FinalizeInvoice_Handler,
+		},
+		{
+			MethodName: "RenderInvoice",
+			Handler:    _Billing_RenderInvoice_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "billing_service.proto",
+}
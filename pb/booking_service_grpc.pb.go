@@ -1,14 +1,13 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
 // - protoc-gen-go-grpc v1.3.0
-// - protoc             v3.6.1
+// - protoc             (unknown)
 // source: booking_service.proto
 
 package pb
 
 import (
 	context "context"
-	empty "github.com/golang/protobuf/ptypes/empty"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -20,11 +19,18 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Booking_BookNewCargo_FullMethodName       = "/pb.Booking/BookNewCargo"
-	Booking_LoadCargo_FullMethodName          = "/pb.Booking/LoadCargo"
-	Booking_AssignCargoToRoute_FullMethodName = "/pb.Booking/AssignCargoToRoute"
-	Booking_ChangeDestination_FullMethodName  = "/pb.Booking/ChangeDestination"
-	Booking_Cargos_FullMethodName             = "/pb.Booking/Cargos"
+	Booking_BookNewCargo_FullMethodName             = "/pb.Booking/BookNewCargo"
+	Booking_LoadCargo_FullMethodName                = "/pb.Booking/LoadCargo"
+	Booking_AssignCargoToRoute_FullMethodName       = "/pb.Booking/AssignCargoToRoute"
+	Booking_ChangeDestination_FullMethodName        = "/pb.Booking/ChangeDestination"
+	Booking_UpdateRouteSpecification_FullMethodName = "/pb.Booking/UpdateRouteSpecification"
+	Booking_CancelBooking_FullMethodName            = "/pb.Booking/CancelBooking"
+	Booking_HoldCargo_FullMethodName                = "/pb.Booking/HoldCargo"
+	Booking_ReleaseCargo_FullMethodName             = "/pb.Booking/ReleaseCargo"
+	Booking_HandlingHistory_FullMethodName          = "/pb.Booking/HandlingHistory"
+	Booking_Cargos_FullMethodName                   = "/pb.Booking/Cargos"
+	Booking_BookCargos_FullMethodName               = "/pb.Booking/BookCargos"
+	Booking_ExportCargos_FullMethodName             = "/pb.Booking/ExportCargos"
 )
 
 // BookingClient is the client API for Booking service.
@@ -35,7 +41,30 @@ type BookingClient interface {
 	LoadCargo(ctx context.Context, in *LoadCargoRequest, opts ...grpc.CallOption) (*LoadCargoResponse, error)
 	AssignCargoToRoute(ctx context.Context, in *AssignCargoToRouteRequest, opts ...grpc.CallOption) (*AssignCargoToRouteResponse, error)
 	ChangeDestination(ctx context.Context, in *ChangeDestinationRequest, opts ...grpc.CallOption) (*ChangeDestinationResponse, error)
-	Cargos(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*CargosResponse, error)
+	// UpdateRouteSpecification changes the origin, destination or arrival
+	// deadline of a cargo. The origin can only change before the cargo is
+	// received.
+	UpdateRouteSpecification(ctx context.Context, in *UpdateRouteSpecificationRequest, opts ...grpc.CallOption) (*UpdateRouteSpecificationResponse, error)
+	// CancelBooking cancels the booking of a cargo that has not been loaded
+	// yet. Cancelled cargos are kept and reported with their status.
+	CancelBooking(ctx context.Context, in *CancelBookingRequest, opts ...grpc.CallOption) (*CancelBookingResponse, error)
+	// HoldCargo places a cargo on customs hold. A held cargo cannot be
+	// claimed until ReleaseCargo lifts the hold. Staff only.
+	HoldCargo(ctx context.Context, in *HoldCargoRequest, opts ...grpc.CallOption) (*HoldCargoResponse, error)
+	ReleaseCargo(ctx context.Context, in *ReleaseCargoRequest, opts ...grpc.CallOption) (*ReleaseCargoResponse, error)
+	// HandlingHistory returns the handling events of a cargo, oldest first,
+	// each flagged as expected or not by the current itinerary.
+	HandlingHistory(ctx context.Context, in *HandlingHistoryRequest, opts ...grpc.CallOption) (*HandlingHistoryResponse, error)
+	Cargos(ctx context.Context, in *CargosRequest, opts ...grpc.CallOption) (*CargosResponse, error)
+	// BookCargos books one cargo per streamed row. Rows are written in
+	// batched transactions and the result of every row is returned once the
+	// client closes the stream. Served over HTTP as POST /booking/cargos:bulk
+	// with a CSV or JSON Lines body.
+	BookCargos(ctx context.Context, opts ...grpc.CallOption) (Booking_BookCargosClient, error)
+	// ExportCargos streams the cargos matching the same filters as Cargos,
+	// with their current delivery state. Served over HTTP as
+	// GET /booking/cargos:export in CSV, JSON Lines or Parquet.
+	ExportCargos(ctx context.Context, in *ExportCargosRequest, opts ...grpc.CallOption) (Booking_ExportCargosClient, error)
 }
 
 type bookingClient struct {
@@ -82,7 +111,52 @@ func (c *bookingClient) ChangeDestination(ctx context.Context, in *ChangeDestina
 	return out, nil
 }
 
-func (c *bookingClient) Cargos(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*CargosResponse, error) {
+func (c *bookingClient) UpdateRouteSpecification(ctx context.Context, in *UpdateRouteSpecificationRequest, opts ...grpc.CallOption) (*UpdateRouteSpecificationResponse, error) {
+	out := new(UpdateRouteSpecificationResponse)
+	err := c.cc.Invoke(ctx, Booking_UpdateRouteSpecification_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingClient) CancelBooking(ctx context.Context, in *CancelBookingRequest, opts ...grpc.CallOption) (*CancelBookingResponse, error) {
+	out := new(CancelBookingResponse)
+	err := c.cc.Invoke(ctx, Booking_CancelBooking_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingClient) HoldCargo(ctx context.Context, in *HoldCargoRequest, opts ...grpc.CallOption) (*HoldCargoResponse, error) {
+	out := new(HoldCargoResponse)
+	err := c.cc.Invoke(ctx, Booking_HoldCargo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingClient) ReleaseCargo(ctx context.Context, in *ReleaseCargoRequest, opts ...grpc.CallOption) (*ReleaseCargoResponse, error) {
+	out := new(ReleaseCargoResponse)
+	err := c.cc.Invoke(ctx, Booking_ReleaseCargo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingClient) HandlingHistory(ctx context.Context, in *HandlingHistoryRequest, opts ...grpc.CallOption) (*HandlingHistoryResponse, error) {
+	out := new(HandlingHistoryResponse)
+	err := c.cc.Invoke(ctx, Booking_HandlingHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingClient) Cargos(ctx context.Context, in *CargosRequest, opts ...grpc.CallOption) (*CargosResponse, error) {
 	out := new(CargosResponse)
 	err := c.cc.Invoke(ctx, Booking_Cargos_FullMethodName, in, out, opts...)
 	if err != nil {
@@ -91,6 +165,72 @@ func (c *bookingClient) Cargos(ctx context.Context, in *empty.Empty, opts ...grp
 	return out, nil
 }
 
+func (c *bookingClient) BookCargos(ctx context.Context, opts ...grpc.CallOption) (Booking_BookCargosClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Booking_ServiceDesc.Streams[0], Booking_BookCargos_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bookingBookCargosClient{stream}
+	return x, nil
+}
+
+type Booking_BookCargosClient interface {
+	Send(*BookCargosRequest) error
+	CloseAndRecv() (*BookCargosResponse, error)
+	grpc.ClientStream
+}
+
+type bookingBookCargosClient struct {
+	grpc.ClientStream
+}
+
+func (x *bookingBookCargosClient) Send(m *BookCargosRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *bookingBookCargosClient) CloseAndRecv() (*BookCargosResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(BookCargosResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *bookingClient) ExportCargos(ctx context.Context, in *ExportCargosRequest, opts ...grpc.CallOption) (Booking_ExportCargosClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Booking_ServiceDesc.Streams[1], Booking_ExportCargos_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bookingExportCargosClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Booking_ExportCargosClient interface {
+	Recv() (*ExportedCargo, error)
+	grpc.ClientStream
+}
+
+type bookingExportCargosClient struct {
+	grpc.ClientStream
+}
+
+func (x *bookingExportCargosClient) Recv() (*ExportedCargo, error) {
+	m := new(ExportedCargo)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // BookingServer is the server API for Booking service.
 // All implementations must embed UnimplementedBookingServer
 // for forward compatibility
@@ -99,7 +239,30 @@ type BookingServer interface {
 	LoadCargo(context.Context, *LoadCargoRequest) (*LoadCargoResponse, error)
 	AssignCargoToRoute(context.Context, *AssignCargoToRouteRequest) (*AssignCargoToRouteResponse, error)
 	ChangeDestination(context.Context, *ChangeDestinationRequest) (*ChangeDestinationResponse, error)
-	Cargos(context.Context, *empty.Empty) (*CargosResponse, error)
+	// UpdateRouteSpecification changes the origin, destination or arrival
+	// deadline of a cargo. The origin can only change before the cargo is
+	// received.
+	UpdateRouteSpecification(context.Context, *UpdateRouteSpecificationRequest) (*UpdateRouteSpecificationResponse, error)
+	// CancelBooking cancels the booking of a cargo that has not been loaded
+	// yet. Cancelled cargos are kept and reported with their status.
+	CancelBooking(context.Context, *CancelBookingRequest) (*CancelBookingResponse, error)
+	// HoldCargo places a cargo on customs hold. A held cargo cannot be
+	// claimed until ReleaseCargo lifts the hold. Staff only.
+	HoldCargo(context.Context, *HoldCargoRequest) (*HoldCargoResponse, error)
+	ReleaseCargo(context.Context, *ReleaseCargoRequest) (*ReleaseCargoResponse, error)
+	// HandlingHistory returns the handling events of a cargo, oldest first,
+	// each flagged as expected or not by the current itinerary.
+	HandlingHistory(context.Context, *HandlingHistoryRequest) (*HandlingHistoryResponse, error)
+	Cargos(context.Context, *CargosRequest) (*CargosResponse, error)
+	// BookCargos books one cargo per streamed row. Rows are written in
+	// batched transactions and the result of every row is returned once the
+	// client closes the stream. Served over HTTP as POST /booking/cargos:bulk
+	// with a CSV or JSON Lines body.
+	BookCargos(Booking_BookCargosServer) error
+	// ExportCargos streams the cargos matching the same filters as Cargos,
+	// with their current delivery state. Served over HTTP as
+	// GET /booking/cargos:export in CSV, JSON Lines or Parquet.
+	ExportCargos(*ExportCargosRequest, Booking_ExportCargosServer) error
 	mustEmbedUnimplementedBookingServer()
 }
 
@@ -119,9 +282,30 @@ func (UnimplementedBookingServer) AssignCargoToRoute(context.Context, *AssignCar
 func (UnimplementedBookingServer) ChangeDestination(context.Context, *ChangeDestinationRequest) (*ChangeDestinationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ChangeDestination not implemented")
 }
-func (UnimplementedBookingServer) Cargos(context.Context, *empty.Empty) (*CargosResponse, error) {
+func (UnimplementedBookingServer) UpdateRouteSpecification(context.Context, *UpdateRouteSpecificationRequest) (*UpdateRouteSpecificationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRouteSpecification not implemented")
+}
+func (UnimplementedBookingServer) CancelBooking(context.Context, *CancelBookingRequest) (*CancelBookingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelBooking not implemented")
+}
+func (UnimplementedBookingServer) HoldCargo(context.Context, *HoldCargoRequest) (*HoldCargoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HoldCargo not implemented")
+}
+func (UnimplementedBookingServer) ReleaseCargo(context.Context, *ReleaseCargoRequest) (*ReleaseCargoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseCargo not implemented")
+}
+func (UnimplementedBookingServer) HandlingHistory(context.Context, *HandlingHistoryRequest) (*HandlingHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HandlingHistory not implemented")
+}
+func (UnimplementedBookingServer) Cargos(context.Context, *CargosRequest) (*CargosResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Cargos not implemented")
 }
+func (UnimplementedBookingServer) BookCargos(Booking_BookCargosServer) error {
+	return status.Errorf(codes.Unimplemented, "method BookCargos not implemented")
+}
+func (UnimplementedBookingServer) ExportCargos(*ExportCargosRequest, Booking_ExportCargosServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportCargos not implemented")
+}
 func (UnimplementedBookingServer) mustEmbedUnimplementedBookingServer() {}
 
 // UnsafeBookingServer may be embedded to opt out of forward compatibility for this service.
@@ -207,8 +391,98 @@ func _Booking_ChangeDestination_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Booking_UpdateRouteSpecification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRouteSpecificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServer).UpdateRouteSpecification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Booking_UpdateRouteSpecification_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServer).UpdateRouteSpecification(ctx, req.(*UpdateRouteSpecificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Booking_CancelBooking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelBookingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServer).CancelBooking(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Booking_CancelBooking_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServer).CancelBooking(ctx, req.(*CancelBookingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Booking_HoldCargo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HoldCargoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServer).HoldCargo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Booking_HoldCargo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServer).HoldCargo(ctx, req.(*HoldCargoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Booking_ReleaseCargo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseCargoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServer).ReleaseCargo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Booking_ReleaseCargo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServer).ReleaseCargo(ctx, req.(*ReleaseCargoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Booking_HandlingHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandlingHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServer).HandlingHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Booking_HandlingHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServer).HandlingHistory(ctx, req.(*HandlingHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Booking_Cargos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(empty.Empty)
+	in := new(CargosRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -220,11 +494,58 @@ func _Booking_Cargos_Handler(srv interface{}, ctx context.Context, dec func(inte
 		FullMethod: Booking_Cargos_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BookingServer).Cargos(ctx, req.(*empty.Empty))
+		return srv.(BookingServer).Cargos(ctx, req.(*CargosRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Booking_BookCargos_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BookingServer).BookCargos(&bookingBookCargosServer{stream})
+}
+
+type Booking_BookCargosServer interface {
+	SendAndClose(*BookCargosResponse) error
+	Recv() (*BookCargosRequest, error)
+	grpc.ServerStream
+}
+
+type bookingBookCargosServer struct {
+	grpc.ServerStream
+}
+
+func (x *bookingBookCargosServer) SendAndClose(m *BookCargosResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *bookingBookCargosServer) Recv() (*BookCargosRequest, error) {
+	m := new(BookCargosRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Booking_ExportCargos_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportCargosRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BookingServer).ExportCargos(m, &bookingExportCargosServer{stream})
+}
+
+type Booking_ExportCargosServer interface {
+	Send(*ExportedCargo) error
+	grpc.ServerStream
+}
+
+type bookingExportCargosServer struct {
+	grpc.ServerStream
+}
+
+func (x *bookingExportCargosServer) Send(m *ExportedCargo) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // Booking_ServiceDesc is the grpc.ServiceDesc for Booking service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -248,11 +569,42 @@ var Booking_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ChangeDestination",
 			Handler:    _Booking_ChangeDestination_Handler,
 		},
+		{
+			MethodName: "UpdateRouteSpecification",
+			Handler:    _Booking_UpdateRouteSpecification_Handler,
+		},
+		{
+			MethodName: "CancelBooking",
+			Handler:    _Booking_CancelBooking_Handler,
+		},
+		{
+			MethodName: "HoldCargo",
+			Handler:    _Booking_HoldCargo_Handler,
+		},
+		{
+			MethodName: "ReleaseCargo",
+			Handler:    _Booking_ReleaseCargo_Handler,
+		},
+		{
+			MethodName: "HandlingHistory",
+			Handler:    _Booking_HandlingHistory_Handler,
+		},
 		{
 			MethodName: "Cargos",
 			Handler:    _Booking_Cargos_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "BookCargos",
+			Handler:       _Booking_BookCargos_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportCargos",
+			Handler:       _Booking_ExportCargos_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "booking_service.proto",
 }
@@ -0,0 +1,221 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: customer_service.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Customer_RegisterCustomer_FullMethodName = "/pb.Customer/RegisterCustomer"
+	Customer_LoadCustomer_FullMethodName     = "/pb.Customer/LoadCustomer"
+	Customer_UpdateCustomer_FullMethodName   = "/pb.Customer/UpdateCustomer"
+	Customer_Customers_FullMethodName        = "/pb.Customer/Customers"
+)
+
+// CustomerClient is the client API for Customer service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CustomerClient interface {
+	RegisterCustomer(ctx context.Context, in *RegisterCustomerRequest, opts ...grpc.CallOption) (*RegisterCustomerResponse, error)
+	LoadCustomer(ctx context.Context, in *LoadCustomerRequest, opts ...grpc.CallOption) (*LoadCustomerResponse, error)
+	UpdateCustomer(ctx context.Context, in *UpdateCustomerRequest, opts ...grpc.CallOption) (*UpdateCustomerResponse, error)
+	Customers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CustomersResponse, error)
+}
+
+type customerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCustomerClient(cc grpc.ClientConnInterface) CustomerClient {
+	return &customerClient{cc}
+}
+
+func (c *customerClient) RegisterCustomer(ctx context.Context, in *RegisterCustomerRequest, opts ...grpc.CallOption) (*RegisterCustomerResponse, error) {
+	out := new(RegisterCustomerResponse)
+	err := c.cc.Invoke(ctx, Customer_RegisterCustomer_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) LoadCustomer(ctx context.Context, in *LoadCustomerRequest, opts ...grpc.CallOption) (*LoadCustomerResponse, error) {
+	out := new(LoadCustomerResponse)
+	err := c.cc.Invoke(ctx, Customer_LoadCustomer_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) UpdateCustomer(ctx context.Context, in *UpdateCustomerRequest, opts ...grpc.CallOption) (*UpdateCustomerResponse, error) {
+	out := new(UpdateCustomerResponse)
+	err := c.cc.Invoke(ctx, Customer_UpdateCustomer_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) Customers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CustomersResponse, error) {
+	out := new(CustomersResponse)
+	err := c.cc.Invoke(ctx, Customer_Customers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CustomerServer is the server API for Customer service.
+// All implementations must embed UnimplementedCustomerServer
+// for forward compatibility
+type CustomerServer interface {
+	RegisterCustomer(context.Context, *RegisterCustomerRequest) (*RegisterCustomerResponse, error)
+	LoadCustomer(context.Context, *LoadCustomerRequest) (*LoadCustomerResponse, error)
+	UpdateCustomer(context.Context, *UpdateCustomerRequest) (*UpdateCustomerResponse, error)
+	Customers(context.Context, *emptypb.Empty) (*CustomersResponse, error)
+	mustEmbedUnimplementedCustomerServer()
+}
+
+// UnimplementedCustomerServer must be embedded to have forward compatible implementations.
+type UnimplementedCustomerServer struct {
+}
+
+func (UnimplementedCustomerServer) RegisterCustomer(context.Context, *RegisterCustomerRequest) (*RegisterCustomerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterCustomer not implemented")
+}
+func (UnimplementedCustomerServer) LoadCustomer(context.Context, *LoadCustomerRequest) (*LoadCustomerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoadCustomer not implemented")
+}
+func (UnimplementedCustomerServer) UpdateCustomer(context.Context, *UpdateCustomerRequest) (*UpdateCustomerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCustomer not implemented")
+}
+func (UnimplementedCustomerServer) Customers(context.Context, *emptypb.Empty) (*CustomersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Customers not implemented")
+}
+func (UnimplementedCustomerServer) mustEmbedUnimplementedCustomerServer() {}
+
+// UnsafeCustomerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CustomerServer will
+// result in compilation errors.
+type UnsafeCustomerServer interface {
+	mustEmbedUnimplementedCustomerServer()
+}
+
+func RegisterCustomerServer(s grpc.ServiceRegistrar, srv CustomerServer) {
+	s.RegisterService(&Customer_ServiceDesc, srv)
+}
+
+func _Customer_RegisterCustomer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterCustomerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).RegisterCustomer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Customer_RegisterCustomer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).RegisterCustomer(ctx, req.(*RegisterCustomerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Customer_LoadCustomer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoadCustomerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).LoadCustomer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Customer_LoadCustomer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).LoadCustomer(ctx, req.(*LoadCustomerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Customer_UpdateCustomer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCustomerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).UpdateCustomer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Customer_UpdateCustomer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).UpdateCustomer(ctx, req.(*UpdateCustomerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Customer_Customers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).Customers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Customer_Customers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).Customers(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Customer_ServiceDesc is the grpc.ServiceDesc for Customer service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Customer_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.Customer",
+	HandlerType: (*CustomerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RegisterCustomer",
+			Handler:    _Customer_RegisterCustomer_Handler,
+		},
+		{
+			MethodName: "LoadCustomer",
+			Handler:    _Customer_LoadCustomer_Handler,
+		},
+		{
+			MethodName: "UpdateCustomer",
+			Handler:    _Customer_UpdateCustomer_Handler,
+		},
+		{
+			MethodName: "Customers",
+			Handler:    _Customer_Customers_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "customer_service.proto",
+}
@@ -0,0 +1,191 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: handling_service.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Handling_RegisterHandlingEvent_FullMethodName          = "/pb.Handling/RegisterHandlingEvent"
+	Handling_RegisterContainerHandlingEvent_FullMethodName = "/pb.Handling/RegisterContainerHandlingEvent"
+	Handling_IngestEdifact_FullMethodName                  = "/pb.Handling/IngestEdifact"
+)
+
+// HandlingClient is the client API for Handling service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type HandlingClient interface {
+	RegisterHandlingEvent(ctx context.Context, in *RegisterHandlingEventRequest, opts ...grpc.CallOption) (*RegisterHandlingEventResponse, error)
+	// RegisterContainerHandlingEvent registers the event for every cargo in
+	// the container.
+	RegisterContainerHandlingEvent(ctx context.Context, in *RegisterContainerHandlingEventRequest, opts ...grpc.CallOption) (*RegisterContainerHandlingEventResponse, error)
+	// IngestEdifact registers the handling events reported by the IFTSTA
+	// and CODECO messages of an EDIFACT interchange, message by message.
+	IngestEdifact(ctx context.Context, in *IngestEdifactRequest, opts ...grpc.CallOption) (*IngestEdifactResponse, error)
+}
+
+type handlingClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHandlingClient(cc grpc.ClientConnInterface) HandlingClient {
+	return &handlingClient{cc}
+}
+
+func (c *handlingClient) RegisterHandlingEvent(ctx context.Context, in *RegisterHandlingEventRequest, opts ...grpc.CallOption) (*RegisterHandlingEventResponse, error) {
+	out := new(RegisterHandlingEventResponse)
+	err := c.cc.Invoke(ctx, Handling_RegisterHandlingEvent_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *handlingClient) RegisterContainerHandlingEvent(ctx context.Context, in *RegisterContainerHandlingEventRequest, opts ...grpc.CallOption) (*RegisterContainerHandlingEventResponse, error) {
+	out := new(RegisterContainerHandlingEventResponse)
+	err := c.cc.Invoke(ctx, Handling_RegisterContainerHandlingEvent_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *handlingClient) IngestEdifact(ctx context.Context, in *IngestEdifactRequest, opts ...grpc.CallOption) (*IngestEdifactResponse, error) {
+	out := new(IngestEdifactResponse)
+	err := c.cc.Invoke(ctx, Handling_IngestEdifact_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HandlingServer is the server API for Handling service.
+// All implementations must embed UnimplementedHandlingServer
+// for forward compatibility
+type HandlingServer interface {
+	RegisterHandlingEvent(context.Context, *RegisterHandlingEventRequest) (*RegisterHandlingEventResponse, error)
+	// RegisterContainerHandlingEvent registers the event for every cargo in
+	// the container.
+	RegisterContainerHandlingEvent(context.Context, *RegisterContainerHandlingEventRequest) (*RegisterContainerHandlingEventResponse, error)
+	// IngestEdifact registers the handling events reported by the IFTSTA
+	// and CODECO messages of an EDIFACT interchange, message by message.
+	IngestEdifact(context.Context, *IngestEdifactRequest) (*IngestEdifactResponse, error)
+	mustEmbedUnimplementedHandlingServer()
+}
+
+// UnimplementedHandlingServer must be embedded to have forward compatible implementations.
+type UnimplementedHandlingServer struct {
+}
+
+func (UnimplementedHandlingServer) RegisterHandlingEvent(context.Context, *RegisterHandlingEventRequest) (*RegisterHandlingEventResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterHandlingEvent not implemented")
+}
+func (UnimplementedHandlingServer) RegisterContainerHandlingEvent(context.Context, *RegisterContainerHandlingEventRequest) (*RegisterContainerHandlingEventResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterContainerHandlingEvent not implemented")
+}
+func (UnimplementedHandlingServer) IngestEdifact(context.Context, *IngestEdifactRequest) (*IngestEdifactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IngestEdifact not implemented")
+}
+func (UnimplementedHandlingServer) mustEmbedUnimplementedHandlingServer() {}
+
+// UnsafeHandlingServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HandlingServer will
+// result in compilation errors.
+type UnsafeHandlingServer interface {
+	mustEmbedUnimplementedHandlingServer()
+}
+
+func RegisterHandlingServer(s grpc.ServiceRegistrar, srv HandlingServer) {
+	s.RegisterService(&Handling_ServiceDesc, srv)
+}
+
+func _Handling_RegisterHandlingEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterHandlingEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandlingServer).RegisterHandlingEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Handling_RegisterHandlingEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandlingServer).RegisterHandlingEvent(ctx, req.(*RegisterHandlingEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Handling_RegisterContainerHandlingEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterContainerHandlingEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandlingServer).RegisterContainerHandlingEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Handling_RegisterContainerHandlingEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandlingServer).RegisterContainerHandlingEvent(ctx, req.(*RegisterContainerHandlingEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Handling_IngestEdifact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IngestEdifactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandlingServer).IngestEdifact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Handling_IngestEdifact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandlingServer).IngestEdifact(ctx, req.(*IngestEdifactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Handling_ServiceDesc is the grpc.ServiceDesc for Handling service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Handling_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.Handling",
+	HandlerType: (*HandlingServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RegisterHandlingEvent",
+			Handler:    _Handling_RegisterHandlingEvent_Handler,
+		},
+		{
+			MethodName: "RegisterContainerHandlingEvent",
+			Handler:    _Handling_RegisterContainerHandlingEvent_Handler,
+		},
+		{
+			MethodName: "IngestEdifact",
+			Handler:    _Handling_IngestEdifact_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "handling_service.proto",
+}
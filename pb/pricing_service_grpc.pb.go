@@ -0,0 +1,117 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: pricing_service.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Pricing_Quote_FullMethodName = "/pb.Pricing/Quote"
+)
+
+// PricingClient is the client API for Pricing service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PricingClient interface {
+	// Quote prices the itineraries found on the scheduled voyages for the
+	// route specification and attributes. The quote is stored and one of its
+	// options is accepted by booking the cargo with quote_id and
+	// quote_option before the quote expires.
+	Quote(ctx context.Context, in *QuoteRequest, opts ...grpc.CallOption) (*QuoteResponse, error)
+}
+
+type pricingClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPricingClient(cc grpc.ClientConnInterface) PricingClient {
+	return &pricingClient{cc}
+}
+
+func (c *pricingClient) Quote(ctx context.Context, in *QuoteRequest, opts ...grpc.CallOption) (*QuoteResponse, error) {
+	out := new(QuoteResponse)
+	err := c.cc.Invoke(ctx, Pricing_Quote_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PricingServer is the server API for Pricing service.
+// All implementations must embed UnimplementedPricingServer
+// for forward compatibility
+type PricingServer interface {
+	// Quote prices the itineraries found on the scheduled voyages for the
+	// route specification and attributes. The quote is stored and one of its
+	// options is accepted by booking the cargo with quote_id and
+	// quote_option before the quote expires.
+	Quote(context.Context, *QuoteRequest) (*QuoteResponse, error)
+	mustEmbedUnimplementedPricingServer()
+}
+
+// UnimplementedPricingServer must be embedded to have forward compatible implementations.
+type UnimplementedPricingServer struct {
+}
+
+func (UnimplementedPricingServer) Quote(context.Context, *QuoteRequest) (*QuoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Quote not implemented")
+}
+func (UnimplementedPricingServer) mustEmbedUnimplementedPricingServer() {}
+
+// UnsafePricingServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PricingServer will
+// result in compilation errors.
+type UnsafePricingServer interface {
+	mustEmbedUnimplementedPricingServer()
+}
+
+func RegisterPricingServer(s grpc.ServiceRegistrar, srv PricingServer) {
+	s.RegisterService(&Pricing_ServiceDesc, srv)
+}
+
+func _Pricing_Quote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PricingServer).Quote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Pricing_Quote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PricingServer).Quote(ctx, req.(*QuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Pricing_ServiceDesc is the grpc.ServiceDesc for Pricing service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Pricing_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.Pricing",
+	HandlerType: (*PricingServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Quote",
+			Handler:    _Pricing_Quote_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pricing_service.proto",
+}
@@ -0,0 +1,265 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: shipment_service.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Shipment_CreateShipment_FullMethodName        = "/pb.Shipment/CreateShipment"
+	Shipment_LoadShipment_FullMethodName          = "/pb.Shipment/LoadShipment"
+	Shipment_StuffCargo_FullMethodName            = "/pb.Shipment/StuffCargo"
+	Shipment_UnstuffCargo_FullMethodName          = "/pb.Shipment/UnstuffCargo"
+	Shipment_AssignShipmentToRoute_FullMethodName = "/pb.Shipment/AssignShipmentToRoute"
+)
+
+// ShipmentClient is the client API for Shipment service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ShipmentClient interface {
+	CreateShipment(ctx context.Context, in *CreateShipmentRequest, opts ...grpc.CallOption) (*CreateShipmentResponse, error)
+	LoadShipment(ctx context.Context, in *LoadShipmentRequest, opts ...grpc.CallOption) (*LoadShipmentResponse, error)
+	// StuffCargo packs a cargo into a container. The cargo must go from the
+	// origin to the destination of the shipment and takes its itinerary
+	// when the shipment is routed.
+	StuffCargo(ctx context.Context, in *StuffCargoRequest, opts ...grpc.CallOption) (*StuffCargoResponse, error)
+	UnstuffCargo(ctx context.Context, in *UnstuffCargoRequest, opts ...grpc.CallOption) (*UnstuffCargoResponse, error)
+	// AssignShipmentToRoute routes the shipment and every cargo in it.
+	AssignShipmentToRoute(ctx context.Context, in *AssignShipmentToRouteRequest, opts ...grpc.CallOption) (*AssignShipmentToRouteResponse, error)
+}
+
+type shipmentClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewShipmentClient(cc grpc.ClientConnInterface) ShipmentClient {
+	return &shipmentClient{cc}
+}
+
+func (c *shipmentClient) CreateShipment(ctx context.Context, in *CreateShipmentRequest, opts ...grpc.CallOption) (*CreateShipmentResponse, error) {
+	out := new(CreateShipmentResponse)
+	err := c.cc.Invoke(ctx, Shipment_CreateShipment_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipmentClient) LoadShipment(ctx context.Context, in *LoadShipmentRequest, opts ...grpc.CallOption) (*LoadShipmentResponse, error) {
+	out := new(LoadShipmentResponse)
+	err := c.cc.Invoke(ctx, Shipment_LoadShipment_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipmentClient) StuffCargo(ctx context.Context, in *StuffCargoRequest, opts ...grpc.CallOption) (*StuffCargoResponse, error) {
+	out := new(StuffCargoResponse)
+	err := c.cc.Invoke(ctx, Shipment_StuffCargo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipmentClient) UnstuffCargo(ctx context.Context, in *UnstuffCargoRequest, opts ...grpc.CallOption) (*UnstuffCargoResponse, error) {
+	out := new(UnstuffCargoResponse)
+	err := c.cc.Invoke(ctx, Shipment_UnstuffCargo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipmentClient) AssignShipmentToRoute(ctx context.Context, in *AssignShipmentToRouteRequest, opts ...grpc.CallOption) (*AssignShipmentToRouteResponse, error) {
+	out := new(AssignShipmentToRouteResponse)
+	err := c.cc.Invoke(ctx, Shipment_AssignShipmentToRoute_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ShipmentServer is the server API for Shipment service.
+// All implementations must embed UnimplementedShipmentServer
+// for forward compatibility
+type ShipmentServer interface {
+	CreateShipment(context.Context, *CreateShipmentRequest) (*CreateShipmentResponse, error)
+	LoadShipment(context.Context, *LoadShipmentRequest) (*LoadShipmentResponse, error)
+	// StuffCargo packs a cargo into a container. The cargo must go from the
+	// origin to the destination of the shipment and takes its itinerary
+	// when the shipment is routed.
+	StuffCargo(context.Context, *StuffCargoRequest) (*StuffCargoResponse, error)
+	UnstuffCargo(context.Context, *UnstuffCargoRequest) (*UnstuffCargoResponse, error)
+	// AssignShipmentToRoute routes the shipment and every cargo in it.
+	AssignShipmentToRoute(context.Context, *AssignShipmentToRouteRequest) (*AssignShipmentToRouteResponse, error)
+	mustEmbedUnimplementedShipmentServer()
+}
+
+// UnimplementedShipmentServer must be embedded to have forward compatible implementations.
+type UnimplementedShipmentServer struct {
+}
+
+func (UnimplementedShipmentServer) CreateShipment(context.Context, *CreateShipmentRequest) (*CreateShipmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateShipment not implemented")
+}
+func (UnimplementedShipmentServer) LoadShipment(context.Context, *LoadShipmentRequest) (*LoadShipmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoadShipment not implemented")
+}
+func (UnimplementedShipmentServer) StuffCargo(context.Context, *StuffCargoRequest) (*StuffCargoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StuffCargo not implemented")
+}
+func (UnimplementedShipmentServer) UnstuffCargo(context.Context, *UnstuffCargoRequest) (*UnstuffCargoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnstuffCargo not implemented")
+}
+func (UnimplementedShipmentServer) AssignShipmentToRoute(context.Context, *AssignShipmentToRouteRequest) (*AssignShipmentToRouteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignShipmentToRoute not implemented")
+}
+func (UnimplementedShipmentServer) mustEmbedUnimplementedShipmentServer() {}
+
+// UnsafeShipmentServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ShipmentServer will
+// result in compilation errors.
+type UnsafeShipmentServer interface {
+	mustEmbedUnimplementedShipmentServer()
+}
+
+func RegisterShipmentServer(s grpc.ServiceRegistrar, srv ShipmentServer) {
+	s.RegisterService(&Shipment_ServiceDesc, srv)
+}
+
+func _Shipment_CreateShipment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateShipmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServer).CreateShipment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Shipment_CreateShipment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServer).CreateShipment(ctx, req.(*CreateShipmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Shipment_LoadShipment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoadShipmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServer).LoadShipment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Shipment_LoadShipment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServer).LoadShipment(ctx, req.(*LoadShipmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Shipment_StuffCargo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StuffCargoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServer).StuffCargo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Shipment_StuffCargo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServer).StuffCargo(ctx, req.(*StuffCargoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Shipment_UnstuffCargo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnstuffCargoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServer).UnstuffCargo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Shipment_UnstuffCargo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServer).UnstuffCargo(ctx, req.(*UnstuffCargoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Shipment_AssignShipmentToRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignShipmentToRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServer).AssignShipmentToRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Shipment_AssignShipmentToRoute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServer).AssignShipmentToRoute(ctx, req.(*AssignShipmentToRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Shipment_ServiceDesc is the grpc.ServiceDesc for Shipment service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Shipment_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.Shipment",
+	HandlerType: (*ShipmentServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateShipment",
+			Handler:    _Shipment_CreateShipment_Handler,
+		},
+		{
+			MethodName: "LoadShipment",
+			Handler:    _Shipment_LoadShipment_Handler,
+		},
+		{
+			MethodName: "StuffCargo",
+			Handler:    _Shipment_StuffCargo_Handler,
+		},
+		{
+			MethodName: "UnstuffCargo",
+			Handler:    _Shipment_UnstuffCargo_Handler,
+		},
+		{
+			MethodName: "AssignShipmentToRoute",
+			Handler:    _Shipment_AssignShipmentToRoute_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "shipment_service.proto",
+}
@@ -0,0 +1,69 @@
+package pricing
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+)
+
+// Repository decorators below record the latency of every query, labeled by
+// repository, method and outcome.
+
+func observeQuery(latency metrics.Histogram, repository, method string, begin time.Time, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+
+	latency.With("repository", repository, "method", method, "outcome", outcome).Observe(time.Since(begin).Seconds())
+}
+
+type instrumentingTariffRepository struct {
+	latency metrics.Histogram
+	TariffRepositoryContract
+}
+
+// NewInstrumentingTariffRepository returns a tariff repository that records
+// query latency.
+func NewInstrumentingTariffRepository(latency metrics.Histogram, r TariffRepositoryContract) TariffRepositoryContract {
+	return &instrumentingTariffRepository{latency: latency, TariffRepositoryContract: r}
+}
+
+func (r *instrumentingTariffRepository) Store(ctx context.Context, dbtx db.DBTX, t Tariff) (err error) {
+	defer func(begin time.Time) { observeQuery(r.latency, "tariff", "store", begin, err) }(time.Now())
+	return r.TariffRepositoryContract.Store(ctx, dbtx, t)
+}
+
+func (r *instrumentingTariffRepository) FindAll(ctx context.Context, dbtx db.DBTX) (tariffs TariffTable, err error) {
+	defer func(begin time.Time) { observeQuery(r.latency, "tariff", "find_all", begin, err) }(time.Now())
+	return r.TariffRepositoryContract.FindAll(ctx, dbtx)
+}
+
+type instrumentingQuoteRepository struct {
+	latency metrics.Histogram
+	QuoteRepositoryContract
+}
+
+// NewInstrumentingQuoteRepository returns a quote repository that records
+// query latency.
+func NewInstrumentingQuoteRepository(latency metrics.Histogram, r QuoteRepositoryContract) QuoteRepositoryContract {
+	return &instrumentingQuoteRepository{latency: latency, QuoteRepositoryContract: r}
+}
+
+func (r *instrumentingQuoteRepository) Store(ctx context.Context, dbtx db.DBTX, q *Quote) (err error) {
+	defer func(begin time.Time) { observeQuery(r.latency, "quote", "store", begin, err) }(time.Now())
+	return r.QuoteRepositoryContract.Store(ctx, dbtx, q)
+}
+
+func (r *instrumentingQuoteRepository) Find(ctx context.Context, dbtx db.DBTX, id QuoteID) (q *Quote, err error) {
+	defer func(begin time.Time) { observeQuery(r.latency, "quote", "find", begin, err) }(time.Now())
+	return r.QuoteRepositoryContract.Find(ctx, dbtx, id)
+}
+
+func (r *instrumentingQuoteRepository) FindByCargo(ctx context.Context, dbtx db.DBTX, id cargo.TrackingID) (q *Quote, err error) {
+	defer func(begin time.Time) { observeQuery(r.latency, "quote", "find_by_cargo", begin, err) }(time.Now())
+	return r.QuoteRepositoryContract.FindByCargo(ctx, dbtx, id)
+}
@@ -0,0 +1,39 @@
+package pricing
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/config"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+)
+
+var (
+	dbTest       *sql.DB
+	cargoTest    cargo.CargoRepositoryContract
+	customerTest customer.CustomerRepositoryContract
+	tariffTest   TariffRepositoryContract
+	quoteTest    QuoteRepositoryContract
+)
+
+func TestMain(m *testing.M) {
+	env := config.Environment{
+		DBUsername: "postgres",
+		DBPassword: "ligmaballs",
+		DBHost:     "localhost",
+		DBPort:     "5432",
+		DBName:     "grpc_shipping",
+	}
+
+	dbTest, _ = db.NewPostgreSQL(env).Connect()
+
+	cargoTest = cargo.NewCargoRepository(cargo.NewItineraryRepository(), cargo.NewDeliveryRepository())
+	customerTest = customer.NewCustomerRepository()
+	tariffTest = TariffRepository{}
+	quoteTest = QuoteRepository{}
+
+	os.Exit(m.Run())
+}
@@ -0,0 +1,220 @@
+// Package pricing prices the transport of cargos from tariff tables and
+// keeps the quotes given to customers before they book.
+package pricing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+	"github.com/mproyyan/grpc-shipping-microservice/location"
+	"github.com/mproyyan/grpc-shipping-microservice/voyage"
+)
+
+var (
+	// ErrNoTariff is returned when pricing a leg no tariff applies to.
+	ErrNoTariff = errors.New("no tariff applies to the leg")
+
+	// ErrCurrencyMismatch is returned when the legs of an itinerary are
+	// priced in different currencies.
+	ErrCurrencyMismatch = errors.New("itinerary legs are priced in different currencies")
+)
+
+// Money is an amount in the minor unit of its ISO 4217 currency, e.g.
+// cents of EUR.
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// String formats the amount with two decimals, e.g. 1250.00 EUR.
+func (m Money) String() string {
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+
+	return fmt.Sprintf("%s%d.%02d %s", sign, amount/100, amount%100, m.Currency)
+}
+
+// Tariff is the price of a leg on a lane, on a voyage or both. An empty
+// voyage number, origin or destination matches every leg. Amounts are in
+// the minor unit of the currency, the weight rate applies per tonne.
+type Tariff struct {
+	VoyageNumber  voyage.Number
+	Origin        location.UNLocode
+	Destination   location.UNLocode
+	Currency      string
+	Base          int64
+	PerTonne      int64
+	PerCubicMetre int64
+}
+
+// Matches reports whether the tariff applies to the leg.
+func (t Tariff) Matches(leg cargo.Leg) bool {
+	return (t.VoyageNumber == "" || t.VoyageNumber == leg.VoyageNumber) &&
+		(t.Origin == "" || t.Origin == leg.LoadLocation) &&
+		(t.Destination == "" || t.Destination == leg.UnloadLocation)
+}
+
+// Price returns the price of carrying a cargo with the given attributes.
+func (t Tariff) Price(attributes cargo.Attributes) int64 {
+	return t.Base +
+		int64(math.Round(float64(t.PerTonne)*attributes.Weight/1000)) +
+		int64(math.Round(float64(t.PerCubicMetre)*attributes.Volume))
+}
+
+// specificity ranks tariffs: a voyage tariff wins over a lane tariff, and
+// naming both ends of the lane wins over naming one.
+func (t Tariff) specificity() int {
+	n := 0
+	if t.VoyageNumber != "" {
+		n += 4
+	}
+	if t.Origin != "" {
+		n++
+	}
+	if t.Destination != "" {
+		n++
+	}
+
+	return n
+}
+
+// TariffTable holds every tariff known.
+type TariffTable []Tariff
+
+// Lookup returns the most specific tariff matching the leg.
+func (tt TariffTable) Lookup(leg cargo.Leg) (Tariff, bool) {
+	best, found := Tariff{}, false
+	for _, t := range tt {
+		if t.Matches(leg) && (!found || t.specificity() > best.specificity()) {
+			best, found = t, true
+		}
+	}
+
+	return best, found
+}
+
+// Surcharges are added on top of the freight of an itinerary, as a
+// percentage of it. A deadline is tight when the itinerary arrives less
+// than TightDeadlineSlack before it.
+type Surcharges struct {
+	DangerousGoods     float64
+	TightDeadline      float64
+	TightDeadlineSlack time.Duration
+}
+
+// Charge is a line of a price.
+type Charge struct {
+	Description string `json:"description"`
+	Amount      int64  `json:"amount"`
+}
+
+// Option is a priced itinerary. Charges break its price down per leg and
+// per surcharge.
+type Option struct {
+	Itinerary cargo.Itinerary `json:"itinerary"`
+	Price     Money           `json:"price"`
+	Charges   []Charge        `json:"charges"`
+}
+
+// Price prices the itinerary for a cargo with the given attributes and
+// arrival deadline.
+func Price(itinerary cargo.Itinerary, attributes cargo.Attributes, deadline time.Time, tariffs TariffTable, surcharges Surcharges) (Option, error) {
+	o := Option{Itinerary: itinerary}
+	for _, leg := range itinerary.Legs {
+		t, ok := tariffs.Lookup(leg)
+		if !ok {
+			return Option{}, ErrNoTariff
+		}
+
+		if o.Price.Currency == "" {
+			o.Price.Currency = t.Currency
+		}
+		if t.Currency != o.Price.Currency {
+			return Option{}, ErrCurrencyMismatch
+		}
+
+		o.charge(fmt.Sprintf("Voyage %s from %s to %s", leg.VoyageNumber, leg.LoadLocation, leg.UnloadLocation), t.Price(attributes))
+	}
+
+	freight := o.Price.Amount
+	if attributes.DangerousGoods.IsDangerous() && surcharges.DangerousGoods > 0 {
+		o.charge(fmt.Sprintf("Dangerous goods surcharge (%g%%)", surcharges.DangerousGoods), percent(freight, surcharges.DangerousGoods))
+	}
+
+	if !deadline.IsZero() && deadline.Sub(itinerary.FinalArrivalTime()) < surcharges.TightDeadlineSlack && surcharges.TightDeadline > 0 {
+		o.charge(fmt.Sprintf("Tight deadline surcharge (%g%%)", surcharges.TightDeadline), percent(freight, surcharges.TightDeadline))
+	}
+
+	return o, nil
+}
+
+func (o *Option) charge(description string, amount int64) {
+	o.Charges = append(o.Charges, Charge{Description: description, Amount: amount})
+	o.Price.Amount += amount
+}
+
+func percent(amount int64, p float64) int64 {
+	return int64(math.Round(float64(amount) * p / 100))
+}
+
+type TariffRepositoryContract interface {
+	Store(ctx context.Context, dbtx db.DBTX, t Tariff) error
+	FindAll(ctx context.Context, dbtx db.DBTX) (TariffTable, error)
+}
+
+type TariffRepository struct {
+}
+
+func NewTariffRepository() TariffRepository {
+	return TariffRepository{}
+}
+
+// Store creates the tariff or replaces the rates of the tariff with the
+// same voyage and lane.
+func (tr TariffRepository) Store(ctx context.Context, dbtx db.DBTX, t Tariff) error {
+	query := `
+		INSERT INTO tariffs (voyage_number, origin, destination, currency, base_amount, per_tonne, per_cubic_metre)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (voyage_number, origin, destination) DO UPDATE SET
+			currency = EXCLUDED.currency,
+			base_amount = EXCLUDED.base_amount,
+			per_tonne = EXCLUDED.per_tonne,
+			per_cubic_metre = EXCLUDED.per_cubic_metre
+	`
+
+	_, err := dbtx.ExecContext(ctx, query, t.VoyageNumber, t.Origin, t.Destination, t.Currency, t.Base, t.PerTonne, t.PerCubicMetre)
+	return err
+}
+
+func (tr TariffRepository) FindAll(ctx context.Context, dbtx db.DBTX) (TariffTable, error) {
+	query := `
+		SELECT voyage_number, origin, destination, currency, base_amount, per_tonne, per_cubic_metre
+		FROM tariffs ORDER BY voyage_number, origin, destination
+	`
+
+	rows, err := dbtx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tariffs TariffTable
+	for rows.Next() {
+		var t Tariff
+		if err := rows.Scan(&t.VoyageNumber, &t.Origin, &t.Destination, &t.Currency, &t.Base, &t.PerTonne, &t.PerCubicMetre); err != nil {
+			return nil, err
+		}
+
+		tariffs = append(tariffs, t)
+	}
+
+	return tariffs, rows.Err()
+}
@@ -0,0 +1,96 @@
+package pricing
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/imdg"
+	"github.com/mproyyan/grpc-shipping-microservice/location"
+	"github.com/mproyyan/grpc-shipping-microservice/voyage"
+	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoneyString(t *testing.T) {
+	require.Equal(t, "1250.05 EUR", Money{Amount: 125005, Currency: "EUR"}.String())
+	require.Equal(t, "-0.50 USD", Money{Amount: -50, Currency: "USD"}.String())
+}
+
+func TestTariffTableLookup(t *testing.T) {
+	tariffs := TariffTable{
+		{Origin: "SESTO", Currency: "EUR", Base: 1},
+		{Origin: "SESTO", Destination: "AUMEL", Currency: "EUR", Base: 2},
+		{VoyageNumber: "V100", Currency: "EUR", Base: 3},
+		{VoyageNumber: "V100", Origin: "SESTO", Destination: "AUMEL", Currency: "EUR", Base: 4},
+	}
+
+	lookup := func(n voyage.Number, from, to string) int64 {
+		tariff, ok := tariffs.Lookup(cargo.Leg{VoyageNumber: n, LoadLocation: location.UNLocode(from), UnloadLocation: location.UNLocode(to)})
+		require.True(t, ok)
+		return tariff.Base
+	}
+
+	require.Equal(t, int64(1), lookup("V200", "SESTO", "DEHAM"))
+	require.Equal(t, int64(2), lookup("V200", "SESTO", "AUMEL"))
+	require.Equal(t, int64(3), lookup("V100", "DEHAM", "AUMEL"))
+	require.Equal(t, int64(4), lookup("V100", "SESTO", "AUMEL"))
+
+	_, ok := tariffs.Lookup(cargo.Leg{VoyageNumber: "V200", LoadLocation: "DEHAM", UnloadLocation: "AUMEL"})
+	require.False(t, ok)
+}
+
+func TestPrice(t *testing.T) {
+	arrival := time.Date(2030, 1, 20, 0, 0, 0, 0, time.UTC)
+	itinerary := cargo.Itinerary{Legs: []cargo.Leg{
+		{VoyageNumber: "V200", LoadLocation: "SESTO", UnloadLocation: "NLRTM", UnloadTime: arrival.Add(-10 * 24 * time.Hour)},
+		{VoyageNumber: "V300", LoadLocation: "NLRTM", UnloadLocation: "AUMEL", UnloadTime: arrival},
+	}}
+	tariffs := TariffTable{
+		{Origin: "SESTO", Destination: "NLRTM", Currency: "EUR", Base: 10000, PerTonne: 5000},
+		{VoyageNumber: "V300", Currency: "EUR", Base: 50000, PerCubicMetre: 2000},
+	}
+	surcharges := Surcharges{DangerousGoods: 25, TightDeadline: 10, TightDeadlineSlack: 48 * time.Hour}
+	attributes := cargo.Attributes{Weight: 2000, Volume: 5}
+
+	o, err := Price(itinerary, attributes, arrival.Add(72*time.Hour), tariffs, surcharges)
+	require.NoError(t, err)
+	require.Equal(t, Money{Amount: 20000 + 60000, Currency: "EUR"}, o.Price)
+	require.Len(t, o.Charges, 2)
+	require.Equal(t, "Voyage V200 from SESTO to NLRTM", o.Charges[0].Description)
+
+	attributes.DangerousGoods = imdg.Goods{Class: "3", UNNumber: "1203"}
+	o, err = Price(itinerary, attributes, arrival.Add(24*time.Hour), tariffs, surcharges)
+	require.NoError(t, err)
+	require.Equal(t, []Charge{
+		{Description: "Voyage V200 from SESTO to NLRTM", Amount: 20000},
+		{Description: "Voyage V300 from NLRTM to AUMEL", Amount: 60000},
+		{Description: "Dangerous goods surcharge (25%)", Amount: 20000},
+		{Description: "Tight deadline surcharge (10%)", Amount: 8000},
+	}, o.Charges)
+	require.Equal(t, int64(108000), o.Price.Amount)
+
+	tariffs[1].Currency = "USD"
+	_, err = Price(itinerary, attributes, time.Time{}, tariffs, surcharges)
+	require.Equal(t, ErrCurrencyMismatch, err)
+
+	_, err = Price(itinerary, attributes, time.Time{}, tariffs[:1], surcharges)
+	require.Equal(t, ErrNoTariff, err)
+}
+
+func TestStoreTariff(t *testing.T) {
+	ctx := context.Background()
+	n := voyage.Number(strings.ToUpper(uuid.New())[:8])
+
+	require.NoError(t, tariffTest.Store(ctx, dbTest, Tariff{VoyageNumber: n, Currency: "EUR", Base: 100}))
+	require.NoError(t, tariffTest.Store(ctx, dbTest, Tariff{VoyageNumber: n, Currency: "EUR", Base: 200, PerTonne: 10}))
+
+	tariffs, err := tariffTest.FindAll(ctx, dbTest)
+	require.NoError(t, err)
+
+	found, ok := tariffs.Lookup(cargo.Leg{VoyageNumber: n, LoadLocation: "SESTO", UnloadLocation: "AUMEL"})
+	require.True(t, ok)
+	require.Equal(t, Tariff{VoyageNumber: n, Currency: "EUR", Base: 200, PerTonne: 10}, found)
+}
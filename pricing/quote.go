@@ -0,0 +1,216 @@
+package pricing
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+	"github.com/pborman/uuid"
+)
+
+var (
+	// ErrUnknownQuote is used when a quote could not be found.
+	ErrUnknownQuote = errors.New("unknown quote")
+
+	// ErrQuoteExpired is returned when accepting a quote after it expired.
+	ErrQuoteExpired = errors.New("quote has expired")
+
+	// ErrQuoteAccepted is returned when accepting a quote a cargo was
+	// already booked from.
+	ErrQuoteAccepted = errors.New("quote has already been accepted")
+
+	// ErrQuoteMismatch is returned when booking a cargo from a quote given
+	// for another route specification or other attributes.
+	ErrQuoteMismatch = errors.New("booking does not match the quote")
+
+	// ErrUnknownOption is returned when accepting an option the quote does
+	// not have.
+	ErrUnknownOption = errors.New("quote has no such option")
+
+	// ErrNoRoute is returned when no priced itinerary satisfies the route
+	// specification.
+	ErrNoRoute = errors.New("no priced itinerary satisfies the route specification")
+)
+
+// DefaultValidity is how long a quote can be accepted unless configured
+// otherwise.
+const DefaultValidity = 24 * time.Hour
+
+// QuoteID uniquely identifies a particular quote.
+type QuoteID string
+
+// NextQuoteID generates a new quote ID.
+func NextQuoteID() QuoteID {
+	return QuoteID(strings.Split(strings.ToUpper(uuid.New()), "-")[0])
+}
+
+// Quote holds the options priced for a customer, valid until it expires.
+// Only the attributes the price depends on are kept. Once a cargo is
+// booked from the quote, TrackingID and AcceptedOption tie the agreed
+// price to it.
+type Quote struct {
+	ID                 QuoteID
+	CustomerID         customer.ID
+	RouteSpecification cargo.RouteSpecification
+	Attributes         cargo.Attributes
+	Options            []Option
+	Created            time.Time
+	Expires            time.Time
+	TrackingID         cargo.TrackingID
+	AcceptedOption     int
+}
+
+// NewQuote creates a quote valid from now on for the given duration.
+func NewQuote(id QuoteID, customerID customer.ID, rs cargo.RouteSpecification, attributes cargo.Attributes, options []Option, now time.Time, validity time.Duration) *Quote {
+	return &Quote{
+		ID:                 id,
+		CustomerID:         customerID,
+		RouteSpecification: rs,
+		Attributes:         priced(attributes),
+		Options:            options,
+		Created:            now,
+		Expires:            now.Add(validity),
+	}
+}
+
+// Accept books the cargo at the price of the given option. The cargo must
+// have the route specification and the attributes that were quoted.
+func (q *Quote) Accept(id cargo.TrackingID, option int, rs cargo.RouteSpecification, attributes cargo.Attributes, now time.Time) error {
+	switch {
+	case q.TrackingID != "":
+		return ErrQuoteAccepted
+	case !now.Before(q.Expires):
+		return ErrQuoteExpired
+	case option < 0 || option >= len(q.Options):
+		return ErrUnknownOption
+	case !q.covers(rs, attributes):
+		return ErrQuoteMismatch
+	}
+
+	q.TrackingID = id
+	q.AcceptedOption = option
+	return nil
+}
+
+// Agreed returns the option a cargo was booked at.
+func (q Quote) Agreed() (Option, bool) {
+	if q.TrackingID == "" {
+		return Option{}, false
+	}
+
+	return q.Options[q.AcceptedOption], true
+}
+
+func (q Quote) covers(rs cargo.RouteSpecification, attributes cargo.Attributes) bool {
+	quoted := q.RouteSpecification
+	return rs.Origin == quoted.Origin &&
+		rs.Destination == quoted.Destination &&
+		rs.ArrivalDeadline.Truncate(time.Second).Equal(quoted.ArrivalDeadline.Truncate(time.Second)) &&
+		priced(attributes) == q.Attributes
+}
+
+// priced keeps the attributes prices depend on.
+func priced(a cargo.Attributes) cargo.Attributes {
+	return cargo.Attributes{Weight: a.Weight, Volume: a.Volume, DangerousGoods: a.DangerousGoods}
+}
+
+// Acceptance picks the option of a quote a cargo is booked at. The zero
+// value books without a quote.
+type Acceptance struct {
+	QuoteID QuoteID
+	Option  int
+}
+
+type QuoteRepositoryContract interface {
+	Store(ctx context.Context, dbtx db.DBTX, q *Quote) error
+	Find(ctx context.Context, dbtx db.DBTX, id QuoteID) (*Quote, error)
+	FindByCargo(ctx context.Context, dbtx db.DBTX, id cargo.TrackingID) (*Quote, error)
+}
+
+type QuoteRepository struct {
+}
+
+func NewQuoteRepository() QuoteRepository {
+	return QuoteRepository{}
+}
+
+// Store creates the quote or records its acceptance, the options of a
+// quote never change.
+func (qr QuoteRepository) Store(ctx context.Context, dbtx db.DBTX, q *Quote) error {
+	options, err := json.Marshal(q.Options)
+	if err != nil {
+		return err
+	}
+
+	var trackingID sql.NullString
+	if q.TrackingID != "" {
+		trackingID = sql.NullString{String: string(q.TrackingID), Valid: true}
+	}
+
+	query := `
+		INSERT INTO quotes (
+			id, customer_id, origin, destination, arrival_deadline, weight, volume, imdg_class, un_number,
+			options, created_at, expires_at, tracking_id, accepted_option
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		ON CONFLICT (id) DO UPDATE SET tracking_id = EXCLUDED.tracking_id, accepted_option = EXCLUDED.accepted_option
+	`
+
+	rs, a := q.RouteSpecification, q.Attributes
+	_, err = dbtx.ExecContext(ctx, query,
+		q.ID, q.CustomerID, rs.Origin, rs.Destination, rs.ArrivalDeadline, a.Weight, a.Volume, a.DangerousGoods.Class, a.DangerousGoods.UNNumber,
+		string(options), q.Created, q.Expires, trackingID, q.AcceptedOption,
+	)
+
+	return err
+}
+
+// Find returns the quote. Within a transaction it stays locked until the
+// transaction ends, so that it is accepted only once.
+func (qr QuoteRepository) Find(ctx context.Context, dbtx db.DBTX, id QuoteID) (*Quote, error) {
+	return qr.find(ctx, dbtx, "id = $1 FOR UPDATE", id)
+}
+
+// FindByCargo returns the quote the cargo was booked from.
+func (qr QuoteRepository) FindByCargo(ctx context.Context, dbtx db.DBTX, id cargo.TrackingID) (*Quote, error) {
+	return qr.find(ctx, dbtx, "tracking_id = $1", id)
+}
+
+func (qr QuoteRepository) find(ctx context.Context, dbtx db.DBTX, where string, arg interface{}) (*Quote, error) {
+	query := `
+		SELECT id, customer_id, origin, destination, arrival_deadline, weight, volume, imdg_class, un_number,
+			options, created_at, expires_at, tracking_id, accepted_option
+		FROM quotes WHERE ` + where
+
+	var (
+		q          Quote
+		options    string
+		trackingID sql.NullString
+	)
+
+	rs, a := &q.RouteSpecification, &q.Attributes
+	err := dbtx.QueryRowContext(ctx, query, arg).Scan(
+		&q.ID, &q.CustomerID, &rs.Origin, &rs.Destination, &rs.ArrivalDeadline, &a.Weight, &a.Volume, &a.DangerousGoods.Class, &a.DangerousGoods.UNNumber,
+		&options, &q.Created, &q.Expires, &trackingID, &q.AcceptedOption,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrUnknownQuote
+		}
+
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(options), &q.Options); err != nil {
+		return nil, err
+	}
+
+	q.TrackingID = cargo.TrackingID(trackingID.String)
+	return &q, nil
+}
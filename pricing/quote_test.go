@@ -0,0 +1,81 @@
+package pricing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/imdg"
+	"github.com/stretchr/testify/require"
+)
+
+func newQuote(now time.Time) *Quote {
+	rs := cargo.RouteSpecification{Origin: "SESTO", Destination: "AUMEL", ArrivalDeadline: now.Add(30 * 24 * time.Hour)}
+	options := []Option{{
+		Itinerary: cargo.Itinerary{Legs: []cargo.Leg{{VoyageNumber: "V100", LoadLocation: "SESTO", UnloadLocation: "AUMEL", LoadTime: now, UnloadTime: now.Add(time.Hour)}}},
+		Price:     Money{Amount: 125000, Currency: "EUR"},
+		Charges:   []Charge{{Description: "Voyage V100 from SESTO to AUMEL", Amount: 125000}},
+	}}
+
+	return NewQuote(NextQuoteID(), "ACME", rs, cargo.Attributes{Weight: 1200, Commodity: "coffee"}, options, now, time.Hour)
+}
+
+func TestAcceptQuote(t *testing.T) {
+	now := time.Now()
+	q := newQuote(now)
+	rs := q.RouteSpecification
+	require.Equal(t, cargo.Attributes{Weight: 1200}, q.Attributes)
+
+	_, ok := q.Agreed()
+	require.False(t, ok)
+
+	require.Equal(t, ErrQuoteExpired, q.Accept("ABC", 0, rs, q.Attributes, now.Add(time.Hour)))
+	require.Equal(t, ErrUnknownOption, q.Accept("ABC", 1, rs, q.Attributes, now))
+	require.Equal(t, ErrQuoteMismatch, q.Accept("ABC", 0, rs, cargo.Attributes{Weight: 1200, DangerousGoods: imdg.Goods{Class: "3"}}, now))
+
+	other := rs
+	other.ArrivalDeadline = other.ArrivalDeadline.Add(time.Hour)
+	require.Equal(t, ErrQuoteMismatch, q.Accept("ABC", 0, other, q.Attributes, now))
+
+	// attributes the price does not depend on may be given at booking
+	require.NoError(t, q.Accept("ABC", 0, rs, cargo.Attributes{Weight: 1200, Packages: 3}, now))
+	agreed, ok := q.Agreed()
+	require.True(t, ok)
+	require.Equal(t, Money{Amount: 125000, Currency: "EUR"}, agreed.Price)
+
+	require.Equal(t, ErrQuoteAccepted, q.Accept("DEF", 0, rs, q.Attributes, now))
+}
+
+func TestStoreQuote(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	c, err := customerTest.Upsert(ctx, dbTest, customer.New(customer.NextID(), "Acme", customer.Contact{}, customer.NotificationPreferences{}))
+	require.NoError(t, err)
+
+	q := newQuote(now)
+	q.CustomerID = c.ID
+	require.NoError(t, quoteTest.Store(ctx, dbTest, q))
+
+	found, err := quoteTest.Find(ctx, dbTest, q.ID)
+	require.NoError(t, err)
+	require.Equal(t, q.Attributes, found.Attributes)
+	require.Equal(t, q.Options[0].Price, found.Options[0].Price)
+	require.True(t, q.Expires.Equal(found.Expires))
+	require.Empty(t, found.TrackingID)
+
+	booked, err := cargoTest.Upsert(ctx, dbTest, cargo.New(cargo.NextTrackingID(), q.RouteSpecification))
+	require.NoError(t, err)
+
+	require.NoError(t, found.Accept(booked.TrackingID, 0, q.RouteSpecification, q.Attributes, now))
+	require.NoError(t, quoteTest.Store(ctx, dbTest, found))
+
+	accepted, err := quoteTest.FindByCargo(ctx, dbTest, booked.TrackingID)
+	require.NoError(t, err)
+	require.Equal(t, q.ID, accepted.ID)
+
+	_, err = quoteTest.Find(ctx, dbTest, "NOPE")
+	require.Equal(t, ErrUnknownQuote, err)
+}
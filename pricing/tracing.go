@@ -0,0 +1,72 @@
+package pricing
+
+import (
+	"context"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+	"github.com/mproyyan/grpc-shipping-microservice/tracing"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Repository decorators below wrap every call in a client span.
+
+var tracer = tracing.Tracer("github.com/mproyyan/grpc-shipping-microservice/pricing")
+
+func startQuerySpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemPostgreSQL),
+	)
+}
+
+type tracingTariffRepository struct {
+	TariffRepositoryContract
+}
+
+// NewTracingTariffRepository returns a tariff repository that wraps every
+// query in a client span.
+func NewTracingTariffRepository(r TariffRepositoryContract) TariffRepositoryContract {
+	return &tracingTariffRepository{TariffRepositoryContract: r}
+}
+
+func (r *tracingTariffRepository) Store(ctx context.Context, dbtx db.DBTX, t Tariff) (err error) {
+	ctx, span := startQuerySpan(ctx, "TariffRepository.Store")
+	defer func() { tracing.End(span, err) }()
+	return r.TariffRepositoryContract.Store(ctx, dbtx, t)
+}
+
+func (r *tracingTariffRepository) FindAll(ctx context.Context, dbtx db.DBTX) (tariffs TariffTable, err error) {
+	ctx, span := startQuerySpan(ctx, "TariffRepository.FindAll")
+	defer func() { tracing.End(span, err) }()
+	return r.TariffRepositoryContract.FindAll(ctx, dbtx)
+}
+
+type tracingQuoteRepository struct {
+	QuoteRepositoryContract
+}
+
+// NewTracingQuoteRepository returns a quote repository that wraps every
+// query in a client span.
+func NewTracingQuoteRepository(r QuoteRepositoryContract) QuoteRepositoryContract {
+	return &tracingQuoteRepository{QuoteRepositoryContract: r}
+}
+
+func (r *tracingQuoteRepository) Store(ctx context.Context, dbtx db.DBTX, q *Quote) (err error) {
+	ctx, span := startQuerySpan(ctx, "QuoteRepository.Store")
+	defer func() { tracing.End(span, err) }()
+	return r.QuoteRepositoryContract.Store(ctx, dbtx, q)
+}
+
+func (r *tracingQuoteRepository) Find(ctx context.Context, dbtx db.DBTX, id QuoteID) (q *Quote, err error) {
+	ctx, span := startQuerySpan(ctx, "QuoteRepository.Find")
+	defer func() { tracing.End(span, err) }()
+	return r.QuoteRepositoryContract.Find(ctx, dbtx, id)
+}
+
+func (r *tracingQuoteRepository) FindByCargo(ctx context.Context, dbtx db.DBTX, id cargo.TrackingID) (q *Quote, err error) {
+	ctx, span := startQuerySpan(ctx, "QuoteRepository.FindByCargo")
+	defer func() { tracing.End(span, err) }()
+	return r.QuoteRepositoryContract.FindByCargo(ctx, dbtx, id)
+}
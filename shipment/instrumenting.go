@@ -0,0 +1,50 @@
+package shipment
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+)
+
+type instrumentingShipmentRepository struct {
+	latency metrics.Histogram
+	ShipmentRepositoryContract
+}
+
+// NewInstrumentingShipmentRepository returns a shipment repository that
+// records query latency, labeled by repository, method and outcome.
+func NewInstrumentingShipmentRepository(latency metrics.Histogram, r ShipmentRepositoryContract) ShipmentRepositoryContract {
+	return &instrumentingShipmentRepository{latency: latency, ShipmentRepositoryContract: r}
+}
+
+func (r *instrumentingShipmentRepository) observe(method string, begin time.Time, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+
+	r.latency.With("repository", "shipment", "method", method, "outcome", outcome).Observe(time.Since(begin).Seconds())
+}
+
+func (r *instrumentingShipmentRepository) Store(ctx context.Context, dbtx db.DBTX, shipment *Shipment) (err error) {
+	defer func(begin time.Time) { r.observe("store", begin, err) }(time.Now())
+	return r.ShipmentRepositoryContract.Store(ctx, dbtx, shipment)
+}
+
+func (r *instrumentingShipmentRepository) Find(ctx context.Context, dbtx db.DBTX, id ID) (s *Shipment, err error) {
+	defer func(begin time.Time) { r.observe("find", begin, err) }(time.Now())
+	return r.ShipmentRepositoryContract.Find(ctx, dbtx, id)
+}
+
+func (r *instrumentingShipmentRepository) FindByContainer(ctx context.Context, dbtx db.DBTX, id ContainerID) (s *Shipment, err error) {
+	defer func(begin time.Time) { r.observe("find_by_container", begin, err) }(time.Now())
+	return r.ShipmentRepositoryContract.FindByContainer(ctx, dbtx, id)
+}
+
+func (r *instrumentingShipmentRepository) FindByCargo(ctx context.Context, dbtx db.DBTX, id cargo.TrackingID) (s *Shipment, err error) {
+	defer func(begin time.Time) { r.observe("find_by_cargo", begin, err) }(time.Now())
+	return r.ShipmentRepositoryContract.FindByCargo(ctx, dbtx, id)
+}
@@ -0,0 +1,34 @@
+package shipment
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/config"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+)
+
+var (
+	dbTest       *sql.DB
+	cargoTest    cargo.CargoRepositoryContract
+	shipmentTest ShipmentRepositoryContract
+)
+
+func TestMain(m *testing.M) {
+	env := config.Environment{
+		DBUsername: "postgres",
+		DBPassword: "ligmaballs",
+		DBHost:     "localhost",
+		DBPort:     "5432",
+		DBName:     "grpc_shipping",
+	}
+
+	dbTest, _ = db.NewPostgreSQL(env).Connect()
+
+	cargoTest = cargo.NewCargoRepository(cargo.NewItineraryRepository(), cargo.NewDeliveryRepository())
+	shipmentTest = ShipmentRepository{}
+
+	os.Exit(m.Run())
+}
@@ -0,0 +1,404 @@
+// Package shipment provides the Shipment aggregate, which consolidates
+// cargos into containers that are routed and handled together.
+package shipment
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+	"github.com/mproyyan/grpc-shipping-microservice/location"
+	"github.com/pborman/uuid"
+)
+
+var (
+	// ErrUnknown is used when a shipment could not be found.
+	ErrUnknown = errors.New("unknown shipment")
+
+	// ErrUnknownContainer is used when a container is not part of any
+	// shipment.
+	ErrUnknownContainer = errors.New("unknown container")
+
+	// ErrContainerInUse is returned when adding a container which is
+	// already part of a shipment.
+	ErrContainerInUse = errors.New("container is already part of a shipment")
+
+	// ErrCapacityExceeded is returned when stuffing a cargo would exceed
+	// the weight or volume a container can take.
+	ErrCapacityExceeded = errors.New("container capacity exceeded")
+
+	// ErrAlreadyStuffed is returned when stuffing a cargo which already is
+	// in a container.
+	ErrAlreadyStuffed = errors.New("cargo is already in a container")
+
+	// ErrNotStuffed is returned when unstuffing a cargo which is not in the
+	// container.
+	ErrNotStuffed = errors.New("cargo is not in the container")
+
+	// ErrEmptyContainer is returned when handling a container without
+	// cargos.
+	ErrEmptyContainer = errors.New("container is empty")
+
+	// ErrRouteMismatch is returned when stuffing a cargo which does not go
+	// from the origin to the destination of the shipment.
+	ErrRouteMismatch = errors.New("cargo route does not match the shipment")
+)
+
+// ID uniquely identifies a particular shipment.
+type ID string
+
+// NextID generates a new shipment ID.
+func NextID() ID {
+	return ID(strings.Split(strings.ToUpper(uuid.New()), "-")[0])
+}
+
+// ContainerID is an ISO 6346 container number, e.g. MSCU1234565.
+type ContainerID string
+
+var containerIDPattern = regexp.MustCompile(`^[A-Z]{3}[UJZ][0-9]{7}$`)
+
+// IsValid checks the owner code, category and serial number layout of the
+// container number. The check digit is not verified.
+func (id ContainerID) IsValid() bool {
+	return containerIDPattern.MatchString(string(id))
+}
+
+// Stuffing is a cargo packed into a container, with the weight in kilograms
+// and the volume in cubic metres it takes up.
+type Stuffing struct {
+	TrackingID cargo.TrackingID
+	Weight     float64
+	Volume     float64
+}
+
+// Container is a box of cargos moved as one unit. Its capacity is given in
+// kilograms and cubic metres.
+type Container struct {
+	ID        ContainerID
+	MaxWeight float64
+	MaxVolume float64
+	Cargos    []Stuffing
+}
+
+// Load returns the weight and volume taken up by the cargos in the
+// container.
+func (c Container) Load() (weight, volume float64) {
+	for _, s := range c.Cargos {
+		weight += s.Weight
+		volume += s.Volume
+	}
+
+	return weight, volume
+}
+
+// Shipment consolidates containers going from one origin to one
+// destination. Every cargo in its containers follows the itinerary of the
+// shipment.
+type Shipment struct {
+	ID          ID
+	Origin      location.UNLocode
+	Destination location.UNLocode
+	Itinerary   cargo.Itinerary
+	Containers  []Container
+}
+
+// New creates a new, unrouted shipment.
+func New(id ID, origin, destination location.UNLocode, containers []Container) *Shipment {
+	return &Shipment{
+		ID:          id,
+		Origin:      origin,
+		Destination: destination,
+		Containers:  containers,
+	}
+}
+
+// Container returns the container with the given ID.
+func (s *Shipment) Container(id ContainerID) (*Container, error) {
+	for i := range s.Containers {
+		if s.Containers[i].ID == id {
+			return &s.Containers[i], nil
+		}
+	}
+
+	return nil, ErrUnknownContainer
+}
+
+// TrackingIDs returns the cargos in the given containers, or in every
+// container when none is given.
+func (s *Shipment) TrackingIDs(containers ...ContainerID) []cargo.TrackingID {
+	var ids []cargo.TrackingID
+	for _, c := range s.Containers {
+		if len(containers) > 0 && !contains(containers, c.ID) {
+			continue
+		}
+
+		for _, stuffing := range c.Cargos {
+			ids = append(ids, stuffing.TrackingID)
+		}
+	}
+
+	return ids
+}
+
+func contains(ids []ContainerID, id ContainerID) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Stuff packs the cargo into the container. The cargo has to go from the
+// origin to the destination of the shipment and must fit in the remaining
+// capacity of the container.
+func (s *Shipment) Stuff(container ContainerID, c *cargo.Cargo, weight, volume float64) error {
+	if !c.Status.Active() {
+		return cargo.ErrInactive
+	}
+
+	if c.RouteSpecification.Origin != s.Origin || c.RouteSpecification.Destination != s.Destination {
+		return ErrRouteMismatch
+	}
+
+	for _, id := range s.TrackingIDs() {
+		if id == c.TrackingID {
+			return ErrAlreadyStuffed
+		}
+	}
+
+	target, err := s.Container(container)
+	if err != nil {
+		return err
+	}
+
+	loadedWeight, loadedVolume := target.Load()
+	if loadedWeight+weight > target.MaxWeight || loadedVolume+volume > target.MaxVolume {
+		return ErrCapacityExceeded
+	}
+
+	target.Cargos = append(target.Cargos, Stuffing{TrackingID: c.TrackingID, Weight: weight, Volume: volume})
+	return nil
+}
+
+// Unstuff takes the cargo out of the container.
+func (s *Shipment) Unstuff(container ContainerID, id cargo.TrackingID) error {
+	target, err := s.Container(container)
+	if err != nil {
+		return err
+	}
+
+	for i, stuffing := range target.Cargos {
+		if stuffing.TrackingID == id {
+			target.Cargos = append(target.Cargos[:i], target.Cargos[i+1:]...)
+			return nil
+		}
+	}
+
+	return ErrNotStuffed
+}
+
+// AssignToRoute routes the shipment. The itinerary applies to every cargo
+// in the shipment, see Route.
+func (s *Shipment) AssignToRoute(itinerary cargo.Itinerary) {
+	s.Itinerary = cargo.Itinerary{Legs: itinerary.Legs}
+}
+
+// Route assigns the itinerary of the shipment to a cargo in it. The cargo
+// keeps its own itinerary record, only the legs are copied.
+func (s *Shipment) Route(c *cargo.Cargo) {
+	if s.Itinerary.IsEmpty() {
+		return
+	}
+
+	c.AssignToRoute(cargo.Itinerary{ID: c.Itinerary.ID, Legs: s.Itinerary.Legs})
+}
+
+type ShipmentRepositoryContract interface {
+	Store(ctx context.Context, dbtx db.DBTX, shipment *Shipment) error
+	Find(ctx context.Context, dbtx db.DBTX, id ID) (*Shipment, error)
+	FindByContainer(ctx context.Context, dbtx db.DBTX, id ContainerID) (*Shipment, error)
+	FindByCargo(ctx context.Context, dbtx db.DBTX, id cargo.TrackingID) (*Shipment, error)
+}
+
+type ShipmentRepository struct {
+}
+
+func NewShipmentRepository() ShipmentRepository {
+	return ShipmentRepository{}
+}
+
+// Store saves the whole aggregate. The containers and their contents are
+// replaced by the ones of the shipment.
+func (sr ShipmentRepository) Store(ctx context.Context, dbtx db.DBTX, shipment *Shipment) error {
+	legs, err := json.Marshal(shipment.Itinerary.Legs)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO shipments (id, origin, destination, legs)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (id) DO UPDATE SET
+			origin = EXCLUDED.origin, destination = EXCLUDED.destination, legs = EXCLUDED.legs
+	`
+
+	_, err = dbtx.ExecContext(ctx, query, shipment.ID, shipment.Origin, shipment.Destination, string(legs))
+	if err != nil {
+		return err
+	}
+
+	for _, c := range shipment.Containers {
+		query := `
+			INSERT INTO containers (id, shipment_id, max_weight, max_volume)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (id) DO UPDATE SET max_weight = EXCLUDED.max_weight, max_volume = EXCLUDED.max_volume
+			WHERE containers.shipment_id = EXCLUDED.shipment_id
+		`
+
+		res, err := dbtx.ExecContext(ctx, query, c.ID, shipment.ID, c.MaxWeight, c.MaxVolume)
+		if err != nil {
+			return err
+		}
+
+		// the conflict clause skips containers of another shipment
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return ErrContainerInUse
+		}
+
+		_, err = dbtx.ExecContext(ctx, "DELETE FROM container_cargos WHERE container_id = $1", c.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range c.Cargos {
+			query := `
+				INSERT INTO container_cargos (container_id, tracking_id, weight, volume)
+				VALUES ($1, $2, $3, $4)
+			`
+
+			_, err := dbtx.ExecContext(ctx, query, c.ID, s.TrackingID, s.Weight, s.Volume)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (sr ShipmentRepository) Find(ctx context.Context, dbtx db.DBTX, id ID) (*Shipment, error) {
+	query := "SELECT id, origin, destination, legs FROM shipments WHERE id = $1 LIMIT 1"
+
+	var (
+		s    Shipment
+		legs string
+	)
+
+	err := dbtx.QueryRowContext(ctx, query, id).Scan(&s.ID, &s.Origin, &s.Destination, &legs)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrUnknown
+		}
+
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(legs), &s.Itinerary.Legs); err != nil {
+		return nil, err
+	}
+
+	s.Containers, err = sr.containers(ctx, dbtx, s.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+func (sr ShipmentRepository) FindByContainer(ctx context.Context, dbtx db.DBTX, id ContainerID) (*Shipment, error) {
+	var shipmentID ID
+	err := dbtx.QueryRowContext(ctx, "SELECT shipment_id FROM containers WHERE id = $1", id).Scan(&shipmentID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrUnknownContainer
+		}
+
+		return nil, err
+	}
+
+	return sr.Find(ctx, dbtx, shipmentID)
+}
+
+// FindByCargo returns the shipment whose containers hold the cargo, or
+// ErrUnknown when the cargo is not in any container.
+func (sr ShipmentRepository) FindByCargo(ctx context.Context, dbtx db.DBTX, id cargo.TrackingID) (*Shipment, error) {
+	query := `
+		SELECT c.shipment_id FROM container_cargos cc JOIN containers c ON c.id = cc.container_id
+		WHERE cc.tracking_id = $1
+	`
+
+	var shipmentID ID
+	err := dbtx.QueryRowContext(ctx, query, id).Scan(&shipmentID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrUnknown
+		}
+
+		return nil, err
+	}
+
+	return sr.Find(ctx, dbtx, shipmentID)
+}
+
+func (sr ShipmentRepository) containers(ctx context.Context, dbtx db.DBTX, id ID) ([]Container, error) {
+	query := `
+		SELECT c.id, c.max_weight, c.max_volume, cc.tracking_id, cc.weight, cc.volume
+		FROM containers c LEFT JOIN container_cargos cc ON cc.container_id = c.id
+		WHERE c.shipment_id = $1 ORDER BY c.id, cc.tracking_id
+	`
+
+	rows, err := dbtx.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var containers []Container
+	for rows.Next() {
+		var (
+			c          Container
+			trackingID sql.NullString
+			weight     sql.NullFloat64
+			volume     sql.NullFloat64
+		)
+
+		err := rows.Scan(&c.ID, &c.MaxWeight, &c.MaxVolume, &trackingID, &weight, &volume)
+		if err != nil {
+			return nil, err
+		}
+
+		if n := len(containers); n == 0 || containers[n-1].ID != c.ID {
+			containers = append(containers, c)
+		}
+
+		if trackingID.Valid {
+			last := &containers[len(containers)-1]
+			last.Cargos = append(last.Cargos, Stuffing{
+				TrackingID: cargo.TrackingID(trackingID.String),
+				Weight:     weight.Float64,
+				Volume:     volume.Float64,
+			})
+		}
+	}
+
+	return containers, rows.Err()
+}
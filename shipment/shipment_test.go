@@ -0,0 +1,102 @@
+package shipment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/location"
+	"github.com/stretchr/testify/require"
+)
+
+func newCargo(id cargo.TrackingID, origin, destination location.UNLocode) *cargo.Cargo {
+	return cargo.New(id, cargo.RouteSpecification{
+		Origin:          origin,
+		Destination:     destination,
+		ArrivalDeadline: time.Date(2030, 1, 10, 0, 0, 0, 0, time.UTC),
+	})
+}
+
+func newShipment() *Shipment {
+	return New(NextID(), "IDJKT", "SGSIN", []Container{
+		{ID: "MSCU1234565", MaxWeight: 1000, MaxVolume: 30},
+		{ID: "MSCU7654321", MaxWeight: 500, MaxVolume: 10},
+	})
+}
+
+func TestContainerID(t *testing.T) {
+	require.True(t, ContainerID("MSCU1234565").IsValid())
+	require.False(t, ContainerID("MSC1234565").IsValid())
+	require.False(t, ContainerID("MSCX1234565").IsValid())
+}
+
+func TestStuffEnforcesCapacity(t *testing.T) {
+	s := newShipment()
+	require.NoError(t, s.Stuff("MSCU1234565", newCargo("A", "IDJKT", "SGSIN"), 600, 10))
+	require.Equal(t, ErrCapacityExceeded, s.Stuff("MSCU1234565", newCargo("B", "IDJKT", "SGSIN"), 500, 10))
+	require.Equal(t, ErrCapacityExceeded, s.Stuff("MSCU1234565", newCargo("B", "IDJKT", "SGSIN"), 100, 25))
+	require.NoError(t, s.Stuff("MSCU1234565", newCargo("B", "IDJKT", "SGSIN"), 400, 20))
+
+	weight, volume := s.Containers[0].Load()
+	require.Equal(t, 1000.0, weight)
+	require.Equal(t, 30.0, volume)
+
+	require.Equal(t, ErrAlreadyStuffed, s.Stuff("MSCU7654321", newCargo("A", "IDJKT", "SGSIN"), 1, 1))
+	require.Equal(t, ErrUnknownContainer, s.Stuff("TGHU0000000", newCargo("C", "IDJKT", "SGSIN"), 1, 1))
+
+	require.NoError(t, s.Unstuff("MSCU1234565", "A"))
+	require.Equal(t, ErrNotStuffed, s.Unstuff("MSCU1234565", "A"))
+	require.Equal(t, []cargo.TrackingID{"B"}, s.TrackingIDs())
+}
+
+func TestStuffChecksCargo(t *testing.T) {
+	s := newShipment()
+
+	c := newCargo("A", "IDJKT", "AUMEL")
+	require.Equal(t, ErrRouteMismatch, s.Stuff("MSCU1234565", c, 1, 1))
+
+	c = newCargo("A", "IDJKT", "SGSIN")
+	require.NoError(t, c.Cancel(cargo.HandlingHistory{}))
+	require.Equal(t, cargo.ErrInactive, s.Stuff("MSCU1234565", c, 1, 1))
+}
+
+func TestRouteAppliesShipmentItinerary(t *testing.T) {
+	s := newShipment()
+	c := newCargo("A", "IDJKT", "SGSIN")
+	c.Itinerary.ID = 7
+
+	s.Route(c)
+	require.True(t, c.Itinerary.IsEmpty())
+
+	load := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.AssignToRoute(cargo.Itinerary{ID: 3, Legs: []cargo.Leg{
+		cargo.NewLeg("V100", "IDJKT", "SGSIN", load, load.AddDate(0, 0, 2)),
+	}})
+	s.Route(c)
+
+	require.Equal(t, int64(7), c.Itinerary.ID)
+	require.Len(t, c.Itinerary.Legs, 1)
+	require.Equal(t, cargo.Routed, c.Delivery.RoutingStatus)
+}
+
+func TestStoreShipment(t *testing.T) {
+	ctx := context.Background()
+	c, err := cargoTest.Upsert(ctx, dbTest, newCargo(cargo.NextTrackingID(), "IDJKT", "SGSIN"))
+	require.NoError(t, err)
+
+	s := New(NextID(), "IDJKT", "SGSIN", []Container{{ID: ContainerID("TEST" + string(NextID())[:7]), MaxWeight: 1000, MaxVolume: 30}})
+	require.NoError(t, s.Stuff(s.Containers[0].ID, c, 100, 2))
+	require.NoError(t, shipmentTest.Store(ctx, dbTest, s))
+
+	found, err := shipmentTest.FindByCargo(ctx, dbTest, c.TrackingID)
+	require.NoError(t, err)
+	require.Equal(t, s.ID, found.ID)
+	require.Equal(t, s.Containers, found.Containers)
+
+	other := New(NextID(), "IDJKT", "SGSIN", []Container{{ID: s.Containers[0].ID, MaxWeight: 1, MaxVolume: 1}})
+	require.Equal(t, ErrContainerInUse, shipmentTest.Store(ctx, dbTest, other))
+
+	_, err = shipmentTest.FindByContainer(ctx, dbTest, "NONE0000000")
+	require.Equal(t, ErrUnknownContainer, err)
+}
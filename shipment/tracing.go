@@ -0,0 +1,54 @@
+package shipment
+
+import (
+	"context"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+	"github.com/mproyyan/grpc-shipping-microservice/tracing"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = tracing.Tracer("github.com/mproyyan/grpc-shipping-microservice/shipment")
+
+type tracingShipmentRepository struct {
+	ShipmentRepositoryContract
+}
+
+// NewTracingShipmentRepository returns a shipment repository that wraps
+// every query in a client span.
+func NewTracingShipmentRepository(r ShipmentRepositoryContract) ShipmentRepositoryContract {
+	return &tracingShipmentRepository{ShipmentRepositoryContract: r}
+}
+
+func (r *tracingShipmentRepository) start(ctx context.Context, name string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemPostgreSQL),
+	)
+}
+
+func (r *tracingShipmentRepository) Store(ctx context.Context, dbtx db.DBTX, shipment *Shipment) (err error) {
+	ctx, span := r.start(ctx, "ShipmentRepository.Store")
+	defer func() { tracing.End(span, err) }()
+	return r.ShipmentRepositoryContract.Store(ctx, dbtx, shipment)
+}
+
+func (r *tracingShipmentRepository) Find(ctx context.Context, dbtx db.DBTX, id ID) (s *Shipment, err error) {
+	ctx, span := r.start(ctx, "ShipmentRepository.Find")
+	defer func() { tracing.End(span, err) }()
+	return r.ShipmentRepositoryContract.Find(ctx, dbtx, id)
+}
+
+func (r *tracingShipmentRepository) FindByContainer(ctx context.Context, dbtx db.DBTX, id ContainerID) (s *Shipment, err error) {
+	ctx, span := r.start(ctx, "ShipmentRepository.FindByContainer")
+	defer func() { tracing.End(span, err) }()
+	return r.ShipmentRepositoryContract.FindByContainer(ctx, dbtx, id)
+}
+
+func (r *tracingShipmentRepository) FindByCargo(ctx context.Context, dbtx db.DBTX, id cargo.TrackingID) (s *Shipment, err error) {
+	ctx, span := r.start(ctx, "ShipmentRepository.FindByCargo")
+	defer func() { tracing.End(span, err) }()
+	return r.ShipmentRepositoryContract.FindByCargo(ctx, dbtx, id)
+}
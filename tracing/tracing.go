@@ -0,0 +1,127 @@
+// Package tracing configures OpenTelemetry tracing and carries trace context
+// across the HTTP and gRPC transports.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Supported span exporters.
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterFile   = "file"
+	ExporterOTLP   = "otlp"
+)
+
+// Config describes where spans are exported to.
+type Config struct {
+	// Exporter is one of none, stdout, file or otlp.
+	Exporter string
+	// File is the path spans are appended to when using the file exporter.
+	File string
+	// OTLPEndpoint is the collector address used by the otlp exporter.
+	OTLPEndpoint string
+	// SampleRatio is the fraction of new traces that are recorded.
+	SampleRatio float64
+}
+
+// Tracer returns the named tracer from the global provider.
+func Tracer(name string) trace.Tracer {
+	return otel.Tracer(name)
+}
+
+// Init installs a global tracer provider and propagator for the given
+// service. The returned function flushes pending spans and must be called on
+// shutdown.
+func Init(ctx context.Context, serviceName string, cfg Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if cfg.Exporter == "" || cfg.Exporter == ExporterNone {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, closer, err := newExporter(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	ratio := cfg.SampleRatio
+	if ratio <= 0 {
+		ratio = 1
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			closer.Close()
+		}
+
+		return err
+	}, nil
+}
+
+func newExporter(ctx context.Context, cfg Config) (sdktrace.SpanExporter, io.Closer, error) {
+	switch cfg.Exporter {
+	case ExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		return exporter, nil, err
+	case ExporterFile:
+		if cfg.File == "" {
+			return nil, nil, fmt.Errorf("tracing: file exporter requires a file path")
+		}
+
+		f, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			f.Close()
+			return nil, nil, err
+		}
+
+		return exporter, f, nil
+	case ExporterOTLP:
+		options := []otlptracegrpc.Option{otlptracegrpc.WithInsecure()}
+		if cfg.OTLPEndpoint != "" {
+			options = append(options, otlptracegrpc.WithEndpoint(cfg.OTLPEndpoint))
+		}
+
+		exporter, err := otlptracegrpc.New(ctx, options...)
+		return exporter, nil, err
+	}
+
+	return nil, nil, fmt.Errorf("tracing: unknown exporter %q", cfg.Exporter)
+}
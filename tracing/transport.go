@@ -0,0 +1,116 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+)
+
+const instrumentationName = "github.com/mproyyan/grpc-shipping-microservice"
+
+// metadataCarrier adapts gRPC metadata to a propagation.TextMapCarrier.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+// GRPCClientBefore injects the current trace context into outgoing gRPC
+// metadata. It is meant to be used with go-kit's grpc.ClientBefore.
+func GRPCClientBefore(ctx context.Context, md *metadata.MD) context.Context {
+	otel.GetTextMapPropagator().Inject(ctx, metadataCarrier(*md))
+	return ctx
+}
+
+// GRPCServerBefore extracts the trace context from incoming gRPC metadata. It
+// is meant to be used with go-kit's grpc.ServerBefore. A span already in ctx
+// wins, that is the case when the gateway serves REST requests through the
+// gRPC handlers and the HTTP server span must stay the parent.
+func GRPCServerBefore(ctx context.Context, md metadata.MD) context.Context {
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+
+	return otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+}
+
+// EndpointMiddleware wraps an endpoint in a span with the given name.
+func EndpointMiddleware(name string, kind trace.SpanKind, attrs ...attribute.KeyValue) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+			ctx, span := Tracer(instrumentationName).Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attrs...))
+			defer func() {
+				End(span, err)
+			}()
+
+			return next(ctx, request)
+		}
+	}
+}
+
+// HTTPMiddleware starts a server span for every request, continuing any
+// trace propagated by the client. Spans are named after the matched route.
+func HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+		route := r.URL.Path
+		if current := mux.CurrentRoute(r); current != nil {
+			if tpl, err := current.GetPathTemplate(); err == nil {
+				route = tpl
+			}
+		}
+
+		ctx, span := Tracer(instrumentationName).Start(
+			ctx,
+			fmt.Sprintf("HTTP %s %s", r.Method, route),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPMethod(r.Method),
+				semconv.HTTPRoute(route),
+			),
+		)
+		defer span.End()
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// End records err on the span, if any, and ends it.
+func End(span trace.Span, err error, attrs ...attribute.KeyValue) {
+	span.SetAttributes(attrs...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
@@ -0,0 +1,46 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestGRPCMetadataPropagation(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01, 0x02, 0x03},
+		SpanID:     trace.SpanID{0x04, 0x05},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	md := metadata.MD{}
+	GRPCClientBefore(ctx, &md)
+	require.NotEmpty(t, md.Get("traceparent"))
+
+	extracted := trace.SpanContextFromContext(GRPCServerBefore(context.Background(), md))
+	require.Equal(t, sc.TraceID(), extracted.TraceID())
+	require.Equal(t, sc.SpanID(), extracted.SpanID())
+	require.True(t, extracted.IsRemote())
+}
+
+func TestGRPCServerBeforeKeepsLocalSpan(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	local := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x0a},
+		SpanID:     trace.SpanID{0x0b},
+		TraceFlags: trace.FlagsSampled,
+	})
+	md := metadata.Pairs("traceparent", "00-01020300000000000000000000000000-0405000000000000-01")
+
+	got := trace.SpanContextFromContext(GRPCServerBefore(trace.ContextWithSpanContext(context.Background(), local), md))
+	require.Equal(t, local, got)
+}
@@ -0,0 +1,113 @@
+// Package validation collects field level violations of incoming requests so
+// they can be reported all at once, over gRPC as errdetails.BadRequest and
+// over HTTP as a problem details body.
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FieldViolation describes a single invalid field. Field is a dot separated
+// path into the request, e.g. "itinerary.legs[1].load_location".
+type FieldViolation struct {
+	Field       string `json:"name"`
+	Description string `json:"reason"`
+}
+
+// Errors is the list of every violation found in a request.
+type Errors []FieldViolation
+
+func (e Errors) Error() string {
+	parts := make([]string, 0, len(e))
+	for _, v := range e {
+		parts = append(parts, fmt.Sprintf("%s: %s", v.Field, v.Description))
+	}
+
+	return "invalid request: " + strings.Join(parts, "; ")
+}
+
+// GRPCStatus lets the gRPC runtime send the violations as an
+// InvalidArgument status carrying errdetails.BadRequest.
+func (e Errors) GRPCStatus() *status.Status {
+	br := &errdetails.BadRequest{}
+	for _, v := range e {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		})
+	}
+
+	st := status.New(codes.InvalidArgument, e.Error())
+	if detailed, err := st.WithDetails(br); err == nil {
+		return detailed
+	}
+
+	return st
+}
+
+// FromError extracts the violations from err. It understands both Errors
+// and gRPC statuses carrying errdetails.BadRequest, so violations survive the
+// hop between the gateway and the booking service.
+func FromError(err error) (Errors, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	var verr Errors
+	if errors.As(err, &verr) {
+		return verr, true
+	}
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		return nil, false
+	}
+
+	for _, detail := range st.Details() {
+		br, ok := detail.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+
+		for _, v := range br.GetFieldViolations() {
+			verr = append(verr, FieldViolation{Field: v.GetField(), Description: v.GetDescription()})
+		}
+	}
+
+	return verr, len(verr) > 0
+}
+
+// Validator accumulates violations.
+type Validator struct {
+	violations Errors
+}
+
+// Add records a violation for field.
+func (v *Validator) Add(field, description string) {
+	v.violations = append(v.violations, FieldViolation{Field: field, Description: description})
+}
+
+// Check records a violation for field unless ok is true. It reports ok so
+// dependent checks can be skipped.
+func (v *Validator) Check(ok bool, field, description string) bool {
+	if !ok {
+		v.Add(field, description)
+	}
+
+	return ok
+}
+
+// Err returns the collected violations, or nil if there are none.
+func (v *Validator) Err() error {
+	if len(v.violations) == 0 {
+		return nil
+	}
+
+	return v.violations
+}
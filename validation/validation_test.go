@@ -0,0 +1,44 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidatorCollectsViolations(t *testing.T) {
+	var v Validator
+	require.True(t, v.Check(true, "origin", "is required"))
+	require.False(t, v.Check(false, "destination", "is required"))
+	v.Add("deadline", "must be in the future")
+
+	err := v.Err()
+	require.Error(t, err)
+	require.Equal(t, Errors{
+		{Field: "destination", Description: "is required"},
+		{Field: "deadline", Description: "must be in the future"},
+	}, err)
+
+	var empty Validator
+	require.NoError(t, empty.Err())
+}
+
+func TestFromErrorThroughGRPCStatus(t *testing.T) {
+	want := Errors{{Field: "itinerary.legs[0].load_location", Description: "is required"}}
+
+	st := want.GRPCStatus()
+	require.Equal(t, codes.InvalidArgument, st.Code())
+
+	got, ok := FromError(st.Err())
+	require.True(t, ok)
+	require.Equal(t, want, got)
+
+	_, ok = FromError(status.Error(codes.InvalidArgument, "no details"))
+	require.False(t, ok)
+
+	_, ok = FromError(errors.New("boom"))
+	require.False(t, ok)
+}
@@ -0,0 +1,44 @@
+package voyage
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+)
+
+type instrumentingVoyageRepository struct {
+	latency metrics.Histogram
+	VoyageRepositoryContract
+}
+
+// NewInstrumentingVoyageRepository returns a voyage repository that records
+// query latency, labeled by repository, method and outcome.
+func NewInstrumentingVoyageRepository(latency metrics.Histogram, r VoyageRepositoryContract) VoyageRepositoryContract {
+	return &instrumentingVoyageRepository{latency: latency, VoyageRepositoryContract: r}
+}
+
+func (r *instrumentingVoyageRepository) observe(method string, begin time.Time, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+
+	r.latency.With("repository", "voyage", "method", method, "outcome", outcome).Observe(time.Since(begin).Seconds())
+}
+
+func (r *instrumentingVoyageRepository) Store(ctx context.Context, dbtx db.DBTX, v *Voyage) (err error) {
+	defer func(begin time.Time) { r.observe("store", begin, err) }(time.Now())
+	return r.VoyageRepositoryContract.Store(ctx, dbtx, v)
+}
+
+func (r *instrumentingVoyageRepository) Find(ctx context.Context, dbtx db.DBTX, n Number) (v *Voyage, err error) {
+	defer func(begin time.Time) { r.observe("find", begin, err) }(time.Now())
+	return r.VoyageRepositoryContract.Find(ctx, dbtx, n)
+}
+
+func (r *instrumentingVoyageRepository) FindDeparting(ctx context.Context, dbtx db.DBTX, after time.Time) (voyages []*Voyage, err error) {
+	defer func(begin time.Time) { r.observe("find_departing", begin, err) }(time.Now())
+	return r.VoyageRepositoryContract.FindDeparting(ctx, dbtx, after)
+}
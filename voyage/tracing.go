@@ -0,0 +1,48 @@
+package voyage
+
+import (
+	"context"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+	"github.com/mproyyan/grpc-shipping-microservice/tracing"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = tracing.Tracer("github.com/mproyyan/grpc-shipping-microservice/voyage")
+
+type tracingVoyageRepository struct {
+	VoyageRepositoryContract
+}
+
+// NewTracingVoyageRepository returns a voyage repository that wraps every
+// query in a client span.
+func NewTracingVoyageRepository(r VoyageRepositoryContract) VoyageRepositoryContract {
+	return &tracingVoyageRepository{VoyageRepositoryContract: r}
+}
+
+func (r *tracingVoyageRepository) start(ctx context.Context, name string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemPostgreSQL),
+	)
+}
+
+func (r *tracingVoyageRepository) Store(ctx context.Context, dbtx db.DBTX, v *Voyage) (err error) {
+	ctx, span := r.start(ctx, "VoyageRepository.Store")
+	defer func() { tracing.End(span, err) }()
+	return r.VoyageRepositoryContract.Store(ctx, dbtx, v)
+}
+
+func (r *tracingVoyageRepository) Find(ctx context.Context, dbtx db.DBTX, n Number) (v *Voyage, err error) {
+	ctx, span := r.start(ctx, "VoyageRepository.Find")
+	defer func() { tracing.End(span, err) }()
+	return r.VoyageRepositoryContract.Find(ctx, dbtx, n)
+}
+
+func (r *tracingVoyageRepository) FindDeparting(ctx context.Context, dbtx db.DBTX, after time.Time) (voyages []*Voyage, err error) {
+	ctx, span := r.start(ctx, "VoyageRepository.FindDeparting")
+	defer func() { tracing.End(span, err) }()
+	return r.VoyageRepositoryContract.FindDeparting(ctx, dbtx, after)
+}
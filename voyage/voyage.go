@@ -2,9 +2,13 @@
 package voyage
 
 import (
+	"context"
+	"database/sql"
 	"errors"
 	"time"
 
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+	"github.com/mproyyan/grpc-shipping-microservice/imdg"
 	"github.com/mproyyan/grpc-shipping-microservice/location"
 )
 
@@ -15,6 +19,8 @@ type Number string
 type Voyage struct {
 	Number   Number
 	Schedule Schedule
+	// DangerousGoods are the dangerous goods the vessel carries.
+	DangerousGoods imdg.Policy
 }
 
 // New creates a voyage with a voyage number and a provided schedule.
@@ -27,18 +33,179 @@ type Schedule struct {
 	CarrierMovements []CarrierMovement
 }
 
+// Span returns the indexes of the first and the last carrier movement taken
+// when travelling from one location to another on the voyage.
+func (s Schedule) Span(from, to location.UNLocode) (first, last int, ok bool) {
+	first = -1
+	for i, m := range s.CarrierMovements {
+		if first < 0 && m.DepartureLocation == from {
+			first = i
+		}
+
+		if first >= 0 && m.ArrivalLocation == to {
+			return first, i, true
+		}
+	}
+
+	return 0, 0, false
+}
+
 // CarrierMovement is a vessel voyage from one location to another.
 type CarrierMovement struct {
 	DepartureLocation location.UNLocode
 	ArrivalLocation   location.UNLocode
 	DepartureTime     time.Time
 	ArrivalTime       time.Time
+	Capacity          Capacity
+}
+
+// Capacity is the weight in kilograms and the volume in cubic metres a
+// carrier movement can take. A zero limit is unlimited.
+type Capacity struct {
+	Weight float64
+	Volume float64
+}
+
+// Fits reports whether the given weight and volume stay within the
+// capacity.
+func (c Capacity) Fits(weight, volume float64) bool {
+	return (c.Weight == 0 || weight <= c.Weight) && (c.Volume == 0 || volume <= c.Volume)
 }
 
 // ErrUnknown is used when a voyage could not be found.
 var ErrUnknown = errors.New("unknown voyage")
 
-// Repository provides access a voyage store.
-type Repository interface {
-	Find(Number) (*Voyage, error)
+type VoyageRepositoryContract interface {
+	Store(ctx context.Context, dbtx db.DBTX, v *Voyage) error
+	Find(ctx context.Context, dbtx db.DBTX, n Number) (*Voyage, error)
+	FindDeparting(ctx context.Context, dbtx db.DBTX, after time.Time) ([]*Voyage, error)
+}
+
+type VoyageRepository struct {
+}
+
+func NewVoyageRepository() VoyageRepository {
+	return VoyageRepository{}
+}
+
+// Store creates the voyage or replaces its schedule and policy.
+func (vr VoyageRepository) Store(ctx context.Context, dbtx db.DBTX, v *Voyage) error {
+	query := `
+		INSERT INTO voyages (voyage_number, dangerous_goods_classes) VALUES ($1, $2)
+		ON CONFLICT (voyage_number) DO UPDATE SET dangerous_goods_classes = EXCLUDED.dangerous_goods_classes
+	`
+	if _, err := dbtx.ExecContext(ctx, query, v.Number, v.DangerousGoods); err != nil {
+		return err
+	}
+
+	if _, err := dbtx.ExecContext(ctx, `DELETE FROM carrier_movements WHERE voyage_number = $1`, v.Number); err != nil {
+		return err
+	}
+
+	query = `
+		INSERT INTO carrier_movements (voyage_number, seq, departure_location, arrival_location, departure_time, arrival_time, max_weight, max_volume)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	for i, m := range v.Schedule.CarrierMovements {
+		_, err := dbtx.ExecContext(ctx, query, v.Number, i, m.DepartureLocation, m.ArrivalLocation, m.DepartureTime, m.ArrivalTime, m.Capacity.Weight, m.Capacity.Volume)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Find returns the voyage with its schedule. Within a transaction the voyage
+// stays locked until the transaction ends, so that concurrent bookings on
+// the same voyage are checked one after the other.
+func (vr VoyageRepository) Find(ctx context.Context, dbtx db.DBTX, n Number) (*Voyage, error) {
+	var (
+		number string
+		policy imdg.Policy
+	)
+
+	row := dbtx.QueryRowContext(ctx, `SELECT voyage_number, dangerous_goods_classes FROM voyages WHERE voyage_number = $1 FOR UPDATE`, n)
+	if err := row.Scan(&number, &policy); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrUnknown
+		}
+
+		return nil, err
+	}
+
+	schedule, err := vr.schedule(ctx, dbtx, n)
+	if err != nil {
+		return nil, err
+	}
+
+	v := New(Number(number), schedule)
+	v.DangerousGoods = policy
+	return v, nil
+}
+
+// FindDeparting returns the voyages with a carrier movement departing after
+// the given time, ordered by voyage number. Unlike Find it locks nothing,
+// the voyages are only read to search for routes.
+func (vr VoyageRepository) FindDeparting(ctx context.Context, dbtx db.DBTX, after time.Time) ([]*Voyage, error) {
+	query := `
+		SELECT voyage_number, dangerous_goods_classes FROM voyages
+		WHERE voyage_number IN (SELECT voyage_number FROM carrier_movements WHERE departure_time > $1)
+		ORDER BY voyage_number
+	`
+
+	rows, err := dbtx.QueryContext(ctx, query, after)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var voyages []*Voyage
+	for rows.Next() {
+		v := &Voyage{}
+		if err := rows.Scan(&v.Number, &v.DangerousGoods); err != nil {
+			return nil, err
+		}
+
+		voyages = append(voyages, v)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, v := range voyages {
+		if v.Schedule, err = vr.schedule(ctx, dbtx, v.Number); err != nil {
+			return nil, err
+		}
+	}
+
+	return voyages, nil
+}
+
+func (vr VoyageRepository) schedule(ctx context.Context, dbtx db.DBTX, n Number) (Schedule, error) {
+	query := `
+		SELECT departure_location, arrival_location, departure_time, arrival_time, max_weight, max_volume
+		FROM carrier_movements WHERE voyage_number = $1 ORDER BY seq
+	`
+
+	rows, err := dbtx.QueryContext(ctx, query, n)
+	if err != nil {
+		return Schedule{}, err
+	}
+	defer rows.Close()
+
+	var schedule Schedule
+	for rows.Next() {
+		var m CarrierMovement
+		err := rows.Scan(&m.DepartureLocation, &m.ArrivalLocation, &m.DepartureTime, &m.ArrivalTime, &m.Capacity.Weight, &m.Capacity.Volume)
+		if err != nil {
+			return Schedule{}, err
+		}
+
+		schedule.CarrierMovements = append(schedule.CarrierMovements, m)
+	}
+
+	return schedule, rows.Err()
 }
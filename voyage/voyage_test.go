@@ -0,0 +1,65 @@
+package voyage
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/imdg"
+	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleSpan(t *testing.T) {
+	s := Schedule{CarrierMovements: []CarrierMovement{
+		{DepartureLocation: "SESTO", ArrivalLocation: "DEHAM"},
+		{DepartureLocation: "DEHAM", ArrivalLocation: "NLRTM"},
+		{DepartureLocation: "NLRTM", ArrivalLocation: "CNHKG"},
+	}}
+
+	first, last, ok := s.Span("DEHAM", "CNHKG")
+	require.True(t, ok)
+	require.Equal(t, 1, first)
+	require.Equal(t, 2, last)
+
+	_, _, ok = s.Span("NLRTM", "DEHAM")
+	require.False(t, ok)
+}
+
+func TestCapacityFits(t *testing.T) {
+	require.True(t, Capacity{}.Fits(1e9, 1e9))
+	require.True(t, Capacity{Weight: 100}.Fits(100, 1e9))
+	require.False(t, Capacity{Weight: 100, Volume: 1}.Fits(50, 1.5))
+}
+
+func TestStoreVoyage(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	v := New(Number(strings.ToUpper(uuid.New())[:8]), Schedule{CarrierMovements: []CarrierMovement{
+		{DepartureLocation: "SESTO", ArrivalLocation: "DEHAM", DepartureTime: now, ArrivalTime: now.Add(time.Hour), Capacity: Capacity{Weight: 1000, Volume: 10}},
+		{DepartureLocation: "DEHAM", ArrivalLocation: "CNHKG", DepartureTime: now.Add(2 * time.Hour), ArrivalTime: now.Add(3 * time.Hour)},
+	}})
+	v.DangerousGoods = imdg.Only("2.1")
+
+	require.NoError(t, voyageTest.Store(context.Background(), dbTest, v))
+
+	found, err := voyageTest.Find(context.Background(), dbTest, v.Number)
+	require.NoError(t, err)
+	require.Equal(t, v.Number, found.Number)
+	require.Equal(t, v.DangerousGoods, found.DangerousGoods)
+	require.Len(t, found.Schedule.CarrierMovements, 2)
+	require.Equal(t, v.Schedule.CarrierMovements[0].Capacity, found.Schedule.CarrierMovements[0].Capacity)
+	require.True(t, now.Equal(found.Schedule.CarrierMovements[0].DepartureTime))
+
+	departing, err := voyageTest.FindDeparting(context.Background(), dbTest, now.Add(90*time.Minute))
+	require.NoError(t, err)
+
+	var listed bool
+	for _, d := range departing {
+		listed = listed || d.Number == v.Number
+	}
+	require.True(t, listed)
+
+	_, err = voyageTest.Find(context.Background(), dbTest, "NOPE")
+	require.Equal(t, ErrUnknown, err)
+}
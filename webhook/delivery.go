@@ -0,0 +1,367 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+	"github.com/pborman/uuid"
+)
+
+var (
+	// ErrUnknownDelivery is used when a delivery could not be found.
+	ErrUnknownDelivery = errors.New("unknown webhook delivery")
+
+	// ErrNotFailed is returned when replaying a delivery which is not in
+	// the dead-letter list.
+	ErrNotFailed = errors.New("webhook delivery has not failed")
+)
+
+// Headers set on every post.
+const (
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+	SignatureHeader = "X-Webhook-Signature"
+)
+
+// Sign returns the signature of the body, the hex encoded HMAC-SHA256 of
+// the body keyed with the secret, prefixed by "sha256=".
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is the signature of the body, it is
+// meant for receivers.
+func Verify(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
+
+// Policy spaces the attempts of a delivery exponentially: the nth retry
+// waits Initial * 2^(n-1), up to Max. A delivery failing Attempts times is
+// given up on.
+type Policy struct {
+	Initial  time.Duration
+	Max      time.Duration
+	Attempts int
+}
+
+// DefaultPolicy is used unless a policy is configured, it gives up after
+// about 4 hours.
+var DefaultPolicy = Policy{Initial: 30 * time.Second, Max: time.Hour, Attempts: 10}
+
+// Delay returns how long to wait after the given number of failed attempts.
+func (p Policy) Delay(failed int) time.Duration {
+	d := p.Initial
+	for i := 1; i < failed && d < p.Max; i++ {
+		d *= 2
+	}
+
+	if d > p.Max {
+		return p.Max
+	}
+
+	return d
+}
+
+// DeliveryID uniquely identifies a particular delivery.
+type DeliveryID string
+
+// NextDeliveryID generates a new delivery ID.
+func NextDeliveryID() DeliveryID {
+	return DeliveryID(strings.Split(strings.ToUpper(uuid.New()), "-")[0])
+}
+
+// Status tells where a delivery is in its life cycle.
+type Status int
+
+// Valid delivery statuses, failed deliveries make up the dead-letter list.
+const (
+	Pending Status = iota
+	Delivered
+	Failed
+)
+
+func (s Status) String() string {
+	switch s {
+	case Pending:
+		return "Pending"
+	case Delivered:
+		return "Delivered"
+	case Failed:
+		return "Failed"
+	}
+
+	return ""
+}
+
+// Delivery is the post of an event to a subscription.
+type Delivery struct {
+	ID             DeliveryID
+	SubscriptionID SubscriptionID
+	CustomerID     customer.ID
+	Event          Event
+	Payload        []byte
+	Status         Status
+	Attempts       int
+	NextAttempt    time.Time
+	LastError      string
+	Created        time.Time
+}
+
+// NewDelivery creates a delivery due now.
+func NewDelivery(id DeliveryID, s *Subscription, e Event, payload []byte, now time.Time) *Delivery {
+	return &Delivery{
+		ID:             id,
+		SubscriptionID: s.ID,
+		CustomerID:     s.CustomerID,
+		Event:          e,
+		Payload:        payload,
+		Status:         Pending,
+		NextAttempt:    now,
+		Created:        now,
+	}
+}
+
+// Succeeded records a successful attempt.
+func (d *Delivery) Succeeded() {
+	d.Attempts++
+	d.Status = Delivered
+	d.LastError = ""
+}
+
+// Fail records a failed attempt, scheduling the next one according to the
+// policy or moving the delivery to the dead-letter list.
+func (d *Delivery) Fail(err error, p Policy, now time.Time) {
+	d.Attempts++
+	d.LastError = err.Error()
+	if d.Attempts >= p.Attempts {
+		d.Status = Failed
+		return
+	}
+
+	d.NextAttempt = now.Add(p.Delay(d.Attempts))
+}
+
+// Replay takes a failed delivery out of the dead-letter list, it is
+// attempted again as soon as possible with a fresh count of attempts.
+func (d *Delivery) Replay(now time.Time) error {
+	if d.Status != Failed {
+		return ErrNotFailed
+	}
+
+	d.Status = Pending
+	d.Attempts = 0
+	d.NextAttempt = now
+	return nil
+}
+
+// Payload is the JSON document posted for an event.
+type Payload struct {
+	DeliveryID DeliveryID   `json:"delivery_id"`
+	Event      string       `json:"event"`
+	Occurred   time.Time    `json:"occurred"`
+	Cargo      CargoState   `json:"cargo"`
+	Handling   *HandlingRef `json:"handling,omitempty"`
+}
+
+// CargoState is the state of the cargo after the event.
+type CargoState struct {
+	TrackingID              string     `json:"tracking_id"`
+	CustomerID              string     `json:"customer_id"`
+	Status                  string     `json:"status"`
+	Origin                  string     `json:"origin"`
+	Destination             string     `json:"destination"`
+	ArrivalDeadline         time.Time  `json:"arrival_deadline"`
+	RoutingStatus           string     `json:"routing_status"`
+	TransportStatus         string     `json:"transport_status"`
+	LastKnownLocation       string     `json:"last_known_location,omitempty"`
+	CurrentVoyage           string     `json:"current_voyage,omitempty"`
+	ETA                     *time.Time `json:"eta,omitempty"`
+	IsMisdirected           bool       `json:"misdirected"`
+	IsUnloadedAtDestination bool       `json:"unloaded_at_destination"`
+}
+
+// HandlingRef describes the handling event behind Handled, Misdirected and
+// Unloaded events.
+type HandlingRef struct {
+	Type         string    `json:"type"`
+	Location     string    `json:"location"`
+	VoyageNumber string    `json:"voyage_number,omitempty"`
+	Completed    time.Time `json:"completed"`
+}
+
+// NewPayload describes the event of the cargo, h is the handling event
+// which raised it if any.
+func NewPayload(id DeliveryID, e Event, c *cargo.Cargo, h *cargo.HandlingEvent, occurred time.Time) Payload {
+	d := c.Delivery
+	p := Payload{
+		DeliveryID: id,
+		Event:      e.String(),
+		Occurred:   occurred,
+		Cargo: CargoState{
+			TrackingID:              string(c.TrackingID),
+			CustomerID:              string(c.CustomerID),
+			Status:                  c.Status.String(),
+			Origin:                  string(c.RouteSpecification.Origin),
+			Destination:             string(c.RouteSpecification.Destination),
+			ArrivalDeadline:         c.RouteSpecification.ArrivalDeadline,
+			RoutingStatus:           d.RoutingStatus.String(),
+			TransportStatus:         d.TransportStatus.String(),
+			LastKnownLocation:       string(d.LastKnownLocation),
+			CurrentVoyage:           string(d.CurrentVoyage),
+			IsMisdirected:           d.IsMisdirected,
+			IsUnloadedAtDestination: d.IsUnloadedAtDestination,
+		},
+	}
+
+	if !d.ETA.IsZero() {
+		eta := d.ETA
+		p.Cargo.ETA = &eta
+	}
+
+	if h != nil {
+		p.Handling = &HandlingRef{
+			Type:         h.Activity.Type.String(),
+			Location:     string(h.Activity.Location),
+			VoyageNumber: string(h.Activity.VoyageNumber),
+			Completed:    h.Completed,
+		}
+	}
+
+	return p
+}
+
+// Outbox queues deliveries of cargo events to the subscriptions of the
+// owner of the cargo. Deliveries are stored along with the change raising
+// the event, so that events of changes rolled back are never posted.
+type Outbox struct {
+	subscriptions SubscriptionRepositoryContract
+	deliveries    DeliveryRepositoryContract
+}
+
+func NewOutbox(subscriptions SubscriptionRepositoryContract, deliveries DeliveryRepositoryContract) Outbox {
+	return Outbox{subscriptions: subscriptions, deliveries: deliveries}
+}
+
+// Publish queues a delivery of every event to each subscription accepting
+// it. h is the handling event which raised the events if any.
+func (o Outbox) Publish(ctx context.Context, dbtx db.DBTX, c *cargo.Cargo, h *cargo.HandlingEvent, events ...Event) error {
+	if o.subscriptions == nil || c.CustomerID == "" || len(events) == 0 {
+		return nil
+	}
+
+	subscriptions, err := o.subscriptions.FindByCustomer(ctx, dbtx, c.CustomerID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, s := range subscriptions {
+		for _, e := range events {
+			if !s.Filter.Accepts(e) {
+				continue
+			}
+
+			id := NextDeliveryID()
+			payload, err := json.Marshal(NewPayload(id, e, c, h, now))
+			if err != nil {
+				return err
+			}
+
+			if err := o.deliveries.Store(ctx, dbtx, NewDelivery(id, s, e, payload, now)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+type DeliveryRepositoryContract interface {
+	Store(ctx context.Context, dbtx db.DBTX, d *Delivery) error
+	Find(ctx context.Context, dbtx db.DBTX, id DeliveryID) (*Delivery, error)
+	Due(ctx context.Context, dbtx db.DBTX, now time.Time, limit int) ([]*Delivery, error)
+	FindFailed(ctx context.Context, dbtx db.DBTX, customerID customer.ID) ([]*Delivery, error)
+}
+
+type DeliveryRepository struct {
+}
+
+func NewDeliveryRepository() DeliveryRepository {
+	return DeliveryRepository{}
+}
+
+// Store creates the delivery or updates its progress, the payload is never
+// changed once stored.
+func (dr DeliveryRepository) Store(ctx context.Context, dbtx db.DBTX, d *Delivery) error {
+	query := `
+		INSERT INTO webhook_deliveries (id, subscription_id, customer_id, event, payload, status, attempts, next_attempt_at, last_error, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (id) DO UPDATE SET status = EXCLUDED.status, attempts = EXCLUDED.attempts,
+			next_attempt_at = EXCLUDED.next_attempt_at, last_error = EXCLUDED.last_error
+	`
+
+	_, err := dbtx.ExecContext(ctx, query, d.ID, d.SubscriptionID, d.CustomerID, d.Event, d.Payload, d.Status, d.Attempts, d.NextAttempt, d.LastError, d.Created)
+	return err
+}
+
+// Find returns the delivery. Within a transaction it stays locked until the
+// transaction ends.
+func (dr DeliveryRepository) Find(ctx context.Context, dbtx db.DBTX, id DeliveryID) (*Delivery, error) {
+	deliveries, err := dr.find(ctx, dbtx, "id = $1 FOR UPDATE", id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(deliveries) == 0 {
+		return nil, ErrUnknownDelivery
+	}
+
+	return deliveries[0], nil
+}
+
+// Due returns up to limit pending deliveries whose next attempt is due,
+// oldest first. Within a transaction they stay locked until it ends and
+// are skipped by other transactions looking for due deliveries.
+func (dr DeliveryRepository) Due(ctx context.Context, dbtx db.DBTX, now time.Time, limit int) ([]*Delivery, error) {
+	return dr.find(ctx, dbtx, "status = $1 AND next_attempt_at <= $2 ORDER BY next_attempt_at, id LIMIT $3 FOR UPDATE SKIP LOCKED", Pending, now, limit)
+}
+
+// FindFailed returns the dead-letter list of the customer, newest first.
+func (dr DeliveryRepository) FindFailed(ctx context.Context, dbtx db.DBTX, customerID customer.ID) ([]*Delivery, error) {
+	return dr.find(ctx, dbtx, "customer_id = $1 AND status = $2 ORDER BY created_at DESC, id", customerID, Failed)
+}
+
+func (dr DeliveryRepository) find(ctx context.Context, dbtx db.DBTX, where string, args ...interface{}) ([]*Delivery, error) {
+	query := `
+		SELECT id, subscription_id, customer_id, event, payload, status, attempts, next_attempt_at, last_error, created_at
+		FROM webhook_deliveries WHERE ` + where
+
+	rows, err := dbtx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*Delivery
+	for rows.Next() {
+		var d Delivery
+		if err := rows.Scan(&d.ID, &d.SubscriptionID, &d.CustomerID, &d.Event, &d.Payload, &d.Status, &d.Attempts, &d.NextAttempt, &d.LastError, &d.Created); err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, &d)
+	}
+
+	return deliveries, rows.Err()
+}
@@ -0,0 +1,89 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/cargo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"event":"Handled"}`)
+	signature := Sign("secret", body)
+
+	require.Regexp(t, `^sha256=[0-9a-f]{64}$`, signature)
+	require.True(t, Verify("secret", body, signature))
+	require.False(t, Verify("other", body, signature))
+	require.False(t, Verify("secret", []byte(`{"event":"Late"}`), signature))
+}
+
+func TestPolicyDelay(t *testing.T) {
+	p := Policy{Initial: time.Second, Max: 10 * time.Second, Attempts: 5}
+
+	require.Equal(t, time.Second, p.Delay(1))
+	require.Equal(t, 2*time.Second, p.Delay(2))
+	require.Equal(t, 8*time.Second, p.Delay(4))
+	require.Equal(t, 10*time.Second, p.Delay(5))
+	require.Equal(t, 10*time.Second, p.Delay(50))
+}
+
+func TestDeliveryRetries(t *testing.T) {
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := Policy{Initial: time.Minute, Max: time.Hour, Attempts: 3}
+	d := NewDelivery("D1", &Subscription{ID: "S1", CustomerID: "ACME"}, Handled, nil, now)
+
+	require.Equal(t, ErrNotFailed, d.Replay(now))
+
+	d.Fail(errors.New("timeout"), p, now)
+	require.Equal(t, Pending, d.Status)
+	require.Equal(t, now.Add(time.Minute), d.NextAttempt)
+
+	d.Fail(errors.New("timeout"), p, now)
+	require.Equal(t, now.Add(2*time.Minute), d.NextAttempt)
+
+	d.Fail(errors.New("503 Service Unavailable"), p, now)
+	require.Equal(t, Failed, d.Status)
+	require.Equal(t, 3, d.Attempts)
+	require.Equal(t, "503 Service Unavailable", d.LastError)
+
+	later := now.Add(time.Hour)
+	require.NoError(t, d.Replay(later))
+	require.Equal(t, Pending, d.Status)
+	require.Zero(t, d.Attempts)
+	require.Equal(t, later, d.NextAttempt)
+
+	d.Succeeded()
+	require.Equal(t, Delivered, d.Status)
+	require.Empty(t, d.LastError)
+}
+
+func TestNewPayload(t *testing.T) {
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := cargo.New("ABC123", cargo.RouteSpecification{Origin: "SESTO", Destination: "AUMEL", ArrivalDeadline: now.AddDate(0, 0, 10)})
+	c.CustomerID = "ACME"
+	h := &cargo.HandlingEvent{TrackingID: "ABC123", Activity: cargo.HandlingActivity{Type: cargo.Receive, Location: "SESTO"}, Completed: now}
+
+	b, err := json.Marshal(NewPayload("D1", Handled, c, h, now))
+	require.NoError(t, err)
+
+	var p map[string]interface{}
+	require.NoError(t, json.Unmarshal(b, &p))
+	require.Equal(t, "Handled", p["event"])
+	require.Equal(t, "D1", p["delivery_id"])
+
+	state := p["cargo"].(map[string]interface{})
+	require.Equal(t, "ABC123", state["tracking_id"])
+	require.Equal(t, "ACME", state["customer_id"])
+	require.NotContains(t, state, "eta")
+
+	handling := p["handling"].(map[string]interface{})
+	require.Equal(t, "Receive", handling["type"])
+	require.NotContains(t, handling, "voyage_number")
+
+	b, err = json.Marshal(NewPayload("D2", Booked, c, nil, now))
+	require.NoError(t, err)
+	require.NotContains(t, string(b), `"handling"`)
+}
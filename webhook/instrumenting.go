@@ -0,0 +1,84 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+)
+
+// Repository decorators below record the latency of every query, labeled by
+// repository, method and outcome.
+
+func observeQuery(latency metrics.Histogram, repository, method string, begin time.Time, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+
+	latency.With("repository", repository, "method", method, "outcome", outcome).Observe(time.Since(begin).Seconds())
+}
+
+type instrumentingSubscriptionRepository struct {
+	latency metrics.Histogram
+	SubscriptionRepositoryContract
+}
+
+// NewInstrumentingSubscriptionRepository returns a subscription repository
+// that records query latency.
+func NewInstrumentingSubscriptionRepository(latency metrics.Histogram, r SubscriptionRepositoryContract) SubscriptionRepositoryContract {
+	return &instrumentingSubscriptionRepository{latency: latency, SubscriptionRepositoryContract: r}
+}
+
+func (r *instrumentingSubscriptionRepository) Store(ctx context.Context, dbtx db.DBTX, s *Subscription) (err error) {
+	defer func(begin time.Time) { observeQuery(r.latency, "webhook_subscription", "store", begin, err) }(time.Now())
+	return r.SubscriptionRepositoryContract.Store(ctx, dbtx, s)
+}
+
+func (r *instrumentingSubscriptionRepository) Find(ctx context.Context, dbtx db.DBTX, id SubscriptionID) (s *Subscription, err error) {
+	defer func(begin time.Time) { observeQuery(r.latency, "webhook_subscription", "find", begin, err) }(time.Now())
+	return r.SubscriptionRepositoryContract.Find(ctx, dbtx, id)
+}
+
+func (r *instrumentingSubscriptionRepository) FindByCustomer(ctx context.Context, dbtx db.DBTX, customerID customer.ID) (subscriptions []*Subscription, err error) {
+	defer func(begin time.Time) { observeQuery(r.latency, "webhook_subscription", "find_by_customer", begin, err) }(time.Now())
+	return r.SubscriptionRepositoryContract.FindByCustomer(ctx, dbtx, customerID)
+}
+
+func (r *instrumentingSubscriptionRepository) Delete(ctx context.Context, dbtx db.DBTX, id SubscriptionID) (err error) {
+	defer func(begin time.Time) { observeQuery(r.latency, "webhook_subscription", "delete", begin, err) }(time.Now())
+	return r.SubscriptionRepositoryContract.Delete(ctx, dbtx, id)
+}
+
+type instrumentingDeliveryRepository struct {
+	latency metrics.Histogram
+	DeliveryRepositoryContract
+}
+
+// NewInstrumentingDeliveryRepository returns a delivery repository that
+// records query latency.
+func NewInstrumentingDeliveryRepository(latency metrics.Histogram, r DeliveryRepositoryContract) DeliveryRepositoryContract {
+	return &instrumentingDeliveryRepository{latency: latency, DeliveryRepositoryContract: r}
+}
+
+func (r *instrumentingDeliveryRepository) Store(ctx context.Context, dbtx db.DBTX, d *Delivery) (err error) {
+	defer func(begin time.Time) { observeQuery(r.latency, "webhook_delivery", "store", begin, err) }(time.Now())
+	return r.DeliveryRepositoryContract.Store(ctx, dbtx, d)
+}
+
+func (r *instrumentingDeliveryRepository) Find(ctx context.Context, dbtx db.DBTX, id DeliveryID) (d *Delivery, err error) {
+	defer func(begin time.Time) { observeQuery(r.latency, "webhook_delivery", "find", begin, err) }(time.Now())
+	return r.DeliveryRepositoryContract.Find(ctx, dbtx, id)
+}
+
+func (r *instrumentingDeliveryRepository) Due(ctx context.Context, dbtx db.DBTX, now time.Time, limit int) (deliveries []*Delivery, err error) {
+	defer func(begin time.Time) { observeQuery(r.latency, "webhook_delivery", "due", begin, err) }(time.Now())
+	return r.DeliveryRepositoryContract.Due(ctx, dbtx, now, limit)
+}
+
+func (r *instrumentingDeliveryRepository) FindFailed(ctx context.Context, dbtx db.DBTX, customerID customer.ID) (deliveries []*Delivery, err error) {
+	defer func(begin time.Time) { observeQuery(r.latency, "webhook_delivery", "find_failed", begin, err) }(time.Now())
+	return r.DeliveryRepositoryContract.FindFailed(ctx, dbtx, customerID)
+}
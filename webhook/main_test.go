@@ -0,0 +1,36 @@
+package webhook
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/mproyyan/grpc-shipping-microservice/config"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+)
+
+var (
+	dbTest           *sql.DB
+	customerTest     customer.CustomerRepositoryContract
+	subscriptionTest SubscriptionRepositoryContract
+	deliveryTest     DeliveryRepositoryContract
+)
+
+func TestMain(m *testing.M) {
+	env := config.Environment{
+		DBUsername: "postgres",
+		DBPassword: "ligmaballs",
+		DBHost:     "localhost",
+		DBPort:     "5432",
+		DBName:     "grpc_shipping",
+	}
+
+	dbTest, _ = db.NewPostgreSQL(env).Connect()
+
+	customerTest = customer.NewCustomerRepository()
+	subscriptionTest = SubscriptionRepository{}
+	deliveryTest = DeliveryRepository{}
+
+	os.Exit(m.Run())
+}
@@ -0,0 +1,218 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+)
+
+const (
+	// DefaultBatchSize is the number of due deliveries a Sender attempts per
+	// round.
+	DefaultBatchSize = 50
+
+	// DefaultTimeout bounds the attempts of senders whose client has no
+	// timeout.
+	DefaultTimeout = 10 * time.Second
+
+	// leaseMargin is added to the time a batch may take to attempt, before
+	// deliveries claimed by a sender can be claimed again.
+	leaseMargin = time.Minute
+)
+
+// Sender posts due deliveries to their subscription. Several senders can
+// share the database, a delivery is only attempted by one of them at a
+// time: it is leased to the sender that claimed it.
+type Sender struct {
+	db            *sql.DB
+	subscriptions SubscriptionRepositoryContract
+	deliveries    DeliveryRepositoryContract
+	client        *http.Client
+	policy        Policy
+	logger        log.Logger
+}
+
+// NewSender posts with the given client, whose timeout bounds every
+// attempt. Clients without a timeout get DefaultTimeout.
+func NewSender(db *sql.DB, subscriptions SubscriptionRepositoryContract, deliveries DeliveryRepositoryContract, client *http.Client, policy Policy, logger log.Logger) Sender {
+	if client.Timeout == 0 {
+		c := *client
+		c.Timeout = DefaultTimeout
+		client = &c
+	}
+
+	return Sender{
+		db:            db,
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		client:        client,
+		policy:        policy,
+		logger:        logger,
+	}
+}
+
+// Run sends due deliveries every interval until the context is done.
+func (s Sender) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		// keep going while full batches come back, there is more to send
+		for {
+			n, err := s.SendDue(ctx, DefaultBatchSize)
+			if err != nil {
+				level.Error(s.logger).Log("msg", "cannot send webhook deliveries", "err", err)
+			}
+
+			if err != nil || n < DefaultBatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SendDue attempts up to limit due deliveries and returns how many it
+// attempted. The deliveries are claimed in a short transaction which leases
+// them: their next attempt is pushed past the time the batch may take, so
+// other senders leave them alone while they are posted outside of any
+// transaction. Each result is then stored in a transaction of its own, a
+// sender stopping midway leaves the rest of its batch to be attempted again
+// once the lease runs out. Deliveries of deleted subscriptions fail right
+// away.
+func (s Sender) SendDue(ctx context.Context, limit int) (int, error) {
+	batch, err := s.claim(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, c := range batch {
+		if c.subscription == nil {
+			continue
+		}
+
+		s.attempt(ctx, c.subscription, c.delivery)
+		if err := s.record(ctx, c.delivery); err != nil {
+			return len(batch), err
+		}
+	}
+
+	return len(batch), nil
+}
+
+// claimed is a leased delivery along with the subscription it is posted to,
+// nil when the delivery failed because the subscription is gone.
+type claimed struct {
+	delivery     *Delivery
+	subscription *Subscription
+}
+
+// claim leases up to limit due deliveries to the sender. Deliveries of
+// deleted subscriptions are failed instead.
+func (s Sender) claim(ctx context.Context, limit int) ([]claimed, error) {
+	var batch []claimed
+	err := db.WithTx(ctx, s.db, func(tx *sql.Tx) error {
+		now := time.Now()
+		due, err := s.deliveries.Due(ctx, tx, now, limit)
+		if err != nil {
+			return err
+		}
+
+		lease := now.Add(time.Duration(len(due))*s.client.Timeout + leaseMargin)
+		for _, d := range due {
+			sub, err := s.subscriptions.Find(ctx, tx, d.SubscriptionID)
+			switch {
+			case err == ErrUnknownSubscription:
+				d.Status, d.LastError = Failed, err.Error()
+			case err != nil:
+				return err
+			default:
+				d.NextAttempt = lease
+			}
+
+			if err := s.deliveries.Store(ctx, tx, d); err != nil {
+				return err
+			}
+
+			batch = append(batch, claimed{delivery: d, subscription: sub})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return batch, nil
+}
+
+// record stores the result of an attempt, unless the delivery went away
+// with its subscription in the meantime.
+func (s Sender) record(ctx context.Context, d *Delivery) error {
+	return db.WithTx(ctx, s.db, func(tx *sql.Tx) error {
+		if _, err := s.deliveries.Find(ctx, tx, d.ID); err != nil {
+			if err == ErrUnknownDelivery {
+				return nil
+			}
+
+			return err
+		}
+
+		return s.deliveries.Store(ctx, tx, d)
+	})
+}
+
+func (s Sender) attempt(ctx context.Context, sub *Subscription, d *Delivery) {
+	if err := s.post(ctx, sub, d); err != nil {
+		d.Fail(err, s.policy, time.Now())
+		logger := level.Warn(s.logger)
+		if d.Status == Failed {
+			logger = level.Error(s.logger)
+		}
+
+		logger.Log("msg", "webhook delivery failed", "delivery_id", d.ID, "subscription_id", sub.ID, "attempts", d.Attempts, "status", d.Status, "err", err)
+		return
+	}
+
+	d.Succeeded()
+}
+
+// post fails unless the subscriber answers with a 2xx status.
+func (s Sender) post(ctx context.Context, sub *Subscription, d *Delivery) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, d.Event.String())
+	req.Header.Set(DeliveryHeader, string(d.ID))
+	req.Header.Set(SignatureHeader, Sign(sub.Secret, d.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("subscriber answered %s", resp.Status)
+	}
+
+	return nil
+}
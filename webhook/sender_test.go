@@ -0,0 +1,79 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSenderAttempt(t *testing.T) {
+	status := http.StatusNoContent
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.Equal(t, "Late", r.Header.Get(EventHeader))
+		require.Equal(t, "D1", r.Header.Get(DeliveryHeader))
+		require.True(t, Verify("secret", body, r.Header.Get(SignatureHeader)))
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	s := NewSender(nil, nil, nil, srv.Client(), Policy{Initial: time.Minute, Max: time.Hour, Attempts: 2}, log.NewNopLogger())
+	sub := NewSubscription("S1", "ACME", srv.URL, nil, "secret", time.Now())
+
+	d := NewDelivery("D1", sub, Late, []byte(`{"event":"Late"}`), time.Now())
+	s.attempt(context.Background(), sub, d)
+	require.Equal(t, Delivered, d.Status)
+	require.Equal(t, 1, d.Attempts)
+
+	status = http.StatusInternalServerError
+	d = NewDelivery("D1", sub, Late, []byte(`{"event":"Late"}`), time.Now())
+	s.attempt(context.Background(), sub, d)
+	require.Equal(t, Pending, d.Status)
+	require.Contains(t, d.LastError, "500")
+
+	s.attempt(context.Background(), sub, d)
+	require.Equal(t, Failed, d.Status)
+	require.Equal(t, 2, d.Attempts)
+}
+
+func TestSendDueLeasesDeliveries(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	c, err := customerTest.Upsert(ctx, dbTest, customer.New(customer.NextID(), "Acme", customer.Contact{}, customer.NotificationPreferences{}))
+	require.NoError(t, err)
+
+	var d *Delivery
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the claim is committed and the delivery is no longer due while it
+		// is being posted
+		found, err := deliveryTest.Find(ctx, dbTest, d.ID)
+		require.NoError(t, err)
+		require.True(t, found.NextAttempt.After(time.Now()))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	sub := NewSubscription(NextSubscriptionID(), c.ID, srv.URL, nil, NewSecret(), now)
+	require.NoError(t, subscriptionTest.Store(ctx, dbTest, sub))
+
+	d = NewDelivery(NextDeliveryID(), sub, Handled, []byte(`{}`), now)
+	require.NoError(t, deliveryTest.Store(ctx, dbTest, d))
+
+	s := NewSender(dbTest, subscriptionTest, deliveryTest, srv.Client(), Policy{Initial: time.Minute, Max: time.Hour, Attempts: 2}, log.NewNopLogger())
+	_, err = s.SendDue(ctx, DefaultBatchSize)
+	require.NoError(t, err)
+
+	found, err := deliveryTest.Find(ctx, dbTest, d.ID)
+	require.NoError(t, err)
+	require.Equal(t, Delivered, found.Status)
+}
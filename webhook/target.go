@@ -0,0 +1,69 @@
+package webhook
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenAddress is returned when a webhook would reach a loopback,
+// link-local, private or otherwise internal address. Subscribers choose the
+// URLs, posting to such addresses would let them probe the services behind
+// the firewall, the cloud metadata endpoint among them.
+var ErrForbiddenAddress = errors.New("webhook address is not public")
+
+// sharedAddressSpace is the carrier-grade NAT range of RFC 6598, which
+// net.IP does not count as private.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// IsPublicHost reports whether webhooks may be posted to host. Addresses
+// must be public unicast ones and names must not be local. A name may still
+// resolve to an internal address, clients from NewClient refuse to connect
+// to those.
+func IsPublicHost(host string) bool {
+	if ip := net.ParseIP(host); ip != nil {
+		return isPublic(ip)
+	}
+
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	return host != "" && host != "localhost" && !strings.HasSuffix(host, ".localhost")
+}
+
+func isPublic(ip net.IP) bool {
+	return ip.IsGlobalUnicast() && !ip.IsPrivate() && !sharedAddressSpace.Contains(ip)
+}
+
+// NewClient returns a client posting webhooks within timeout. It connects
+// directly, without going through a proxy, and only to public addresses:
+// the address is checked once the host is resolved, so neither a name
+// pointing at an internal address nor a redirect to one gets through.
+func NewClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			if ip := net.ParseIP(host); ip == nil || !isPublic(ip) {
+				return ErrForbiddenAddress
+			}
+
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConns:        100,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}
+}
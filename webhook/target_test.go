@@ -0,0 +1,36 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPublicHost(t *testing.T) {
+	for _, host := range []string{"partner.example", "93.184.216.34", "2606:2800:220:1::"} {
+		require.True(t, IsPublicHost(host), host)
+	}
+
+	for _, host := range []string{"", "localhost", "api.localhost.", "127.0.0.1", "::1", "169.254.169.254", "10.1.2.3", "172.16.0.1", "192.168.1.1", "100.64.0.1", "fd00::1", "0.0.0.0", "::ffff:127.0.0.1"} {
+		require.False(t, IsPublicHost(host), host)
+	}
+}
+
+func TestClientRefusesInternalAddresses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	// the test server listens on loopback, as would a service behind the
+	// firewall
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, srv.URL, nil)
+	require.NoError(t, err)
+
+	_, err = NewClient(time.Second).Do(req)
+	require.ErrorIs(t, err, ErrForbiddenAddress)
+}
@@ -0,0 +1,91 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+	"github.com/mproyyan/grpc-shipping-microservice/tracing"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Repository decorators below wrap every call in a client span.
+
+var tracer = tracing.Tracer("github.com/mproyyan/grpc-shipping-microservice/webhook")
+
+func startQuerySpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemPostgreSQL),
+	)
+}
+
+type tracingSubscriptionRepository struct {
+	SubscriptionRepositoryContract
+}
+
+// NewTracingSubscriptionRepository returns a subscription repository that
+// wraps every query in a client span.
+func NewTracingSubscriptionRepository(r SubscriptionRepositoryContract) SubscriptionRepositoryContract {
+	return &tracingSubscriptionRepository{SubscriptionRepositoryContract: r}
+}
+
+func (r *tracingSubscriptionRepository) Store(ctx context.Context, dbtx db.DBTX, s *Subscription) (err error) {
+	ctx, span := startQuerySpan(ctx, "SubscriptionRepository.Store")
+	defer func() { tracing.End(span, err) }()
+	return r.SubscriptionRepositoryContract.Store(ctx, dbtx, s)
+}
+
+func (r *tracingSubscriptionRepository) Find(ctx context.Context, dbtx db.DBTX, id SubscriptionID) (s *Subscription, err error) {
+	ctx, span := startQuerySpan(ctx, "SubscriptionRepository.Find")
+	defer func() { tracing.End(span, err) }()
+	return r.SubscriptionRepositoryContract.Find(ctx, dbtx, id)
+}
+
+func (r *tracingSubscriptionRepository) FindByCustomer(ctx context.Context, dbtx db.DBTX, customerID customer.ID) (subscriptions []*Subscription, err error) {
+	ctx, span := startQuerySpan(ctx, "SubscriptionRepository.FindByCustomer")
+	defer func() { tracing.End(span, err) }()
+	return r.SubscriptionRepositoryContract.FindByCustomer(ctx, dbtx, customerID)
+}
+
+func (r *tracingSubscriptionRepository) Delete(ctx context.Context, dbtx db.DBTX, id SubscriptionID) (err error) {
+	ctx, span := startQuerySpan(ctx, "SubscriptionRepository.Delete")
+	defer func() { tracing.End(span, err) }()
+	return r.SubscriptionRepositoryContract.Delete(ctx, dbtx, id)
+}
+
+type tracingDeliveryRepository struct {
+	DeliveryRepositoryContract
+}
+
+// NewTracingDeliveryRepository returns a delivery repository that wraps
+// every query in a client span.
+func NewTracingDeliveryRepository(r DeliveryRepositoryContract) DeliveryRepositoryContract {
+	return &tracingDeliveryRepository{DeliveryRepositoryContract: r}
+}
+
+func (r *tracingDeliveryRepository) Store(ctx context.Context, dbtx db.DBTX, d *Delivery) (err error) {
+	ctx, span := startQuerySpan(ctx, "DeliveryRepository.Store")
+	defer func() { tracing.End(span, err) }()
+	return r.DeliveryRepositoryContract.Store(ctx, dbtx, d)
+}
+
+func (r *tracingDeliveryRepository) Find(ctx context.Context, dbtx db.DBTX, id DeliveryID) (d *Delivery, err error) {
+	ctx, span := startQuerySpan(ctx, "DeliveryRepository.Find")
+	defer func() { tracing.End(span, err) }()
+	return r.DeliveryRepositoryContract.Find(ctx, dbtx, id)
+}
+
+func (r *tracingDeliveryRepository) Due(ctx context.Context, dbtx db.DBTX, now time.Time, limit int) (deliveries []*Delivery, err error) {
+	ctx, span := startQuerySpan(ctx, "DeliveryRepository.Due")
+	defer func() { tracing.End(span, err) }()
+	return r.DeliveryRepositoryContract.Due(ctx, dbtx, now, limit)
+}
+
+func (r *tracingDeliveryRepository) FindFailed(ctx context.Context, dbtx db.DBTX, customerID customer.ID) (deliveries []*Delivery, err error) {
+	ctx, span := startQuerySpan(ctx, "DeliveryRepository.FindFailed")
+	defer func() { tracing.End(span, err) }()
+	return r.DeliveryRepositoryContract.FindFailed(ctx, dbtx, customerID)
+}
@@ -0,0 +1,290 @@
+// Package webhook pushes cargo events to partner systems. Customers
+// subscribe a URL to the events they are interested in, every event is
+// posted as JSON signed with the secret of the subscription. Deliveries
+// are stored with the change that raised them and retried with exponential
+// backoff until they succeed or end up in the dead-letter list.
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/mproyyan/grpc-shipping-microservice/db"
+	"github.com/pborman/uuid"
+)
+
+var (
+	// ErrUnknownSubscription is used when a subscription could not be found.
+	ErrUnknownSubscription = errors.New("unknown webhook subscription")
+
+	// ErrUnknownEvent is returned when parsing the name of an event which
+	// does not exist.
+	ErrUnknownEvent = errors.New("unknown webhook event")
+)
+
+// Event is a change of a cargo subscriptions can be filtered on.
+type Event int
+
+// Events posted to webhooks.
+const (
+	Booked Event = iota
+	Routed
+	Handled
+	Misdirected
+	Unloaded
+	Late
+	Cancelled
+)
+
+// Events lists every event, in order.
+var Events = []Event{Booked, Routed, Handled, Misdirected, Unloaded, Late, Cancelled}
+
+func (e Event) String() string {
+	switch e {
+	case Booked:
+		return "Booked"
+	case Routed:
+		return "Routed"
+	case Handled:
+		return "Handled"
+	case Misdirected:
+		return "Misdirected"
+	case Unloaded:
+		return "Unloaded"
+	case Late:
+		return "Late"
+	case Cancelled:
+		return "Cancelled"
+	}
+
+	return ""
+}
+
+// ParseEvent returns the event named s, ignoring case.
+func ParseEvent(s string) (Event, error) {
+	for _, e := range Events {
+		if strings.EqualFold(e.String(), s) {
+			return e, nil
+		}
+	}
+
+	return 0, ErrUnknownEvent
+}
+
+// Filter lists the events a subscription is posted, an empty filter
+// accepts every event.
+type Filter []Event
+
+// ParseFilter parses event names, see ParseEvent.
+func ParseFilter(names []string) (Filter, error) {
+	var f Filter
+	for _, n := range names {
+		e, err := ParseEvent(n)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", err, n)
+		}
+
+		f = append(f, e)
+	}
+
+	return f, nil
+}
+
+// Accepts reports whether the event passes the filter.
+func (f Filter) Accepts(e Event) bool {
+	if len(f) == 0 {
+		return true
+	}
+
+	for _, accepted := range f {
+		if accepted == e {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Names returns the names of the events of the filter.
+func (f Filter) Names() []string {
+	names := make([]string, len(f))
+	for i, e := range f {
+		names[i] = e.String()
+	}
+
+	return names
+}
+
+// Scan reads the filter from a text array column of event names.
+func (f *Filter) Scan(src interface{}) error {
+	var s string
+	switch src := src.(type) {
+	case nil:
+		*f = nil
+		return nil
+	case []byte:
+		s = string(src)
+	case string:
+		s = src
+	default:
+		return fmt.Errorf("webhook: cannot scan %T into a filter", src)
+	}
+
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return fmt.Errorf("webhook: malformed filter %q", s)
+	}
+
+	*f = nil
+	if s = s[1 : len(s)-1]; s == "" {
+		return nil
+	}
+
+	for _, n := range strings.Split(s, ",") {
+		e, err := ParseEvent(strings.Trim(n, `"`))
+		if err != nil {
+			return fmt.Errorf("webhook: %w: %q", err, n)
+		}
+
+		*f = append(*f, e)
+	}
+
+	return nil
+}
+
+// Value writes the filter as a text array of event names.
+func (f Filter) Value() (driver.Value, error) {
+	return "{" + strings.Join(f.Names(), ",") + "}", nil
+}
+
+// SubscriptionID uniquely identifies a particular subscription.
+type SubscriptionID string
+
+// NextSubscriptionID generates a new subscription ID.
+func NextSubscriptionID() SubscriptionID {
+	return SubscriptionID(strings.Split(strings.ToUpper(uuid.New()), "-")[0])
+}
+
+// NewSecret generates a random secret to sign payloads with.
+func NewSecret() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(b)
+}
+
+// Subscription posts the events of the cargos of a customer passing the
+// filter to the URL, signed with the secret.
+type Subscription struct {
+	ID         SubscriptionID
+	CustomerID customer.ID
+	URL        string
+	Filter     Filter
+	Secret     string
+	Created    time.Time
+}
+
+// NewSubscription creates a new subscription.
+func NewSubscription(id SubscriptionID, customerID customer.ID, url string, filter Filter, secret string, created time.Time) *Subscription {
+	return &Subscription{
+		ID:         id,
+		CustomerID: customerID,
+		URL:        url,
+		Filter:     filter,
+		Secret:     secret,
+		Created:    created,
+	}
+}
+
+type SubscriptionRepositoryContract interface {
+	Store(ctx context.Context, dbtx db.DBTX, s *Subscription) error
+	Find(ctx context.Context, dbtx db.DBTX, id SubscriptionID) (*Subscription, error)
+	FindByCustomer(ctx context.Context, dbtx db.DBTX, customerID customer.ID) ([]*Subscription, error)
+	Delete(ctx context.Context, dbtx db.DBTX, id SubscriptionID) error
+}
+
+type SubscriptionRepository struct {
+}
+
+func NewSubscriptionRepository() SubscriptionRepository {
+	return SubscriptionRepository{}
+}
+
+// Store creates the subscription or replaces its URL, filter and secret.
+func (sr SubscriptionRepository) Store(ctx context.Context, dbtx db.DBTX, s *Subscription) error {
+	query := `
+		INSERT INTO webhook_subscriptions (id, customer_id, url, events, secret, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (id) DO UPDATE SET url = EXCLUDED.url, events = EXCLUDED.events, secret = EXCLUDED.secret
+	`
+
+	_, err := dbtx.ExecContext(ctx, query, s.ID, s.CustomerID, s.URL, s.Filter, s.Secret, s.Created)
+	return err
+}
+
+func (sr SubscriptionRepository) Find(ctx context.Context, dbtx db.DBTX, id SubscriptionID) (*Subscription, error) {
+	subscriptions, err := sr.find(ctx, dbtx, "id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(subscriptions) == 0 {
+		return nil, ErrUnknownSubscription
+	}
+
+	return subscriptions[0], nil
+}
+
+// FindByCustomer returns the subscriptions of the customer, oldest first.
+func (sr SubscriptionRepository) FindByCustomer(ctx context.Context, dbtx db.DBTX, customerID customer.ID) ([]*Subscription, error) {
+	return sr.find(ctx, dbtx, "customer_id = $1 ORDER BY created_at, id", customerID)
+}
+
+// Delete removes the subscription along with its deliveries.
+func (sr SubscriptionRepository) Delete(ctx context.Context, dbtx db.DBTX, id SubscriptionID) error {
+	res, err := dbtx.ExecContext(ctx, "DELETE FROM webhook_subscriptions WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return ErrUnknownSubscription
+	}
+
+	return nil
+}
+
+func (sr SubscriptionRepository) find(ctx context.Context, dbtx db.DBTX, where string, args ...interface{}) ([]*Subscription, error) {
+	query := `SELECT id, customer_id, url, events, secret, created_at FROM webhook_subscriptions WHERE ` + where
+
+	rows, err := dbtx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subscriptions []*Subscription
+	for rows.Next() {
+		var s Subscription
+		if err := rows.Scan(&s.ID, &s.CustomerID, &s.URL, &s.Filter, &s.Secret, &s.Created); err != nil {
+			return nil, err
+		}
+
+		subscriptions = append(subscriptions, &s)
+	}
+
+	return subscriptions, rows.Err()
+}
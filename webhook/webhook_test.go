@@ -0,0 +1,71 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mproyyan/grpc-shipping-microservice/customer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter(t *testing.T) {
+	f, err := ParseFilter([]string{"handled", "LATE"})
+	require.NoError(t, err)
+	require.Equal(t, Filter{Handled, Late}, f)
+	require.True(t, f.Accepts(Late))
+	require.False(t, f.Accepts(Booked))
+	require.True(t, Filter(nil).Accepts(Booked))
+
+	_, err = ParseFilter([]string{"Shipped"})
+	require.ErrorIs(t, err, ErrUnknownEvent)
+
+	v, err := f.Value()
+	require.NoError(t, err)
+	require.Equal(t, "{Handled,Late}", v)
+
+	var scanned Filter
+	require.NoError(t, scanned.Scan([]byte(`{Handled,"Late"}`)))
+	require.Equal(t, f, scanned)
+
+	require.NoError(t, scanned.Scan("{}"))
+	require.Empty(t, scanned)
+	require.Error(t, scanned.Scan("{Shipped}"))
+}
+
+func TestStoreSubscription(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	c, err := customerTest.Upsert(ctx, dbTest, customer.New(customer.NextID(), "Acme", customer.Contact{}, customer.NotificationPreferences{}))
+	require.NoError(t, err)
+
+	s := NewSubscription(NextSubscriptionID(), c.ID, "https://partner.example/hooks", Filter{Handled}, NewSecret(), now)
+	require.NoError(t, subscriptionTest.Store(ctx, dbTest, s))
+
+	found, err := subscriptionTest.Find(ctx, dbTest, s.ID)
+	require.NoError(t, err)
+	require.Equal(t, s.Filter, found.Filter)
+	require.Equal(t, s.Secret, found.Secret)
+
+	d := NewDelivery(NextDeliveryID(), s, Handled, []byte(`{}`), now)
+	require.NoError(t, deliveryTest.Store(ctx, dbTest, d))
+
+	failed, err := deliveryTest.FindFailed(ctx, dbTest, c.ID)
+	require.NoError(t, err)
+	require.Empty(t, failed)
+
+	d.Fail(context.DeadlineExceeded, Policy{Attempts: 1}, now)
+	require.NoError(t, deliveryTest.Store(ctx, dbTest, d))
+
+	failed, err = deliveryTest.FindFailed(ctx, dbTest, c.ID)
+	require.NoError(t, err)
+	require.Len(t, failed, 1)
+	require.Equal(t, d.LastError, failed[0].LastError)
+
+	require.NoError(t, subscriptionTest.Delete(ctx, dbTest, s.ID))
+	require.Equal(t, ErrUnknownSubscription, subscriptionTest.Delete(ctx, dbTest, s.ID))
+
+	_, err = deliveryTest.Find(ctx, dbTest, d.ID)
+	require.Equal(t, ErrUnknownDelivery, err)
+}